@@ -87,6 +87,12 @@ func parseAccountIdFromArn(arn string) (string, error) {
 // environment in the case that they're not explicitly specified
 // in the Terraform configuration.
 func GetCredentials(key, secret, token, profile, credsfile string) *awsCredentials.Credentials {
+	return getCredentials(key, secret, token, profile, credsfile, false)
+}
+
+// getCredentials is GetCredentials, but with skipMetadataApiCheck set the
+// EC2 metadata API isn't probed and EC2 role credentials aren't used.
+func getCredentials(key, secret, token, profile, credsfile string, skipMetadataApiCheck bool) *awsCredentials.Credentials {
 	// build a chain provider, lazy-evaulated by aws-sdk
 	providers := []awsCredentials.Provider{
 		&awsCredentials.StaticProvider{Value: awsCredentials.Value{
@@ -101,6 +107,11 @@ func GetCredentials(key, secret, token, profile, credsfile string) *awsCredentia
 		},
 	}
 
+	if skipMetadataApiCheck {
+		log.Printf("[INFO] Skipping the AWS metadata API check")
+		return awsCredentials.NewChainCredentials(providers)
+	}
+
 	// Build isolated HTTP client to avoid issues with globally-shared settings
 	client := cleanhttp.DefaultClient()
 
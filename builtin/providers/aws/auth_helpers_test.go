@@ -421,6 +421,19 @@ func TestAWSGetCredentials_shouldCatchEC2RoleProvider(t *testing.T) {
 	}
 }
 
+func TestAWSGetCredentials_skipMetadataApiCheck(t *testing.T) {
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+	// capture the test server's close method, to call after the test returns
+	ts := awsEnv(t)
+	defer ts()
+
+	creds := getCredentials("", "", "", "", "", true)
+	if _, err := creds.Get(); err == nil {
+		t.Fatal("Expected an error with the EC2RoleProvider skipped")
+	}
+}
+
 var credentialsFileContents = `[myprofile]
 aws_access_key_id = accesskey
 aws_secret_access_key = secretkey
@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-multierror"
@@ -71,7 +72,31 @@ type Config struct {
 	Ec2Endpoint      string
 	IamEndpoint      string
 	ElbEndpoint      string
+	S3Endpoint       string
+	StsEndpoint      string
+	SnsEndpoint      string
+	SqsEndpoint      string
+	RdsEndpoint      string
 	Insecure         bool
+
+	SkipCredsValidation  bool
+	SkipMetadataApiCheck bool
+	S3ForcePathStyle     bool
+
+	DependencyViolationTimeout time.Duration
+
+	Features Features
+}
+
+// Features are opt-in behaviors that apply to all the resources of the
+// provider, set in its features block.
+type Features struct {
+	// SkipFinalSnapshots skips the final snapshot of DB instances, RDS
+	// clusters and Redshift clusters on destroy.
+	SkipFinalSnapshots bool
+
+	// ForceDestroyS3Buckets deletes the objects in S3 buckets on destroy.
+	ForceDestroyS3Buckets bool
 }
 
 type AWSClient struct {
@@ -112,6 +137,12 @@ type AWSClient struct {
 	glacierconn          *glacier.Glacier
 	codedeployconn       *codedeploy.CodeDeploy
 	codecommitconn       *codecommit.CodeCommit
+
+	// dependencyViolationTimeout is how long deletes that fail with
+	// DependencyViolation are retried.
+	dependencyViolationTimeout time.Duration
+
+	features Features
 }
 
 // Client configures and returns a fully initialized AWSClient
@@ -131,9 +162,11 @@ func (c *Config) Client() (interface{}, error) {
 		// store AWS region in client struct, for region specific operations such as
 		// bucket storage in S3
 		client.region = c.Region
+		client.dependencyViolationTimeout = c.DependencyViolationTimeout
+		client.features = c.Features
 
 		log.Println("[INFO] Building AWS auth structure")
-		creds := GetCredentials(c.AccessKey, c.SecretKey, c.Token, c.Profile, c.CredsFilename)
+		creds := getCredentials(c.AccessKey, c.SecretKey, c.Token, c.Profile, c.CredsFilename, c.SkipMetadataApiCheck)
 		// Call Get to check for credential provider. If nothing found, we'll get an
 		// error, and we can present it nicely to the user
 		cp, err := creds.Get()
@@ -178,12 +211,15 @@ func (c *Config) Client() (interface{}, error) {
 		client.iamconn = iam.New(awsIamSess)
 
 		log.Println("[INFO] Initializing STS connection")
-		client.stsconn = sts.New(sess)
-
-		err = c.ValidateCredentials(client.iamconn)
-		if err != nil {
-			errs = append(errs, err)
-			return nil, &multierror.Error{Errors: errs}
+		awsStsSess := sess.Copy(&aws.Config{Endpoint: aws.String(c.StsEndpoint)})
+		client.stsconn = sts.New(awsStsSess)
+
+		if !c.SkipCredsValidation {
+			err = c.ValidateCredentials(client.iamconn)
+			if err != nil {
+				errs = append(errs, err)
+				return nil, &multierror.Error{Errors: errs}
+			}
 		}
 
 		// Some services exist only in us-east-1, e.g. because they manage
@@ -193,9 +229,20 @@ func (c *Config) Client() (interface{}, error) {
 		// http://docs.aws.amazon.com/general/latest/gr/sigv4_changes.html
 		usEast1Sess := sess.Copy(&aws.Config{Region: aws.String("us-east-1")})
 
-		accountId, err := GetAccountId(client.iamconn, client.stsconn, cp.ProviderName)
+		// Without credentials validation, IAM and STS may well not be
+		// there at all, so the account ID is only looked up once, without
+		// the retries that would otherwise stall every run.
+		iamconn, stsconn := client.iamconn, client.stsconn
+		if c.SkipCredsValidation {
+			noRetries := &aws.Config{MaxRetries: aws.Int(0)}
+			iamconn = iam.New(awsIamSess.Copy(noRetries))
+			stsconn = sts.New(awsStsSess.Copy(noRetries))
+		}
+		accountId, err := GetAccountId(iamconn, stsconn, cp.ProviderName)
 		if err == nil {
 			client.accountid = accountId
+		} else if c.SkipCredsValidation {
+			log.Printf("[WARN] Couldn't get the account ID: %s", err)
 		}
 
 		log.Println("[INFO] Initializing DynamoDB connection")
@@ -210,16 +257,23 @@ func (c *Config) Client() (interface{}, error) {
 		client.elbconn = elb.New(awsElbSess)
 
 		log.Println("[INFO] Initializing S3 connection")
-		client.s3conn = s3.New(sess)
+		awsS3Sess := sess.Copy(&aws.Config{
+			Endpoint:         aws.String(c.S3Endpoint),
+			S3ForcePathStyle: aws.Bool(c.S3ForcePathStyle),
+		})
+		client.s3conn = s3.New(awsS3Sess)
 
 		log.Println("[INFO] Initializing SQS connection")
-		client.sqsconn = sqs.New(sess)
+		awsSqsSess := sess.Copy(&aws.Config{Endpoint: aws.String(c.SqsEndpoint)})
+		client.sqsconn = sqs.New(awsSqsSess)
 
 		log.Println("[INFO] Initializing SNS connection")
-		client.snsconn = sns.New(sess)
+		awsSnsSess := sess.Copy(&aws.Config{Endpoint: aws.String(c.SnsEndpoint)})
+		client.snsconn = sns.New(awsSnsSess)
 
 		log.Println("[INFO] Initializing RDS Connection")
-		client.rdsconn = rds.New(sess)
+		awsRdsSess := sess.Copy(&aws.Config{Endpoint: aws.String(c.RdsEndpoint)})
+		client.rdsconn = rds.New(awsRdsSess)
 
 		log.Println("[INFO] Initializing Kinesis Connection")
 		kinesisSess := sess.Copy(&aws.Config{Endpoint: aws.String(c.KinesisEndpoint)})
@@ -0,0 +1,43 @@
+package aws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestAWSClient_skipCredsValidationAccountIdNoRetries(t *testing.T) {
+	var lock sync.Mutex
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		requests++
+		lock.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := &Config{
+		AccessKey:            "accesskey",
+		SecretKey:            "secretkey",
+		Region:               "us-west-2",
+		MaxRetries:           2,
+		IamEndpoint:          ts.URL,
+		StsEndpoint:          ts.URL,
+		SkipCredsValidation:  true,
+		SkipMetadataApiCheck: true,
+	}
+
+	raw, err := c.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if id := raw.(*AWSClient).accountid; id != "" {
+		t.Fatalf("expected no account ID, got %q", id)
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 request for the account ID, got %d", requests)
+	}
+}
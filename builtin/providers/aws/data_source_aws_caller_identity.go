@@ -0,0 +1,40 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsCallerIdentity() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsCallerIdentityRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsCallerIdentityRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient)
+
+	log.Printf("[DEBUG] Reading Caller Identity")
+	d.SetId(time.Now().UTC().String())
+
+	// The account ID is looked up once, when the provider is configured.
+	if client.accountid == "" {
+		return fmt.Errorf("AWS account ID is not available. " +
+			"The credentials in use may not be allowed to look it up via IAM or STS.")
+	}
+
+	log.Printf("[DEBUG] Setting AWS Account ID to %s", client.accountid)
+	d.Set("account_id", client.accountid)
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package aws
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsEcrAuthorizationToken() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsEcrAuthorizationTokenRead,
+
+		Schema: map[string]*schema.Schema{
+			"registry_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"authorization_token": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"proxy_endpoint": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expires_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"password": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsEcrAuthorizationTokenRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ecrconn
+
+	params := &ecr.GetAuthorizationTokenInput{}
+	if v, ok := d.GetOk("registry_id"); ok {
+		params.RegistryIds = []*string{aws.String(v.(string))}
+	}
+
+	log.Printf("[DEBUG] Getting ECR authorization token")
+	out, err := conn.GetAuthorizationToken(params)
+	if err != nil {
+		return fmt.Errorf("Error getting ECR authorization token: %s", err)
+	}
+	if len(out.AuthorizationData) == 0 {
+		return fmt.Errorf("No ECR authorization data returned")
+	}
+
+	authorizationData := out.AuthorizationData[0]
+	authorizationToken := aws.StringValue(authorizationData.AuthorizationToken)
+
+	// The token is a base64 encoded "user:password" pair, which is split
+	// up as well for tools that take the two separately.
+	decoded, err := base64.StdEncoding.DecodeString(authorizationToken)
+	if err != nil {
+		return fmt.Errorf("Error decoding ECR authorization token: %s", err)
+	}
+	basicAuth := strings.SplitN(string(decoded), ":", 2)
+	if len(basicAuth) != 2 {
+		return fmt.Errorf("Unexpected format of ECR authorization token")
+	}
+
+	d.SetId(time.Now().UTC().String())
+	d.Set("authorization_token", authorizationToken)
+	d.Set("proxy_endpoint", authorizationData.ProxyEndpoint)
+	if authorizationData.ExpiresAt != nil {
+		d.Set("expires_at", authorizationData.ExpiresAt.Format(time.RFC3339))
+	}
+	d.Set("user_name", basicAuth[0])
+	d.Set("password", basicAuth[1])
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package aws
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccAWSEcrAuthorizationTokenDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckAwsEcrAuthorizationTokenDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.aws_ecr_authorization_token.repo", "authorization_token",
+						regexp.MustCompile(".+")),
+					resource.TestMatchResourceAttr("data.aws_ecr_authorization_token.repo", "proxy_endpoint",
+						regexp.MustCompile("^https://[0-9]{12}\\.dkr\\.ecr\\.[a-z0-9-]+\\.amazonaws\\.com$")),
+					resource.TestMatchResourceAttr("data.aws_ecr_authorization_token.repo", "expires_at",
+						regexp.MustCompile(".+")),
+					resource.TestCheckResourceAttr("data.aws_ecr_authorization_token.repo", "user_name", "AWS"),
+					resource.TestMatchResourceAttr("data.aws_ecr_authorization_token.repo", "password",
+						regexp.MustCompile(".+")),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckAwsEcrAuthorizationTokenDataSourceConfig = `
+resource "aws_ecr_repository" "repo" {
+  name = "tf-acc-test-ecr-token"
+}
+
+data "aws_ecr_authorization_token" "repo" {
+  registry_id = "${aws_ecr_repository.repo.registry_id}"
+}
+`
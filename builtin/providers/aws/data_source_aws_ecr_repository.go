@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsEcrRepository() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsEcrRepositoryRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"registry_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"repository_url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsEcrRepositoryRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ecrconn
+
+	repositoryName := d.Get("name").(string)
+	params := &ecr.DescribeRepositoriesInput{
+		RepositoryNames: []*string{aws.String(repositoryName)},
+	}
+
+	log.Printf("[DEBUG] Reading ECR repository: %s", params)
+	out, err := conn.DescribeRepositories(params)
+	if err != nil {
+		return fmt.Errorf("Error reading ECR repository %s: %s", repositoryName, err)
+	}
+	if len(out.Repositories) != 1 {
+		return fmt.Errorf("Expected exactly one ECR repository named %s, found %d",
+			repositoryName, len(out.Repositories))
+	}
+
+	repository := out.Repositories[0]
+
+	d.SetId(*repository.RepositoryName)
+	d.Set("arn", repository.RepositoryArn)
+	d.Set("registry_id", repository.RegistryId)
+	d.Set("repository_url", buildRepositoryUrl(repository, meta.(*AWSClient).region))
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccAWSEcrDataSource_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckAwsEcrRepositoryDataSourceConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aws_ecr_repository.default", "name", name),
+					resource.TestMatchResourceAttr("data.aws_ecr_repository.default", "arn",
+						regexp.MustCompile("^arn:aws:ecr:[a-z0-9-]+:[0-9]{12}:repository/"+name+"$")),
+					resource.TestMatchResourceAttr("data.aws_ecr_repository.default", "registry_id",
+						regexp.MustCompile("^[0-9]{12}$")),
+					resource.TestMatchResourceAttr("data.aws_ecr_repository.default", "repository_url",
+						regexp.MustCompile("^https://[0-9]{12}\\.dkr\\.ecr\\.[a-z0-9-]+\\.amazonaws\\.com/"+name+"$")),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAwsEcrRepositoryDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "aws_ecr_repository" "default" {
+  name = "%s"
+}
+
+data "aws_ecr_repository" "default" {
+  name = "${aws_ecr_repository.default.name}"
+}
+`, name)
+}
@@ -2,11 +2,12 @@ package aws
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
-	"strconv"
 )
 
 var dataSourceAwsIamPolicyDocumentVarReplacer = strings.NewReplacer("&{", "${")
@@ -25,15 +26,35 @@ func dataSourceAwsIamPolicyDocument() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"id": &schema.Schema{
+				Type:       schema.TypeString,
+				Optional:   true,
+				Deprecated: "Use policy_id instead",
+			},
+			"policy_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"source_json": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateJsonString,
+			},
+			"override_json": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateJsonString,
+			},
 			"statement": &schema.Schema{
 				Type:     schema.TypeSet,
-				Required: true,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"id": &schema.Schema{
+							Type:       schema.TypeString,
+							Optional:   true,
+							Deprecated: "Use sid instead",
+						},
+						"sid": &schema.Schema{
 							Type:     schema.TypeString,
 							Optional: true,
 						},
@@ -83,23 +104,49 @@ func dataSourceAwsIamPolicyDocument() *schema.Resource {
 }
 
 func dataSourceAwsIamPolicyDocumentRead(d *schema.ResourceData, meta interface{}) error {
+	// The statements in the configuration are merged over source_json,
+	// and override_json is merged over the result. Statements replace any
+	// earlier statement with the same sid.
 	doc := &IAMPolicyDoc{
 		Version: "2012-10-17",
 	}
 
-	if policyId, hasPolicyId := d.GetOk("id"); hasPolicyId {
-		doc.Id = policyId.(string)
+	if sourceJSON, hasSourceJSON := d.GetOk("source_json"); hasSourceJSON {
+		if err := json.Unmarshal([]byte(sourceJSON.(string)), doc); err != nil {
+			return fmt.Errorf("Error parsing source_json: %s", err)
+		}
+	}
+
+	cfgDoc := &IAMPolicyDoc{}
+
+	if policyId, hasPolicyId := d.GetOk("policy_id"); hasPolicyId {
+		cfgDoc.Id = policyId.(string)
+	} else if policyId, hasPolicyId := d.GetOk("id"); hasPolicyId {
+		cfgDoc.Id = policyId.(string)
 	}
 
 	var cfgStmts = d.Get("statement").(*schema.Set).List()
 	stmts := make([]*IAMPolicyStatement, len(cfgStmts))
-	doc.Statements = stmts
+	cfgDoc.Statements = stmts
+	sids := make(map[string]bool)
 	for i, stmtI := range cfgStmts {
 		cfgStmt := stmtI.(map[string]interface{})
 		stmt := &IAMPolicyStatement{
 			Effect: cfgStmt["effect"].(string),
 		}
 
+		if sid := cfgStmt["sid"].(string); sid != "" {
+			stmt.Sid = sid
+		} else {
+			stmt.Sid = cfgStmt["id"].(string)
+		}
+		if stmt.Sid != "" {
+			if sids[stmt.Sid] {
+				return fmt.Errorf("Found duplicate sid (%s) in statements", stmt.Sid)
+			}
+			sids[stmt.Sid] = true
+		}
+
 		if actions := cfgStmt["actions"].(*schema.Set).List(); len(actions) > 0 {
 			stmt.Actions = iamPolicyDecodeConfigStringList(actions)
 		}
@@ -133,6 +180,20 @@ func dataSourceAwsIamPolicyDocumentRead(d *schema.ResourceData, meta interface{}
 		stmts[i] = stmt
 	}
 
+	doc.Merge(cfgDoc)
+
+	if overrideJSON, hasOverrideJSON := d.GetOk("override_json"); hasOverrideJSON {
+		overrideDoc := &IAMPolicyDoc{}
+		if err := json.Unmarshal([]byte(overrideJSON.(string)), overrideDoc); err != nil {
+			return fmt.Errorf("Error parsing override_json: %s", err)
+		}
+		doc.Merge(overrideDoc)
+	}
+
+	if len(doc.Statements) == 0 {
+		return fmt.Errorf("At least one statement is required, either in statement, source_json or override_json")
+	}
+
 	jsonDoc, err := json.MarshalIndent(doc, "", "  ")
 	if err != nil {
 		// should never happen if the above code is correct
@@ -30,6 +30,25 @@ func TestAccAWSIAMPolicyDocument(t *testing.T) {
 	})
 }
 
+func TestAccAWSIAMPolicyDocument_sourceAndOverride(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSIAMPolicyDocumentSourceAndOverrideConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStateValue(
+						"data.aws_iam_policy_document.test",
+						"json",
+						testAccAWSIAMPolicyDocumentSourceAndOverrideExpectedJSON,
+					),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckStateValue(id, name, value string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[id]
@@ -170,3 +189,91 @@ var testAccAWSIAMPolicyDocumentExpectedJSON = `{
     }
   ]
 }`
+
+var testAccAWSIAMPolicyDocumentSourceAndOverrideConfig = `
+data "aws_iam_policy_document" "test" {
+    policy_id = "policy_id"
+
+    source_json = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "SourceOnly",
+      "Effect": "Allow",
+      "Action": "ec2:DescribeInstances",
+      "Resource": "*"
+    },
+    {
+      "Sid": "Replaced",
+      "Effect": "Allow",
+      "Action": "ec2:*",
+      "Resource": "*"
+    }
+  ]
+}
+EOF
+
+    override_json = <<EOF
+{
+  "Statement": [
+    {
+      "Sid": "Overridden",
+      "Effect": "Deny",
+      "Action": "s3:*",
+      "Resource": "*"
+    }
+  ]
+}
+EOF
+
+    statement {
+        sid = "Replaced"
+        actions = ["ec2:DescribeTags"]
+        resources = ["*"]
+    }
+
+    statement {
+        sid = "Overridden"
+        actions = ["s3:GetObject"]
+        resources = ["*"]
+    }
+}
+`
+
+var testAccAWSIAMPolicyDocumentSourceAndOverrideExpectedJSON = `{
+  "Id": "policy_id",
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "SourceOnly",
+      "Effect": "Allow",
+      "Action": [
+        "ec2:DescribeInstances"
+      ],
+      "Resource": [
+        "*"
+      ]
+    },
+    {
+      "Sid": "Replaced",
+      "Effect": "Allow",
+      "Action": [
+        "ec2:DescribeTags"
+      ],
+      "Resource": [
+        "*"
+      ]
+    },
+    {
+      "Sid": "Overridden",
+      "Effect": "Deny",
+      "Action": [
+        "s3:*"
+      ],
+      "Resource": [
+        "*"
+      ]
+    }
+  ]
+}`
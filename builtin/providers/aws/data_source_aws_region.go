@@ -0,0 +1,74 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsRegion() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsRegionRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"current": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"endpoint": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsRegionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	currentRegion := meta.(*AWSClient).region
+
+	req := &ec2.DescribeRegionsInput{}
+
+	// With no arguments, or with current = true, this returns the region
+	// the provider is configured for.
+	name := d.Get("name").(string)
+	if name == "" || d.Get("current").(bool) {
+		if name != "" && name != currentRegion {
+			return fmt.Errorf("Region %q is not the current region %q", name, currentRegion)
+		}
+		name = currentRegion
+	}
+	req.RegionNames = []*string{aws.String(name)}
+
+	log.Printf("[DEBUG] DescribeRegions %s\n", req)
+	resp, err := conn.DescribeRegions(req)
+	if err != nil {
+		return err
+	}
+	if resp == nil || len(resp.Regions) == 0 {
+		return fmt.Errorf("no matching regions found")
+	}
+	if len(resp.Regions) > 1 {
+		return fmt.Errorf("multiple regions matched; use additional constraints to reduce matches to a single region")
+	}
+
+	region := resp.Regions[0]
+
+	d.SetId(*region.RegionName)
+	d.Set("name", region.RegionName)
+	d.Set("endpoint", region.Endpoint)
+	d.Set("current", *region.RegionName == currentRegion)
+
+	return nil
+}
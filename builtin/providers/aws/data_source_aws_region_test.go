@@ -0,0 +1,41 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccAWSRegion_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckAwsRegionConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aws_region.current", "name", "us-west-2"),
+					resource.TestCheckResourceAttr("data.aws_region.current", "current", "true"),
+					resource.TestCheckResourceAttr("data.aws_region.current", "endpoint", "ec2.us-west-2.amazonaws.com"),
+					resource.TestCheckResourceAttr("data.aws_region.other", "name", "us-east-1"),
+					resource.TestCheckResourceAttr("data.aws_region.other", "current", "false"),
+					resource.TestCheckResourceAttr("data.aws_region.other", "endpoint", "ec2.us-east-1.amazonaws.com"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckAwsRegionConfig = `
+provider "aws" {
+  region = "us-west-2"
+}
+
+data "aws_region" "current" {
+  current = true
+}
+
+data "aws_region" "other" {
+  name = "us-east-1"
+}
+`
@@ -0,0 +1,58 @@
+package aws
+
+import (
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// deleteRetryingDependencyViolation calls f until it succeeds, retrying
+// for up to timeout while it fails with DependencyViolation. This is
+// common for VPC resources whose dependents (ENIs left behind by Lambda or
+// ELB, instances still terminating, and so on) take a while to disappear
+// after Terraform has deleted them. A timeout of zero calls f only once.
+//
+// Errors with one of the given notFoundCodes are treated as success, since
+// the resource is already gone. Errors that don't come from the AWS API,
+// such as connection errors, are retried as well. Any other error stops
+// the retries.
+func deleteRetryingDependencyViolation(
+	timeout time.Duration, notFoundCodes []string, f func() error) error {
+	check := func(err error) *resource.RetryError {
+		if err == nil {
+			return nil
+		}
+
+		awsErr, ok := err.(awserr.Error)
+		if !ok {
+			log.Printf("[DEBUG] Error on delete, retrying: %s", err)
+			return resource.RetryableError(err)
+		}
+
+		if awsErr.Code() == "DependencyViolation" {
+			log.Printf("[DEBUG] Dependency violation on delete, retrying: %s", err)
+			return resource.RetryableError(err)
+		}
+
+		for _, code := range notFoundCodes {
+			if awsErr.Code() == code {
+				return nil
+			}
+		}
+
+		return resource.NonRetryableError(err)
+	}
+
+	if timeout <= 0 {
+		if rerr := check(f()); rerr != nil {
+			return rerr.Err
+		}
+		return nil
+	}
+
+	return resource.Retry(timeout, func() *resource.RetryError {
+		return check(f())
+	})
+}
@@ -0,0 +1,107 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+func TestDeleteRetryingDependencyViolation(t *testing.T) {
+	cases := []struct {
+		Errors   []error
+		Calls    int
+		ErrorOut bool
+	}{
+		// Succeeds straight away
+		{
+			Errors: []error{nil},
+			Calls:  1,
+		},
+
+		// Retries dependency violations
+		{
+			Errors: []error{
+				awserr.New("DependencyViolation", "in use", nil),
+				awserr.New("DependencyViolation", "in use", nil),
+				nil,
+			},
+			Calls: 3,
+		},
+
+		// Already deleted
+		{
+			Errors: []error{awserr.New("InvalidGroup.NotFound", "not found", nil)},
+			Calls:  1,
+		},
+
+		// Other errors stop immediately
+		{
+			Errors:   []error{awserr.New("UnauthorizedOperation", "denied", nil)},
+			Calls:    1,
+			ErrorOut: true,
+		},
+
+		// Errors that don't come from the AWS API are retried
+		{
+			Errors: []error{errors.New("connection reset"), nil},
+			Calls:  2,
+		},
+	}
+
+	for i, tc := range cases {
+		calls := 0
+		err := deleteRetryingDependencyViolation(
+			10*time.Second, []string{"InvalidGroup.NotFound"}, func() error {
+				err := tc.Errors[calls]
+				calls++
+				return err
+			})
+
+		if (err != nil) != tc.ErrorOut {
+			t.Fatalf("%d: bad error: %s", i, err)
+		}
+		if calls != tc.Calls {
+			t.Fatalf("%d: expected %d calls, got %d", i, tc.Calls, calls)
+		}
+	}
+}
+
+func TestDeleteRetryingDependencyViolation_timeout(t *testing.T) {
+	err := deleteRetryingDependencyViolation(
+		time.Second, nil, func() error {
+			return awserr.New("DependencyViolation", "in use", nil)
+		})
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != "DependencyViolation" {
+		t.Fatalf("bad error: %s", err)
+	}
+}
+
+func TestDeleteRetryingDependencyViolation_zeroTimeout(t *testing.T) {
+	calls := 0
+	err := deleteRetryingDependencyViolation(
+		0, []string{"InvalidGroup.NotFound"}, func() error {
+			calls++
+			return awserr.New("DependencyViolation", "in use", nil)
+		})
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+
+	err = deleteRetryingDependencyViolation(
+		0, []string{"InvalidGroup.NotFound"}, func() error {
+			return awserr.New("InvalidGroup.NotFound", "not found", nil)
+		})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
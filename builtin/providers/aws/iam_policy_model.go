@@ -2,6 +2,8 @@ package aws
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
 )
 
 type IAMPolicyDoc struct {
@@ -13,10 +15,10 @@ type IAMPolicyDoc struct {
 type IAMPolicyStatement struct {
 	Sid           string                         `json:",omitempty"`
 	Effect        string                         `json:",omitempty"`
-	Actions       []string                       `json:"Action,omitempty"`
-	NotActions    []string                       `json:"NotAction,omitempty"`
-	Resources     []string                       `json:"Resource,omitempty"`
-	NotResources  []string                       `json:"NotResource,omitempty"`
+	Actions       IAMPolicyStringList            `json:"Action,omitempty"`
+	NotActions    IAMPolicyStringList            `json:"NotAction,omitempty"`
+	Resources     IAMPolicyStringList            `json:"Resource,omitempty"`
+	NotResources  IAMPolicyStringList            `json:"NotResource,omitempty"`
 	Principals    IAMPolicyStatementPrincipalSet `json:"Principal,omitempty"`
 	NotPrincipals IAMPolicyStatementPrincipalSet `json:"NotPrincipal,omitempty"`
 	Conditions    IAMPolicyStatementConditionSet `json:"Condition,omitempty"`
@@ -33,10 +35,61 @@ type IAMPolicyStatementCondition struct {
 	Values   []string
 }
 
+// IAMPolicyStringList is a list of strings that, in a policy document, may
+// also be written as a single string.
+type IAMPolicyStringList []string
+
 type IAMPolicyStatementPrincipalSet []IAMPolicyStatementPrincipal
 type IAMPolicyStatementConditionSet []IAMPolicyStatementCondition
 
+// Merge merges the statements of newDoc into the document. Statements
+// with the same Sid as an existing statement replace it, and all others
+// are appended.
+func (doc *IAMPolicyDoc) Merge(newDoc *IAMPolicyDoc) {
+	if newDoc.Id != "" {
+		doc.Id = newDoc.Id
+	}
+	if newDoc.Version != "" {
+		doc.Version = newDoc.Version
+	}
+
+	for _, stmt := range newDoc.Statements {
+		replaced := false
+		if stmt.Sid != "" {
+			for i, existing := range doc.Statements {
+				if existing.Sid == stmt.Sid {
+					doc.Statements[i] = stmt
+					replaced = true
+					break
+				}
+			}
+		}
+		if !replaced {
+			doc.Statements = append(doc.Statements, stmt)
+		}
+	}
+}
+
+func (l *IAMPolicyStringList) UnmarshalJSON(b []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	values, err := iamPolicyDecodeJSONStringList(raw)
+	if err != nil {
+		return err
+	}
+	*l = IAMPolicyStringList(values)
+	return nil
+}
+
 func (ps IAMPolicyStatementPrincipalSet) MarshalJSON() ([]byte, error) {
+	// "Principal": "*" matches everyone, and can't be written as a map.
+	if len(ps) == 1 && ps[0].Type == "*" {
+		return json.Marshal("*")
+	}
+
 	raw := map[string][]string{}
 
 	for _, p := range ps {
@@ -49,6 +102,40 @@ func (ps IAMPolicyStatementPrincipalSet) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&raw)
 }
 
+func (ps *IAMPolicyStatementPrincipalSet) UnmarshalJSON(b []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	switch v := raw.(type) {
+	case string:
+		if v != "*" {
+			return fmt.Errorf("Unsupported principal %q", v)
+		}
+		*ps = IAMPolicyStatementPrincipalSet{
+			IAMPolicyStatementPrincipal{Type: "*", Identifiers: []string{"*"}},
+		}
+	case map[string]interface{}:
+		out := make(IAMPolicyStatementPrincipalSet, 0, len(v))
+		for _, typ := range iamPolicySortedKeys(v) {
+			identifiers, err := iamPolicyDecodeJSONStringList(v[typ])
+			if err != nil {
+				return fmt.Errorf("Principal %s: %s", typ, err)
+			}
+			out = append(out, IAMPolicyStatementPrincipal{
+				Type:        typ,
+				Identifiers: identifiers,
+			})
+		}
+		*ps = out
+	default:
+		return fmt.Errorf("Unsupported principal: %s", b)
+	}
+
+	return nil
+}
+
 func (cs IAMPolicyStatementConditionSet) MarshalJSON() ([]byte, error) {
 	raw := map[string]map[string][]string{}
 
@@ -65,6 +152,37 @@ func (cs IAMPolicyStatementConditionSet) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&raw)
 }
 
+func (cs *IAMPolicyStatementConditionSet) UnmarshalJSON(b []byte) error {
+	var raw map[string]map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return fmt.Errorf("Unsupported condition: %s", err)
+	}
+
+	out := IAMPolicyStatementConditionSet{}
+	tests := make([]string, 0, len(raw))
+	for test := range raw {
+		tests = append(tests, test)
+	}
+	sort.Strings(tests)
+
+	for _, test := range tests {
+		for _, variable := range iamPolicySortedKeys(raw[test]) {
+			values, err := iamPolicyDecodeJSONStringList(raw[test][variable])
+			if err != nil {
+				return fmt.Errorf("Condition %s %s: %s", test, variable, err)
+			}
+			out = append(out, IAMPolicyStatementCondition{
+				Test:     test,
+				Variable: variable,
+				Values:   values,
+			})
+		}
+	}
+
+	*cs = out
+	return nil
+}
+
 func iamPolicyDecodeConfigStringList(lI []interface{}) []string {
 	ret := make([]string, len(lI))
 	for i, vI := range lI {
@@ -72,3 +190,48 @@ func iamPolicyDecodeConfigStringList(lI []interface{}) []string {
 	}
 	return ret
 }
+
+// iamPolicyDecodeJSONStringList decodes a value of a policy document that
+// may be either a single value or a list of them. Condition values can
+// also be booleans or numbers, which are kept in their JSON form.
+func iamPolicyDecodeJSONStringList(raw interface{}) ([]string, error) {
+	switch v := raw.(type) {
+	case []interface{}:
+		ret := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := iamPolicyDecodeJSONString(item)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, s)
+		}
+		return ret, nil
+	default:
+		s, err := iamPolicyDecodeJSONString(v)
+		if err != nil {
+			return nil, err
+		}
+		return []string{s}, nil
+	}
+}
+
+func iamPolicyDecodeJSONString(raw interface{}) (string, error) {
+	switch v := raw.(type) {
+	case string:
+		return v, nil
+	case bool, float64:
+		b, err := json.Marshal(v)
+		return string(b), err
+	default:
+		return "", fmt.Errorf("Unsupported value: %#v", raw)
+	}
+}
+
+func iamPolicySortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,151 @@
+package aws
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestIAMPolicyDocUnmarshal(t *testing.T) {
+	input := `{
+  "Version": "2012-10-17",
+  "Id": "policy",
+  "Statement": [
+    {
+      "Sid": "Single",
+      "Effect": "Allow",
+      "Action": "s3:GetObject",
+      "Resource": "arn:aws:s3:::foo/*",
+      "Principal": "*"
+    },
+    {
+      "Effect": "Deny",
+      "NotAction": ["s3:*"],
+      "Principal": {
+        "AWS": "arn:aws:iam::123456789012:root",
+        "Service": ["ec2.amazonaws.com", "lambda.amazonaws.com"]
+      },
+      "Condition": {
+        "Bool": {"aws:SecureTransport": false},
+        "StringEquals": {"s3:prefix": ["home/", "tmp/"]}
+      }
+    }
+  ]
+}`
+
+	var doc IAMPolicyDoc
+	if err := json.Unmarshal([]byte(input), &doc); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := IAMPolicyDoc{
+		Version: "2012-10-17",
+		Id:      "policy",
+		Statements: []*IAMPolicyStatement{
+			&IAMPolicyStatement{
+				Sid:       "Single",
+				Effect:    "Allow",
+				Actions:   IAMPolicyStringList{"s3:GetObject"},
+				Resources: IAMPolicyStringList{"arn:aws:s3:::foo/*"},
+				Principals: IAMPolicyStatementPrincipalSet{
+					IAMPolicyStatementPrincipal{Type: "*", Identifiers: []string{"*"}},
+				},
+			},
+			&IAMPolicyStatement{
+				Effect:     "Deny",
+				NotActions: IAMPolicyStringList{"s3:*"},
+				Principals: IAMPolicyStatementPrincipalSet{
+					IAMPolicyStatementPrincipal{
+						Type:        "AWS",
+						Identifiers: []string{"arn:aws:iam::123456789012:root"},
+					},
+					IAMPolicyStatementPrincipal{
+						Type:        "Service",
+						Identifiers: []string{"ec2.amazonaws.com", "lambda.amazonaws.com"},
+					},
+				},
+				Conditions: IAMPolicyStatementConditionSet{
+					IAMPolicyStatementCondition{
+						Test:     "Bool",
+						Variable: "aws:SecureTransport",
+						Values:   []string{"false"},
+					},
+					IAMPolicyStatementCondition{
+						Test:     "StringEquals",
+						Variable: "s3:prefix",
+						Values:   []string{"home/", "tmp/"},
+					},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(doc, expected) {
+		t.Fatalf("bad: %#v", doc)
+	}
+
+	out, err := json.Marshal(doc.Statements[0])
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expectedJSON := `{"Sid":"Single","Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::foo/*"],"Principal":"*"}`
+	if string(out) != expectedJSON {
+		t.Fatalf("bad: %s\n\nexpected: %s", out, expectedJSON)
+	}
+}
+
+func TestIAMPolicyDocUnmarshal_invalid(t *testing.T) {
+	cases := []string{
+		`{"Statement": [{"Action": {"s3": "GetObject"}}]}`,
+		`{"Statement": [{"Principal": "arn:aws:iam::123456789012:root"}]}`,
+		`{"Statement": [{"Condition": {"Bool": "true"}}]}`,
+	}
+
+	for _, tc := range cases {
+		var doc IAMPolicyDoc
+		if err := json.Unmarshal([]byte(tc), &doc); err == nil {
+			t.Fatalf("expected error for %s", tc)
+		}
+	}
+}
+
+func TestIAMPolicyDocMerge(t *testing.T) {
+	doc := &IAMPolicyDoc{
+		Version: "2012-10-17",
+		Id:      "source",
+		Statements: []*IAMPolicyStatement{
+			&IAMPolicyStatement{Sid: "A", Effect: "Allow"},
+			&IAMPolicyStatement{Effect: "Allow"},
+			&IAMPolicyStatement{Sid: "B", Effect: "Allow"},
+		},
+	}
+
+	doc.Merge(&IAMPolicyDoc{
+		Statements: []*IAMPolicyStatement{
+			&IAMPolicyStatement{Sid: "B", Effect: "Deny"},
+			&IAMPolicyStatement{Sid: "C", Effect: "Deny"},
+			&IAMPolicyStatement{Effect: "Deny"},
+		},
+	})
+
+	expected := &IAMPolicyDoc{
+		Version: "2012-10-17",
+		Id:      "source",
+		Statements: []*IAMPolicyStatement{
+			&IAMPolicyStatement{Sid: "A", Effect: "Allow"},
+			&IAMPolicyStatement{Effect: "Allow"},
+			&IAMPolicyStatement{Sid: "B", Effect: "Deny"},
+			&IAMPolicyStatement{Sid: "C", Effect: "Deny"},
+			&IAMPolicyStatement{Effect: "Deny"},
+		},
+	}
+
+	if !reflect.DeepEqual(doc, expected) {
+		t.Fatalf("bad: %#v", doc)
+	}
+
+	doc.Merge(&IAMPolicyDoc{Id: "override"})
+	if doc.Id != "override" {
+		t.Fatalf("bad id: %s", doc.Id)
+	}
+}
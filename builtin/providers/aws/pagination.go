@@ -0,0 +1,118 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// The helpers in this file wrap the SDK's *Pages functions for list and
+// describe calls whose results are truncated at 100 (IAM) or 1000 (S3) items
+// per call. Read functions must use these rather than the plain API calls,
+// otherwise they silently miss entries in large accounts.
+
+// iamGroupUsers returns all users that are members of the given group.
+func iamGroupUsers(conn *iam.IAM, group string) ([]*iam.User, error) {
+	var users []*iam.User
+	err := conn.GetGroupPages(&iam.GetGroupInput{
+		GroupName: aws.String(group),
+	}, func(page *iam.GetGroupOutput, lastPage bool) bool {
+		users = append(users, page.Users...)
+		return true
+	})
+	return users, err
+}
+
+// iamGroupsForUser returns all groups the given user is a member of.
+func iamGroupsForUser(conn *iam.IAM, user string) ([]*iam.Group, error) {
+	var groups []*iam.Group
+	err := conn.ListGroupsForUserPages(&iam.ListGroupsForUserInput{
+		UserName: aws.String(user),
+	}, func(page *iam.ListGroupsForUserOutput, lastPage bool) bool {
+		groups = append(groups, page.Groups...)
+		return true
+	})
+	return groups, err
+}
+
+// iamEntitiesForPolicy returns all users, roles and groups the given managed
+// policy is attached to, merged into a single output.
+func iamEntitiesForPolicy(conn *iam.IAM, arn string) (*iam.ListEntitiesForPolicyOutput, error) {
+	entities := &iam.ListEntitiesForPolicyOutput{}
+	err := conn.ListEntitiesForPolicyPages(&iam.ListEntitiesForPolicyInput{
+		PolicyArn: aws.String(arn),
+	}, func(page *iam.ListEntitiesForPolicyOutput, lastPage bool) bool {
+		entities.PolicyUsers = append(entities.PolicyUsers, page.PolicyUsers...)
+		entities.PolicyRoles = append(entities.PolicyRoles, page.PolicyRoles...)
+		entities.PolicyGroups = append(entities.PolicyGroups, page.PolicyGroups...)
+		return true
+	})
+	return entities, err
+}
+
+// iamAttachedRolePolicies returns all managed policies attached to a role.
+func iamAttachedRolePolicies(conn *iam.IAM, role string) ([]*iam.AttachedPolicy, error) {
+	var policies []*iam.AttachedPolicy
+	err := conn.ListAttachedRolePoliciesPages(&iam.ListAttachedRolePoliciesInput{
+		RoleName: aws.String(role),
+	}, func(page *iam.ListAttachedRolePoliciesOutput, lastPage bool) bool {
+		policies = append(policies, page.AttachedPolicies...)
+		return true
+	})
+	return policies, err
+}
+
+// iamAttachedUserPolicies returns all managed policies attached to a user.
+func iamAttachedUserPolicies(conn *iam.IAM, user string) ([]*iam.AttachedPolicy, error) {
+	var policies []*iam.AttachedPolicy
+	err := conn.ListAttachedUserPoliciesPages(&iam.ListAttachedUserPoliciesInput{
+		UserName: aws.String(user),
+	}, func(page *iam.ListAttachedUserPoliciesOutput, lastPage bool) bool {
+		policies = append(policies, page.AttachedPolicies...)
+		return true
+	})
+	return policies, err
+}
+
+// iamAttachedGroupPolicies returns all managed policies attached to a group.
+func iamAttachedGroupPolicies(conn *iam.IAM, group string) ([]*iam.AttachedPolicy, error) {
+	var policies []*iam.AttachedPolicy
+	err := conn.ListAttachedGroupPoliciesPages(&iam.ListAttachedGroupPoliciesInput{
+		GroupName: aws.String(group),
+	}, func(page *iam.ListAttachedGroupPoliciesOutput, lastPage bool) bool {
+		policies = append(policies, page.AttachedPolicies...)
+		return true
+	})
+	return policies, err
+}
+
+// iamInstanceProfilesForRole returns all instance profiles a role belongs to.
+func iamInstanceProfilesForRole(conn *iam.IAM, role string) ([]*iam.InstanceProfile, error) {
+	var profiles []*iam.InstanceProfile
+	err := conn.ListInstanceProfilesForRolePages(&iam.ListInstanceProfilesForRoleInput{
+		RoleName: aws.String(role),
+	}, func(page *iam.ListInstanceProfilesForRoleOutput, lastPage bool) bool {
+		profiles = append(profiles, page.InstanceProfiles...)
+		return true
+	})
+	return profiles, err
+}
+
+// s3ObjectVersions returns all versions of the object with exactly the given
+// key. ListObjectVersions only filters by prefix, so versions of other keys
+// sharing the prefix are dropped.
+func s3ObjectVersions(conn *s3.S3, bucket, key string) ([]*s3.ObjectVersion, error) {
+	var versions []*s3.ObjectVersion
+	err := conn.ListObjectVersionsPages(&s3.ListObjectVersionsInput{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(key),
+	}, func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
+		for _, v := range page.Versions {
+			if v.Key != nil && *v.Key == key {
+				versions = append(versions, v)
+			}
+		}
+		return true
+	})
+	return versions, err
+}
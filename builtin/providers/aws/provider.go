@@ -3,6 +3,7 @@ package aws
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/mutexkv"
@@ -108,13 +109,49 @@ func Provider() terraform.ResourceProvider {
 				Default:     false,
 				Description: descriptions["insecure"],
 			},
+
+			"skip_credentials_validation": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["skip_credentials_validation"],
+			},
+
+			"skip_metadata_api_check": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["skip_metadata_api_check"],
+			},
+
+			"s3_force_path_style": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["s3_force_path_style"],
+			},
+
+			"dependency_violation_timeout": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "5m",
+				Description:  descriptions["dependency_violation_timeout"],
+				ValidateFunc: validateDuration,
+			},
+
+			"features": featuresSchema(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"aws_ami":                 dataSourceAwsAmi(),
-			"aws_availability_zones":  dataSourceAwsAvailabilityZones(),
-			"aws_iam_policy_document": dataSourceAwsIamPolicyDocument(),
-			"aws_s3_bucket_object":    dataSourceAwsS3BucketObject(),
+			"aws_ami":                     dataSourceAwsAmi(),
+			"aws_availability_zones":      dataSourceAwsAvailabilityZones(),
+			"aws_caller_identity":         dataSourceAwsCallerIdentity(),
+			"aws_ecr_authorization_token": dataSourceAwsEcrAuthorizationToken(),
+			"aws_ecr_repository":          dataSourceAwsEcrRepository(),
+			"aws_elb_service_account":     dataSourceAwsElbServiceAccount(),
+			"aws_iam_policy_document":     dataSourceAwsIamPolicyDocument(),
+			"aws_region":                  dataSourceAwsRegion(),
+			"aws_s3_bucket_object":        dataSourceAwsS3BucketObject(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -182,6 +219,7 @@ func Provider() terraform.ResourceProvider {
 			"aws_flow_log":                                 resourceAwsFlowLog(),
 			"aws_glacier_vault":                            resourceAwsGlacierVault(),
 			"aws_iam_access_key":                           resourceAwsIamAccessKey(),
+			"aws_iam_account_alias":                        resourceAwsIamAccountAlias(),
 			"aws_iam_account_password_policy":              resourceAwsIamAccountPasswordPolicy(),
 			"aws_iam_group_policy":                         resourceAwsIamGroupPolicy(),
 			"aws_iam_group":                                resourceAwsIamGroup(),
@@ -199,6 +237,7 @@ func Provider() terraform.ResourceProvider {
 			"aws_iam_user_policy":                          resourceAwsIamUserPolicy(),
 			"aws_iam_user_ssh_key":                         resourceAwsIamUserSshKey(),
 			"aws_iam_user":                                 resourceAwsIamUser(),
+			"aws_iam_user_group_membership":                resourceAwsIamUserGroupMembership(),
 			"aws_instance":                                 resourceAwsInstance(),
 			"aws_internet_gateway":                         resourceAwsInternetGateway(),
 			"aws_key_pair":                                 resourceAwsKeyPair(),
@@ -212,6 +251,8 @@ func Provider() terraform.ResourceProvider {
 			"aws_lambda_permission":                        resourceAwsLambdaPermission(),
 			"aws_launch_configuration":                     resourceAwsLaunchConfiguration(),
 			"aws_lb_cookie_stickiness_policy":              resourceAwsLBCookieStickinessPolicy(),
+			"aws_load_balancer_backend_server_policy":      resourceAwsLoadBalancerBackendServerPolicy(),
+			"aws_load_balancer_policy":                     resourceAwsLoadBalancerPolicy(),
 			"aws_main_route_table_association":             resourceAwsMainRouteTableAssociation(),
 			"aws_nat_gateway":                              resourceAwsNatGateway(),
 			"aws_network_acl":                              resourceAwsNetworkAcl(),
@@ -262,6 +303,7 @@ func Provider() terraform.ResourceProvider {
 			"aws_vpc_dhcp_options_association":             resourceAwsVpcDhcpOptionsAssociation(),
 			"aws_vpc_dhcp_options":                         resourceAwsVpcDhcpOptions(),
 			"aws_vpc_peering_connection":                   resourceAwsVpcPeeringConnection(),
+			"aws_vpc_peering_connection_accepter":          resourceAwsVpcPeeringConnectionAccepter(),
 			"aws_vpc":                                      resourceAwsVpc(),
 			"aws_vpc_endpoint":                             resourceAwsVpcEndpoint(),
 			"aws_vpn_connection":                           resourceAwsVpnConnection(),
@@ -310,8 +352,38 @@ func init() {
 
 		"elb_endpoint": "Use this to override the default endpoint URL constructed from the `region`.\n",
 
+		"s3_endpoint": "Use this to override the default endpoint URL constructed from the `region`.\n",
+
+		"sts_endpoint": "Use this to override the default endpoint URL constructed from the `region`.\n",
+
+		"sns_endpoint": "Use this to override the default endpoint URL constructed from the `region`.\n",
+
+		"sqs_endpoint": "Use this to override the default endpoint URL constructed from the `region`.\n",
+
+		"rds_endpoint": "Use this to override the default endpoint URL constructed from the `region`.\n",
+
 		"insecure": "Explicitly allow the provider to perform \"insecure\" SSL requests. If omitted," +
 			"default value is `false`",
+
+		"skip_credentials_validation": "Skip the credentials validation via the IAM API.\n" +
+			"Used for AWS API implementations that do not have IAM available.",
+
+		"skip_metadata_api_check": "Skip the AWS metadata API check. Useful outside of EC2, where\n" +
+			"the check only slows down the provider configuration.",
+
+		"s3_force_path_style": "Set this to true to force the S3 client to use path-style\n" +
+			"addressing, i.e. http://s3.amazonaws.com/BUCKET/KEY instead of\n" +
+			"http://BUCKET.s3.amazonaws.com/KEY.",
+
+		"dependency_violation_timeout": "How long to keep retrying the delete of a resource that\n" +
+			"other resources still depend on, such as a security group or subnet.\n" +
+			"Given as a duration, e.g. \"10m\". 0s disables the retries. Defaults to 5m.",
+
+		"skip_final_snapshots": "Skip the final snapshot when destroying DB instances, RDS clusters\n" +
+			"and Redshift clusters, whatever their skip_final_snapshot is set to.",
+
+		"force_destroy_s3_buckets": "Delete all objects in S3 buckets when destroying them, whatever\n" +
+			"their force_destroy is set to.",
 	}
 }
 
@@ -327,6 +399,10 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		DynamoDBEndpoint: d.Get("dynamodb_endpoint").(string),
 		KinesisEndpoint:  d.Get("kinesis_endpoint").(string),
 		Insecure:         d.Get("insecure").(bool),
+
+		SkipCredsValidation:  d.Get("skip_credentials_validation").(bool),
+		SkipMetadataApiCheck: d.Get("skip_metadata_api_check").(bool),
+		S3ForcePathStyle:     d.Get("s3_force_path_style").(bool),
 	}
 
 	endpointsSet := d.Get("endpoints").(*schema.Set)
@@ -336,6 +412,11 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		config.IamEndpoint = endpoints["iam"].(string)
 		config.Ec2Endpoint = endpoints["ec2"].(string)
 		config.ElbEndpoint = endpoints["elb"].(string)
+		config.S3Endpoint = endpoints["s3"].(string)
+		config.StsEndpoint = endpoints["sts"].(string)
+		config.SnsEndpoint = endpoints["sns"].(string)
+		config.SqsEndpoint = endpoints["sqs"].(string)
+		config.RdsEndpoint = endpoints["rds"].(string)
 	}
 
 	if v, ok := d.GetOk("allowed_account_ids"); ok {
@@ -346,6 +427,12 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		config.ForbiddenAccountIds = v.(*schema.Set).List()
 	}
 
+	// Already validated by the schema
+	timeout, _ := time.ParseDuration(d.Get("dependency_violation_timeout").(string))
+	config.DependencyViolationTimeout = timeout
+
+	config.Features = expandProviderFeatures(d.Get("features").(*schema.Set))
+
 	return config.Client()
 }
 
@@ -378,6 +465,41 @@ func endpointsSchema() *schema.Schema {
 					Default:     "",
 					Description: descriptions["elb_endpoint"],
 				},
+
+				"s3": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "",
+					Description: descriptions["s3_endpoint"],
+				},
+
+				"sts": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "",
+					Description: descriptions["sts_endpoint"],
+				},
+
+				"sns": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "",
+					Description: descriptions["sns_endpoint"],
+				},
+
+				"sqs": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "",
+					Description: descriptions["sqs_endpoint"],
+				},
+
+				"rds": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "",
+					Description: descriptions["rds_endpoint"],
+				},
 			},
 		},
 		Set: endpointsToHash,
@@ -390,6 +512,59 @@ func endpointsToHash(v interface{}) int {
 	buf.WriteString(fmt.Sprintf("%s-", m["iam"].(string)))
 	buf.WriteString(fmt.Sprintf("%s-", m["ec2"].(string)))
 	buf.WriteString(fmt.Sprintf("%s-", m["elb"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["s3"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["sts"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["sns"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["sqs"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["rds"].(string)))
 
 	return hashcode.String(buf.String())
 }
+
+// featuresSchema is the schema for the features block, which switches on
+// opt-in behaviors for all the resources of the provider.
+func featuresSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"skip_final_snapshots": &schema.Schema{
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: descriptions["skip_final_snapshots"],
+				},
+
+				"force_destroy_s3_buckets": &schema.Schema{
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: descriptions["force_destroy_s3_buckets"],
+				},
+			},
+		},
+		Set: featuresToHash,
+	}
+}
+
+func featuresToHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%t-", m["skip_final_snapshots"].(bool)))
+	buf.WriteString(fmt.Sprintf("%t-", m["force_destroy_s3_buckets"].(bool)))
+
+	return hashcode.String(buf.String())
+}
+
+func expandProviderFeatures(s *schema.Set) Features {
+	var f Features
+	for _, v := range s.List() {
+		m := v.(map[string]interface{})
+		f.SkipFinalSnapshots = m["skip_final_snapshots"].(bool)
+		f.ForceDestroyS3Buckets = m["force_destroy_s3_buckets"].(bool)
+	}
+
+	return f
+}
@@ -1,10 +1,13 @@
 package aws
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -15,10 +18,73 @@ var testAccProvider *schema.Provider
 func init() {
 	testAccProvider = Provider().(*schema.Provider)
 	testAccProviders = map[string]terraform.ResourceProvider{
-		"aws": testAccProvider,
+		"aws": &testAccCostGuardProvider{testAccProvider},
 	}
 }
 
+// testAccProviderFactories returns the provider factories for tests whose
+// configuration declares more than one aws provider, usually one alias per
+// region. Every provider Terraform initializes is appended to providers so
+// that checks can talk to each region the test created resources in.
+func testAccProviderFactories(providers *[]*schema.Provider) map[string]terraform.ResourceProviderFactory {
+	return map[string]terraform.ResourceProviderFactory{
+		"aws": func() (terraform.ResourceProvider, error) {
+			p := Provider().(*schema.Provider)
+			*providers = append(*providers, p)
+			return &testAccCostGuardProvider{p}, nil
+		},
+	}
+}
+
+// testAccCheckWithProviders runs f with each provider in providers that has
+// been configured. Providers that were only used to validate the
+// configuration have no meta and are skipped.
+func testAccCheckWithProviders(f func(*terraform.State, *schema.Provider) error, providers *[]*schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, provider := range *providers {
+			if provider.Meta() == nil {
+				continue
+			}
+			if err := f(s, provider); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// testAccRegionProviderFunc returns a function that looks up the configured
+// provider for region in providers. The lookup is deferred because the
+// providers are only initialized once the test runs.
+func testAccRegionProviderFunc(region string, providers *[]*schema.Provider) func() *schema.Provider {
+	return func() *schema.Provider {
+		for _, provider := range *providers {
+			if provider.Meta() == nil {
+				continue
+			}
+			if provider.Meta().(*AWSClient).region == region {
+				return provider
+			}
+		}
+		return nil
+	}
+}
+
+// testAccMultipleRegionProviderConfig declares one aliased provider per
+// test region. Configurations for tests using testAccProviderFactories can
+// start with it and pick a region with provider = "aws.west" or "aws.east".
+const testAccMultipleRegionProviderConfig = `
+provider "aws" {
+	alias = "west"
+	region = "us-west-2"
+}
+
+provider "aws" {
+	alias = "east"
+	region = "us-east-1"
+}
+`
+
 func TestProvider(t *testing.T) {
 	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -43,3 +109,150 @@ func testAccPreCheck(t *testing.T) {
 		os.Setenv("AWS_DEFAULT_REGION", "us-west-2")
 	}
 }
+
+// testAccCostClassEnvVar names the environment variable that sets the most
+// expensive class of resources acceptance tests are allowed to create.
+const testAccCostClassEnvVar = "TF_ACC_AWS_COST_CLASS"
+
+// testAccCostClass ranks resource types by how much it costs to create them,
+// or to leak them when a test fails to clean up after itself.
+type testAccCostClass int
+
+const (
+	testAccCostLow testAccCostClass = iota
+	testAccCostMedium
+	testAccCostHigh
+)
+
+var testAccCostClassNames = map[string]testAccCostClass{
+	"low":    testAccCostLow,
+	"medium": testAccCostMedium,
+	"high":   testAccCostHigh,
+}
+
+// testAccResourceCostClasses lists the resource types that cost more than
+// testAccCostLow. Types not listed here are always allowed.
+var testAccResourceCostClasses = map[string]testAccCostClass{
+	"aws_db_instance":                   testAccCostMedium,
+	"aws_elasticache_cluster":           testAccCostMedium,
+	"aws_elasticache_replication_group": testAccCostMedium,
+	"aws_elasticsearch_domain":          testAccCostMedium,
+	"aws_rds_cluster":                   testAccCostMedium,
+	"aws_rds_cluster_instance":          testAccCostMedium,
+	"aws_dx_connection":                 testAccCostHigh,
+	"aws_emr_cluster":                   testAccCostHigh,
+	"aws_redshift_cluster":              testAccCostHigh,
+}
+
+// testAccMaxCostClass returns the cost class configured via
+// TF_ACC_AWS_COST_CLASS. Anything above medium must be opted into explicitly.
+func testAccMaxCostClass() (testAccCostClass, error) {
+	v := os.Getenv(testAccCostClassEnvVar)
+	if v == "" {
+		return testAccCostMedium, nil
+	}
+
+	class, ok := testAccCostClassNames[strings.ToLower(v)]
+	if !ok {
+		return 0, fmt.Errorf("%s must be one of low, medium or high, got %q",
+			testAccCostClassEnvVar, v)
+	}
+
+	return class, nil
+}
+
+// testAccCostAllowed returns an error if the given resource type is more
+// expensive than the configured cost class.
+func testAccCostAllowed(resourceType string) error {
+	max, err := testAccMaxCostClass()
+	if err != nil {
+		return err
+	}
+
+	if class, ok := testAccResourceCostClasses[resourceType]; ok && class > max {
+		return fmt.Errorf(
+			"%s is above the allowed acceptance test cost class; "+
+				"set %s=high to run tests that create it",
+			resourceType, testAccCostClassEnvVar)
+	}
+
+	return nil
+}
+
+// testAccPreCheckCost skips the test if it creates any resource type above
+// the configured cost class. Tests for expensive resources should call this
+// from their PreCheck so they are skipped rather than failed by the guard.
+func testAccPreCheckCost(t *testing.T, resourceTypes ...string) {
+	for _, rt := range resourceTypes {
+		if err := testAccCostAllowed(rt); err != nil {
+			t.Skip(err.Error())
+		}
+	}
+}
+
+// testAccCostGuardProvider wraps the provider used by acceptance tests and
+// refuses to create resource types above the configured cost class, so a
+// test that forgot testAccPreCheckCost still can't run up a bill.
+type testAccCostGuardProvider struct {
+	terraform.ResourceProvider
+}
+
+func (p *testAccCostGuardProvider) Apply(
+	info *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	d *terraform.InstanceDiff) (*terraform.InstanceState, error) {
+	creating := (s == nil || s.ID == "") && d != nil && !d.Destroy
+	if creating {
+		if err := testAccCostAllowed(info.Type); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.ResourceProvider.Apply(info, s, d)
+}
+
+func TestProvider_accCostGuard(t *testing.T) {
+	defer os.Setenv(testAccCostClassEnvVar, os.Getenv(testAccCostClassEnvVar))
+
+	cases := []struct {
+		Class        string
+		ResourceType string
+		Allowed      bool
+	}{
+		{"", "aws_vpc", true},
+		{"", "aws_db_instance", true},
+		{"", "aws_redshift_cluster", false},
+		{"low", "aws_vpc", true},
+		{"low", "aws_db_instance", false},
+		{"medium", "aws_emr_cluster", false},
+		{"HIGH", "aws_redshift_cluster", true},
+		{"bogus", "aws_vpc", false},
+	}
+
+	for _, tc := range cases {
+		os.Setenv(testAccCostClassEnvVar, tc.Class)
+		err := testAccCostAllowed(tc.ResourceType)
+		if (err == nil) != tc.Allowed {
+			t.Fatalf("%s with %s=%q: expected allowed %t, got err: %v",
+				tc.ResourceType, testAccCostClassEnvVar, tc.Class, tc.Allowed, err)
+		}
+	}
+}
+
+func TestExpandProviderFeatures(t *testing.T) {
+	empty := schema.NewSet(featuresToHash, nil)
+	if f := expandProviderFeatures(empty); f != (Features{}) {
+		t.Fatalf("bad: %#v", f)
+	}
+
+	s := schema.NewSet(featuresToHash, []interface{}{
+		map[string]interface{}{
+			"skip_final_snapshots":     true,
+			"force_destroy_s3_buckets": false,
+		},
+	})
+	expected := Features{SkipFinalSnapshots: true}
+	if f := expandProviderFeatures(s); f != expected {
+		t.Fatalf("bad: %#v", f)
+	}
+}
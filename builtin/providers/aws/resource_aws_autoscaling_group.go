@@ -163,6 +163,35 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 				Default:  "1Minute",
 			},
 
+			"suspended_processes": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"rolling_update": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"batch_size": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  1,
+						},
+
+						"suspend_processes": &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+					},
+				},
+			},
+
 			"tag": autoscalingTagsSchema(),
 		},
 	}
@@ -249,6 +278,16 @@ func resourceAwsAutoscalingGroupCreate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	if v, ok := d.GetOk("suspended_processes"); ok && v.(*schema.Set).Len() > 0 {
+		_, err := conn.SuspendProcesses(&autoscaling.ScalingProcessQuery{
+			AutoScalingGroupName: aws.String(d.Id()),
+			ScalingProcesses:     expandStringList(v.(*schema.Set).List()),
+		})
+		if err != nil {
+			return fmt.Errorf("Error suspending processes for ASG %q: %s", d.Id(), err)
+		}
+	}
+
 	return resourceAwsAutoscalingGroupRead(d, meta)
 }
 
@@ -296,6 +335,10 @@ func resourceAwsAutoscalingGroupRead(d *schema.ResourceData, meta interface{}) e
 		d.Set("metrics_granularity", g.EnabledMetrics[0].Granularity)
 	}
 
+	if err := d.Set("suspended_processes", flattenAsgSuspendedProcesses(g.SuspendedProcesses)); err != nil {
+		log.Printf("[WARN] Error setting suspended processes for (%s): %s", d.Id(), err)
+	}
+
 	return nil
 }
 
@@ -412,10 +455,26 @@ func resourceAwsAutoscalingGroupUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	if d.HasChange("suspended_processes") {
+		if err := updateASGSuspendedProcesses(d, conn); err != nil {
+			return err
+		}
+	}
+
 	if shouldWaitForCapacity {
 		waitForASGCapacity(d, meta, capacitySatifiedUpdate)
 	}
 
+	// Instances launched from the old launch configuration are left alone
+	// by AWS; replace them here if the user opted into rolling updates.
+	if d.HasChange("launch_configuration") {
+		if _, ok := d.GetOk("rolling_update"); ok {
+			if err := rollASGInstances(d, meta); err != nil {
+				return err
+			}
+		}
+	}
+
 	if d.HasChange("enabled_metrics") {
 		updateASGMetricsCollection(d, conn)
 	}
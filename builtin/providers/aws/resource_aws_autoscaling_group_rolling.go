@@ -0,0 +1,219 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// defaultRollingUpdateSuspendedProcesses are the scaling processes that are
+// suspended while instances are being rolled when the configuration doesn't
+// specify its own list. These are the processes that would otherwise launch
+// or terminate instances behind our back while the group is being replaced.
+var defaultRollingUpdateSuspendedProcesses = []string{
+	"AZRebalance",
+	"AlarmNotification",
+	"ScheduledActions",
+}
+
+// rollASGInstances replaces every instance in the group that is not running
+// the currently configured launch configuration, batch_size instances at a
+// time. After each batch is terminated it waits for the group to return to
+// capacity (honoring min_elb_capacity and wait_for_elb_capacity) before
+// moving on, so the group never drops more than one batch below capacity.
+//
+// See "Rolling Updates" in docs for more discussion of the feature.
+func rollASGInstances(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).autoscalingconn
+
+	wait, err := time.ParseDuration(d.Get("wait_for_capacity_timeout").(string))
+	if err != nil {
+		return err
+	}
+	if wait == 0 {
+		return fmt.Errorf(
+			"rolling_update on %q requires a non-zero wait_for_capacity_timeout", d.Id())
+	}
+
+	batchSize := d.Get("rolling_update.0.batch_size").(int)
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	processes := expandStringList(d.Get("rolling_update.0.suspend_processes").(*schema.Set).List())
+	if len(processes) == 0 {
+		processes = aws.StringSlice(defaultRollingUpdateSuspendedProcesses)
+	}
+
+	// Only resume what we suspended ourselves; processes the user has
+	// suspended explicitly via suspended_processes must stay suspended.
+	var resume []*string
+	suspended := d.Get("suspended_processes").(*schema.Set)
+	for _, p := range processes {
+		if !suspended.Contains(*p) {
+			resume = append(resume, p)
+		}
+	}
+
+	log.Printf("[DEBUG] Suspending processes on %q for rolling update: %s",
+		d.Id(), strings.Join(aws.StringValueSlice(processes), ", "))
+	_, err = conn.SuspendProcesses(&autoscaling.ScalingProcessQuery{
+		AutoScalingGroupName: aws.String(d.Id()),
+		ScalingProcesses:     processes,
+	})
+	if err != nil {
+		return fmt.Errorf("Error suspending processes for rolling update of %q: %s", d.Id(), err)
+	}
+
+	rollErr := rollASGInstanceBatches(d, meta, batchSize)
+
+	if len(resume) > 0 {
+		log.Printf("[DEBUG] Resuming processes on %q after rolling update: %s",
+			d.Id(), strings.Join(aws.StringValueSlice(resume), ", "))
+		_, err = conn.ResumeProcesses(&autoscaling.ScalingProcessQuery{
+			AutoScalingGroupName: aws.String(d.Id()),
+			ScalingProcesses:     resume,
+		})
+		if err != nil && rollErr == nil {
+			return fmt.Errorf("Error resuming processes after rolling update of %q: %s", d.Id(), err)
+		}
+	}
+
+	return rollErr
+}
+
+func rollASGInstanceBatches(d *schema.ResourceData, meta interface{}, batchSize int) error {
+	conn := meta.(*AWSClient).autoscalingconn
+	lcName := d.Get("launch_configuration").(string)
+
+	g, err := getAwsAutoscalingGroup(d.Id(), conn)
+	if err != nil {
+		return err
+	}
+	if g == nil {
+		return fmt.Errorf("Autoscaling Group %q not found", d.Id())
+	}
+
+	outdated := outdatedASGInstances(g, lcName)
+	log.Printf("[DEBUG] %q has %d instances not running launch configuration %q",
+		d.Id(), len(outdated), lcName)
+
+	// desired_capacity is optional, so the configuration alone doesn't tell us
+	// how many instances the group is actually running. Wait for whatever
+	// AWS currently considers the desired capacity instead; min_size alone
+	// would let later batches eat into the instances still serving traffic.
+	desired := int(aws.Int64Value(g.DesiredCapacity))
+
+	for len(outdated) > 0 {
+		n := batchSize
+		if n > len(outdated) {
+			n = len(outdated)
+		}
+		batch := outdated[:n]
+		outdated = outdated[n:]
+
+		for _, id := range batch {
+			log.Printf("[DEBUG] Terminating instance %q in %q for rolling update", id, d.Id())
+			_, err := conn.TerminateInstanceInAutoScalingGroup(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+				InstanceId:                     aws.String(id),
+				ShouldDecrementDesiredCapacity: aws.Bool(false),
+			})
+			if err != nil {
+				return fmt.Errorf("Error terminating instance %q in %q: %s", id, d.Id(), err)
+			}
+		}
+
+		// Terminating instances are no longer counted as healthy, so once
+		// the group is back at its desired capacity the replacements are in.
+		if err := waitForASGCapacity(d, meta, capacitySatisfiedRolling(desired)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// capacitySatisfiedRolling requires the group to be back at the given
+// desired capacity, and treats the ELB targets as minimums like
+// capacitySatifiedCreate does.
+func capacitySatisfiedRolling(desired int) capacitySatisfiedFunc {
+	return func(d *schema.ResourceData, haveASG, haveELB int) (bool, string) {
+		if haveASG < desired {
+			return false, fmt.Sprintf(
+				"Need at least %d healthy instances in ASG, have %d", desired, haveASG)
+		}
+		minELB := d.Get("min_elb_capacity").(int)
+		if wantELB := d.Get("wait_for_elb_capacity").(int); wantELB > 0 {
+			minELB = wantELB
+		}
+		if haveELB < minELB {
+			return false, fmt.Sprintf(
+				"Need at least %d healthy instances in ELB, have %d", minELB, haveELB)
+		}
+		return true, ""
+	}
+}
+
+// outdatedASGInstances returns the IDs of all instances in the group that were
+// not launched from the given launch configuration and are not already on
+// their way out.
+func outdatedASGInstances(g *autoscaling.Group, lcName string) []string {
+	var ids []string
+	for _, i := range g.Instances {
+		if i.InstanceId == nil || i.LifecycleState == nil {
+			continue
+		}
+		if strings.HasPrefix(*i.LifecycleState, "Terminating") {
+			continue
+		}
+		// Instances whose launch configuration has been deleted report no
+		// name at all; those need replacing too.
+		if i.LaunchConfigurationName != nil && *i.LaunchConfigurationName == lcName {
+			continue
+		}
+		ids = append(ids, *i.InstanceId)
+	}
+	return ids
+}
+
+func updateASGSuspendedProcesses(d *schema.ResourceData, conn *autoscaling.AutoScaling) error {
+	o, n := d.GetChange("suspended_processes")
+	if o == nil {
+		o = new(schema.Set)
+	}
+	if n == nil {
+		n = new(schema.Set)
+	}
+
+	os := o.(*schema.Set)
+	ns := n.(*schema.Set)
+
+	resume := os.Difference(ns)
+	if resume.Len() != 0 {
+		_, err := conn.ResumeProcesses(&autoscaling.ScalingProcessQuery{
+			AutoScalingGroupName: aws.String(d.Id()),
+			ScalingProcesses:     expandStringList(resume.List()),
+		})
+		if err != nil {
+			return fmt.Errorf("Error resuming processes for ASG %q: %s", d.Id(), err)
+		}
+	}
+
+	suspend := ns.Difference(os)
+	if suspend.Len() != 0 {
+		_, err := conn.SuspendProcesses(&autoscaling.ScalingProcessQuery{
+			AutoScalingGroupName: aws.String(d.Id()),
+			ScalingProcesses:     expandStringList(suspend.List()),
+		})
+		if err != nil {
+			return fmt.Errorf("Error suspending processes for ASG %q: %s", d.Id(), err)
+		}
+	}
+
+	return nil
+}
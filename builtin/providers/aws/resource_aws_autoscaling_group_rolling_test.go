@@ -0,0 +1,107 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+)
+
+func TestOutdatedASGInstances(t *testing.T) {
+	g := &autoscaling.Group{
+		Instances: []*autoscaling.Instance{
+			&autoscaling.Instance{
+				InstanceId:              aws.String("i-current"),
+				LaunchConfigurationName: aws.String("lc-new"),
+				LifecycleState:          aws.String("InService"),
+			},
+			&autoscaling.Instance{
+				InstanceId:              aws.String("i-old"),
+				LaunchConfigurationName: aws.String("lc-old"),
+				LifecycleState:          aws.String("InService"),
+			},
+			&autoscaling.Instance{
+				InstanceId:              aws.String("i-old-pending"),
+				LaunchConfigurationName: aws.String("lc-old"),
+				LifecycleState:          aws.String("Pending"),
+			},
+			&autoscaling.Instance{
+				InstanceId:              aws.String("i-old-terminating"),
+				LaunchConfigurationName: aws.String("lc-old"),
+				LifecycleState:          aws.String("Terminating:Wait"),
+			},
+			&autoscaling.Instance{
+				InstanceId:     aws.String("i-deleted-lc"),
+				LifecycleState: aws.String("InService"),
+			},
+		},
+	}
+
+	expected := []string{"i-old", "i-old-pending", "i-deleted-lc"}
+	actual := outdatedASGInstances(g, "lc-new")
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: expected %#v, got %#v", expected, actual)
+	}
+
+	if actual := outdatedASGInstances(&autoscaling.Group{}, "lc-new"); len(actual) != 0 {
+		t.Fatalf("bad: expected no instances, got %#v", actual)
+	}
+}
+
+func TestCapacitySatisfiedRolling(t *testing.T) {
+	cases := map[string]struct {
+		Desired         int
+		Data            map[string]interface{}
+		HaveASG         int
+		HaveELB         int
+		ExpectSatisfied bool
+		ExpectReason    string
+	}{
+		"desired capacity from the group, not min_size": {
+			Desired: 4,
+			Data: map[string]interface{}{
+				"min_size": 1,
+			},
+			HaveASG:         2,
+			ExpectSatisfied: false,
+			ExpectReason:    "Need at least 4 healthy instances in ASG, have 2",
+		},
+		"back at desired capacity": {
+			Desired:         4,
+			HaveASG:         4,
+			ExpectSatisfied: true,
+		},
+		"min_elb_capacity still honored": {
+			Desired: 2,
+			Data: map[string]interface{}{
+				"min_elb_capacity": 2,
+			},
+			HaveASG:         2,
+			HaveELB:         1,
+			ExpectSatisfied: false,
+			ExpectReason:    "Need at least 2 healthy instances in ELB, have 1",
+		},
+	}
+
+	r := resourceAwsAutoscalingGroup()
+	for tn, tc := range cases {
+		d := r.TestResourceData()
+		for k, v := range tc.Data {
+			if err := d.Set(k, v); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+		}
+		gotSatisfied, gotReason := capacitySatisfiedRolling(tc.Desired)(d, tc.HaveASG, tc.HaveELB)
+
+		if gotSatisfied != tc.ExpectSatisfied {
+			t.Fatalf("%s: expected satisfied: %t, got: %t (reason: %s)",
+				tn, tc.ExpectSatisfied, gotSatisfied, gotReason)
+		}
+
+		if gotReason != tc.ExpectReason {
+			t.Fatalf("%s: expected reason: %s, got: %s",
+				tn, tc.ExpectReason, gotReason)
+		}
+	}
+}
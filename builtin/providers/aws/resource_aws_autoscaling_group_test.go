@@ -284,6 +284,65 @@ func TestAccAWSAutoScalingGroup_withMetrics(t *testing.T) {
 	})
 }
 
+func TestAccAWSAutoScalingGroup_suspendedProcesses(t *testing.T) {
+	var group autoscaling.Group
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAutoScalingGroupConfig_suspendedProcesses,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_group.bar", "suspended_processes.#", "2"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSAutoScalingGroupConfig_suspendedProcessesUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_group.bar", "suspended_processes.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSAutoScalingGroup_rollingUpdate(t *testing.T) {
+	var group autoscaling.Group
+	var lc autoscaling.LaunchConfiguration
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSAutoScalingGroupConfig_rollingUpdate, "t1.micro"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					testAccCheckAWSAutoScalingGroupHealthyCapacity(&group, 2),
+				),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSAutoScalingGroupConfig_rollingUpdate, "m1.small"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					testAccCheckAWSLaunchConfigurationExists("aws_launch_configuration.foobar", &lc),
+					testAccCheckAWSAutoScalingGroupHealthyCapacity(&group, 2),
+					testAccCheckAWSAutoScalingGroupInstancesLaunchConfiguration(&group, &lc),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckAWSAutoScalingGroupDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
 
@@ -448,6 +507,16 @@ func testAccCheckAWSAutoScalingGroupHealthyCapacity(
 	}
 }
 
+func testAccCheckAWSAutoScalingGroupInstancesLaunchConfiguration(
+	g *autoscaling.Group, lc *autoscaling.LaunchConfiguration) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if ids := outdatedASGInstances(g, *lc.LaunchConfigurationName); len(ids) > 0 {
+			return fmt.Errorf("Instances not running %s: %v", *lc.LaunchConfigurationName, ids)
+		}
+		return nil
+	}
+}
+
 func testAccCheckAWSAutoScalingGroupAttributesVPCZoneIdentifer(group *autoscaling.Group) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// Grab Subnet Ids
@@ -846,3 +915,59 @@ resource "aws_autoscaling_group" "bar" {
   metrics_granularity = "1Minute"
 }
 `
+
+const testAccAWSAutoScalingGroupConfig_suspendedProcesses = `
+resource "aws_launch_configuration" "foobar" {
+  image_id = "ami-21f78e11"
+  instance_type = "t1.micro"
+}
+
+resource "aws_autoscaling_group" "bar" {
+  availability_zones = ["us-west-2a"]
+  desired_capacity = 0
+  max_size = 0
+  min_size = 0
+  launch_configuration = "${aws_launch_configuration.foobar.name}"
+  suspended_processes = ["AZRebalance", "ScheduledActions"]
+}
+`
+
+const testAccAWSAutoScalingGroupConfig_suspendedProcessesUpdate = `
+resource "aws_launch_configuration" "foobar" {
+  image_id = "ami-21f78e11"
+  instance_type = "t1.micro"
+}
+
+resource "aws_autoscaling_group" "bar" {
+  availability_zones = ["us-west-2a"]
+  desired_capacity = 0
+  max_size = 0
+  min_size = 0
+  launch_configuration = "${aws_launch_configuration.foobar.name}"
+  suspended_processes = ["AZRebalance"]
+}
+`
+
+const testAccAWSAutoScalingGroupConfig_rollingUpdate = `
+resource "aws_launch_configuration" "foobar" {
+  image_id = "ami-21f78e11"
+  instance_type = "%s"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "aws_autoscaling_group" "bar" {
+  availability_zones = ["us-west-2a"]
+  max_size = 3
+  min_size = 2
+  desired_capacity = 2
+  force_delete = true
+  launch_configuration = "${aws_launch_configuration.foobar.name}"
+
+  rolling_update {
+    batch_size = 1
+  }
+}
+`
@@ -3,9 +3,13 @@ package aws
 import (
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
@@ -15,6 +19,9 @@ func resourceAwsCloudTrail() *schema.Resource {
 		Read:   resourceAwsCloudTrailRead,
 		Update: resourceAwsCloudTrailUpdate,
 		Delete: resourceAwsCloudTrailDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -112,9 +119,27 @@ func resourceAwsCloudTrailCreate(d *schema.ResourceData, meta interface{}) error
 		input.SnsTopicName = aws.String(v.(string))
 	}
 
-	t, err := conn.CreateTrail(&input)
+	// Both the bucket policy and the CloudWatch Logs role are commonly
+	// created alongside the trail, and CloudTrail may not see them yet.
+	var t *cloudtrail.CreateTrailOutput
+	err := resource.Retry(2*time.Minute, func() *resource.RetryError {
+		var err error
+		t, err = conn.CreateTrail(&input)
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok {
+				switch awsErr.Code() {
+				case "InsufficientS3BucketPolicyException", "InvalidCloudWatchLogsRoleArnException":
+					log.Printf("[DEBUG] Retrying CloudTrail creation: %s", err)
+					return resource.RetryableError(err)
+				}
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
 	if err != nil {
-		return err
+		return cloudTrailBucketPolicyError(err,
+			d.Get("s3_bucket_name").(string), d.Get("s3_key_prefix").(string))
 	}
 
 	log.Printf("[DEBUG] CloudTrail created: %s", t)
@@ -136,7 +161,7 @@ func resourceAwsCloudTrailCreate(d *schema.ResourceData, meta interface{}) error
 func resourceAwsCloudTrailRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).cloudtrailconn
 
-	name := d.Get("name").(string)
+	name := d.Id()
 	input := cloudtrail.DescribeTrailsInput{
 		TrailNameList: []*string{
 			aws.String(name),
@@ -157,7 +182,7 @@ func resourceAwsCloudTrailRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if trail == nil {
-		log.Printf("[WARN] CloudTrail (%s) not found", name)
+		log.Printf("[WARN] CloudTrail (%s) not found, removing from state", name)
 		d.SetId("")
 		return nil
 	}
@@ -249,7 +274,8 @@ func resourceAwsCloudTrailUpdate(d *schema.ResourceData, meta interface{}) error
 	log.Printf("[DEBUG] Updating CloudTrail: %s", input)
 	t, err := conn.UpdateTrail(&input)
 	if err != nil {
-		return err
+		return cloudTrailBucketPolicyError(err,
+			d.Get("s3_bucket_name").(string), d.Get("s3_key_prefix").(string))
 	}
 
 	if d.HasChange("tags") {
@@ -325,3 +351,26 @@ func cloudTrailSetLogging(conn *cloudtrail.CloudTrail, enabled bool, id string)
 
 	return nil
 }
+
+// cloudTrailBucketPolicyError explains what the bucket policy is missing
+// when CloudTrail rejects a bucket, since the API error doesn't say.
+// Other errors are returned unchanged.
+func cloudTrailBucketPolicyError(err error, bucket, prefix string) error {
+	awsErr, ok := err.(awserr.Error)
+	if !ok || awsErr.Code() != "InsufficientS3BucketPolicyException" {
+		return err
+	}
+
+	logsPath := "AWSLogs/*"
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		logsPath = prefix + "/" + logsPath
+	}
+
+	return fmt.Errorf(
+		"The policy of S3 bucket %q does not allow CloudTrail to deliver logs to it. "+
+			"It must allow the cloudtrail.amazonaws.com service to perform "+
+			"s3:GetBucketAcl on \"arn:aws:s3:::%s\" and s3:PutObject on "+
+			"\"arn:aws:s3:::%s/%s\" with the condition that s3:x-amz-acl is "+
+			"\"bucket-owner-full-control\": %s",
+		bucket, bucket, bucket, logsPath, err)
+}
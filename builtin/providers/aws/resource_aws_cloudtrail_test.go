@@ -1,11 +1,14 @@
 package aws
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/cloudtrail"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
@@ -209,6 +212,33 @@ func TestAccAWSCloudTrail_tags(t *testing.T) {
 	})
 }
 
+func TestCloudTrailBucketPolicyError(t *testing.T) {
+	err := errors.New("boom")
+	if actual := cloudTrailBucketPolicyError(err, "bucket", ""); actual != err {
+		t.Fatalf("expected other errors to be unchanged, got: %s", actual)
+	}
+
+	cases := []struct {
+		Prefix   string
+		Expected string
+	}{
+		{"", `"arn:aws:s3:::bucket/AWSLogs/*"`},
+		{"prefix", `"arn:aws:s3:::bucket/prefix/AWSLogs/*"`},
+		{"/prefix/", `"arn:aws:s3:::bucket/prefix/AWSLogs/*"`},
+	}
+
+	for _, tc := range cases {
+		err := awserr.New("InsufficientS3BucketPolicyException", "bad policy", nil)
+		actual := cloudTrailBucketPolicyError(err, "bucket", tc.Prefix).Error()
+		if !strings.Contains(actual, tc.Expected) {
+			t.Fatalf("%q: expected %s in: %s", tc.Prefix, tc.Expected, actual)
+		}
+		if !strings.Contains(actual, `"arn:aws:s3:::bucket"`) {
+			t.Fatalf("%q: expected bucket ARN in: %s", tc.Prefix, actual)
+		}
+	}
+}
+
 func testAccCheckCloudTrailExists(n string, trail *cloudtrail.Trail) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -154,10 +154,8 @@ func resourceAwsCloudWatchEventRuleUpdate(d *schema.ResourceData, meta interface
 	log.Printf("[DEBUG] Updating CloudWatch Event Rule: %s", input)
 
 	// IAM Roles take some time to propagate
-	var out *events.PutRuleOutput
 	err := resource.Retry(30*time.Second, func() *resource.RetryError {
-		var err error
-		out, err = conn.PutRule(input)
+		_, err := conn.PutRule(input)
 		pattern := regexp.MustCompile("cannot be assumed by principal '[a-z]+\\.amazonaws\\.com'\\.$")
 		if err != nil {
 			if awsErr, ok := err.(awserr.Error); ok {
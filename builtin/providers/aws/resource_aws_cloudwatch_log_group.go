@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 )
 
@@ -16,6 +18,9 @@ func resourceAwsCloudWatchLogGroup() *schema.Resource {
 		Read:   resourceAwsCloudWatchLogGroupRead,
 		Update: resourceAwsCloudWatchLogGroupUpdate,
 		Delete: resourceAwsCloudWatchLogGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -26,9 +31,10 @@ func resourceAwsCloudWatchLogGroup() *schema.Resource {
 			},
 
 			"retention_in_days": &schema.Schema{
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  0,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validateCloudWatchLogRetentionInDays,
 			},
 
 			"arn": &schema.Schema{
@@ -59,9 +65,15 @@ func resourceAwsCloudWatchLogGroupCreate(d *schema.ResourceData, meta interface{
 
 func resourceAwsCloudWatchLogGroupRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).cloudwatchlogsconn
-	log.Printf("[DEBUG] Reading CloudWatch Log Group: %q", d.Get("name").(string))
-	lg, err := lookupCloudWatchLogGroup(conn, d.Get("name").(string), nil)
+	log.Printf("[DEBUG] Reading CloudWatch Log Group: %q", d.Id())
+	lg, err := lookupCloudWatchLogGroup(conn, d.Id(), nil)
 	if err != nil {
+		if _, ok := err.(*resource.NotFoundError); ok {
+			log.Printf("[WARN] Removing CloudWatch Log Group %q as it is gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+
 		return err
 	}
 
@@ -72,6 +84,8 @@ func resourceAwsCloudWatchLogGroupRead(d *schema.ResourceData, meta interface{})
 
 	if lg.RetentionInDays != nil {
 		d.Set("retention_in_days", *lg.RetentionInDays)
+	} else {
+		d.Set("retention_in_days", 0)
 	}
 
 	return nil
@@ -85,7 +99,7 @@ func lookupCloudWatchLogGroup(conn *cloudwatchlogs.CloudWatchLogs,
 	}
 	resp, err := conn.DescribeLogGroups(input)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("Failed describing CloudWatch Log Groups: %s", err)
 	}
 
 	for _, lg := range resp.LogGroups {
@@ -98,7 +112,11 @@ func lookupCloudWatchLogGroup(conn *cloudwatchlogs.CloudWatchLogs,
 		return lookupCloudWatchLogGroup(conn, name, resp.NextToken)
 	}
 
-	return nil, fmt.Errorf("CloudWatch Log Group %q not found", name)
+	return nil, &resource.NotFoundError{
+		Message:      fmt.Sprintf("CloudWatch Log Group %q not found", name),
+		LastResponse: resp,
+		LastRequest:  input,
+	}
 }
 
 func resourceAwsCloudWatchLogGroupUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -139,6 +157,11 @@ func resourceAwsCloudWatchLogGroupDelete(d *schema.ResourceData, meta interface{
 		LogGroupName: aws.String(d.Get("name").(string)),
 	})
 	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFoundException" {
+			log.Printf("[WARN] CloudWatch Log Group %q already gone", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("Error deleting CloudWatch Log Group: %s", err)
 	}
 	log.Println("[INFO] CloudWatch Log Group deleted")
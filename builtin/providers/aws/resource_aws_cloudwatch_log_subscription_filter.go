@@ -56,26 +56,30 @@ func resourceAwsCloudwatchLogSubscriptionFilterCreate(d *schema.ResourceData, me
 	params := getAwsCloudWatchLogsSubscriptionFilterInput(d)
 	log.Printf("[DEBUG] Creating SubscriptionFilter %#v", params)
 
-	return resource.Retry(30*time.Second, func() *resource.RetryError {
+	err := resource.Retry(30*time.Second, func() *resource.RetryError {
 		_, err := conn.PutSubscriptionFilter(&params)
-
 		if err == nil {
-			d.SetId(cloudwatchLogsSubscriptionFilterId(d.Get("log_group_name").(string)))
-			log.Printf("[DEBUG] Cloudwatch logs subscription %q created", d.Id())
-		}
-
-		awsErr, ok := err.(awserr.Error)
-		if !ok {
-			return resource.RetryableError(err)
+			return nil
 		}
 
-		if awsErr.Code() == "InvalidParameterException" {
+		// Lambda permissions and IAM roles for Kinesis destinations take a
+		// while to propagate, and until they do the API refuses to deliver
+		// a test message to the destination.
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "InvalidParameterException" {
 			log.Printf("[DEBUG] Caught message: %q, code: %q: Retrying", awsErr.Message(), awsErr.Code())
-			resource.NonRetryableError(err)
+			return resource.RetryableError(err)
 		}
 
 		return resource.NonRetryableError(err)
 	})
+	if err != nil {
+		return fmt.Errorf("Error creating Cloudwatch logs subscription filter: %s", err)
+	}
+
+	d.SetId(cloudwatchLogsSubscriptionFilterId(d.Get("log_group_name").(string)))
+	log.Printf("[DEBUG] Cloudwatch logs subscription %q created", d.Id())
+
+	return resourceAwsCloudwatchLogSubscriptionFilterRead(d, meta)
 }
 
 func resourceAwsCloudwatchLogSubscriptionFilterUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -130,17 +134,29 @@ func resourceAwsCloudwatchLogSubscriptionFilterRead(d *schema.ResourceData, meta
 
 	resp, err := conn.DescribeSubscriptionFilters(req)
 	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFoundException" {
+			log.Printf("[WARN] Log group %s is gone, removing subscription filter %s", log_group_name, name)
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("Error reading SubscriptionFilters for log group %s with name prefix %s: %#v", log_group_name, d.Get("name").(string), err)
 	}
 
 	for _, subscriptionFilter := range resp.SubscriptionFilters {
-		if *subscriptionFilter.LogGroupName == log_group_name {
+		if *subscriptionFilter.LogGroupName == log_group_name && *subscriptionFilter.FilterName == name {
 			d.SetId(cloudwatchLogsSubscriptionFilterId(log_group_name))
+			d.Set("destination_arn", subscriptionFilter.DestinationArn)
+			d.Set("filter_pattern", subscriptionFilter.FilterPattern)
+			if subscriptionFilter.RoleArn != nil {
+				d.Set("role_arn", subscriptionFilter.RoleArn)
+			}
 			return nil // OK, matching subscription filter found
 		}
 	}
 
-	return fmt.Errorf("Subscription filter for log group %s with name prefix %s not found!", log_group_name, d.Get("name").(string))
+	log.Printf("[WARN] Subscription filter %s for log group %s not found, removing from state", name, log_group_name)
+	d.SetId("")
+	return nil
 }
 
 func resourceAwsCloudwatchLogSubscriptionFilterDelete(d *schema.ResourceData, meta interface{}) error {
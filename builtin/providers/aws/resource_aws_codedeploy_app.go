@@ -18,6 +18,9 @@ func resourceAwsCodeDeployApp() *schema.Resource {
 		Read:   resourceAwsCodeDeployAppRead,
 		Update: resourceAwsCodeDeployUpdate,
 		Delete: resourceAwsCodeDeployAppDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsCodeDeployAppImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -70,6 +73,7 @@ func resourceAwsCodeDeployAppRead(d *schema.ResourceData, meta interface{}) erro
 	})
 	if err != nil {
 		if codedeployerr, ok := err.(awserr.Error); ok && codedeployerr.Code() == "ApplicationDoesNotExistException" {
+			log.Printf("[WARN] CodeDeploy application (%s) not found, removing from state", application)
 			d.SetId("")
 			return nil
 		} else {
@@ -79,10 +83,28 @@ func resourceAwsCodeDeployAppRead(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	d.Set("name", *resp.Application.ApplicationName)
+	d.Set("unique_id", *resp.Application.ApplicationId)
 
 	return nil
 }
 
+// resourceAwsCodeDeployAppImport imports an application by its name.
+func resourceAwsCodeDeployAppImport(
+	d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	conn := meta.(*AWSClient).codedeployconn
+
+	resp, err := conn.GetApplication(&codedeploy.GetApplicationInput{
+		ApplicationName: aws.String(d.Id()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", *resp.Application.ApplicationId, *resp.Application.ApplicationName))
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceAwsCodeDeployUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).codedeployconn
 
@@ -6,6 +6,7 @@ import (
 	"log"
 	"regexp"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
@@ -23,6 +24,9 @@ func resourceAwsCodeDeployDeploymentGroup() *schema.Resource {
 		Read:   resourceAwsCodeDeployDeploymentGroupRead,
 		Update: resourceAwsCodeDeployDeploymentGroupUpdate,
 		Delete: resourceAwsCodeDeployDeploymentGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsCodeDeployDeploymentGroupImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"app_name": &schema.Schema{
@@ -238,6 +242,11 @@ func resourceAwsCodeDeployDeploymentGroupRead(d *schema.ResourceData, meta inter
 		DeploymentGroupName: aws.String(d.Get("deployment_group_name").(string)),
 	})
 	if err != nil {
+		if isCodeDeployDeploymentGroupNotFound(err) {
+			log.Printf("[WARN] CodeDeploy DeploymentGroup (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
@@ -314,7 +323,7 @@ func resourceAwsCodeDeployDeploymentGroupDelete(d *schema.ResourceData, meta int
 		ApplicationName:     aws.String(d.Get("app_name").(string)),
 		DeploymentGroupName: aws.String(d.Get("deployment_group_name").(string)),
 	})
-	if err != nil {
+	if err != nil && !isCodeDeployDeploymentGroupNotFound(err) {
 		return err
 	}
 
@@ -323,6 +332,45 @@ func resourceAwsCodeDeployDeploymentGroupDelete(d *schema.ResourceData, meta int
 	return nil
 }
 
+// resourceAwsCodeDeployDeploymentGroupImport imports a deployment group
+// given as APP_NAME:DEPLOYMENT_GROUP_NAME.
+func resourceAwsCodeDeployDeploymentGroupImport(
+	d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf(
+			"Deployment groups are imported as APP_NAME:DEPLOYMENT_GROUP_NAME, got %q", d.Id())
+	}
+
+	conn := meta.(*AWSClient).codedeployconn
+	resp, err := conn.GetDeploymentGroup(&codedeploy.GetDeploymentGroupInput{
+		ApplicationName:     aws.String(parts[0]),
+		DeploymentGroupName: aws.String(parts[1]),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(*resp.DeploymentGroupInfo.DeploymentGroupId)
+	d.Set("app_name", parts[0])
+	d.Set("deployment_group_name", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// isCodeDeployDeploymentGroupNotFound returns true if err means the
+// deployment group, or the application it belongs to, no longer exists.
+func isCodeDeployDeploymentGroupNotFound(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case "DeploymentGroupDoesNotExistException", "ApplicationDoesNotExistException":
+			return true
+		}
+	}
+
+	return false
+}
+
 // buildOnPremTagFilters converts raw schema lists into a list of
 // codedeploy.TagFilters.
 func buildOnPremTagFilters(configured []interface{}) []*codedeploy.TagFilter {
@@ -425,7 +473,7 @@ func triggerConfigsToMap(list []*codedeploy.TriggerConfig) []map[string]interfac
 	result := make([]map[string]interface{}, 0, len(list))
 	for _, tc := range list {
 		item := make(map[string]interface{})
-		item["trigger_events"] = schema.NewSet(schema.HashString, flattenStringList(tc.TriggerEvents))
+		item["trigger_events"] = flattenStringSet(tc.TriggerEvents)
 		item["trigger_name"] = *tc.TriggerName
 		item["trigger_target_arn"] = *tc.TriggerTargetArn
 		result = append(result, item)
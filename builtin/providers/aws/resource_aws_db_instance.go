@@ -746,7 +746,8 @@ func resourceAwsDbInstanceDelete(d *schema.ResourceData, meta interface{}) error
 
 	opts := rds.DeleteDBInstanceInput{DBInstanceIdentifier: aws.String(d.Id())}
 
-	skipFinalSnapshot := d.Get("skip_final_snapshot").(bool)
+	skipFinalSnapshot := d.Get("skip_final_snapshot").(bool) ||
+		meta.(*AWSClient).features.SkipFinalSnapshots
 	opts.SkipFinalSnapshot = aws.Bool(skipFinalSnapshot)
 
 	if !skipFinalSnapshot {
@@ -420,9 +420,9 @@ func resourceAwsDirectoryServiceDirectoryRead(d *schema.ResourceData, meta inter
 	}
 
 	if *dir.Type == "ADConnector" {
-		d.Set("dns_ip_addresses", schema.NewSet(schema.HashString, flattenStringList(dir.ConnectSettings.ConnectIps)))
+		d.Set("dns_ip_addresses", flattenStringSet(dir.ConnectSettings.ConnectIps))
 	} else {
-		d.Set("dns_ip_addresses", schema.NewSet(schema.HashString, flattenStringList(dir.DnsIpAddrs)))
+		d.Set("dns_ip_addresses", flattenStringSet(dir.DnsIpAddrs))
 	}
 	d.Set("name", *dir.Name)
 	if dir.ShortName != nil {
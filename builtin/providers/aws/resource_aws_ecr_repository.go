@@ -16,6 +16,9 @@ func resourceAwsEcrRepository() *schema.Resource {
 		Create: resourceAwsEcrRepositoryCreate,
 		Read:   resourceAwsEcrRepositoryRead,
 		Delete: resourceAwsEcrRepositoryDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -67,23 +70,36 @@ func resourceAwsEcrRepositoryRead(d *schema.ResourceData, meta interface{}) erro
 	conn := meta.(*AWSClient).ecrconn
 
 	log.Printf("[DEBUG] Reading repository %s", d.Id())
-	out, err := conn.DescribeRepositories(&ecr.DescribeRepositoriesInput{
-		RegistryId:      aws.String(d.Get("registry_id").(string)),
+	input := &ecr.DescribeRepositoriesInput{
 		RepositoryNames: []*string{aws.String(d.Id())},
-	})
+	}
+	// The registry ID isn't known yet when importing, in which case the
+	// default registry of the account is used.
+	if v, ok := d.GetOk("registry_id"); ok {
+		input.RegistryId = aws.String(v.(string))
+	}
+	out, err := conn.DescribeRepositories(input)
 	if err != nil {
 		if ecrerr, ok := err.(awserr.Error); ok && ecrerr.Code() == "RepositoryNotFoundException" {
+			log.Printf("[WARN] ECR repository (%s) not found, removing from state", d.Id())
 			d.SetId("")
 			return nil
 		}
 		return err
 	}
 
+	if len(out.Repositories) == 0 {
+		log.Printf("[WARN] ECR repository (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	repository := out.Repositories[0]
 
 	log.Printf("[DEBUG] Received repository %s", out)
 
 	d.SetId(*repository.RepositoryName)
+	d.Set("name", repository.RepositoryName)
 	d.Set("arn", *repository.RepositoryArn)
 	d.Set("registry_id", *repository.RegistryId)
 
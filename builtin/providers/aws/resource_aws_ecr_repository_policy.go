@@ -15,6 +15,9 @@ func resourceAwsEcrRepositoryPolicy() *schema.Resource {
 		Read:   resourceAwsEcrRepositoryPolicyRead,
 		Update: resourceAwsEcrRepositoryPolicyUpdate,
 		Delete: resourceAwsEcrRepositoryPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"repository": &schema.Schema{
@@ -62,14 +65,18 @@ func resourceAwsEcrRepositoryPolicyRead(d *schema.ResourceData, meta interface{}
 	conn := meta.(*AWSClient).ecrconn
 
 	log.Printf("[DEBUG] Reading repository policy %s", d.Id())
-	out, err := conn.GetRepositoryPolicy(&ecr.GetRepositoryPolicyInput{
-		RegistryId:     aws.String(d.Get("registry_id").(string)),
+	input := &ecr.GetRepositoryPolicyInput{
 		RepositoryName: aws.String(d.Id()),
-	})
+	}
+	if v, ok := d.GetOk("registry_id"); ok {
+		input.RegistryId = aws.String(v.(string))
+	}
+	out, err := conn.GetRepositoryPolicy(input)
 	if err != nil {
 		if ecrerr, ok := err.(awserr.Error); ok {
 			switch ecrerr.Code() {
 			case "RepositoryNotFoundException", "RepositoryPolicyNotFoundException":
+				log.Printf("[WARN] ECR repository policy (%s) not found, removing from state", d.Id())
 				d.SetId("")
 				return nil
 			default:
@@ -84,8 +91,15 @@ func resourceAwsEcrRepositoryPolicyRead(d *schema.ResourceData, meta interface{}
 	repositoryPolicy := out
 
 	d.SetId(*repositoryPolicy.RepositoryName)
+	d.Set("repository", repositoryPolicy.RepositoryName)
 	d.Set("registry_id", *repositoryPolicy.RegistryId)
 
+	// ECR doesn't return the policy exactly as it was given, so only
+	// update it when it has actually changed.
+	if normalizeJson(d.Get("policy").(string)) != normalizeJson(*repositoryPolicy.PolicyText) {
+		d.Set("policy", repositoryPolicy.PolicyText)
+	}
+
 	return nil
 }
 
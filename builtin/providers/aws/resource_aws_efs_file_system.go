@@ -18,6 +18,9 @@ func resourceAwsEfsFileSystem() *schema.Resource {
 		Read:   resourceAwsEfsFileSystemRead,
 		Update: resourceAwsEfsFileSystemUpdate,
 		Delete: resourceAwsEfsFileSystemDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"reference_name": &schema.Schema{
@@ -100,10 +103,17 @@ func resourceAwsEfsFileSystemRead(d *schema.ResourceData, meta interface{}) erro
 		FileSystemId: aws.String(d.Id()),
 	})
 	if err != nil {
+		if efsErr, ok := err.(awserr.Error); ok && efsErr.Code() == "FileSystemNotFound" {
+			log.Printf("[WARN] EFS file system (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 	if len(resp.FileSystems) < 1 {
-		return fmt.Errorf("EFS file system %q not found", d.Id())
+		log.Printf("[WARN] EFS file system (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
 	}
 
 	tagsResp, err := conn.DescribeTags(&efs.DescribeTagsInput{
@@ -125,6 +135,13 @@ func resourceAwsEfsFileSystemDelete(d *schema.ResourceData, meta interface{}) er
 	_, err := conn.DeleteFileSystem(&efs.DeleteFileSystemInput{
 		FileSystemId: aws.String(d.Id()),
 	})
+	if err != nil {
+		if efsErr, ok := err.(awserr.Error); ok && efsErr.Code() == "FileSystemNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting EFS file system (%s): %s", d.Id(), err)
+	}
+
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"available", "deleting"},
 		Target:  []string{},
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/efs"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -18,6 +19,9 @@ func resourceAwsEfsMountTarget() *schema.Resource {
 		Read:   resourceAwsEfsMountTargetRead,
 		Update: resourceAwsEfsMountTargetUpdate,
 		Delete: resourceAwsEfsMountTargetDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"file_system_id": &schema.Schema{
@@ -51,6 +55,11 @@ func resourceAwsEfsMountTarget() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"dns_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -58,8 +67,17 @@ func resourceAwsEfsMountTarget() *schema.Resource {
 func resourceAwsEfsMountTargetCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).efsconn
 
+	fsId := d.Get("file_system_id").(string)
+
+	// EFS only processes one mount target change per file system at a time
+	// and rejects the others with IncorrectFileSystemLifeCycleState, so
+	// mount targets for the same file system in different subnets are
+	// created one after the other.
+	awsMutexKV.Lock(fsId)
+	defer awsMutexKV.Unlock(fsId)
+
 	input := efs.CreateMountTargetInput{
-		FileSystemId: aws.String(d.Get("file_system_id").(string)),
+		FileSystemId: aws.String(fsId),
 		SubnetId:     aws.String(d.Get("subnet_id").(string)),
 	}
 
@@ -137,11 +155,18 @@ func resourceAwsEfsMountTargetRead(d *schema.ResourceData, meta interface{}) err
 		MountTargetId: aws.String(d.Id()),
 	})
 	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "MountTargetNotFound" {
+			log.Printf("[WARN] EFS mount target (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
 	if len(resp.MountTargets) < 1 {
-		return fmt.Errorf("EFS mount target %q not found", d.Id())
+		log.Printf("[WARN] EFS mount target (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
 	}
 
 	mt := resp.MountTargets[0]
@@ -161,11 +186,38 @@ func resourceAwsEfsMountTargetRead(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
-	d.Set("security_groups", schema.NewSet(schema.HashString, flattenStringList(sgResp.SecurityGroups)))
+	d.Set("security_groups", flattenStringSet(sgResp.SecurityGroups))
+
+	// DNS name per http://docs.aws.amazon.com/efs/latest/ug/mounting-fs-mount-cmd-dns-name.html
+	az, err := getAzFromSubnetId(*mt.SubnetId, meta.(*AWSClient).ec2conn)
+	if err != nil {
+		return fmt.Errorf("Failed getting Availability Zone from subnet ID (%s): %s", *mt.SubnetId, err)
+	}
+	region := meta.(*AWSClient).region
+	d.Set("dns_name", resourceAwsEfsMountTargetDnsName(az, *mt.FileSystemId, region))
 
 	return nil
 }
 
+func getAzFromSubnetId(subnetId string, conn *ec2.EC2) (string, error) {
+	out, err := conn.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: []*string{aws.String(subnetId)},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(out.Subnets) != 1 {
+		return "", fmt.Errorf("Expected exactly 1 subnet returned for %q", subnetId)
+	}
+
+	return *out.Subnets[0].AvailabilityZone, nil
+}
+
+func resourceAwsEfsMountTargetDnsName(az, fileSystemId, region string) string {
+	return fmt.Sprintf("%s.%s.efs.%s.amazonaws.com", az, fileSystemId, region)
+}
+
 func resourceAwsEfsMountTargetDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).efsconn
 
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -24,6 +25,11 @@ func TestAccAWSEFSMountTarget_basic(t *testing.T) {
 					testAccCheckEfsMountTarget(
 						"aws_efs_mount_target.alpha",
 					),
+					resource.TestMatchResourceAttr(
+						"aws_efs_mount_target.alpha",
+						"dns_name",
+						regexp.MustCompile("^us-west-2a.[^.]+.efs.us-west-2.amazonaws.com$"),
+					),
 				),
 			},
 			resource.TestStep{
@@ -41,6 +47,16 @@ func TestAccAWSEFSMountTarget_basic(t *testing.T) {
 	})
 }
 
+func TestResourceAWSEFSMountTarget_mountTargetDnsName(t *testing.T) {
+	actual := resourceAwsEfsMountTargetDnsName("non-existent-1c", "fs-123456ab", "non-existent-1")
+
+	expected := "non-existent-1c.fs-123456ab.efs.non-existent-1.amazonaws.com"
+	if actual != expected {
+		t.Fatalf("Expected EFS mount target DNS name to be %s, got %s",
+			expected, actual)
+	}
+}
+
 func testAccCheckEfsMountTargetDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).efsconn
 	for _, rs := range s.RootModule().Resources {
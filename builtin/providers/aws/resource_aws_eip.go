@@ -132,7 +132,8 @@ func resourceAwsEipRead(d *schema.ResourceData, meta interface{}) error {
 
 	describeAddresses, err := ec2conn.DescribeAddresses(req)
 	if err != nil {
-		if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "InvalidAllocationID.NotFound" {
+		if ec2err, ok := err.(awserr.Error); ok && (ec2err.Code() == "InvalidAllocationID.NotFound" || ec2err.Code() == "InvalidAddress.NotFound") {
+			log.Printf("[WARN] EIP (%s) not found, removing from state", id)
 			d.SetId("")
 			return nil
 		}
@@ -143,10 +144,8 @@ func resourceAwsEipRead(d *schema.ResourceData, meta interface{}) error {
 	// Verify AWS returned our EIP
 	if len(describeAddresses.Addresses) != 1 ||
 		domain == "vpc" && *describeAddresses.Addresses[0].AllocationId != id ||
-		*describeAddresses.Addresses[0].PublicIp != id {
-		if err != nil {
-			return fmt.Errorf("Unable to find EIP: %#v", describeAddresses.Addresses)
-		}
+		domain != "vpc" && *describeAddresses.Addresses[0].PublicIp != id {
+		return fmt.Errorf("Unable to find EIP: %#v", describeAddresses.Addresses)
 	}
 
 	address := describeAddresses.Addresses[0]
@@ -201,10 +200,21 @@ func resourceAwsEipUpdate(d *schema.ResourceData, meta interface{}) error {
 				privateIpAddress = aws.String(v)
 			}
 			assocOpts = &ec2.AssociateAddressInput{
-				NetworkInterfaceId: aws.String(networkInterfaceId),
-				InstanceId:         aws.String(instanceId),
-				AllocationId:       aws.String(d.Id()),
-				PrivateIpAddress:   privateIpAddress,
+				AllocationId:     aws.String(d.Id()),
+				PrivateIpAddress: privateIpAddress,
+				// Allows moving an associated EIP to another instance or
+				// network interface without releasing it first.
+				AllowReassociation: aws.Bool(true),
+			}
+
+			// AWS only accepts one of the instance or the network
+			// interface. Both are computed, so once associated with an
+			// instance the state also holds its primary network interface;
+			// prefer the network interface only when it's what changed.
+			if networkInterfaceId != "" && (instanceId == "" || d.HasChange("network_interface")) {
+				assocOpts.NetworkInterfaceId = aws.String(networkInterfaceId)
+			} else {
+				assocOpts.InstanceId = aws.String(instanceId)
 			}
 		}
 
@@ -105,6 +105,44 @@ func TestAccAWSEIP_twoEIPsOneNetworkInterface(t *testing.T) {
 	})
 }
 
+func TestAccAWSEIP_networkInterfaceReassociate(t *testing.T) {
+	var before, after ec2.Address
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "aws_eip.bar",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckAWSEIPDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSEIPNetworkInterfaceReassociateConfig, "one"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSEIPExists("aws_eip.bar", &before),
+					testAccCheckAWSEIPAssociated(&before),
+				),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSEIPNetworkInterfaceReassociateConfig, "two"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSEIPExists("aws_eip.bar", &after),
+					testAccCheckAWSEIPAssociated(&after),
+					func(*terraform.State) error {
+						if *before.AllocationId != *after.AllocationId {
+							return fmt.Errorf("EIP was replaced: %s != %s",
+								*before.AllocationId, *after.AllocationId)
+						}
+						if *before.NetworkInterfaceId == *after.NetworkInterfaceId {
+							return fmt.Errorf("EIP was not reassociated")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 // This test is an expansion of TestAccAWSEIP_instance, by testing the
 // associated Private EIPs of two instances
 func TestAccAWSEIP_associated_user_private_ip(t *testing.T) {
@@ -479,3 +517,36 @@ resource "aws_eip" "two" {
   associate_with_private_ip = "10.0.0.11"
 }
 `
+
+const testAccAWSEIPNetworkInterfaceReassociateConfig = `
+resource "aws_vpc" "bar" {
+  cidr_block = "10.0.0.0/24"
+}
+
+resource "aws_internet_gateway" "bar" {
+  vpc_id = "${aws_vpc.bar.id}"
+}
+
+resource "aws_subnet" "bar" {
+  vpc_id            = "${aws_vpc.bar.id}"
+  availability_zone = "us-west-2a"
+  cidr_block        = "10.0.0.0/24"
+}
+
+resource "aws_network_interface" "one" {
+  subnet_id       = "${aws_subnet.bar.id}"
+  private_ips     = ["10.0.0.10"]
+  security_groups = ["${aws_vpc.bar.default_security_group_id}"]
+}
+
+resource "aws_network_interface" "two" {
+  subnet_id       = "${aws_subnet.bar.id}"
+  private_ips     = ["10.0.0.11"]
+  security_groups = ["${aws_vpc.bar.default_security_group_id}"]
+}
+
+resource "aws_eip" "bar" {
+  vpc               = "true"
+  network_interface = "${aws_network_interface.%s.id}"
+}
+`
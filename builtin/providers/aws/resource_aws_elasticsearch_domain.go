@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -19,12 +20,16 @@ func resourceAwsElasticSearchDomain() *schema.Resource {
 		Read:   resourceAwsElasticSearchDomainRead,
 		Update: resourceAwsElasticSearchDomainUpdate,
 		Delete: resourceAwsElasticSearchDomainDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsElasticSearchDomainImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"access_policies": &schema.Schema{
-				Type:      schema.TypeString,
-				StateFunc: normalizeJson,
-				Optional:  true,
+				Type:         schema.TypeString,
+				StateFunc:    normalizeJson,
+				Optional:     true,
+				ValidateFunc: validateJsonString,
 			},
 			"advanced_options": &schema.Schema{
 				Type:     schema.TypeMap,
@@ -60,6 +65,7 @@ func resourceAwsElasticSearchDomain() *schema.Resource {
 				Type:     schema.TypeList,
 				Optional: true,
 				Computed: true,
+				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"ebs_enabled": &schema.Schema{
@@ -85,6 +91,7 @@ func resourceAwsElasticSearchDomain() *schema.Resource {
 				Type:     schema.TypeList,
 				Optional: true,
 				Computed: true,
+				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"dedicated_master_count": &schema.Schema{
@@ -120,6 +127,7 @@ func resourceAwsElasticSearchDomain() *schema.Resource {
 			"snapshot_options": &schema.Schema{
 				Type:     schema.TypeList,
 				Optional: true,
+				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"automated_snapshot_start_hour": &schema.Schema{
@@ -207,21 +215,7 @@ func resourceAwsElasticSearchDomainCreate(d *schema.ResourceData, meta interface
 	d.SetId(*out.DomainStatus.ARN)
 
 	log.Printf("[DEBUG] Waiting for ElasticSearch domain %q to be created", d.Id())
-	err = resource.Retry(30*time.Minute, func() *resource.RetryError {
-		out, err := conn.DescribeElasticsearchDomain(&elasticsearch.DescribeElasticsearchDomainInput{
-			DomainName: aws.String(d.Get("domain_name").(string)),
-		})
-		if err != nil {
-			return resource.NonRetryableError(err)
-		}
-
-		if !*out.DomainStatus.Processing && out.DomainStatus.Endpoint != nil {
-			return nil
-		}
-
-		return resource.RetryableError(
-			fmt.Errorf("%q: Timeout while waiting for the domain to be created", d.Id()))
-	})
+	err = waitForElasticSearchDomainProcessing(conn, d.Get("domain_name").(string), 60*time.Minute)
 	if err != nil {
 		return err
 	}
@@ -248,6 +242,11 @@ func resourceAwsElasticSearchDomainRead(d *schema.ResourceData, meta interface{}
 		DomainName: aws.String(d.Get("domain_name").(string)),
 	})
 	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFoundException" {
+			log.Printf("[INFO] ElasticSearch Domain %q not found", d.Get("domain_name").(string))
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
@@ -276,9 +275,11 @@ func resourceAwsElasticSearchDomainRead(d *schema.ResourceData, meta interface{}
 	if err != nil {
 		return err
 	}
-	if ds.SnapshotOptions != nil {
-		d.Set("snapshot_options", map[string]interface{}{
-			"automated_snapshot_start_hour": *ds.SnapshotOptions.AutomatedSnapshotStartHour,
+	if ds.SnapshotOptions != nil && ds.SnapshotOptions.AutomatedSnapshotStartHour != nil {
+		d.Set("snapshot_options", []map[string]interface{}{
+			map[string]interface{}{
+				"automated_snapshot_start_hour": *ds.SnapshotOptions.AutomatedSnapshotStartHour,
+			},
 		})
 	}
 
@@ -367,21 +368,10 @@ func resourceAwsElasticSearchDomainUpdate(d *schema.ResourceData, meta interface
 		return err
 	}
 
-	err = resource.Retry(50*time.Minute, func() *resource.RetryError {
-		out, err := conn.DescribeElasticsearchDomain(&elasticsearch.DescribeElasticsearchDomainInput{
-			DomainName: aws.String(d.Get("domain_name").(string)),
-		})
-		if err != nil {
-			return resource.NonRetryableError(err)
-		}
-
-		if *out.DomainStatus.Processing == false {
-			return nil
-		}
-
-		return resource.RetryableError(
-			fmt.Errorf("%q: Timeout while waiting for changes to be processed", d.Id()))
-	})
+	// Configuration changes are applied with a blue/green deployment of the
+	// whole cluster, which routinely takes the better part of an hour.
+	log.Printf("[DEBUG] Waiting for ElasticSearch domain %q to process changes", d.Id())
+	err = waitForElasticSearchDomainProcessing(conn, d.Get("domain_name").(string), 60*time.Minute)
 	if err != nil {
 		return err
 	}
@@ -403,7 +393,7 @@ func resourceAwsElasticSearchDomainDelete(d *schema.ResourceData, meta interface
 	}
 
 	log.Printf("[DEBUG] Waiting for ElasticSearch domain %q to be deleted", d.Get("domain_name").(string))
-	err = resource.Retry(15*time.Minute, func() *resource.RetryError {
+	err = resource.Retry(60*time.Minute, func() *resource.RetryError {
 		out, err := conn.DescribeElasticsearchDomain(&elasticsearch.DescribeElasticsearchDomainInput{
 			DomainName: aws.String(d.Get("domain_name").(string)),
 		})
@@ -433,3 +423,46 @@ func resourceAwsElasticSearchDomainDelete(d *schema.ResourceData, meta interface
 
 	return err
 }
+
+func resourceAwsElasticSearchDomainImport(
+	d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// Accept either the domain name or the ARN, which is what we use as ID
+	name := d.Id()
+	if parts := strings.SplitN(d.Id(), ":domain/", 2); len(parts) == 2 {
+		name = parts[1]
+	}
+
+	conn := meta.(*AWSClient).esconn
+	out, err := conn.DescribeElasticsearchDomain(&elasticsearch.DescribeElasticsearchDomainInput{
+		DomainName: aws.String(name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(*out.DomainStatus.ARN)
+	d.Set("domain_name", name)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// waitForElasticSearchDomainProcessing waits until the domain has finished
+// processing its latest configuration change and has an endpoint.
+func waitForElasticSearchDomainProcessing(
+	conn *elasticsearch.ElasticsearchService, name string, timeout time.Duration) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		out, err := conn.DescribeElasticsearchDomain(&elasticsearch.DescribeElasticsearchDomainInput{
+			DomainName: aws.String(name),
+		})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if !*out.DomainStatus.Processing && out.DomainStatus.Endpoint != nil {
+			return nil
+		}
+
+		return resource.RetryableError(
+			fmt.Errorf("%q: Timeout while waiting for changes to be processed", name))
+	})
+}
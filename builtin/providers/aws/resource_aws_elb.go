@@ -127,6 +127,11 @@ func resourceAwsElb() *schema.Resource {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
 					},
 				},
 			},
@@ -368,7 +373,13 @@ func resourceAwsElbRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("connection_draining_timeout", lbAttrs.ConnectionDraining.Timeout)
 	d.Set("cross_zone_load_balancing", lbAttrs.CrossZoneLoadBalancing.Enabled)
 	if lbAttrs.AccessLog != nil {
-		if err := d.Set("access_logs", flattenAccessLog(lbAttrs.AccessLog)); err != nil {
+		// Disabled access logs are only kept when they're configured, so
+		// that removing the access_logs block doesn't leave a diff.
+		logs := flattenAccessLog(lbAttrs.AccessLog)
+		if !*lbAttrs.AccessLog.Enabled && len(d.Get("access_logs").([]interface{})) == 0 {
+			logs = nil
+		}
+		if err := d.Set("access_logs", logs); err != nil {
 			return err
 		}
 	}
@@ -514,7 +525,7 @@ func resourceAwsElbUpdate(d *schema.ResourceData, meta interface{}) error {
 		} else if len(logs) == 1 {
 			log := logs[0].(map[string]interface{})
 			accessLog := &elb.AccessLog{
-				Enabled:      aws.Bool(true),
+				Enabled:      aws.Bool(log["enabled"].(bool)),
 				EmitInterval: aws.Int64(int64(log["interval"].(int))),
 				S3BucketName: aws.String(log["bucket"].(string)),
 			}
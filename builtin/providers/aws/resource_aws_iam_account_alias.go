@@ -0,0 +1,91 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsIamAccountAlias() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsIamAccountAliasCreate,
+		Read:   resourceAwsIamAccountAliasRead,
+		Delete: resourceAwsIamAccountAliasDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_alias": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAccountAlias,
+			},
+		},
+	}
+}
+
+func resourceAwsIamAccountAliasCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).iamconn
+
+	accountAlias := d.Get("account_alias").(string)
+
+	params := &iam.CreateAccountAliasInput{
+		AccountAlias: aws.String(accountAlias),
+	}
+
+	log.Printf("[DEBUG] Creating IAM account alias: %s", accountAlias)
+	if _, err := conn.CreateAccountAlias(params); err != nil {
+		return fmt.Errorf("Error creating account alias with name %s: %s", accountAlias, err)
+	}
+
+	d.SetId(accountAlias)
+
+	return resourceAwsIamAccountAliasRead(d, meta)
+}
+
+func resourceAwsIamAccountAliasRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).iamconn
+
+	resp, err := conn.ListAccountAliases(&iam.ListAccountAliasesInput{})
+	if err != nil {
+		return fmt.Errorf("Error listing account aliases: %s", err)
+	}
+
+	// An account has at most one alias, so this is either it or nothing.
+	for _, alias := range resp.AccountAliases {
+		if *alias == d.Id() {
+			d.Set("account_alias", alias)
+			return nil
+		}
+	}
+
+	log.Printf("[WARN] IAM account alias (%s) not found, removing from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceAwsIamAccountAliasDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).iamconn
+
+	accountAlias := d.Get("account_alias").(string)
+
+	params := &iam.DeleteAccountAliasInput{
+		AccountAlias: aws.String(accountAlias),
+	}
+
+	log.Printf("[DEBUG] Deleting IAM account alias: %s", accountAlias)
+	if _, err := conn.DeleteAccountAlias(params); err != nil {
+		if iamerr, ok := err.(awserr.Error); ok && iamerr.Code() == "NoSuchEntity" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting account alias with name %s: %s", accountAlias, err)
+	}
+
+	return nil
+}
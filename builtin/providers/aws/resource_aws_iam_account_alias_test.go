@@ -0,0 +1,91 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSIAMAccountAlias_basic(t *testing.T) {
+	rstring := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSIAMAccountAliasDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSIAMAccountAliasConfig(rstring),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSIAMAccountAliasExists("aws_iam_account_alias.test"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "aws_iam_account_alias.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAWSIAMAccountAliasDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).iamconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_iam_account_alias" {
+			continue
+		}
+
+		resp, err := conn.ListAccountAliases(&iam.ListAccountAliasesInput{})
+		if err != nil {
+			return err
+		}
+
+		for _, alias := range resp.AccountAliases {
+			if *alias == rs.Primary.ID {
+				return fmt.Errorf("Account alias %s still exists", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSIAMAccountAliasExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No account alias ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).iamconn
+		resp, err := conn.ListAccountAliases(&iam.ListAccountAliasesInput{})
+		if err != nil {
+			return err
+		}
+
+		for _, alias := range resp.AccountAliases {
+			if *alias == rs.Primary.ID {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Account alias %s not found", rs.Primary.ID)
+	}
+}
+
+func testAccAWSIAMAccountAliasConfig(rstring string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_account_alias" "test" {
+  account_alias = "terraform-%s-alias"
+}
+`, rstring)
+}
@@ -56,10 +56,7 @@ func resourceAwsIamGroupMembershipCreate(d *schema.ResourceData, meta interface{
 func resourceAwsIamGroupMembershipRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).iamconn
 	group := d.Get("group").(string)
-	resp, err := conn.GetGroup(&iam.GetGroupInput{
-		GroupName: aws.String(group),
-	})
-
+	users, err := iamGroupUsers(conn, group)
 	if err != nil {
 		if awsErr, ok := err.(awserr.Error); ok {
 			// aws specific error
@@ -72,8 +69,8 @@ func resourceAwsIamGroupMembershipRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	ul := make([]string, 0, len(resp.Users))
-	for _, u := range resp.Users {
+	ul := make([]string, 0, len(users))
+	for _, u := range users {
 		ul = append(ul, *u.UserName)
 	}
 
@@ -136,7 +133,7 @@ func removeUsersFromGroup(conn *iam.IAM, users []*string, group string) error {
 
 		if err != nil {
 			if iamerr, ok := err.(awserr.Error); ok && iamerr.Code() == "NoSuchEntity" {
-				return nil
+				continue
 			}
 			return err
 		}
@@ -67,15 +67,13 @@ func resourceAwsIamGroupPolicyAttachmentRead(d *schema.ResourceData, meta interf
 		return err
 	}
 
-	attachedPolicies, err := conn.ListAttachedGroupPolicies(&iam.ListAttachedGroupPoliciesInput{
-		GroupName: aws.String(group),
-	})
+	attachedPolicies, err := iamAttachedGroupPolicies(conn, group)
 	if err != nil {
 		return err
 	}
 
 	var policy string
-	for _, p := range attachedPolicies.AttachedPolicies {
+	for _, p := range attachedPolicies {
 		if *p.PolicyArn == arn {
 			policy = *p.PolicyArn
 		}
@@ -2,7 +2,9 @@ package aws
 
 import (
 	"fmt"
+	"log"
 	"regexp"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -17,6 +19,9 @@ func resourceAwsIamInstanceProfile() *schema.Resource {
 		Read:   resourceAwsIamInstanceProfileRead,
 		Update: resourceAwsIamInstanceProfileUpdate,
 		Delete: resourceAwsIamInstanceProfileDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"arn": &schema.Schema{
@@ -173,6 +178,7 @@ func resourceAwsIamInstanceProfileRead(d *schema.ResourceData, meta interface{})
 	result, err := iamconn.GetInstanceProfile(request)
 	if err != nil {
 		if iamerr, ok := err.(awserr.Error); ok && iamerr.Code() == "NoSuchEntity" {
+			log.Printf("[WARN] IAM instance profile (%s) not found, removing from state", d.Id())
 			d.SetId("")
 			return nil
 		}
@@ -194,6 +200,9 @@ func resourceAwsIamInstanceProfileDelete(d *schema.ResourceData, meta interface{
 	}
 	_, err := iamconn.DeleteInstanceProfile(request)
 	if err != nil {
+		if iamerr, ok := err.(awserr.Error); ok && iamerr.Code() == "NoSuchEntity" {
+			return nil
+		}
 		return fmt.Errorf("Error deleting IAM instance profile %s: %s", d.Id(), err)
 	}
 	d.SetId("")
@@ -211,6 +220,14 @@ func instanceProfileReadResult(d *schema.ResourceData, result *iam.InstanceProfi
 	if err := d.Set("path", result.Path); err != nil {
 		return err
 	}
+	if err := d.Set("unique_id", result.InstanceProfileId); err != nil {
+		return err
+	}
+	if result.CreateDate != nil {
+		if err := d.Set("create_date", result.CreateDate.Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
 
 	roles := &schema.Set{F: schema.HashString}
 	for _, role := range result.Roles {
@@ -1,9 +1,11 @@
 package aws
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAWSIAMInstanceProfile_basic(t *testing.T) {
@@ -18,6 +20,56 @@ func TestAccAWSIAMInstanceProfile_basic(t *testing.T) {
 	})
 }
 
+func TestAccAWSIAMInstanceProfile_roleChange(t *testing.T) {
+	var uniqueId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAwsIamInstanceProfileConfig_roleChange("test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSInstanceProfileUniqueId("aws_iam_instance_profile.test", &uniqueId),
+					resource.TestCheckResourceAttr("aws_iam_instance_profile.test", "roles.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAwsIamInstanceProfileConfig_roleChange("test_other"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSInstanceProfileUniqueId("aws_iam_instance_profile.test", &uniqueId),
+					resource.TestCheckResourceAttr("aws_iam_instance_profile.test", "roles.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckAWSInstanceProfileUniqueId records the unique ID of the
+// instance profile the first time it is called, and checks that the
+// profile was not recreated on later calls.
+func testAccCheckAWSInstanceProfileUniqueId(n string, uniqueId *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		id := rs.Primary.Attributes["unique_id"]
+		if id == "" {
+			return fmt.Errorf("No unique_id is set")
+		}
+
+		if *uniqueId == "" {
+			*uniqueId = id
+		} else if *uniqueId != id {
+			return fmt.Errorf("Instance profile was recreated: %s != %s", *uniqueId, id)
+		}
+
+		return nil
+	}
+}
+
 const testAccAwsIamInstanceProfileConfig = `
 resource "aws_iam_role" "test" {
 	name = "test"
@@ -29,3 +81,22 @@ resource "aws_iam_instance_profile" "test" {
 	roles = ["${aws_iam_role.test.name}"]
 }
 `
+
+func testAccAwsIamInstanceProfileConfig_roleChange(role string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+	name = "test"
+	assume_role_policy = "{\"Version\":\"2012-10-17\",\"Statement\":[{\"Effect\":\"Allow\",\"Principal\":{\"Service\":[\"ec2.amazonaws.com\"]},\"Action\":[\"sts:AssumeRole\"]}]}"
+}
+
+resource "aws_iam_role" "test_other" {
+	name = "test_other"
+	assume_role_policy = "{\"Version\":\"2012-10-17\",\"Statement\":[{\"Effect\":\"Allow\",\"Principal\":{\"Service\":[\"ec2.amazonaws.com\"]},\"Action\":[\"sts:AssumeRole\"]}]}"
+}
+
+resource "aws_iam_instance_profile" "test" {
+	name = "test"
+	roles = ["${aws_iam_role.%s.name}"]
+}
+`, role)
+}
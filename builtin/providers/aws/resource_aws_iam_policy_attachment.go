@@ -103,10 +103,7 @@ func resourceAwsIamPolicyAttachmentRead(d *schema.ResourceData, meta interface{}
 		return err
 	}
 
-	policyEntities, err := conn.ListEntitiesForPolicy(&iam.ListEntitiesForPolicyInput{
-		PolicyArn: aws.String(arn),
-	})
-
+	policyEntities, err := iamEntitiesForPolicy(conn, arn)
 	if err != nil {
 		return err
 	}
@@ -135,16 +135,14 @@ func resourceAwsIamRoleDelete(d *schema.ResourceData, meta interface{}) error {
 	iamconn := meta.(*AWSClient).iamconn
 
 	// Roles cannot be destroyed when attached to an existing Instance Profile
-	resp, err := iamconn.ListInstanceProfilesForRole(&iam.ListInstanceProfilesForRoleInput{
-		RoleName: aws.String(d.Id()),
-	})
+	profiles, err := iamInstanceProfilesForRole(iamconn, d.Id())
 	if err != nil {
 		return fmt.Errorf("Error listing Profiles for IAM Role (%s) when trying to delete: %s", d.Id(), err)
 	}
 
 	// Loop and remove this Role from any Profiles
-	if len(resp.InstanceProfiles) > 0 {
-		for _, i := range resp.InstanceProfiles {
+	if len(profiles) > 0 {
+		for _, i := range profiles {
 			_, err := iamconn.RemoveRoleFromInstanceProfile(&iam.RemoveRoleFromInstanceProfileInput{
 				InstanceProfileName: i.InstanceProfileName,
 				RoleName:            aws.String(d.Id()),
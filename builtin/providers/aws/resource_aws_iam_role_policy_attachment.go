@@ -67,15 +67,13 @@ func resourceAwsIamRolePolicyAttachmentRead(d *schema.ResourceData, meta interfa
 		return err
 	}
 
-	attachedPolicies, err := conn.ListAttachedRolePolicies(&iam.ListAttachedRolePoliciesInput{
-		RoleName: aws.String(role),
-	})
+	attachedPolicies, err := iamAttachedRolePolicies(conn, role)
 	if err != nil {
 		return err
 	}
 
 	var policy string
-	for _, p := range attachedPolicies.AttachedPolicies {
+	for _, p := range attachedPolicies {
 		if *p.PolicyArn == arn {
 			policy = *p.PolicyArn
 		}
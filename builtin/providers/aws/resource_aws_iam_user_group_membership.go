@@ -0,0 +1,156 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceAwsIamUserGroupMembership manages a set of groups for a single user
+// without taking ownership of the groups themselves. Unlike
+// aws_iam_group_membership, it leaves alone any other members of the groups
+// and any other groups the user belongs to, so several of these can share a
+// user or group across configurations.
+func resourceAwsIamUserGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsIamUserGroupMembershipCreate,
+		Read:   resourceAwsIamUserGroupMembershipRead,
+		Update: resourceAwsIamUserGroupMembershipUpdate,
+		Delete: resourceAwsIamUserGroupMembershipDelete,
+
+		Schema: map[string]*schema.Schema{
+			"user": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"groups": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceAwsIamUserGroupMembershipCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).iamconn
+
+	user := d.Get("user").(string)
+	groupList := expandStringList(d.Get("groups").(*schema.Set).List())
+
+	if err := addUserToGroups(conn, user, groupList); err != nil {
+		return err
+	}
+
+	d.SetId(resource.UniqueId())
+	return resourceAwsIamUserGroupMembershipRead(d, meta)
+}
+
+func resourceAwsIamUserGroupMembershipRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).iamconn
+	user := d.Get("user").(string)
+
+	groups, err := iamGroupsForUser(conn, user)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchEntity" {
+			log.Printf("[WARN] IAM User %q not found, removing group membership %s from state", user, d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	// Only report the groups this resource manages; membership of any other
+	// group belongs to someone else.
+	configured := d.Get("groups").(*schema.Set)
+	gl := make([]string, 0, configured.Len())
+	for _, g := range groups {
+		if configured.Contains(*g.GroupName) {
+			gl = append(gl, *g.GroupName)
+		}
+	}
+
+	if err := d.Set("groups", gl); err != nil {
+		return fmt.Errorf("[WARN] Error setting group list from IAM User Group Membership (%s), error: %s", user, err)
+	}
+
+	return nil
+}
+
+func resourceAwsIamUserGroupMembershipUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).iamconn
+
+	if d.HasChange("groups") {
+		user := d.Get("user").(string)
+
+		o, n := d.GetChange("groups")
+		if o == nil {
+			o = new(schema.Set)
+		}
+		if n == nil {
+			n = new(schema.Set)
+		}
+
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+		remove := expandStringList(os.Difference(ns).List())
+		add := expandStringList(ns.Difference(os).List())
+
+		if err := removeUserFromGroups(conn, user, remove); err != nil {
+			return err
+		}
+
+		if err := addUserToGroups(conn, user, add); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsIamUserGroupMembershipRead(d, meta)
+}
+
+func resourceAwsIamUserGroupMembershipDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).iamconn
+	user := d.Get("user").(string)
+	groupList := expandStringList(d.Get("groups").(*schema.Set).List())
+
+	return removeUserFromGroups(conn, user, groupList)
+}
+
+func removeUserFromGroups(conn *iam.IAM, user string, groups []*string) error {
+	for _, g := range groups {
+		_, err := conn.RemoveUserFromGroup(&iam.RemoveUserFromGroupInput{
+			UserName:  aws.String(user),
+			GroupName: g,
+		})
+
+		if err != nil {
+			if iamerr, ok := err.(awserr.Error); ok && iamerr.Code() == "NoSuchEntity" {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func addUserToGroups(conn *iam.IAM, user string, groups []*string) error {
+	for _, g := range groups {
+		_, err := conn.AddUserToGroup(&iam.AddUserToGroupInput{
+			UserName:  aws.String(user),
+			GroupName: g,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
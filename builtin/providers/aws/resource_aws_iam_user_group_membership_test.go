@@ -0,0 +1,166 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSUserGroupMembership_basic(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	configBase := fmt.Sprintf(testAccAWSUserGroupMemberConfig, rString, rString, rString, rString)
+	configUpdate := fmt.Sprintf(testAccAWSUserGroupMemberConfigUpdate, rString, rString, rString, rString)
+
+	testUser := fmt.Sprintf("test-user-%s", rString)
+	testGroup := fmt.Sprintf("test-group-%s", rString)
+	testGroupTwo := fmt.Sprintf("test-group-two-%s", rString)
+	testGroupThree := fmt.Sprintf("test-group-three-%s", rString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSUserGroupMembershipDestroy,
+		Steps: []resource.TestStep{
+			// Two memberships for the same user must not clobber each other.
+			resource.TestStep{
+				Config: configBase,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSUserGroupMembershipGroups(testUser, []string{testGroup, testGroupTwo}),
+					resource.TestCheckResourceAttr("aws_iam_user_group_membership.team_one", "groups.#", "1"),
+					resource.TestCheckResourceAttr("aws_iam_user_group_membership.team_two", "groups.#", "1"),
+				),
+			},
+
+			resource.TestStep{
+				Config: configUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSUserGroupMembershipGroups(testUser, []string{testGroupTwo, testGroupThree}),
+					resource.TestCheckResourceAttr("aws_iam_user_group_membership.team_one", "groups.#", "1"),
+					resource.TestCheckResourceAttr("aws_iam_user_group_membership.team_two", "groups.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSUserGroupMembershipDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).iamconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_iam_user_group_membership" {
+			continue
+		}
+
+		groups, err := iamGroupsForUser(conn, rs.Primary.Attributes["user"])
+		if err != nil {
+			if ae, ok := err.(awserr.Error); ok && ae.Code() == "NoSuchEntity" {
+				continue
+			}
+			return err
+		}
+
+		if len(groups) > 0 {
+			return fmt.Errorf("Error: User (%s) is still a member of %d groups",
+				rs.Primary.Attributes["user"], len(groups))
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSUserGroupMembershipGroups(user string, groups []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).iamconn
+
+		actual, err := iamGroupsForUser(conn, user)
+		if err != nil {
+			return fmt.Errorf("Error: User (%s) not found", user)
+		}
+
+		if len(actual) != len(groups) {
+			return fmt.Errorf("Bad group membership count for %s, expected (%d), got (%d)",
+				user, len(groups), len(actual))
+		}
+
+		for _, g := range groups {
+			found := false
+			for _, ag := range actual {
+				if g == *ag.GroupName {
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("Bad group membership: %s is not a member of %s", user, g)
+			}
+		}
+		return nil
+	}
+}
+
+const testAccAWSUserGroupMemberConfig = `
+resource "aws_iam_user" "user" {
+	name = "test-user-%s"
+	path = "/"
+}
+
+resource "aws_iam_group" "group" {
+	name = "test-group-%s"
+	path = "/"
+}
+
+resource "aws_iam_group" "group_two" {
+	name = "test-group-two-%s"
+	path = "/"
+}
+
+resource "aws_iam_group" "group_three" {
+	name = "test-group-three-%s"
+	path = "/"
+}
+
+resource "aws_iam_user_group_membership" "team_one" {
+	user = "${aws_iam_user.user.name}"
+	groups = ["${aws_iam_group.group.name}"]
+}
+
+resource "aws_iam_user_group_membership" "team_two" {
+	user = "${aws_iam_user.user.name}"
+	groups = ["${aws_iam_group.group_two.name}"]
+}
+`
+
+const testAccAWSUserGroupMemberConfigUpdate = `
+resource "aws_iam_user" "user" {
+	name = "test-user-%s"
+	path = "/"
+}
+
+resource "aws_iam_group" "group" {
+	name = "test-group-%s"
+	path = "/"
+}
+
+resource "aws_iam_group" "group_two" {
+	name = "test-group-two-%s"
+	path = "/"
+}
+
+resource "aws_iam_group" "group_three" {
+	name = "test-group-three-%s"
+	path = "/"
+}
+
+resource "aws_iam_user_group_membership" "team_one" {
+	user = "${aws_iam_user.user.name}"
+	groups = ["${aws_iam_group.group_three.name}"]
+}
+
+resource "aws_iam_user_group_membership" "team_two" {
+	user = "${aws_iam_user.user.name}"
+	groups = ["${aws_iam_group.group_two.name}"]
+}
+`
@@ -67,15 +67,13 @@ func resourceAwsIamUserPolicyAttachmentRead(d *schema.ResourceData, meta interfa
 		return err
 	}
 
-	attachedPolicies, err := conn.ListAttachedUserPolicies(&iam.ListAttachedUserPoliciesInput{
-		UserName: aws.String(user),
-	})
+	attachedPolicies, err := iamAttachedUserPolicies(conn, user)
 	if err != nil {
 		return err
 	}
 
 	var policy string
-	for _, p := range attachedPolicies.AttachedPolicies {
+	for _, p := range attachedPolicies {
 		if *p.PolicyArn == arn {
 			policy = *p.PolicyArn
 		}
@@ -129,28 +129,15 @@ func resourceAwsInternetGatewayDelete(d *schema.ResourceData, meta interface{})
 
 	log.Printf("[INFO] Deleting Internet Gateway: %s", d.Id())
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
-		_, err := conn.DeleteInternetGateway(&ec2.DeleteInternetGatewayInput{
-			InternetGatewayId: aws.String(d.Id()),
+	return deleteRetryingDependencyViolation(
+		meta.(*AWSClient).dependencyViolationTimeout,
+		[]string{"InvalidInternetGatewayID.NotFound"},
+		func() error {
+			_, err := conn.DeleteInternetGateway(&ec2.DeleteInternetGatewayInput{
+				InternetGatewayId: aws.String(d.Id()),
+			})
+			return err
 		})
-		if err == nil {
-			return nil
-		}
-
-		ec2err, ok := err.(awserr.Error)
-		if !ok {
-			return resource.RetryableError(err)
-		}
-
-		switch ec2err.Code() {
-		case "InvalidInternetGatewayID.NotFound":
-			return nil
-		case "DependencyViolation":
-			return resource.RetryableError(err) // retry
-		}
-
-		return resource.NonRetryableError(err)
-	})
 }
 
 func resourceAwsInternetGatewayAttach(d *schema.ResourceData, meta interface{}) error {
@@ -35,39 +35,243 @@ func resourceAwsKinesisFirehoseDeliveryStream() *schema.Resource {
 					value := v.(string)
 					return strings.ToLower(value)
 				},
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					value := strings.ToLower(v.(string))
+					if value != "s3" && value != "redshift" && value != "elasticsearch" {
+						errors = append(errors, fmt.Errorf(
+							"%q must be one of 's3', 'redshift', 'elasticsearch'", k))
+					}
+					return
+				},
+			},
+
+			// The s3_configuration block is used for S3 destinations and as the
+			// intermediate (Redshift) or backup (Elasticsearch) bucket for the
+			// other destinations.
+			"s3_configuration": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket_arn": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"buffer_size": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  5,
+						},
+
+						"buffer_interval": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  300,
+						},
+
+						"compression_format": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "UNCOMPRESSED",
+						},
+
+						"prefix": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"role_arn": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"redshift_configuration": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster_jdbcurl": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"username": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"password": &schema.Schema{
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+
+						"role_arn": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"copy_options": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"data_table_columns": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"data_table_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"elasticsearch_configuration": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"buffering_interval": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  300,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								value := v.(int)
+								if value < 60 || value > 900 {
+									errors = append(errors, fmt.Errorf(
+										"%q must be in the range from 60 to 900 seconds.", k))
+								}
+								return
+							},
+						},
+
+						"buffering_size": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  5,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								value := v.(int)
+								if value < 1 || value > 100 {
+									errors = append(errors, fmt.Errorf(
+										"%q must be in the range from 1 to 100 MB.", k))
+								}
+								return
+							},
+						},
+
+						"domain_arn": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"index_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"index_rotation_period": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "OneDay",
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								value := v.(string)
+								if value != "NoRotation" && value != "OneHour" && value != "OneDay" && value != "OneWeek" && value != "OneMonth" {
+									errors = append(errors, fmt.Errorf(
+										"%q must be one of 'NoRotation', 'OneHour', 'OneDay', 'OneWeek', 'OneMonth'", k))
+								}
+								return
+							},
+						},
+
+						"retry_duration": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  300,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								value := v.(int)
+								if value < 0 || value > 7200 {
+									errors = append(errors, fmt.Errorf(
+										"%q must be in the range from 0 to 7200 seconds.", k))
+								}
+								return
+							},
+						},
+
+						"role_arn": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"s3_backup_mode": &schema.Schema{
+							Type:     schema.TypeString,
+							ForceNew: true,
+							Optional: true,
+							Default:  "FailedDocumentsOnly",
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								value := v.(string)
+								if value != "FailedDocumentsOnly" && value != "AllDocuments" {
+									errors = append(errors, fmt.Errorf(
+										"%q must be one of 'FailedDocumentsOnly', 'AllDocuments'", k))
+								}
+								return
+							},
+						},
+
+						"type_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
 			},
 
 			"role_arn": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:       schema.TypeString,
+				Optional:   true,
+				Deprecated: "Use s3_configuration.role_arn instead",
 			},
 
 			"s3_bucket_arn": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:       schema.TypeString,
+				Optional:   true,
+				Deprecated: "Use s3_configuration.bucket_arn instead",
 			},
 
 			"s3_prefix": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:       schema.TypeString,
+				Optional:   true,
+				Deprecated: "Use s3_configuration.prefix instead",
 			},
 
 			"s3_buffer_size": &schema.Schema{
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  5,
+				Type:       schema.TypeInt,
+				Optional:   true,
+				Default:    5,
+				Deprecated: "Use s3_configuration.buffer_size instead",
 			},
 
 			"s3_buffer_interval": &schema.Schema{
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  300,
+				Type:       schema.TypeInt,
+				Optional:   true,
+				Default:    300,
+				Deprecated: "Use s3_configuration.buffer_interval instead",
 			},
 
 			"s3_data_compression": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "UNCOMPRESSED",
+				Type:       schema.TypeString,
+				Optional:   true,
+				Default:    "UNCOMPRESSED",
+				Deprecated: "Use s3_configuration.compression_format instead",
 			},
 
 			"arn": &schema.Schema{
@@ -91,21 +295,38 @@ func resourceAwsKinesisFirehoseDeliveryStream() *schema.Resource {
 	}
 }
 
-func resourceAwsKinesisFirehoseDeliveryStreamCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).firehoseconn
-
-	if d.Get("destination").(string) != "s3" {
-		return fmt.Errorf("[ERROR] AWS Kinesis Firehose only supports S3 destinations for the first implementation")
+// createS3Config builds the S3 destination configuration from the
+// s3_configuration block, falling back to the deprecated top-level s3_*
+// attributes for configurations written before the block existed.
+func createS3Config(d *schema.ResourceData) (*firehose.S3DestinationConfiguration, error) {
+	if v, ok := d.GetOk("s3_configuration"); ok {
+		s3 := v.([]interface{})[0].(map[string]interface{})
+
+		config := &firehose.S3DestinationConfiguration{
+			BucketARN: aws.String(s3["bucket_arn"].(string)),
+			RoleARN:   aws.String(s3["role_arn"].(string)),
+			BufferingHints: &firehose.BufferingHints{
+				IntervalInSeconds: aws.Int64(int64(s3["buffer_interval"].(int))),
+				SizeInMBs:         aws.Int64(int64(s3["buffer_size"].(int))),
+			},
+			CompressionFormat: aws.String(s3["compression_format"].(string)),
+		}
+		if v := s3["prefix"].(string); v != "" {
+			config.Prefix = aws.String(v)
+		}
+		return config, nil
 	}
 
-	sn := d.Get("name").(string)
-	input := &firehose.CreateDeliveryStreamInput{
-		DeliveryStreamName: aws.String(sn),
+	bucket, bucketOk := d.GetOk("s3_bucket_arn")
+	role, roleOk := d.GetOk("role_arn")
+	if !bucketOk || !roleOk {
+		return nil, fmt.Errorf(
+			"s3_configuration is required for Kinesis Firehose Delivery Stream %q", d.Get("name").(string))
 	}
 
-	s3Config := &firehose.S3DestinationConfiguration{
-		BucketARN: aws.String(d.Get("s3_bucket_arn").(string)),
-		RoleARN:   aws.String(d.Get("role_arn").(string)),
+	config := &firehose.S3DestinationConfiguration{
+		BucketARN: aws.String(bucket.(string)),
+		RoleARN:   aws.String(role.(string)),
 		BufferingHints: &firehose.BufferingHints{
 			IntervalInSeconds: aws.Int64(int64(d.Get("s3_buffer_interval").(int))),
 			SizeInMBs:         aws.Int64(int64(d.Get("s3_buffer_size").(int))),
@@ -113,25 +334,168 @@ func resourceAwsKinesisFirehoseDeliveryStreamCreate(d *schema.ResourceData, meta
 		CompressionFormat: aws.String(d.Get("s3_data_compression").(string)),
 	}
 	if v, ok := d.GetOk("s3_prefix"); ok {
-		s3Config.Prefix = aws.String(v.(string))
+		config.Prefix = aws.String(v.(string))
+	}
+	return config, nil
+}
+
+func updateS3Config(d *schema.ResourceData) (*firehose.S3DestinationUpdate, error) {
+	config, err := createS3Config(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return &firehose.S3DestinationUpdate{
+		BucketARN:         config.BucketARN,
+		RoleARN:           config.RoleARN,
+		Prefix:            config.Prefix,
+		BufferingHints:    config.BufferingHints,
+		CompressionFormat: config.CompressionFormat,
+	}, nil
+}
+
+func createRedshiftConfig(d *schema.ResourceData, s3Config *firehose.S3DestinationConfiguration) (*firehose.RedshiftDestinationConfiguration, error) {
+	v, ok := d.GetOk("redshift_configuration")
+	if !ok {
+		return nil, fmt.Errorf("[ERR] Error loading Redshift Configuration for Kinesis Firehose: redshift_configuration not found")
+	}
+	redshift := v.([]interface{})[0].(map[string]interface{})
+
+	return &firehose.RedshiftDestinationConfiguration{
+		ClusterJDBCURL:  aws.String(redshift["cluster_jdbcurl"].(string)),
+		Password:        aws.String(redshift["password"].(string)),
+		Username:        aws.String(redshift["username"].(string)),
+		RoleARN:         aws.String(redshift["role_arn"].(string)),
+		CopyCommand:     extractCopyCommandConfiguration(redshift),
+		S3Configuration: s3Config,
+	}, nil
+}
+
+func updateRedshiftConfig(d *schema.ResourceData, s3Update *firehose.S3DestinationUpdate) (*firehose.RedshiftDestinationUpdate, error) {
+	v, ok := d.GetOk("redshift_configuration")
+	if !ok {
+		return nil, fmt.Errorf("[ERR] Error loading Redshift Configuration for Kinesis Firehose: redshift_configuration not found")
+	}
+	redshift := v.([]interface{})[0].(map[string]interface{})
+
+	return &firehose.RedshiftDestinationUpdate{
+		ClusterJDBCURL: aws.String(redshift["cluster_jdbcurl"].(string)),
+		Password:       aws.String(redshift["password"].(string)),
+		Username:       aws.String(redshift["username"].(string)),
+		RoleARN:        aws.String(redshift["role_arn"].(string)),
+		CopyCommand:    extractCopyCommandConfiguration(redshift),
+		S3Update:       s3Update,
+	}, nil
+}
+
+func extractCopyCommandConfiguration(redshift map[string]interface{}) *firehose.CopyCommand {
+	cmd := &firehose.CopyCommand{
+		DataTableName: aws.String(redshift["data_table_name"].(string)),
+	}
+	if v := redshift["copy_options"].(string); v != "" {
+		cmd.CopyOptions = aws.String(v)
+	}
+	if v := redshift["data_table_columns"].(string); v != "" {
+		cmd.DataTableColumns = aws.String(v)
+	}
+	return cmd
+}
+
+func createElasticsearchConfig(d *schema.ResourceData, s3Config *firehose.S3DestinationConfiguration) (*firehose.ElasticsearchDestinationConfiguration, error) {
+	v, ok := d.GetOk("elasticsearch_configuration")
+	if !ok {
+		return nil, fmt.Errorf("[ERR] Error loading Elasticsearch Configuration for Kinesis Firehose: elasticsearch_configuration not found")
+	}
+	es := v.([]interface{})[0].(map[string]interface{})
+
+	return &firehose.ElasticsearchDestinationConfiguration{
+		BufferingHints:      extractBufferingHints(es),
+		DomainARN:           aws.String(es["domain_arn"].(string)),
+		IndexName:           aws.String(es["index_name"].(string)),
+		IndexRotationPeriod: aws.String(es["index_rotation_period"].(string)),
+		RetryOptions:        extractRetryOptions(es),
+		RoleARN:             aws.String(es["role_arn"].(string)),
+		TypeName:            aws.String(es["type_name"].(string)),
+		S3BackupMode:        aws.String(es["s3_backup_mode"].(string)),
+		S3Configuration:     s3Config,
+	}, nil
+}
+
+func updateElasticsearchConfig(d *schema.ResourceData, s3Update *firehose.S3DestinationUpdate) (*firehose.ElasticsearchDestinationUpdate, error) {
+	v, ok := d.GetOk("elasticsearch_configuration")
+	if !ok {
+		return nil, fmt.Errorf("[ERR] Error loading Elasticsearch Configuration for Kinesis Firehose: elasticsearch_configuration not found")
+	}
+	es := v.([]interface{})[0].(map[string]interface{})
+
+	return &firehose.ElasticsearchDestinationUpdate{
+		BufferingHints:      extractBufferingHints(es),
+		DomainARN:           aws.String(es["domain_arn"].(string)),
+		IndexName:           aws.String(es["index_name"].(string)),
+		IndexRotationPeriod: aws.String(es["index_rotation_period"].(string)),
+		RetryOptions:        extractRetryOptions(es),
+		RoleARN:             aws.String(es["role_arn"].(string)),
+		TypeName:            aws.String(es["type_name"].(string)),
+		S3Update:            s3Update,
+	}, nil
+}
+
+func extractBufferingHints(es map[string]interface{}) *firehose.ElasticsearchBufferingHints {
+	return &firehose.ElasticsearchBufferingHints{
+		IntervalInSeconds: aws.Int64(int64(es["buffering_interval"].(int))),
+		SizeInMBs:         aws.Int64(int64(es["buffering_size"].(int))),
+	}
+}
+
+func extractRetryOptions(es map[string]interface{}) *firehose.ElasticsearchRetryOptions {
+	return &firehose.ElasticsearchRetryOptions{
+		DurationInSeconds: aws.Int64(int64(es["retry_duration"].(int))),
 	}
+}
+
+func resourceAwsKinesisFirehoseDeliveryStreamCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).firehoseconn
 
-	input.S3DestinationConfiguration = s3Config
+	sn := d.Get("name").(string)
+	input := &firehose.CreateDeliveryStreamInput{
+		DeliveryStreamName: aws.String(sn),
+	}
+
+	s3Config, err := createS3Config(d)
+	if err != nil {
+		return err
+	}
+
+	switch d.Get("destination").(string) {
+	case "s3":
+		input.S3DestinationConfiguration = s3Config
+	case "redshift":
+		rc, err := createRedshiftConfig(d, s3Config)
+		if err != nil {
+			return err
+		}
+		input.RedshiftDestinationConfiguration = rc
+	case "elasticsearch":
+		esc, err := createElasticsearchConfig(d, s3Config)
+		if err != nil {
+			return err
+		}
+		input.ElasticsearchDestinationConfiguration = esc
+	}
 
-	var err error
-	for i := 0; i < 5; i++ {
+	err = resource.Retry(1*time.Minute, func() *resource.RetryError {
 		_, err := conn.CreateDeliveryStream(input)
-		if awsErr, ok := err.(awserr.Error); ok {
+		if err != nil {
 			// IAM roles can take ~10 seconds to propagate in AWS:
 			//  http://docs.aws.amazon.com/AWSEC2/latest/UserGuide/iam-roles-for-amazon-ec2.html#launch-instance-with-role-console
-			if awsErr.Code() == "InvalidArgumentException" && strings.Contains(awsErr.Message(), "Firehose is unable to assume role") {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "InvalidArgumentException" && strings.Contains(awsErr.Message(), "Firehose is unable to assume role") {
 				log.Printf("[DEBUG] Firehose could not assume role referenced, retrying...")
-				time.Sleep(2 * time.Second)
-				continue
+				return resource.RetryableError(err)
 			}
+			return resource.NonRetryableError(err)
 		}
-		break
-	}
+		return nil
+	})
 	if err != nil {
 		if awsErr, ok := err.(awserr.Error); ok {
 			return fmt.Errorf("[WARN] Error creating Kinesis Firehose Delivery Stream: \"%s\", code: \"%s\"", awsErr.Message(), awsErr.Code())
@@ -143,7 +507,7 @@ func resourceAwsKinesisFirehoseDeliveryStreamCreate(d *schema.ResourceData, meta
 		Pending:    []string{"CREATING"},
 		Target:     []string{"ACTIVE"},
 		Refresh:    firehoseStreamStateRefreshFunc(conn, sn),
-		Timeout:    5 * time.Minute,
+		Timeout:    20 * time.Minute,
 		Delay:      10 * time.Second,
 		MinTimeout: 3 * time.Second,
 	}
@@ -165,45 +529,36 @@ func resourceAwsKinesisFirehoseDeliveryStreamCreate(d *schema.ResourceData, meta
 func resourceAwsKinesisFirehoseDeliveryStreamUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).firehoseconn
 
-	if d.Get("destination").(string) != "s3" {
-		return fmt.Errorf("[ERROR] AWS Kinesis Firehose only supports S3 destinations for the first implementation")
-	}
-
 	sn := d.Get("name").(string)
-	s3_config := &firehose.S3DestinationUpdate{}
-
-	if d.HasChange("role_arn") {
-		s3_config.RoleARN = aws.String(d.Get("role_arn").(string))
-	}
-
-	if d.HasChange("s3_bucket_arn") {
-		s3_config.BucketARN = aws.String(d.Get("s3_bucket_arn").(string))
-	}
-
-	if d.HasChange("s3_prefix") {
-		s3_config.Prefix = aws.String(d.Get("s3_prefix").(string))
+	updateInput := &firehose.UpdateDestinationInput{
+		DeliveryStreamName:             aws.String(sn),
+		CurrentDeliveryStreamVersionId: aws.String(d.Get("version_id").(string)),
+		DestinationId:                  aws.String(d.Get("destination_id").(string)),
 	}
 
-	if d.HasChange("s3_data_compression") {
-		s3_config.CompressionFormat = aws.String(d.Get("s3_data_compression").(string))
+	s3Update, err := updateS3Config(d)
+	if err != nil {
+		return err
 	}
 
-	if d.HasChange("s3_buffer_interval") || d.HasChange("s3_buffer_size") {
-		bufferingHints := &firehose.BufferingHints{
-			IntervalInSeconds: aws.Int64(int64(d.Get("s3_buffer_interval").(int))),
-			SizeInMBs:         aws.Int64(int64(d.Get("s3_buffer_size").(int))),
+	switch d.Get("destination").(string) {
+	case "s3":
+		updateInput.S3DestinationUpdate = s3Update
+	case "redshift":
+		rc, err := updateRedshiftConfig(d, s3Update)
+		if err != nil {
+			return err
 		}
-		s3_config.BufferingHints = bufferingHints
-	}
-
-	destOpts := &firehose.UpdateDestinationInput{
-		DeliveryStreamName:             aws.String(sn),
-		CurrentDeliveryStreamVersionId: aws.String(d.Get("version_id").(string)),
-		DestinationId:                  aws.String(d.Get("destination_id").(string)),
-		S3DestinationUpdate:            s3_config,
+		updateInput.RedshiftDestinationUpdate = rc
+	case "elasticsearch":
+		esc, err := updateElasticsearchConfig(d, s3Update)
+		if err != nil {
+			return err
+		}
+		updateInput.ElasticsearchDestinationUpdate = esc
 	}
 
-	_, err := conn.UpdateDestination(destOpts)
+	_, err = conn.UpdateDestination(updateInput)
 	if err != nil {
 		return fmt.Errorf(
 			"Error Updating Kinesis Firehose Delivery Stream: \"%s\"\n%s",
@@ -237,11 +592,58 @@ func resourceAwsKinesisFirehoseDeliveryStreamRead(d *schema.ResourceData, meta i
 	if len(s.Destinations) > 0 {
 		destination := s.Destinations[0]
 		d.Set("destination_id", *destination.DestinationId)
+
+		if rd := destination.RedshiftDestinationDescription; rd != nil {
+			if err := d.Set("redshift_configuration", flattenFirehoseRedshiftConfiguration(d, rd)); err != nil {
+				return err
+			}
+		}
+
+		if ed := destination.ElasticsearchDestinationDescription; ed != nil {
+			if err := d.Set("elasticsearch_configuration", flattenFirehoseElasticsearchConfiguration(ed)); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
+func flattenFirehoseRedshiftConfiguration(d *schema.ResourceData, rd *firehose.RedshiftDestinationDescription) []map[string]interface{} {
+	m := map[string]interface{}{
+		"cluster_jdbcurl": aws.StringValue(rd.ClusterJDBCURL),
+		"username":        aws.StringValue(rd.Username),
+		"role_arn":        aws.StringValue(rd.RoleARN),
+		// The password is never returned by the API.
+		"password": d.Get("redshift_configuration.0.password").(string),
+	}
+	if cmd := rd.CopyCommand; cmd != nil {
+		m["copy_options"] = aws.StringValue(cmd.CopyOptions)
+		m["data_table_columns"] = aws.StringValue(cmd.DataTableColumns)
+		m["data_table_name"] = aws.StringValue(cmd.DataTableName)
+	}
+	return []map[string]interface{}{m}
+}
+
+func flattenFirehoseElasticsearchConfiguration(ed *firehose.ElasticsearchDestinationDescription) []map[string]interface{} {
+	m := map[string]interface{}{
+		"domain_arn":            aws.StringValue(ed.DomainARN),
+		"index_name":            aws.StringValue(ed.IndexName),
+		"index_rotation_period": aws.StringValue(ed.IndexRotationPeriod),
+		"role_arn":              aws.StringValue(ed.RoleARN),
+		"s3_backup_mode":        aws.StringValue(ed.S3BackupMode),
+		"type_name":             aws.StringValue(ed.TypeName),
+	}
+	if ed.BufferingHints != nil {
+		m["buffering_interval"] = int(aws.Int64Value(ed.BufferingHints.IntervalInSeconds))
+		m["buffering_size"] = int(aws.Int64Value(ed.BufferingHints.SizeInMBs))
+	}
+	if ed.RetryOptions != nil {
+		m["retry_duration"] = int(aws.Int64Value(ed.RetryOptions.DurationInSeconds))
+	}
+	return []map[string]interface{}{m}
+}
+
 func resourceAwsKinesisFirehoseDeliveryStreamDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).firehoseconn
 
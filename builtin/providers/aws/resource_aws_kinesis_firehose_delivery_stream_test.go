@@ -92,6 +92,132 @@ func TestAccAWSKinesisFirehoseDeliveryStream_s3ConfigUpdates(t *testing.T) {
 	})
 }
 
+func TestAccAWSKinesisFirehoseDeliveryStream_s3Configuration(t *testing.T) {
+	var stream firehose.DeliveryStreamDescription
+
+	ri := rand.New(rand.NewSource(time.Now().UnixNano())).Int()
+	config := fmt.Sprintf(testAccKinesisFirehoseDeliveryStreamBaseConfig,
+		os.Getenv("AWS_ACCOUNT_ID"), ri) +
+		fmt.Sprintf(testAccKinesisFirehoseDeliveryStreamConfig_s3Configuration, ri)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if os.Getenv("AWS_ACCOUNT_ID") == "" {
+				t.Fatal("AWS_ACCOUNT_ID must be set")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKinesisFirehoseDeliveryStreamDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKinesisFirehoseDeliveryStreamExists("aws_kinesis_firehose_delivery_stream.test_stream", &stream),
+					testAccCheckAWSKinesisFirehoseDeliveryStreamAttributes(&stream),
+					resource.TestCheckResourceAttr(
+						"aws_kinesis_firehose_delivery_stream.test_stream", "s3_configuration.0.buffer_size", "10"),
+					resource.TestCheckResourceAttr(
+						"aws_kinesis_firehose_delivery_stream.test_stream", "s3_configuration.0.compression_format", "GZIP"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSKinesisFirehoseDeliveryStream_RedshiftConfigUpdates(t *testing.T) {
+	var stream firehose.DeliveryStreamDescription
+
+	ri := rand.New(rand.NewSource(time.Now().UnixNano())).Int()
+	preConfig := fmt.Sprintf(testAccKinesisFirehoseDeliveryStreamBaseConfig,
+		os.Getenv("AWS_ACCOUNT_ID"), ri) +
+		fmt.Sprintf(testAccKinesisFirehoseDeliveryStreamConfig_RedshiftBasic, ri, ri)
+	postConfig := fmt.Sprintf(testAccKinesisFirehoseDeliveryStreamBaseConfig,
+		os.Getenv("AWS_ACCOUNT_ID"), ri) +
+		fmt.Sprintf(testAccKinesisFirehoseDeliveryStreamConfig_RedshiftUpdates, ri, ri)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckCost(t, "aws_redshift_cluster")
+			if os.Getenv("AWS_ACCOUNT_ID") == "" {
+				t.Fatal("AWS_ACCOUNT_ID must be set")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKinesisFirehoseDeliveryStreamDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: preConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKinesisFirehoseDeliveryStreamExists("aws_kinesis_firehose_delivery_stream.test_stream", &stream),
+					testAccCheckAWSKinesisFirehoseDeliveryStreamAttributes(&stream),
+					resource.TestCheckResourceAttr(
+						"aws_kinesis_firehose_delivery_stream.test_stream", "redshift_configuration.0.copy_options", ""),
+				),
+			},
+
+			resource.TestStep{
+				Config: postConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKinesisFirehoseDeliveryStreamExists("aws_kinesis_firehose_delivery_stream.test_stream", &stream),
+					testAccCheckAWSKinesisFirehoseDeliveryStreamAttributes(&stream),
+					resource.TestCheckResourceAttr(
+						"aws_kinesis_firehose_delivery_stream.test_stream", "redshift_configuration.0.copy_options", "GZIP"),
+					resource.TestCheckResourceAttr(
+						"aws_kinesis_firehose_delivery_stream.test_stream", "redshift_configuration.0.data_table_columns", "test-col"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSKinesisFirehoseDeliveryStream_ElasticsearchConfigUpdates(t *testing.T) {
+	var stream firehose.DeliveryStreamDescription
+
+	ri := rand.New(rand.NewSource(time.Now().UnixNano())).Int()
+	preConfig := fmt.Sprintf(testAccKinesisFirehoseDeliveryStreamBaseElasticsearchConfig,
+		os.Getenv("AWS_ACCOUNT_ID"), ri, ri) +
+		fmt.Sprintf(testAccKinesisFirehoseDeliveryStreamConfig_ElasticsearchBasic, ri)
+	postConfig := fmt.Sprintf(testAccKinesisFirehoseDeliveryStreamBaseElasticsearchConfig,
+		os.Getenv("AWS_ACCOUNT_ID"), ri, ri) +
+		fmt.Sprintf(testAccKinesisFirehoseDeliveryStreamConfig_ElasticsearchUpdates, ri)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if os.Getenv("AWS_ACCOUNT_ID") == "" {
+				t.Fatal("AWS_ACCOUNT_ID must be set")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKinesisFirehoseDeliveryStreamDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: preConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKinesisFirehoseDeliveryStreamExists("aws_kinesis_firehose_delivery_stream.test_stream_es", &stream),
+					testAccCheckAWSKinesisFirehoseDeliveryStreamAttributes(&stream),
+					resource.TestCheckResourceAttr(
+						"aws_kinesis_firehose_delivery_stream.test_stream_es", "elasticsearch_configuration.0.buffering_interval", "300"),
+				),
+			},
+
+			resource.TestStep{
+				Config: postConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKinesisFirehoseDeliveryStreamExists("aws_kinesis_firehose_delivery_stream.test_stream_es", &stream),
+					testAccCheckAWSKinesisFirehoseDeliveryStreamAttributes(&stream),
+					resource.TestCheckResourceAttr(
+						"aws_kinesis_firehose_delivery_stream.test_stream_es", "elasticsearch_configuration.0.buffering_interval", "500"),
+					resource.TestCheckResourceAttr(
+						"aws_kinesis_firehose_delivery_stream.test_stream_es", "elasticsearch_configuration.0.retry_duration", "60"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckKinesisFirehoseDeliveryStreamExists(n string, stream *firehose.DeliveryStreamDescription) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -359,3 +485,211 @@ resource "aws_kinesis_firehose_delivery_stream" "test_stream" {
 	s3_buffer_interval = 400
 	s3_data_compression = "GZIP"
 }`
+
+var testAccKinesisFirehoseDeliveryStreamBaseConfig = `
+resource "aws_iam_role" "firehose" {
+	name = "terraform_acctest_firehose_delivery_role_base"
+	assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "firehose.amazonaws.com"
+      },
+      "Action": "sts:AssumeRole",
+      "Condition": {
+        "StringEquals": {
+          "sts:ExternalId": "%s"
+        }
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	acl = "private"
+}
+
+resource "aws_iam_role_policy" "firehose" {
+	name = "terraform_acctest_firehose_delivery_policy_base"
+	role = "${aws_iam_role.firehose.id}"
+	policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "",
+      "Effect": "Allow",
+      "Action": [
+        "s3:AbortMultipartUpload",
+        "s3:GetBucketLocation",
+        "s3:GetObject",
+        "s3:ListBucket",
+        "s3:ListBucketMultipartUploads",
+        "s3:PutObject"
+      ],
+      "Resource": [
+        "arn:aws:s3:::${aws_s3_bucket.bucket.id}",
+        "arn:aws:s3:::${aws_s3_bucket.bucket.id}/*"
+      ]
+    }
+  ]
+}
+EOF
+}
+`
+
+var testAccKinesisFirehoseDeliveryStreamConfig_s3Configuration = `
+resource "aws_kinesis_firehose_delivery_stream" "test_stream" {
+	depends_on = ["aws_iam_role_policy.firehose"]
+	name = "terraform-kinesis-firehose-s3configtest-%d"
+	destination = "s3"
+	s3_configuration {
+		role_arn = "${aws_iam_role.firehose.arn}"
+		bucket_arn = "${aws_s3_bucket.bucket.arn}"
+		buffer_size = 10
+		compression_format = "GZIP"
+	}
+}`
+
+var testAccKinesisFirehoseDeliveryStreamConfig_RedshiftBasic = `
+resource "aws_redshift_cluster" "test_cluster" {
+	cluster_identifier = "tf-redshift-cluster-%d"
+	database_name = "test"
+	master_username = "testuser"
+	master_password = "T3stPass"
+	node_type = "dc1.large"
+	cluster_type = "single-node"
+}
+
+resource "aws_kinesis_firehose_delivery_stream" "test_stream" {
+	depends_on = ["aws_iam_role_policy.firehose"]
+	name = "terraform-kinesis-firehose-redshifttest-%d"
+	destination = "redshift"
+	s3_configuration {
+		role_arn = "${aws_iam_role.firehose.arn}"
+		bucket_arn = "${aws_s3_bucket.bucket.arn}"
+	}
+	redshift_configuration {
+		role_arn = "${aws_iam_role.firehose.arn}"
+		cluster_jdbcurl = "jdbc:redshift://${aws_redshift_cluster.test_cluster.endpoint}/${aws_redshift_cluster.test_cluster.database_name}"
+		username = "testuser"
+		password = "T3stPass"
+		data_table_name = "test-table"
+	}
+}`
+
+var testAccKinesisFirehoseDeliveryStreamConfig_RedshiftUpdates = `
+resource "aws_redshift_cluster" "test_cluster" {
+	cluster_identifier = "tf-redshift-cluster-%d"
+	database_name = "test"
+	master_username = "testuser"
+	master_password = "T3stPass"
+	node_type = "dc1.large"
+	cluster_type = "single-node"
+}
+
+resource "aws_kinesis_firehose_delivery_stream" "test_stream" {
+	depends_on = ["aws_iam_role_policy.firehose"]
+	name = "terraform-kinesis-firehose-redshifttest-%d"
+	destination = "redshift"
+	s3_configuration {
+		role_arn = "${aws_iam_role.firehose.arn}"
+		bucket_arn = "${aws_s3_bucket.bucket.arn}"
+	}
+	redshift_configuration {
+		role_arn = "${aws_iam_role.firehose.arn}"
+		cluster_jdbcurl = "jdbc:redshift://${aws_redshift_cluster.test_cluster.endpoint}/${aws_redshift_cluster.test_cluster.database_name}"
+		username = "testuser"
+		password = "T3stPass"
+		data_table_name = "test-table"
+		copy_options = "GZIP"
+		data_table_columns = "test-col"
+	}
+}`
+
+var testAccKinesisFirehoseDeliveryStreamBaseElasticsearchConfig = testAccKinesisFirehoseDeliveryStreamBaseConfig + `
+resource "aws_elasticsearch_domain" "test_cluster" {
+	domain_name = "es-test-%d"
+
+	access_policies = <<CONFIG
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {
+        "AWS": "${aws_iam_role.firehose.arn}"
+      },
+      "Action": "es:*",
+      "Resource": "*"
+    }
+  ]
+}
+CONFIG
+}
+
+resource "aws_iam_role_policy" "firehose-elasticsearch" {
+	name = "elasticsearch"
+	role = "${aws_iam_role.firehose.id}"
+	policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": [
+        "es:*"
+      ],
+      "Resource": [
+        "${aws_elasticsearch_domain.test_cluster.arn}",
+        "${aws_elasticsearch_domain.test_cluster.arn}/*"
+      ]
+    }
+  ]
+}
+EOF
+}
+`
+
+var testAccKinesisFirehoseDeliveryStreamConfig_ElasticsearchBasic = `
+resource "aws_kinesis_firehose_delivery_stream" "test_stream_es" {
+	depends_on = ["aws_iam_role_policy.firehose", "aws_iam_role_policy.firehose-elasticsearch"]
+	name = "terraform-kinesis-firehose-es-%d"
+	destination = "elasticsearch"
+	s3_configuration {
+		role_arn = "${aws_iam_role.firehose.arn}"
+		bucket_arn = "${aws_s3_bucket.bucket.arn}"
+	}
+	elasticsearch_configuration {
+		domain_arn = "${aws_elasticsearch_domain.test_cluster.arn}"
+		role_arn = "${aws_iam_role.firehose.arn}"
+		index_name = "test"
+		type_name = "test"
+	}
+}`
+
+var testAccKinesisFirehoseDeliveryStreamConfig_ElasticsearchUpdates = `
+resource "aws_kinesis_firehose_delivery_stream" "test_stream_es" {
+	depends_on = ["aws_iam_role_policy.firehose", "aws_iam_role_policy.firehose-elasticsearch"]
+	name = "terraform-kinesis-firehose-es-%d"
+	destination = "elasticsearch"
+	s3_configuration {
+		role_arn = "${aws_iam_role.firehose.arn}"
+		bucket_arn = "${aws_s3_bucket.bucket.arn}"
+	}
+	elasticsearch_configuration {
+		domain_arn = "${aws_elasticsearch_domain.test_cluster.arn}"
+		role_arn = "${aws_iam_role.firehose.arn}"
+		index_name = "test"
+		type_name = "test"
+		buffering_interval = 500
+		retry_duration = 60
+	}
+}`
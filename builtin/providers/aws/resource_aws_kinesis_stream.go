@@ -3,6 +3,8 @@ package aws
 import (
 	"fmt"
 	"log"
+	"math/big"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -29,7 +31,6 @@ func resourceAwsKinesisStream() *schema.Resource {
 			"shard_count": &schema.Schema{
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"retention_period": &schema.Schema{
@@ -111,6 +112,12 @@ func resourceAwsKinesisStreamUpdate(d *schema.ResourceData, meta interface{}) er
 		return err
 	}
 
+	if !d.IsNewResource() {
+		if err := updateKinesisShardCount(conn, d); err != nil {
+			return err
+		}
+	}
+
 	return resourceAwsKinesisStreamRead(d, meta)
 }
 
@@ -231,6 +238,160 @@ func setKinesisRetentionPeriod(conn *kinesis.Kinesis, d *schema.ResourceData) er
 	return nil
 }
 
+// updateKinesisShardCount reshards the stream one shard at a time until it
+// has shard_count open shards. Kinesis only allows one resharding operation
+// in flight per stream, so we wait for the stream to become ACTIVE again
+// after each split or merge.
+func updateKinesisShardCount(conn *kinesis.Kinesis, d *schema.ResourceData) error {
+	if !d.HasChange("shard_count") {
+		return nil
+	}
+
+	sn := d.Get("name").(string)
+	oraw, nraw := d.GetChange("shard_count")
+	o := oraw.(int)
+	n := nraw.(int)
+
+	for o != n {
+		shards, err := readKinesisOpenShards(conn, sn)
+		if err != nil {
+			return fmt.Errorf("Error reading shards of Kinesis Stream (%s): %s", sn, err)
+		}
+		o = len(shards)
+		if o == n {
+			break
+		}
+
+		if o < n {
+			shard, hashKey := kinesisShardToSplit(shards)
+			log.Printf("[DEBUG] Splitting shard %s of Kinesis Stream %s (%d of %d shards)",
+				*shard.ShardId, sn, o, n)
+			_, err = conn.SplitShard(&kinesis.SplitShardInput{
+				StreamName:         aws.String(sn),
+				ShardToSplit:       shard.ShardId,
+				NewStartingHashKey: aws.String(hashKey),
+			})
+		} else {
+			shard, adjacent := kinesisShardsToMerge(shards)
+			if shard == nil {
+				return fmt.Errorf(
+					"Error merging shards of Kinesis Stream (%s): no adjacent open shards", sn)
+			}
+			log.Printf("[DEBUG] Merging shards %s and %s of Kinesis Stream %s (%d of %d shards)",
+				*shard.ShardId, *adjacent.ShardId, sn, o, n)
+			_, err = conn.MergeShards(&kinesis.MergeShardsInput{
+				StreamName:           aws.String(sn),
+				ShardToMerge:         shard.ShardId,
+				AdjacentShardToMerge: adjacent.ShardId,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("Error resharding Kinesis Stream (%s): %s", sn, err)
+		}
+
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"UPDATING"},
+			Target:     []string{"ACTIVE"},
+			Refresh:    streamStateRefreshFunc(conn, sn),
+			Timeout:    5 * time.Minute,
+			Delay:      10 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf(
+				"Error waiting for Kinesis Stream (%s) to become active: %s",
+				sn, err)
+		}
+	}
+
+	return nil
+}
+
+func readKinesisOpenShards(conn *kinesis.Kinesis, sn string) ([]*kinesis.Shard, error) {
+	describeOpts := &kinesis.DescribeStreamInput{
+		StreamName: aws.String(sn),
+	}
+
+	var shards []*kinesis.Shard
+	err := conn.DescribeStreamPages(describeOpts, func(page *kinesis.DescribeStreamOutput, last bool) (shouldContinue bool) {
+		shards = append(shards, openShards(page.StreamDescription.Shards)...)
+		return !last
+	})
+	return shards, err
+}
+
+// kinesisShardToSplit picks the open shard covering the widest hash key range
+// and returns it along with the hash key halfway through that range, so that
+// repeated splits keep the key space spread as evenly as possible.
+func kinesisShardToSplit(shards []*kinesis.Shard) (*kinesis.Shard, string) {
+	var widest *kinesis.Shard
+	var widestStart, widestSize *big.Int
+	for _, s := range shards {
+		start, end := kinesisShardHashKeyRange(s)
+		size := new(big.Int).Sub(end, start)
+		if widest == nil || size.Cmp(widestSize) > 0 {
+			widest, widestStart, widestSize = s, start, size
+		}
+	}
+	if widest == nil {
+		return nil, ""
+	}
+
+	mid := new(big.Int).Rsh(widestSize, 1)
+	mid.Add(mid, widestStart)
+	mid.Add(mid, big.NewInt(1))
+	return widest, mid.String()
+}
+
+// kinesisShardsToMerge picks the pair of adjacent open shards that together
+// cover the narrowest hash key range. It returns nil if no two open shards
+// are adjacent.
+func kinesisShardsToMerge(shards []*kinesis.Shard) (*kinesis.Shard, *kinesis.Shard) {
+	sorted := make([]*kinesis.Shard, len(shards))
+	copy(sorted, shards)
+	sort.Sort(kinesisShardsByHashKey(sorted))
+
+	var a, b *kinesis.Shard
+	var narrowest *big.Int
+	for i := 0; i+1 < len(sorted); i++ {
+		start, end := kinesisShardHashKeyRange(sorted[i])
+		nextStart, nextEnd := kinesisShardHashKeyRange(sorted[i+1])
+		if new(big.Int).Add(end, big.NewInt(1)).Cmp(nextStart) != 0 {
+			continue
+		}
+
+		size := new(big.Int).Sub(nextEnd, start)
+		if narrowest == nil || size.Cmp(narrowest) < 0 {
+			a, b, narrowest = sorted[i], sorted[i+1], size
+		}
+	}
+
+	return a, b
+}
+
+func kinesisShardHashKeyRange(s *kinesis.Shard) (*big.Int, *big.Int) {
+	start, _ := new(big.Int).SetString(aws.StringValue(s.HashKeyRange.StartingHashKey), 10)
+	end, _ := new(big.Int).SetString(aws.StringValue(s.HashKeyRange.EndingHashKey), 10)
+	if start == nil {
+		start = new(big.Int)
+	}
+	if end == nil {
+		end = new(big.Int)
+	}
+	return start, end
+}
+
+type kinesisShardsByHashKey []*kinesis.Shard
+
+func (s kinesisShardsByHashKey) Len() int      { return len(s) }
+func (s kinesisShardsByHashKey) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s kinesisShardsByHashKey) Less(i, j int) bool {
+	a, _ := kinesisShardHashKeyRange(s[i])
+	b, _ := kinesisShardHashKeyRange(s[j])
+	return a.Cmp(b) < 0
+}
+
 type kinesisStreamState struct {
 	arn             string
 	status          string
@@ -66,10 +66,84 @@ func TestAccAWSKinesisStream_shardCount(t *testing.T) {
 						"aws_kinesis_stream.test_stream", "shard_count", "4"),
 				),
 			},
+
+			resource.TestStep{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKinesisStreamExists("aws_kinesis_stream.test_stream", &stream),
+					testAccCheckAWSKinesisStreamAttributes(&stream),
+					resource.TestCheckResourceAttr(
+						"aws_kinesis_stream.test_stream", "shard_count", "2"),
+				),
+			},
 		},
 	})
 }
 
+func TestKinesisShardToSplit(t *testing.T) {
+	shards := []*kinesis.Shard{
+		testKinesisShard("shardId-000000000001", "0", "99"),
+		testKinesisShard("shardId-000000000002", "100", "399"),
+		testKinesisShard("shardId-000000000003", "400", "499"),
+	}
+
+	shard, hashKey := kinesisShardToSplit(shards)
+	if *shard.ShardId != "shardId-000000000002" {
+		t.Fatalf("expected widest shard to be split, got %s", *shard.ShardId)
+	}
+	if hashKey != "250" {
+		t.Fatalf("expected split at 250, got %s", hashKey)
+	}
+
+	// The full 128-bit key space must not overflow.
+	shards = []*kinesis.Shard{
+		testKinesisShard("shardId-000000000000", "0", "340282366920938463463374607431768211455"),
+	}
+	_, hashKey = kinesisShardToSplit(shards)
+	if hashKey != "170141183460469231731687303715884105728" {
+		t.Fatalf("bad split of full key space: %s", hashKey)
+	}
+}
+
+func TestKinesisShardsToMerge(t *testing.T) {
+	shards := []*kinesis.Shard{
+		testKinesisShard("shardId-000000000003", "400", "499"),
+		testKinesisShard("shardId-000000000001", "0", "99"),
+		testKinesisShard("shardId-000000000002", "100", "349"),
+		testKinesisShard("shardId-000000000004", "350", "399"),
+	}
+
+	a, b := kinesisShardsToMerge(shards)
+	if a == nil || b == nil {
+		t.Fatal("expected a pair of shards to merge")
+	}
+	if *a.ShardId != "shardId-000000000004" || *b.ShardId != "shardId-000000000003" {
+		t.Fatalf("expected narrowest adjacent pair, got %s and %s", *a.ShardId, *b.ShardId)
+	}
+
+	// Shards that don't touch can't be merged.
+	shards = []*kinesis.Shard{
+		testKinesisShard("shardId-000000000001", "0", "99"),
+		testKinesisShard("shardId-000000000003", "400", "499"),
+	}
+	if a, _ := kinesisShardsToMerge(shards); a != nil {
+		t.Fatalf("expected no shards to merge, got %s", *a.ShardId)
+	}
+}
+
+func testKinesisShard(id, start, end string) *kinesis.Shard {
+	return &kinesis.Shard{
+		ShardId: aws.String(id),
+		HashKeyRange: &kinesis.HashKeyRange{
+			StartingHashKey: aws.String(start),
+			EndingHashKey:   aws.String(end),
+		},
+		SequenceNumberRange: &kinesis.SequenceNumberRange{
+			StartingSequenceNumber: aws.String("1"),
+		},
+	}
+}
+
 func TestAccAWSKinesisStream_retentionPeriod(t *testing.T) {
 	var stream kinesis.StreamDescription
 
@@ -154,7 +228,7 @@ func testAccCheckAWSKinesisStreamAttributes(stream *kinesis.StreamDescription) r
 			if *stream.StreamARN != rs.Primary.Attributes["arn"] {
 				return fmt.Errorf("Bad Stream ARN\n\t expected: %s\n\tgot: %s\n", rs.Primary.Attributes["arn"], *stream.StreamARN)
 			}
-			shard_count := strconv.Itoa(len(stream.Shards))
+			shard_count := strconv.Itoa(len(openShards(stream.Shards)))
 			if shard_count != rs.Primary.Attributes["shard_count"] {
 				return fmt.Errorf("Bad Stream Shard Count\n\t expected: %s\n\tgot: %s\n", rs.Primary.Attributes["shard_count"], shard_count)
 			}
@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceAwsLoadBalancerBackendServerPolicy manages the full list of
+// policies attached to one instance port of a load balancer. Destroying it
+// detaches every policy from that port.
+func resourceAwsLoadBalancerBackendServerPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsLoadBalancerBackendServerPolicySet,
+		Read:   resourceAwsLoadBalancerBackendServerPolicyRead,
+		Update: resourceAwsLoadBalancerBackendServerPolicySet,
+		Delete: resourceAwsLoadBalancerBackendServerPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"load_balancer_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"instance_port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"policy_names": &schema.Schema{
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceAwsLoadBalancerBackendServerPolicySet(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	lbName := d.Get("load_balancer_name").(string)
+	instancePort := d.Get("instance_port").(int)
+
+	input := &elb.SetLoadBalancerPoliciesForBackendServerInput{
+		LoadBalancerName: aws.String(lbName),
+		InstancePort:     aws.Int64(int64(instancePort)),
+		PolicyNames:      expandStringList(d.Get("policy_names").(*schema.Set).List()),
+	}
+
+	log.Printf("[DEBUG] ELB set backend server policies opts: %#v", input)
+	if _, err := elbconn.SetLoadBalancerPoliciesForBackendServer(input); err != nil {
+		return fmt.Errorf("Error setting backend server policies: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", lbName, instancePort))
+	return resourceAwsLoadBalancerBackendServerPolicyRead(d, meta)
+}
+
+func resourceAwsLoadBalancerBackendServerPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	lbName, instancePort, err := resourceAwsLoadBalancerBackendServerPolicyParseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := elbconn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(lbName)},
+	})
+	if err != nil {
+		if isLoadBalancerNotFound(err) {
+			log.Printf("[WARN] ELB (%s) not found, removing backend server policies from state", lbName)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving ELB attributes: %s", err)
+	}
+
+	if len(resp.LoadBalancerDescriptions) != 1 {
+		return fmt.Errorf("Unable to find ELB: %#v", resp.LoadBalancerDescriptions)
+	}
+
+	backends := flattenBackendPolicies(resp.LoadBalancerDescriptions[0].BackendServerDescriptions)
+
+	d.Set("load_balancer_name", lbName)
+	d.Set("instance_port", instancePort)
+	d.Set("policy_names", backends[instancePort])
+
+	return nil
+}
+
+func resourceAwsLoadBalancerBackendServerPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	lbName, instancePort, err := resourceAwsLoadBalancerBackendServerPolicyParseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// Setting an empty list of policies detaches them all from the port.
+	input := &elb.SetLoadBalancerPoliciesForBackendServerInput{
+		LoadBalancerName: aws.String(lbName),
+		InstancePort:     aws.Int64(instancePort),
+		PolicyNames:      []*string{},
+	}
+
+	if _, err := elbconn.SetLoadBalancerPoliciesForBackendServer(input); err != nil {
+		if isLoadBalancerNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error removing backend server policies: %s", err)
+	}
+	return nil
+}
+
+// resourceAwsLoadBalancerBackendServerPolicyParseId takes an ID of the form
+// LB_NAME:INSTANCE_PORT and returns the load balancer name and port.
+func resourceAwsLoadBalancerBackendServerPolicyParseId(id string) (string, int64, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", 0, fmt.Errorf("Invalid backend server policy ID %q, expected LB_NAME:INSTANCE_PORT", id)
+	}
+
+	port, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("Invalid instance port in backend server policy ID %q: %s", id, err)
+	}
+	return parts[0], port, nil
+}
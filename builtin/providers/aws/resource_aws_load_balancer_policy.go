@@ -0,0 +1,192 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsLoadBalancerPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsLoadBalancerPolicyCreate,
+		Read:   resourceAwsLoadBalancerPolicyRead,
+		Delete: resourceAwsLoadBalancerPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"load_balancer_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"policy_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"policy_type_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"policy_attribute": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsLoadBalancerPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	attributes := []*elb.PolicyAttribute{}
+	for _, raw := range d.Get("policy_attribute").(*schema.Set).List() {
+		attr := raw.(map[string]interface{})
+		attributes = append(attributes, &elb.PolicyAttribute{
+			AttributeName:  aws.String(attr["name"].(string)),
+			AttributeValue: aws.String(attr["value"].(string)),
+		})
+	}
+
+	lbName := d.Get("load_balancer_name").(string)
+	policyName := d.Get("policy_name").(string)
+	input := &elb.CreateLoadBalancerPolicyInput{
+		LoadBalancerName: aws.String(lbName),
+		PolicyName:       aws.String(policyName),
+		PolicyTypeName:   aws.String(d.Get("policy_type_name").(string)),
+		PolicyAttributes: attributes,
+	}
+
+	log.Printf("[DEBUG] ELB create policy opts: %#v", input)
+	if _, err := elbconn.CreateLoadBalancerPolicy(input); err != nil {
+		return fmt.Errorf("Error creating load balancer policy %s: %s", policyName, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", lbName, policyName))
+	return resourceAwsLoadBalancerPolicyRead(d, meta)
+}
+
+func resourceAwsLoadBalancerPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	lbName, policyName, err := resourceAwsLoadBalancerPolicyParseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	request := &elb.DescribeLoadBalancerPoliciesInput{
+		LoadBalancerName: aws.String(lbName),
+		PolicyNames:      []*string{aws.String(policyName)},
+	}
+
+	resp, err := elbconn.DescribeLoadBalancerPolicies(request)
+	if err != nil {
+		if isLoadBalancerNotFound(err) || isLoadBalancerPolicyNotFound(err) {
+			log.Printf("[WARN] Load balancer policy (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving load balancer policy %s: %s", d.Id(), err)
+	}
+
+	if len(resp.PolicyDescriptions) != 1 {
+		return fmt.Errorf("Unable to find load balancer policy %s", d.Id())
+	}
+
+	policyDesc := resp.PolicyDescriptions[0]
+	attributes := make([]map[string]interface{}, 0, len(policyDesc.PolicyAttributeDescriptions))
+	for _, a := range policyDesc.PolicyAttributeDescriptions {
+		attributes = append(attributes, map[string]interface{}{
+			"name":  *a.AttributeName,
+			"value": *a.AttributeValue,
+		})
+	}
+
+	d.Set("load_balancer_name", lbName)
+	d.Set("policy_name", policyDesc.PolicyName)
+	d.Set("policy_type_name", policyDesc.PolicyTypeName)
+
+	// Policy types fill in every attribute they support with a default, so
+	// only read back the attributes that were configured. On import there
+	// is nothing configured and all of them are kept.
+	if configured := d.Get("policy_attribute").(*schema.Set); configured.Len() > 0 {
+		names := make(map[string]bool)
+		for _, raw := range configured.List() {
+			names[raw.(map[string]interface{})["name"].(string)] = true
+		}
+		filtered := make([]map[string]interface{}, 0, len(names))
+		for _, a := range attributes {
+			if names[a["name"].(string)] {
+				filtered = append(filtered, a)
+			}
+		}
+		attributes = filtered
+	}
+	if err := d.Set("policy_attribute", attributes); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceAwsLoadBalancerPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	lbName, policyName, err := resourceAwsLoadBalancerPolicyParseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	request := &elb.DeleteLoadBalancerPolicyInput{
+		LoadBalancerName: aws.String(lbName),
+		PolicyName:       aws.String(policyName),
+	}
+
+	if _, err := elbconn.DeleteLoadBalancerPolicy(request); err != nil {
+		if isLoadBalancerNotFound(err) || isLoadBalancerPolicyNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting load balancer policy %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+// resourceAwsLoadBalancerPolicyParseId takes an ID of the form
+// LB_NAME:POLICY_NAME and returns the load balancer and policy names.
+func resourceAwsLoadBalancerPolicyParseId(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid load balancer policy ID %q, expected LB_NAME:POLICY_NAME", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func isLoadBalancerPolicyNotFound(err error) bool {
+	elberr, ok := err.(awserr.Error)
+	return ok && elberr.Code() == "PolicyNotFound"
+}
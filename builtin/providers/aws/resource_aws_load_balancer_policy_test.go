@@ -0,0 +1,219 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSLoadBalancerPolicy_basic(t *testing.T) {
+	lbName := fmt.Sprintf("tf-test-lb-%s", acctest.RandString(5))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLoadBalancerPolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSLoadBalancerPolicyConfig(lbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLoadBalancerPolicyExists("aws_load_balancer_policy.proxy"),
+					resource.TestCheckResourceAttr(
+						"aws_load_balancer_policy.proxy", "policy_type_name", "ProxyProtocolPolicyType"),
+					resource.TestCheckResourceAttr(
+						"aws_load_balancer_policy.proxy", "policy_attribute.#", "1"),
+					testAccCheckAWSLoadBalancerBackendServerPolicyExists(
+						"aws_load_balancer_backend_server_policy.proxy", []string{"proxy-policy"}),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSLoadBalancerPolicyConfig_detached(lbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLoadBalancerPolicyExists("aws_load_balancer_policy.proxy"),
+					testAccCheckAWSLoadBalancerBackendServerPolicyExists(
+						"aws_load_balancer_backend_server_policy.proxy", []string{}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSLoadBalancerPolicy_importBasic(t *testing.T) {
+	lbName := fmt.Sprintf("tf-test-lb-%s", acctest.RandString(5))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLoadBalancerPolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSLoadBalancerPolicyConfig(lbName),
+			},
+			resource.TestStep{
+				ResourceName:      "aws_load_balancer_policy.proxy",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				ResourceName:      "aws_load_balancer_backend_server_policy.proxy",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAWSLoadBalancerPolicyDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).elbconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_load_balancer_policy" {
+			continue
+		}
+
+		lbName, policyName, err := resourceAwsLoadBalancerPolicyParseId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		out, err := conn.DescribeLoadBalancerPolicies(
+			&elb.DescribeLoadBalancerPoliciesInput{
+				LoadBalancerName: aws.String(lbName),
+				PolicyNames:      []*string{aws.String(policyName)},
+			})
+		if err != nil {
+			if isLoadBalancerNotFound(err) || isLoadBalancerPolicyNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		if len(out.PolicyDescriptions) > 0 {
+			return fmt.Errorf("Policy still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSLoadBalancerPolicyExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		lbName, policyName, err := resourceAwsLoadBalancerPolicyParseId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).elbconn
+		_, err = conn.DescribeLoadBalancerPolicies(&elb.DescribeLoadBalancerPoliciesInput{
+			LoadBalancerName: aws.String(lbName),
+			PolicyNames:      []*string{aws.String(policyName)},
+		})
+		return err
+	}
+}
+
+func testAccCheckAWSLoadBalancerBackendServerPolicyExists(n string, expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		lbName, port, err := resourceAwsLoadBalancerBackendServerPolicyParseId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).elbconn
+		resp, err := conn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+			LoadBalancerNames: []*string{aws.String(lbName)},
+		})
+		if err != nil {
+			return err
+		}
+
+		backends := flattenBackendPolicies(resp.LoadBalancerDescriptions[0].BackendServerDescriptions)
+		actual := backends[port]
+		if len(actual) != len(expected) {
+			return fmt.Errorf("Expected policies %v on port %d, got %v", expected, port, actual)
+		}
+		for i := range expected {
+			if actual[i] != expected[i] {
+				return fmt.Errorf("Expected policies %v on port %d, got %v", expected, port, actual)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccAWSLoadBalancerPolicyConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_elb" "lb" {
+	name = "%s"
+	availability_zones = ["us-west-2a"]
+	listener {
+		instance_port = 25
+		instance_protocol = "tcp"
+		lb_port = 25
+		lb_protocol = "tcp"
+	}
+}
+
+resource "aws_load_balancer_policy" "proxy" {
+	load_balancer_name = "${aws_elb.lb.name}"
+	policy_name = "proxy-policy"
+	policy_type_name = "ProxyProtocolPolicyType"
+
+	policy_attribute {
+		name = "ProxyProtocol"
+		value = "true"
+	}
+}
+
+resource "aws_load_balancer_backend_server_policy" "proxy" {
+	load_balancer_name = "${aws_elb.lb.name}"
+	instance_port = 25
+	policy_names = ["${aws_load_balancer_policy.proxy.policy_name}"]
+}`, rName)
+}
+
+func testAccAWSLoadBalancerPolicyConfig_detached(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_elb" "lb" {
+	name = "%s"
+	availability_zones = ["us-west-2a"]
+	listener {
+		instance_port = 25
+		instance_protocol = "tcp"
+		lb_port = 25
+		lb_protocol = "tcp"
+	}
+}
+
+resource "aws_load_balancer_policy" "proxy" {
+	load_balancer_name = "${aws_elb.lb.name}"
+	policy_name = "proxy-policy"
+	policy_type_name = "ProxyProtocolPolicyType"
+
+	policy_attribute {
+		name = "ProxyProtocol"
+		value = "true"
+	}
+}
+
+resource "aws_load_balancer_backend_server_policy" "proxy" {
+	load_balancer_name = "${aws_elb.lb.name}"
+	instance_port = 25
+}`, rName)
+}
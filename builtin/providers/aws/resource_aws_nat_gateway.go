@@ -85,7 +85,14 @@ func resourceAwsNatGatewayCreate(d *schema.ResourceData, meta interface{}) error
 		Timeout: 10 * time.Minute,
 	}
 
-	if _, err := stateConf.WaitForState(); err != nil {
+	ngRaw, err := stateConf.WaitForState()
+	if err != nil {
+		// A gateway that failed to create says why, e.g. the EIP is
+		// already associated or the subnet has no free addresses.
+		if ng, ok := ngRaw.(*ec2.NatGateway); ok && ng != nil && *ng.State == "failed" {
+			return fmt.Errorf("Error creating NAT Gateway (%s): %s: %s",
+				d.Id(), aws.StringValue(ng.FailureCode), aws.StringValue(ng.FailureMessage))
+		}
 		return fmt.Errorf("Error waiting for NAT Gateway (%s) to become available: %s", d.Id(), err)
 	}
 
@@ -101,8 +108,10 @@ func resourceAwsNatGatewayRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return err
 	}
-	if ngRaw == nil || strings.ToLower(state) == "deleted" {
-		log.Printf("[INFO] Removing %s from Terraform state as it is not found or in the deleted state.", d.Id())
+	// Failed gateways can't be used and are deleted by AWS after a while,
+	// so they are treated as gone and recreated.
+	if ngRaw == nil || strings.ToLower(state) == "deleted" || strings.ToLower(state) == "failed" {
+		log.Printf("[INFO] Removing %s from Terraform state as it is not found or in the %s state.", d.Id(), state)
 		d.SetId("")
 		return nil
 	}
@@ -112,11 +121,13 @@ func resourceAwsNatGatewayRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("subnet_id", ng.SubnetId)
 
 	// Address
-	address := ng.NatGatewayAddresses[0]
-	d.Set("allocation_id", address.AllocationId)
-	d.Set("network_interface_id", address.NetworkInterfaceId)
-	d.Set("private_ip", address.PrivateIp)
-	d.Set("public_ip", address.PublicIp)
+	if len(ng.NatGatewayAddresses) > 0 {
+		address := ng.NatGatewayAddresses[0]
+		d.Set("allocation_id", address.AllocationId)
+		d.Set("network_interface_id", address.NetworkInterfaceId)
+		d.Set("private_ip", address.PrivateIp)
+		d.Set("public_ip", address.PublicIp)
+	}
 
 	return nil
 }
@@ -153,7 +164,7 @@ func resourceAwsNatGatewayDelete(d *schema.ResourceData, meta interface{}) error
 
 	_, stateErr := stateConf.WaitForState()
 	if stateErr != nil {
-		return fmt.Errorf("Error waiting for NAT Gateway (%s) to delete: %s", d.Id(), err)
+		return fmt.Errorf("Error waiting for NAT Gateway (%s) to delete: %s", d.Id(), stateErr)
 	}
 
 	return nil
@@ -176,7 +187,7 @@ func NGStateRefreshFunc(conn *ec2.EC2, id string) resource.StateRefreshFunc {
 			}
 		}
 
-		if resp == nil {
+		if resp == nil || len(resp.NatGateways) == 0 {
 			// Sometimes AWS just has consistency issues and doesn't see
 			// our instance yet. Return an empty state.
 			return nil, "", nil
@@ -350,7 +350,14 @@ func resourceAwsNetworkInterfaceDelete(d *schema.ResourceData, meta interface{})
 	deleteEniOpts := ec2.DeleteNetworkInterfaceInput{
 		NetworkInterfaceId: aws.String(d.Id()),
 	}
-	if _, err := conn.DeleteNetworkInterface(&deleteEniOpts); err != nil {
+	err := deleteRetryingDependencyViolation(
+		meta.(*AWSClient).dependencyViolationTimeout,
+		[]string{"InvalidNetworkInterfaceID.NotFound"},
+		func() error {
+			_, err := conn.DeleteNetworkInterface(&deleteEniOpts)
+			return err
+		})
+	if err != nil {
 		return fmt.Errorf("Error deleting ENI: %s", err)
 	}
 
@@ -20,6 +20,9 @@ func resourceAwsRDSCluster() *schema.Resource {
 		Read:   resourceAwsRDSClusterRead,
 		Update: resourceAwsRDSClusterUpdate,
 		Delete: resourceAwsRDSClusterDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 
@@ -295,6 +298,7 @@ func resourceAwsRDSClusterRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("database_name", dbc.DatabaseName)
 	}
 
+	d.Set("cluster_identifier", dbc.DBClusterIdentifier)
 	d.Set("db_subnet_group_name", dbc.DBSubnetGroup)
 	d.Set("parameter_group_name", dbc.DBClusterParameterGroup)
 	d.Set("endpoint", dbc.Endpoint)
@@ -378,7 +382,8 @@ func resourceAwsRDSClusterDelete(d *schema.ResourceData, meta interface{}) error
 		DBClusterIdentifier: aws.String(d.Id()),
 	}
 
-	skipFinalSnapshot := d.Get("skip_final_snapshot").(bool)
+	skipFinalSnapshot := d.Get("skip_final_snapshot").(bool) ||
+		meta.(*AWSClient).features.SkipFinalSnapshots
 	deleteOpts.SkipFinalSnapshot = aws.Bool(skipFinalSnapshot)
 
 	if skipFinalSnapshot == false {
@@ -17,6 +17,9 @@ func resourceAwsRDSClusterInstance() *schema.Resource {
 		Read:   resourceAwsRDSClusterInstanceRead,
 		Update: resourceAwsRDSClusterInstanceUpdate,
 		Delete: resourceAwsRDSClusterInstanceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"identifier": &schema.Schema{
@@ -67,6 +70,20 @@ func resourceAwsRDSClusterInstance() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"db_parameter_group_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			// apply_immediately is used to determine when the update modifications
+			// take place, as for aws_db_instance.
+			"apply_immediately": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
 			"tags": tagsSchema(),
 		},
 	}
@@ -94,6 +111,10 @@ func resourceAwsRDSClusterInstanceCreate(d *schema.ResourceData, meta interface{
 		createOpts.DBSubnetGroupName = aws.String(attr.(string))
 	}
 
+	if attr, ok := d.GetOk("db_parameter_group_name"); ok {
+		createOpts.DBParameterGroupName = aws.String(attr.(string))
+	}
+
 	log.Printf("[DEBUG] Creating RDS DB Instance opts: %s", createOpts)
 	resp, err := conn.CreateDBInstance(createOpts)
 	if err != nil {
@@ -139,6 +160,10 @@ func resourceAwsRDSClusterInstanceRead(d *schema.ResourceData, meta interface{})
 	resp, err := conn.DescribeDBClusters(&rds.DescribeDBClustersInput{
 		DBClusterIdentifier: db.DBClusterIdentifier,
 	})
+	if err != nil {
+		return fmt.Errorf("Error describing RDS Cluster (%s) for Cluster Instance (%s): %s",
+			*db.DBClusterIdentifier, d.Id(), err)
+	}
 
 	var dbc *rds.DBCluster
 	for _, c := range resp.DBClusters {
@@ -167,8 +192,19 @@ func resourceAwsRDSClusterInstanceRead(d *schema.ResourceData, meta interface{})
 		d.Set("port", db.Endpoint.Port)
 	}
 
+	d.Set("identifier", db.DBInstanceIdentifier)
+	d.Set("cluster_identifier", db.DBClusterIdentifier)
+	d.Set("instance_class", db.DBInstanceClass)
 	d.Set("publicly_accessible", db.PubliclyAccessible)
 
+	if db.DBSubnetGroup != nil {
+		d.Set("db_subnet_group_name", db.DBSubnetGroup.DBSubnetGroupName)
+	}
+
+	if len(db.DBParameterGroups) > 0 {
+		d.Set("db_parameter_group_name", db.DBParameterGroups[0].DBParameterGroupName)
+	}
+
 	// Fetch and save tags
 	arn, err := buildRDSARN(d.Id(), meta)
 	if err != nil {
@@ -185,6 +221,33 @@ func resourceAwsRDSClusterInstanceRead(d *schema.ResourceData, meta interface{})
 func resourceAwsRDSClusterInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).rdsconn
 
+	if d.HasChange("db_parameter_group_name") {
+		req := &rds.ModifyDBInstanceInput{
+			ApplyImmediately:     aws.Bool(d.Get("apply_immediately").(bool)),
+			DBInstanceIdentifier: aws.String(d.Id()),
+			DBParameterGroupName: aws.String(d.Get("db_parameter_group_name").(string)),
+		}
+
+		log.Printf("[DEBUG] Send RDS Cluster Instance Modification request: %#v", req)
+		if _, err := conn.ModifyDBInstance(req); err != nil {
+			return fmt.Errorf("Error modifying RDS Cluster Instance (%s): %s", d.Id(), err)
+		}
+
+		// reuse db_instance refresh func
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"creating", "backing-up", "modifying"},
+			Target:     []string{"available"},
+			Refresh:    resourceAwsDbInstanceStateRefreshFunc(d, meta),
+			Timeout:    40 * time.Minute,
+			MinTimeout: 10 * time.Second,
+			Delay:      10 * time.Second,
+		}
+
+		if _, err := stateConf.WaitForState(); err != nil {
+			return err
+		}
+	}
+
 	if arn, err := buildRDSARN(d.Id(), meta); err == nil {
 		if err := setTagsRDS(conn, d, arn); err != nil {
 			return err
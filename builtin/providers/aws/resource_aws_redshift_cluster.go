@@ -545,7 +545,8 @@ func resourceAwsRedshiftClusterDelete(d *schema.ResourceData, meta interface{})
 		ClusterIdentifier: aws.String(d.Id()),
 	}
 
-	skipFinalSnapshot := d.Get("skip_final_snapshot").(bool)
+	skipFinalSnapshot := d.Get("skip_final_snapshot").(bool) ||
+		meta.(*AWSClient).features.SkipFinalSnapshots
 	deleteOpts.SkipFinalClusterSnapshot = aws.Bool(skipFinalSnapshot)
 
 	if !skipFinalSnapshot {
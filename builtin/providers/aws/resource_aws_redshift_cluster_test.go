@@ -20,7 +20,10 @@ func TestAccAWSRedshiftCluster_basic(t *testing.T) {
 	config := fmt.Sprintf(testAccAWSRedshiftClusterConfig_basic, ri)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckCost(t, "aws_redshift_cluster")
+		},
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSRedshiftClusterDestroy,
 		Steps: []resource.TestStep{
@@ -46,7 +49,10 @@ func TestAccAWSRedshiftCluster_iamRoles(t *testing.T) {
 	postConfig := fmt.Sprintf(testAccAWSRedshiftClusterConfig_updateIamRoles, ri, ri, ri)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckCost(t, "aws_redshift_cluster")
+		},
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSRedshiftClusterDestroy,
 		Steps: []resource.TestStep{
@@ -79,7 +85,10 @@ func TestAccAWSRedshiftCluster_publiclyAccessible(t *testing.T) {
 	postConfig := fmt.Sprintf(testAccAWSRedshiftClusterConfig_updatePubliclyAccessible, ri)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckCost(t, "aws_redshift_cluster")
+		},
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSRedshiftClusterDestroy,
 		Steps: []resource.TestStep{
@@ -112,7 +121,10 @@ func TestAccAWSRedshiftCluster_updateNodeCount(t *testing.T) {
 	postConfig := fmt.Sprintf(testAccAWSRedshiftClusterConfig_updateNodeCount, ri)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckCost(t, "aws_redshift_cluster")
+		},
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSRedshiftClusterDestroy,
 		Steps: []resource.TestStep{
@@ -145,7 +157,10 @@ func TestAccAWSRedshiftCluster_tags(t *testing.T) {
 	postConfig := fmt.Sprintf(testAccAWSRedshiftClusterConfig_updatedTags, ri)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckCost(t, "aws_redshift_cluster")
+		},
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSRedshiftClusterDestroy,
 		Steps: []resource.TestStep{
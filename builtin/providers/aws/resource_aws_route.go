@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -27,6 +29,9 @@ func resourceAwsRoute() *schema.Resource {
 		Update: resourceAwsRouteUpdate,
 		Delete: resourceAwsRouteDelete,
 		Exists: resourceAwsRouteExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsRouteImportState,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"destination_cidr_block": &schema.Schema{
@@ -169,6 +174,10 @@ func resourceAwsRouteCreate(d *schema.ResourceData, meta interface{}) error {
 				log.Printf("[DEBUG] Trying to create route again: %q", ec2err.Message())
 				return resource.RetryableError(err)
 			}
+			if ec2err.Code() == "RouteAlreadyExists" {
+				return resource.NonRetryableError(routeConflictError(
+					d.Get("route_table_id").(string), d.Get("destination_cidr_block").(string)))
+			}
 
 			return resource.NonRetryableError(err)
 		}
@@ -191,11 +200,35 @@ func resourceAwsRouteCreate(d *schema.ResourceData, meta interface{}) error {
 
 func resourceAwsRouteRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
-	route, err := findResourceRoute(conn, d.Get("route_table_id").(string), d.Get("destination_cidr_block").(string))
+	routeTableId := d.Get("route_table_id").(string)
+	cidr := d.Get("destination_cidr_block").(string)
+
+	route, err := findResourceRoute(conn, routeTableId, cidr)
 	if err != nil {
+		if _, ok := err.(*resource.NotFoundError); ok {
+			// The most common cause of this is an aws_route_table with inline
+			// route blocks for the same table, which removes every route it
+			// doesn't know about on apply. If we know that's the case, removing
+			// the route from the state would only have it recreated and deleted
+			// again on every run.
+			if awsRoutes.InlineManaged(routeTableId) {
+				return fmt.Errorf(
+					"Route in Route Table (%s) with destination (%s) was removed by the "+
+						"inline route blocks of the aws_route_table for %s; a route table "+
+						"with inline route blocks cannot be combined with aws_route "+
+						"resources for the same table.", routeTableId, cidr, routeTableId)
+			}
+			log.Printf("[WARN] Route in Route Table (%s) with destination (%s) not found, "+
+				"removing from state. If the route table also has inline route blocks, "+
+				"they will remove this route on every apply.", routeTableId, cidr)
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
+	awsRoutes.Claim(routeTableId, cidr)
+
 	d.Set("destination_prefix_list_id", route.DestinationPrefixListId)
 	d.Set("gateway_id", route.GatewayId)
 	d.Set("nat_gateway_id", route.NatGatewayId)
@@ -318,6 +351,8 @@ func resourceAwsRouteDelete(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	awsRoutes.Release(d.Get("route_table_id").(string), d.Get("destination_cidr_block").(string))
+
 	d.SetId("")
 	return nil
 }
@@ -351,6 +386,50 @@ func resourceAwsRouteExists(d *schema.ResourceData, meta interface{}) (bool, err
 	return false, nil
 }
 
+// Routes are imported by route table ID and destination CIDR block joined with
+// an underscore, e.g. rtb-656c65616e6f72_10.42.0.0/16.
+func resourceAwsRouteImportState(
+	d *schema.ResourceData,
+	meta interface{}) ([]*schema.ResourceData, error) {
+	routeTableId, cidr, err := parseRouteImportId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	conn := meta.(*AWSClient).ec2conn
+	route, err := findResourceRoute(conn, routeTableId, cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("route_table_id", routeTableId)
+	d.Set("destination_cidr_block", cidr)
+	d.SetId(routeIDHash(d, route))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func parseRouteImportId(id string) (string, string, error) {
+	idParts := strings.SplitN(id, "_", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return "", "", fmt.Errorf(
+			"unexpected format of ID (%q), expected ROUTETABLEID_DESTINATION", id)
+	}
+	return idParts[0], idParts[1], nil
+}
+
+// routeConflictError explains a RouteAlreadyExists error, which almost always
+// means the route is managed twice in the configuration.
+func routeConflictError(routeTableId, cidr string) error {
+	return fmt.Errorf(
+		"Route in Route Table (%s) with destination (%s) already exists. "+
+			"It is either managed by another aws_route resource or by an inline "+
+			"route block of an aws_route_table; a route table with inline route "+
+			"blocks cannot be combined with aws_route resources for the same table. "+
+			"To manage the existing route with an aws_route resource, import it with "+
+			"ID %s_%s.", routeTableId, cidr, routeTableId, cidr)
+}
+
 // Create an ID for a route
 func routeIDHash(d *schema.ResourceData, r *ec2.Route) string {
 	return fmt.Sprintf("r-%s%d", d.Get("route_table_id").(string), hashcode.String(*r.DestinationCidrBlock))
@@ -370,8 +449,10 @@ func findResourceRoute(conn *ec2.EC2, rtbid string, cidr string) (*ec2.Route, er
 	}
 
 	if len(resp.RouteTables) < 1 || resp.RouteTables[0] == nil {
-		return nil, fmt.Errorf("Route table %s is gone, so route does not exist.",
-			routeTableID)
+		return nil, &resource.NotFoundError{
+			Message: fmt.Sprintf("Route table %s is gone, so route does not exist.",
+				routeTableID),
+		}
 	}
 
 	for _, route := range (*resp.RouteTables[0]).Routes {
@@ -380,7 +461,63 @@ func findResourceRoute(conn *ec2.EC2, rtbid string, cidr string) (*ec2.Route, er
 		}
 	}
 
-	return nil, fmt.Errorf(`
-error finding matching route for Route table (%s) and destination CIDR block (%s)`,
-		rtbid, cidr)
+	return nil, &resource.NotFoundError{
+		Message: fmt.Sprintf(
+			"error finding matching route for Route table (%s) and destination CIDR block (%s)",
+			rtbid, cidr),
+	}
+}
+
+// routeRegistry remembers, for the lifetime of the plugin, which routes are
+// managed by aws_route resources and which route tables manage their routes
+// with inline route blocks. Resources are refreshed and applied by the same
+// plugin process, so this lets each side notice the other one.
+type routeRegistry struct {
+	sync.Mutex
+
+	claimed map[string]bool
+	inline  map[string]bool
+}
+
+var awsRoutes = &routeRegistry{
+	claimed: make(map[string]bool),
+	inline:  make(map[string]bool),
+}
+
+// Claim records that the route to cidr in the route table is managed by an
+// aws_route resource.
+func (r *routeRegistry) Claim(routeTableId, cidr string) {
+	r.Lock()
+	defer r.Unlock()
+	r.claimed[routeTableId+"_"+cidr] = true
+}
+
+// Release forgets a route claimed with Claim.
+func (r *routeRegistry) Release(routeTableId, cidr string) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.claimed, routeTableId+"_"+cidr)
+}
+
+// Claimed returns true if an aws_route resource manages the route.
+func (r *routeRegistry) Claimed(routeTableId, cidr string) bool {
+	r.Lock()
+	defer r.Unlock()
+	return r.claimed[routeTableId+"_"+cidr]
+}
+
+// SetInlineManaged records that the routes of the route table are managed
+// by the inline route blocks of an aws_route_table.
+func (r *routeRegistry) SetInlineManaged(routeTableId string) {
+	r.Lock()
+	defer r.Unlock()
+	r.inline[routeTableId] = true
+}
+
+// InlineManaged returns true if the routes of the route table are managed
+// by inline route blocks.
+func (r *routeRegistry) InlineManaged(routeTableId string) bool {
+	r.Lock()
+	defer r.Unlock()
+	return r.inline[routeTableId]
 }
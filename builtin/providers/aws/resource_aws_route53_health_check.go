@@ -95,6 +95,35 @@ func resourceAwsRoute53HealthCheck() *schema.Resource {
 				},
 			},
 
+			"cloudwatch_alarm_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"cloudwatch_alarm_region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"insufficient_data_health_status": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRoute53HealthCheckInsufficientDataHealthStatus,
+			},
+
+			"enable_sni": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"regions": &schema.Schema{
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				Set:      schema.HashString,
+			},
+
 			"tags": tagsSchema(),
 		},
 	}
@@ -135,6 +164,29 @@ func resourceAwsRoute53HealthCheckUpdate(d *schema.ResourceData, meta interface{
 		updateHealthCheck.HealthThreshold = aws.Int64(int64(d.Get("child_health_threshold").(int)))
 	}
 
+	if d.HasChange("search_string") {
+		updateHealthCheck.SearchString = aws.String(d.Get("search_string").(string))
+	}
+
+	if d.HasChange("cloudwatch_alarm_name") || d.HasChange("cloudwatch_alarm_region") {
+		updateHealthCheck.AlarmIdentifier = &route53.AlarmIdentifier{
+			Name:   aws.String(d.Get("cloudwatch_alarm_name").(string)),
+			Region: aws.String(d.Get("cloudwatch_alarm_region").(string)),
+		}
+	}
+
+	if d.HasChange("insufficient_data_health_status") {
+		updateHealthCheck.InsufficientDataHealthStatus = aws.String(d.Get("insufficient_data_health_status").(string))
+	}
+
+	if d.HasChange("enable_sni") {
+		updateHealthCheck.EnableSNI = aws.Bool(d.Get("enable_sni").(bool))
+	}
+
+	if d.HasChange("regions") {
+		updateHealthCheck.Regions = expandStringList(d.Get("regions").(*schema.Set).List())
+	}
+
 	_, err := conn.UpdateHealthCheck(updateHealthCheck)
 	if err != nil {
 		return err
@@ -192,6 +244,33 @@ func resourceAwsRoute53HealthCheckCreate(d *schema.ResourceData, meta interface{
 		healthConfig.Inverted = aws.Bool(v.(bool))
 	}
 
+	if v, ok := d.GetOk("enable_sni"); ok {
+		healthConfig.EnableSNI = aws.Bool(v.(bool))
+	}
+
+	if v, ok := d.GetOk("regions"); ok {
+		healthConfig.Regions = expandStringList(v.(*schema.Set).List())
+	}
+
+	if *healthConfig.Type == route53.HealthCheckTypeCloudwatchMetric {
+		cloudwatchAlarmName, ok := d.GetOk("cloudwatch_alarm_name")
+		if !ok {
+			return fmt.Errorf("cloudwatch_alarm_name is required when type is %s", route53.HealthCheckTypeCloudwatchMetric)
+		}
+		cloudwatchAlarmRegion, ok := d.GetOk("cloudwatch_alarm_region")
+		if !ok {
+			return fmt.Errorf("cloudwatch_alarm_region is required when type is %s", route53.HealthCheckTypeCloudwatchMetric)
+		}
+		healthConfig.AlarmIdentifier = &route53.AlarmIdentifier{
+			Name:   aws.String(cloudwatchAlarmName.(string)),
+			Region: aws.String(cloudwatchAlarmRegion.(string)),
+		}
+
+		if v, ok := d.GetOk("insufficient_data_health_status"); ok {
+			healthConfig.InsufficientDataHealthStatus = aws.String(v.(string))
+		}
+	}
+
 	if *healthConfig.Type == route53.HealthCheckTypeCalculated {
 		if v, ok := d.GetOk("child_healthchecks"); ok {
 			healthConfig.ChildHealthChecks = expandStringList(v.(*schema.Set).List())
@@ -228,9 +307,9 @@ func resourceAwsRoute53HealthCheckRead(d *schema.ResourceData, meta interface{})
 	read, err := conn.GetHealthCheck(&route53.GetHealthCheckInput{HealthCheckId: aws.String(d.Id())})
 	if err != nil {
 		if r53err, ok := err.(awserr.Error); ok && r53err.Code() == "NoSuchHealthCheck" {
+			log.Printf("[WARN] Route53 health check (%s) not found, removing from state", d.Id())
 			d.SetId("")
 			return nil
-
 		}
 		return err
 	}
@@ -252,6 +331,14 @@ func resourceAwsRoute53HealthCheckRead(d *schema.ResourceData, meta interface{})
 	d.Set("invert_healthcheck", updated.Inverted)
 	d.Set("child_healthchecks", updated.ChildHealthChecks)
 	d.Set("child_health_threshold", updated.HealthThreshold)
+	d.Set("insufficient_data_health_status", updated.InsufficientDataHealthStatus)
+	d.Set("enable_sni", updated.EnableSNI)
+	d.Set("regions", flattenStringList(updated.Regions))
+
+	if updated.AlarmIdentifier != nil {
+		d.Set("cloudwatch_alarm_name", updated.AlarmIdentifier.Name)
+		d.Set("cloudwatch_alarm_region", updated.AlarmIdentifier.Region)
+	}
 
 	// read the tags
 	req := &route53.ListTagsForResourceInput{
@@ -282,6 +369,9 @@ func resourceAwsRoute53HealthCheckDelete(d *schema.ResourceData, meta interface{
 	log.Printf("[DEBUG] Deleteing Route53 health check: %s", d.Id())
 	_, err := conn.DeleteHealthCheck(&route53.DeleteHealthCheckInput{HealthCheckId: aws.String(d.Id())})
 	if err != nil {
+		if r53err, ok := err.(awserr.Error); ok && r53err.Code() == "NoSuchHealthCheck" {
+			return nil
+		}
 		return err
 	}
 
@@ -73,6 +73,70 @@ func TestAccAWSRoute53HealthCheck_IpConfig(t *testing.T) {
 	})
 }
 
+func TestAccAWSRoute53HealthCheck_withHealthCheckRegions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRoute53HealthCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRoute53HealthCheckConfig_withHealthCheckRegions,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoute53HealthCheckExists("aws_route53_health_check.foo"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_health_check.foo", "regions.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSRoute53HealthCheck_withSNI(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRoute53HealthCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRoute53HealthCheckConfigWithSNI(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoute53HealthCheckExists("aws_route53_health_check.foo"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_health_check.foo", "enable_sni", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccRoute53HealthCheckConfigWithSNI(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoute53HealthCheckExists("aws_route53_health_check.foo"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_health_check.foo", "enable_sni", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSRoute53HealthCheck_CloudWatchAlarmCheck(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRoute53HealthCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRoute53HealthCheckCloudWatchAlarm,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoute53HealthCheckExists("aws_route53_health_check.foo"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_health_check.foo", "cloudwatch_alarm_name", "cloudwatch-healthcheck-alarm"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_health_check.foo", "insufficient_data_health_status", "Healthy"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckRoute53HealthCheckDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).r53conn
 
@@ -208,3 +272,59 @@ resource "aws_route53_health_check" "foo" {
    }
 }
 `
+
+const testAccRoute53HealthCheckConfig_withHealthCheckRegions = `
+resource "aws_route53_health_check" "foo" {
+  ip_address = "1.2.3.4"
+  port = 80
+  type = "HTTP"
+  resource_path = "/"
+  failure_threshold = "2"
+  request_interval = "30"
+
+  regions = ["us-west-1","us-east-1","eu-west-1"]
+
+  tags = {
+    Name = "tf-test-check-with-regions"
+   }
+}
+`
+
+func testAccRoute53HealthCheckConfigWithSNI(enableSNI bool) string {
+	return fmt.Sprintf(`
+resource "aws_route53_health_check" "foo" {
+  fqdn = "dev.notexample.com"
+  port = 443
+  type = "HTTPS"
+  resource_path = "/"
+  failure_threshold = "2"
+  request_interval = "30"
+  enable_sni = %t
+
+  tags = {
+    Name = "tf-test-health-check"
+   }
+}
+`, enableSNI)
+}
+
+const testAccRoute53HealthCheckCloudWatchAlarm = `
+resource "aws_cloudwatch_metric_alarm" "foobar" {
+  alarm_name = "cloudwatch-healthcheck-alarm"
+  comparison_operator = "GreaterThanOrEqualToThreshold"
+  evaluation_periods = "2"
+  metric_name = "CPUUtilization"
+  namespace = "AWS/EC2"
+  period = "120"
+  statistic = "Average"
+  threshold = "80"
+  alarm_description = "This metric monitors ec2 cpu utilization"
+}
+
+resource "aws_route53_health_check" "foo" {
+  type = "CLOUDWATCH_METRIC"
+  cloudwatch_alarm_name = "${aws_cloudwatch_metric_alarm.foobar.alarm_name}"
+  cloudwatch_alarm_region = "us-west-2"
+  insufficient_data_health_status = "Healthy"
+}
+`
@@ -26,6 +26,9 @@ func resourceAwsRoute53Record() *schema.Resource {
 		Read:   resourceAwsRoute53RecordRead,
 		Update: resourceAwsRoute53RecordUpdate,
 		Delete: resourceAwsRoute53RecordDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		SchemaVersion: 2,
 		MigrateState:  resourceAwsRoute53RecordMigrateState,
@@ -342,8 +345,6 @@ func resourceAwsRoute53RecordRead(d *schema.ResourceData, meta interface{}) erro
 		if len(parts) > 3 {
 			d.Set("set_identifier", parts[3])
 		}
-
-		d.Set("weight", -1)
 	}
 
 	record, err := findRecord(d, meta)
@@ -462,25 +463,40 @@ func findRecord(d *schema.ResourceData, meta interface{}) (*route53.ResourceReco
 
 	log.Printf("[DEBUG] List resource records sets for zone: %s, opts: %s",
 		zone, lopts)
-	resp, err := conn.ListResourceRecordSets(lopts)
+
+	// Records sharing a name and type (weighted, latency, failover, ...)
+	// can span several pages, so keep paging for as long as every record on
+	// the page still matches the name and type we started at.
+	var found *route53.ResourceRecordSet
+	err = conn.ListResourceRecordSetsPages(lopts, func(resp *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+		allMatched := true
+		for _, record := range resp.ResourceRecordSets {
+			name := cleanRecordName(*record.Name)
+			if FQDN(strings.ToLower(name)) != FQDN(strings.ToLower(*lopts.StartRecordName)) {
+				allMatched = false
+				continue
+			}
+			if strings.ToUpper(*record.Type) != strings.ToUpper(*lopts.StartRecordType) {
+				allMatched = false
+				continue
+			}
+
+			if record.SetIdentifier != nil && *record.SetIdentifier != d.Get("set_identifier") {
+				continue
+			}
+
+			found = record
+			return false
+		}
+		return allMatched
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	for _, record := range resp.ResourceRecordSets {
-		name := cleanRecordName(*record.Name)
-		if FQDN(strings.ToLower(name)) != FQDN(strings.ToLower(*lopts.StartRecordName)) {
-			continue
-		}
-		if strings.ToUpper(*record.Type) != strings.ToUpper(*lopts.StartRecordType) {
-			continue
-		}
-
-		if record.SetIdentifier != nil && *record.SetIdentifier != d.Get("set_identifier") {
-			continue
-		}
-		// The only safe return where a record is found
-		return record, nil
+	// The only safe return where a record is found
+	if found != nil {
+		return found, nil
 	}
 	return nil, r53NoRecordsFound
 }
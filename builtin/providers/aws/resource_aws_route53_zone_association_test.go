@@ -36,18 +36,11 @@ func TestAccAWSRoute53ZoneAssociation_region(t *testing.T) {
 	// record the initialized providers so that we can use them to
 	// check for the instances in each region
 	var providers []*schema.Provider
-	providerFactories := map[string]terraform.ResourceProviderFactory{
-		"aws": func() (terraform.ResourceProvider, error) {
-			p := Provider()
-			providers = append(providers, p.(*schema.Provider))
-			return p, nil
-		},
-	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckRoute53ZoneAssociationDestroyWithProviders(&providers),
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckWithProviders(testAccCheckRoute53ZoneAssociationDestroyWithProvider, &providers),
 		Steps: []resource.TestStep{
 			resource.TestStep{
 				Config: testAccRoute53ZoneAssociationRegionConfig,
@@ -63,20 +56,6 @@ func testAccCheckRoute53ZoneAssociationDestroy(s *terraform.State) error {
 	return testAccCheckRoute53ZoneAssociationDestroyWithProvider(s, testAccProvider)
 }
 
-func testAccCheckRoute53ZoneAssociationDestroyWithProviders(providers *[]*schema.Provider) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		for _, provider := range *providers {
-			if provider.Meta() == nil {
-				continue
-			}
-			if err := testAccCheckRoute53ZoneAssociationDestroyWithProvider(s, provider); err != nil {
-				return err
-			}
-		}
-		return nil
-	}
-}
-
 func testAccCheckRoute53ZoneAssociationDestroyWithProvider(s *terraform.State, provider *schema.Provider) error {
 	conn := provider.Meta().(*AWSClient).r53conn
 	for _, rs := range s.RootModule().Resources {
@@ -178,17 +157,7 @@ resource "aws_route53_zone_association" "foobar" {
 }
 `
 
-const testAccRoute53ZoneAssociationRegionConfig = `
-provider "aws" {
-	alias = "west"
-	region = "us-west-2"
-}
-
-provider "aws" {
-	alias = "east"
-	region = "us-east-1"
-}
-
+const testAccRoute53ZoneAssociationRegionConfig = testAccMultipleRegionProviderConfig + `
 resource "aws_vpc" "foo" {
 	provider = "aws.west"
 	cidr_block = "10.6.0.0/16"
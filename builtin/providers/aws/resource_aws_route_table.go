@@ -262,10 +262,23 @@ func resourceAwsRouteTableUpdate(d *schema.ResourceData, meta interface{}) error
 		ors := o.(*schema.Set).Difference(n.(*schema.Set))
 		nrs := n.(*schema.Set).Difference(o.(*schema.Set))
 
+		awsRoutes.SetInlineManaged(d.Id())
+
 		// Now first loop through all the old routes and delete any obsolete ones
 		for _, route := range ors.List() {
 			m := route.(map[string]interface{})
 
+			// A route that isn't in the config but managed by an aws_route
+			// would be deleted here and recreated by the aws_route on every
+			// run.
+			if awsRoutes.Claimed(d.Id(), m["cidr_block"].(string)) {
+				return fmt.Errorf(
+					"Route Table (%s) has inline route blocks, but its route with "+
+						"destination (%s) is managed by an aws_route resource; a route "+
+						"table with inline route blocks cannot be combined with aws_route "+
+						"resources for the same table.", d.Id(), m["cidr_block"].(string))
+			}
+
 			// Delete the route as it no longer exists in the config
 			log.Printf(
 				"[INFO] Deleting route from %s: %s",
@@ -302,6 +315,9 @@ func resourceAwsRouteTableUpdate(d *schema.ResourceData, meta interface{}) error
 
 			log.Printf("[INFO] Creating route for %s: %#v", d.Id(), opts)
 			if _, err := conn.CreateRoute(&opts); err != nil {
+				if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "RouteAlreadyExists" {
+					return routeConflictError(d.Id(), m["cidr_block"].(string))
+				}
 				return err
 			}
 
@@ -238,6 +238,22 @@ func TestAccAWSRoute_mix(t *testing.T) {
 }
 */
 
+func TestParseRouteImportId(t *testing.T) {
+	rtb, cidr, err := parseRouteImportId("rtb-656c65616e6f72_10.42.0.0/16")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if rtb != "rtb-656c65616e6f72" || cidr != "10.42.0.0/16" {
+		t.Fatalf("bad: %s, %s", rtb, cidr)
+	}
+
+	for _, id := range []string{"rtb-656c65616e6f72", "rtb-656c65616e6f72_", "_10.42.0.0/16"} {
+		if _, _, err := parseRouteImportId(id); err == nil {
+			t.Fatalf("expected error for %q", id)
+		}
+	}
+}
+
 func testAccCheckAWSRouteExists(n string, res *ec2.Route) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -412,3 +428,33 @@ resource "aws_vpc_endpoint" "baz" {
   route_table_ids = ["${aws_route_table.foo.id}"]
 }
 `)
+
+func TestRouteRegistry(t *testing.T) {
+	r := &routeRegistry{
+		claimed: make(map[string]bool),
+		inline:  make(map[string]bool),
+	}
+
+	if r.Claimed("rtb-1", "10.0.0.0/16") {
+		t.Fatal("route shouldn't be claimed")
+	}
+	r.Claim("rtb-1", "10.0.0.0/16")
+	if !r.Claimed("rtb-1", "10.0.0.0/16") {
+		t.Fatal("route should be claimed")
+	}
+	if r.Claimed("rtb-2", "10.0.0.0/16") || r.Claimed("rtb-1", "10.1.0.0/16") {
+		t.Fatal("only the claimed route should be claimed")
+	}
+	r.Release("rtb-1", "10.0.0.0/16")
+	if r.Claimed("rtb-1", "10.0.0.0/16") {
+		t.Fatal("route should be released")
+	}
+
+	if r.InlineManaged("rtb-1") {
+		t.Fatal("route table shouldn't be inline managed")
+	}
+	r.SetInlineManaged("rtb-1")
+	if !r.InlineManaged("rtb-1") || r.InlineManaged("rtb-2") {
+		t.Fatal("only rtb-1 should be inline managed")
+	}
+}
@@ -737,7 +737,7 @@ func resourceAwsS3BucketDelete(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		ec2err, ok := err.(awserr.Error)
 		if ok && ec2err.Code() == "BucketNotEmpty" {
-			if d.Get("force_destroy").(bool) {
+			if d.Get("force_destroy").(bool) || meta.(*AWSClient).features.ForceDestroyS3Buckets {
 				// bucket may have things delete them
 				log.Printf("[DEBUG] S3 Bucket attempting to forceDestroy %+v", err)
 
@@ -417,7 +417,7 @@ func flattenTopicConfigurations(configs []*s3.TopicConfiguration) []map[string]i
 		}
 
 		conf["id"] = *notification.Id
-		conf["events"] = schema.NewSet(schema.HashString, flattenStringList(notification.Events))
+		conf["events"] = flattenStringSet(notification.Events)
 		conf["topic_arn"] = *notification.TopicArn
 		topicNotifications = append(topicNotifications, conf)
 	}
@@ -436,7 +436,7 @@ func flattenQueueConfigurations(configs []*s3.QueueConfiguration) []map[string]i
 		}
 
 		conf["id"] = *notification.Id
-		conf["events"] = schema.NewSet(schema.HashString, flattenStringList(notification.Events))
+		conf["events"] = flattenStringSet(notification.Events)
 		conf["queue_arn"] = *notification.QueueArn
 		queueNotifications = append(queueNotifications, conf)
 	}
@@ -455,7 +455,7 @@ func flattenLambdaFunctionConfigurations(configs []*s3.LambdaFunctionConfigurati
 		}
 
 		conf["id"] = *notification.Id
-		conf["events"] = schema.NewSet(schema.HashString, flattenStringList(notification.Events))
+		conf["events"] = flattenStringSet(notification.Events)
 		conf["lambda_function_arn"] = *notification.LambdaFunctionArn
 		lambdaFunctionNotifications = append(lambdaFunctionNotifications, conf)
 	}
@@ -216,16 +216,12 @@ func resourceAwsS3BucketObjectDelete(d *schema.ResourceData, meta interface{}) e
 
 	if _, ok := d.GetOk("version_id"); ok {
 		// Bucket is versioned, we need to delete all versions
-		vInput := s3.ListObjectVersionsInput{
-			Bucket: aws.String(bucket),
-			Prefix: aws.String(key),
-		}
-		out, err := s3conn.ListObjectVersions(&vInput)
+		versions, err := s3ObjectVersions(s3conn, bucket, key)
 		if err != nil {
 			return fmt.Errorf("Failed listing S3 object versions: %s", err)
 		}
 
-		for _, v := range out.Versions {
+		for _, v := range versions {
 			input := s3.DeleteObjectInput{
 				Bucket:    aws.String(bucket),
 				Key:       aws.String(key),
@@ -167,34 +167,14 @@ func resourceAwsSubnetDelete(d *schema.ResourceData, meta interface{}) error {
 		SubnetId: aws.String(d.Id()),
 	}
 
-	wait := resource.StateChangeConf{
-		Pending:    []string{"pending"},
-		Target:     []string{"destroyed"},
-		Timeout:    5 * time.Minute,
-		MinTimeout: 1 * time.Second,
-		Refresh: func() (interface{}, string, error) {
+	err := deleteRetryingDependencyViolation(
+		meta.(*AWSClient).dependencyViolationTimeout,
+		[]string{"InvalidSubnetID.NotFound"},
+		func() error {
 			_, err := conn.DeleteSubnet(req)
-			if err != nil {
-				if apiErr, ok := err.(awserr.Error); ok {
-					if apiErr.Code() == "DependencyViolation" {
-						// There is some pending operation, so just retry
-						// in a bit.
-						return 42, "pending", nil
-					}
-
-					if apiErr.Code() == "InvalidSubnetID.NotFound" {
-						return 42, "destroyed", nil
-					}
-				}
-
-				return 42, "failure", err
-			}
-
-			return 42, "destroyed", nil
-		},
-	}
-
-	if _, err := wait.WaitForState(); err != nil {
+			return err
+		})
+	if err != nil {
 		return fmt.Errorf("Error deleting subnet: %s", err)
 	}
 
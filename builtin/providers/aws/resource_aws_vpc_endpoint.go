@@ -16,6 +16,10 @@ func resourceAwsVpcEndpoint() *schema.Resource {
 		Read:   resourceAwsVPCEndpointRead,
 		Update: resourceAwsVPCEndpointUpdate,
 		Delete: resourceAwsVPCEndpointDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"policy": &schema.Schema{
 				Type:      schema.TypeString,
@@ -39,6 +43,15 @@ func resourceAwsVpcEndpoint() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      schema.HashString,
 			},
+			"prefix_list_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cidr_blocks": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -84,6 +97,8 @@ func resourceAwsVPCEndpointRead(d *schema.ResourceData, meta interface{}) error
 		}
 
 		if ec2err.Code() == "InvalidVpcEndpointId.NotFound" {
+			log.Printf("[WARN] VPC Endpoint (%s) not found, removing from state", d.Id())
+			d.SetId("")
 			return nil
 		}
 
@@ -97,16 +112,59 @@ func resourceAwsVPCEndpointRead(d *schema.ResourceData, meta interface{}) error
 
 	vpce := output.VpcEndpoints[0]
 
+	if vpce.State != nil && *vpce.State == "deleted" {
+		log.Printf("[WARN] VPC Endpoint (%s) is deleted, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	d.Set("vpc_id", vpce.VpcId)
-	d.Set("policy", normalizeJson(*vpce.PolicyDocument))
+	if vpce.PolicyDocument != nil {
+		d.Set("policy", normalizeJson(*vpce.PolicyDocument))
+	}
 	d.Set("service_name", vpce.ServiceName)
 	if err := d.Set("route_table_ids", aws.StringValueSlice(vpce.RouteTableIds)); err != nil {
 		return err
 	}
 
+	// The prefix list can be used in security group rules to allow
+	// traffic to the service through the endpoint.
+	pl, err := vpcEndpointPrefixList(conn, *vpce.ServiceName)
+	if err != nil {
+		return err
+	}
+	if pl != nil {
+		d.Set("prefix_list_id", pl.PrefixListId)
+		if err := d.Set("cidr_blocks", aws.StringValueSlice(pl.Cidrs)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// vpcEndpointPrefixList returns the prefix list for the service of a VPC
+// endpoint, or nil if the service has none.
+func vpcEndpointPrefixList(conn *ec2.EC2, serviceName string) (*ec2.PrefixList, error) {
+	resp, err := conn.DescribePrefixLists(&ec2.DescribePrefixListsInput{
+		Filters: []*ec2.Filter{
+			&ec2.Filter{
+				Name:   aws.String("prefix-list-name"),
+				Values: []*string{aws.String(serviceName)},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error reading prefix list for %s: %s", serviceName, err)
+	}
+
+	if len(resp.PrefixLists) == 0 {
+		return nil, nil
+	}
+
+	return resp.PrefixLists[0], nil
+}
+
 func resourceAwsVPCEndpointUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 	input := &ec2.ModifyVpcEndpointInput{
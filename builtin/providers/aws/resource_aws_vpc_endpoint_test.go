@@ -2,6 +2,7 @@ package aws
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -25,6 +26,10 @@ func TestAccAWSVpcEndpoint_basic(t *testing.T) {
 				Config: testAccVpcEndpointWithRouteTableAndPolicyConfig,
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckVpcEndpointExists("aws_vpc_endpoint.second-private-s3", &endpoint),
+					resource.TestMatchResourceAttr(
+						"aws_vpc_endpoint.second-private-s3", "prefix_list_id", regexp.MustCompile("^pl-")),
+					resource.TestMatchResourceAttr(
+						"aws_vpc_endpoint.second-private-s3", "cidr_blocks.#", regexp.MustCompile("^[1-9]")),
 				),
 			},
 		},
@@ -18,11 +18,15 @@ func resourceAwsVpcPeeringConnection() *schema.Resource {
 		Read:   resourceAwsVPCPeeringRead,
 		Update: resourceAwsVPCPeeringUpdate,
 		Delete: resourceAwsVPCPeeringDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"peer_owner_id": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
 				DefaultFunc: schema.EnvDefaultFunc("AWS_ACCOUNT_ID", nil),
 			},
@@ -52,9 +56,15 @@ func resourceAwsVpcPeeringConnection() *schema.Resource {
 func resourceAwsVPCPeeringCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 
+	// Peer within the same account unless told otherwise
+	peerOwnerId := d.Get("peer_owner_id").(string)
+	if peerOwnerId == "" {
+		peerOwnerId = meta.(*AWSClient).accountid
+	}
+
 	// Create the vpc peering connection
 	createOpts := &ec2.CreateVpcPeeringConnectionInput{
-		PeerOwnerId: aws.String(d.Get("peer_owner_id").(string)),
+		PeerOwnerId: aws.String(peerOwnerId),
 		PeerVpcId:   aws.String(d.Get("peer_vpc_id").(string)),
 		VpcId:       aws.String(d.Get("vpc_id").(string)),
 	}
@@ -146,7 +156,7 @@ func resourceAwsVPCPeeringUpdate(d *schema.ResourceData, meta interface{}) error
 		d.SetPartial("tags")
 	}
 
-	if _, ok := d.GetOk("auto_accept"); ok {
+	if v, ok := d.GetOk("auto_accept"); ok && v.(bool) {
 		pcRaw, _, err := resourceAwsVPCPeeringConnectionStateRefreshFunc(conn, d.Id())()
 
 		if err != nil {
@@ -159,19 +169,40 @@ func resourceAwsVPCPeeringUpdate(d *schema.ResourceData, meta interface{}) error
 		pc := pcRaw.(*ec2.VpcPeeringConnection)
 
 		if pc.Status != nil && *pc.Status.Code == "pending-acceptance" {
-			status, err := resourceVPCPeeringConnectionAccept(conn, d.Id())
-			if err != nil {
+			if err := vpcPeeringConnectionAcceptAndWait(conn, d.Id()); err != nil {
 				return err
 			}
-			log.Printf(
-				"[DEBUG] VPC Peering connection accept status: %s",
-				status)
 		}
 	}
 
 	return resourceAwsVPCPeeringRead(d, meta)
 }
 
+// vpcPeeringConnectionAcceptAndWait accepts a peering connection and waits
+// for it to become active, so that routes through it can be created
+// straight away.
+func vpcPeeringConnectionAcceptAndWait(conn *ec2.EC2, id string) error {
+	status, err := resourceVPCPeeringConnectionAccept(conn, id)
+	if err != nil {
+		return fmt.Errorf("Error accepting VPC Peering Connection (%s): %s", id, err)
+	}
+	log.Printf("[DEBUG] VPC Peering connection accept status: %s", status)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending-acceptance", "provisioning"},
+		Target:  []string{"active"},
+		Refresh: resourceAwsVPCPeeringConnectionStateRefreshFunc(conn, id),
+		Timeout: 1 * time.Minute,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf(
+			"Error waiting for VPC Peering Connection (%s) to become active: %s",
+			id, err)
+	}
+
+	return nil
+}
+
 func resourceAwsVPCPeeringDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 
@@ -199,7 +230,7 @@ func resourceAwsVPCPeeringConnectionStateRefreshFunc(conn *ec2.EC2, id string) r
 			}
 		}
 
-		if resp == nil {
+		if resp == nil || len(resp.VpcPeeringConnections) == 0 {
 			// Sometimes AWS just has consistency issues and doesn't see
 			// our instance yet. Return an empty state.
 			return nil, "", nil
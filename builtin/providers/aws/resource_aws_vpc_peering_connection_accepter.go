@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceAwsVpcPeeringConnectionAccepter manages the accepter's side of a
+// VPC peering connection, which is usually requested from another account.
+// The connection itself belongs to the requester, so destroying this
+// resource only removes it from the state.
+func resourceAwsVpcPeeringConnectionAccepter() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsVPCPeeringAccepterCreate,
+		Read:   resourceAwsVPCPeeringAccepterRead,
+		Update: resourceAwsVPCPeeringAccepterUpdate,
+		Delete: resourceAwsVPCPeeringAccepterDelete,
+
+		Schema: map[string]*schema.Schema{
+			"vpc_peering_connection_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"auto_accept": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"accept_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// The accepter's VPC
+			"vpc_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// The requester's VPC and account
+			"peer_vpc_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"peer_owner_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsVPCPeeringAccepterCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	id := d.Get("vpc_peering_connection_id").(string)
+
+	pcRaw, _, err := resourceAwsVPCPeeringConnectionStateRefreshFunc(conn, id)()
+	if err != nil {
+		return err
+	}
+	if pcRaw == nil {
+		return fmt.Errorf("VPC Peering Connection %q not found", id)
+	}
+
+	pc := pcRaw.(*ec2.VpcPeeringConnection)
+	log.Printf("[INFO] Managing accepter side of VPC Peering Connection: %s", id)
+	d.SetId(*pc.VpcPeeringConnectionId)
+
+	return resourceAwsVPCPeeringAccepterUpdate(d, meta)
+}
+
+func resourceAwsVPCPeeringAccepterRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	pcRaw, _, err := resourceAwsVPCPeeringConnectionStateRefreshFunc(conn, d.Id())()
+	if err != nil {
+		return err
+	}
+	if pcRaw == nil {
+		log.Printf("[WARN] VPC Peering Connection (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	pc := pcRaw.(*ec2.VpcPeeringConnection)
+	if pc.Status != nil {
+		if *pc.Status.Code == "failed" || *pc.Status.Code == "deleted" {
+			log.Printf("[DEBUG] VPC Peering Connect (%s) in state (%s), removing", d.Id(), *pc.Status.Code)
+			d.SetId("")
+			return nil
+		}
+	}
+
+	// The attributes are from the accepter's point of view, the opposite
+	// of aws_vpc_peering_connection.
+	d.Set("vpc_peering_connection_id", pc.VpcPeeringConnectionId)
+	d.Set("accept_status", *pc.Status.Code)
+	d.Set("vpc_id", pc.AccepterVpcInfo.VpcId)
+	d.Set("peer_vpc_id", pc.RequesterVpcInfo.VpcId)
+	d.Set("peer_owner_id", pc.RequesterVpcInfo.OwnerId)
+	d.Set("tags", tagsToMap(pc.Tags))
+
+	return nil
+}
+
+func resourceAwsVPCPeeringAccepterUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if err := setTags(conn, d); err != nil {
+		return err
+	} else {
+		d.SetPartial("tags")
+	}
+
+	if v, ok := d.GetOk("auto_accept"); ok && v.(bool) {
+		pcRaw, _, err := resourceAwsVPCPeeringConnectionStateRefreshFunc(conn, d.Id())()
+		if err != nil {
+			return err
+		}
+		if pcRaw == nil {
+			d.SetId("")
+			return nil
+		}
+		pc := pcRaw.(*ec2.VpcPeeringConnection)
+
+		if pc.Status != nil && *pc.Status.Code == "pending-acceptance" {
+			if err := vpcPeeringConnectionAcceptAndWait(conn, d.Id()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return resourceAwsVPCPeeringAccepterRead(d, meta)
+}
+
+func resourceAwsVPCPeeringAccepterDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[WARN] Will not delete VPC Peering Connection (%s). Terraform will remove this resource from the state file, however resources may remain.", d.Id())
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,89 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSVPCPeeringConnectionAccepter_sameAccount(t *testing.T) {
+	var connection ec2.VpcPeeringConnection
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSVpcPeeringConnectionDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcPeeringAccepterConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSVpcPeeringConnectionExists("aws_vpc_peering_connection_accepter.peer", &connection),
+					resource.TestCheckResourceAttr(
+						"aws_vpc_peering_connection_accepter.peer", "accept_status", "active"),
+					testAccCheckAWSVpcPeeringConnectionAccepterVpcs(
+						"aws_vpc_peering_connection_accepter.peer", "aws_vpc.peer", "aws_vpc.main"),
+					testAccCheckTags(&connection.Tags, "Side", "Accepter"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckAWSVpcPeeringConnectionAccepterVpcs checks that the accepter
+// reports its own VPC as vpc_id and the requester's as peer_vpc_id.
+func testAccCheckAWSVpcPeeringConnectionAccepterVpcs(n, vpc, peerVpc string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		expected := map[string]string{
+			"vpc_id":      vpc,
+			"peer_vpc_id": peerVpc,
+		}
+		for attr, name := range expected {
+			vrs, ok := s.RootModule().Resources[name]
+			if !ok {
+				return fmt.Errorf("Not found: %s", name)
+			}
+			if rs.Primary.Attributes[attr] != vrs.Primary.ID {
+				return fmt.Errorf("Bad %s: expected %s, got %s",
+					attr, vrs.Primary.ID, rs.Primary.Attributes[attr])
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccVpcPeeringAccepterConfig = `
+resource "aws_vpc" "main" {
+	cidr_block = "10.0.0.0/16"
+	tags {
+		Name = "TestAccAWSVPCPeeringConnectionAccepter_sameAccount"
+	}
+}
+
+resource "aws_vpc" "peer" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_vpc_peering_connection" "main" {
+	vpc_id = "${aws_vpc.main.id}"
+	peer_vpc_id = "${aws_vpc.peer.id}"
+	auto_accept = false
+}
+
+resource "aws_vpc_peering_connection_accepter" "peer" {
+	vpc_peering_connection_id = "${aws_vpc_peering_connection.main.id}"
+	auto_accept = true
+
+	tags {
+		Side = "Accepter"
+	}
+}
+`
@@ -345,8 +345,14 @@ func expandElastiCacheParameters(configured []interface{}) ([]*elasticache.Param
 func flattenAccessLog(l *elb.AccessLog) []map[string]interface{} {
 	result := make([]map[string]interface{}, 0, 1)
 
-	if l != nil && *l.Enabled {
+	if l == nil {
+		return result
+	}
+
+	// Disabled access logs are only returned if they still have a bucket
+	if *l.Enabled || (l.S3BucketName != nil && *l.S3BucketName != "") {
 		r := make(map[string]interface{})
+		r["enabled"] = *l.Enabled
 		if l.S3BucketName != nil {
 			r["bucket"] = *l.S3BucketName
 		}
@@ -681,6 +687,12 @@ func flattenStringList(list []*string) []interface{} {
 	return vs
 }
 
+// Takes list of pointers to strings and returns a schema.Set of
+// the raw strings
+func flattenStringSet(list []*string) *schema.Set {
+	return schema.NewSet(schema.HashString, flattenStringList(list))
+}
+
 //Flattens an array of private ip addresses into a []string, where the elements returned are the IP strings e.g. "192.168.0.0"
 func flattenNetworkInterfacesPrivateIPAddresses(dtos []*ec2.NetworkInterfacePrivateIpAddress) []string {
 	ips := make([]string, 0, len(dtos))
@@ -887,7 +899,7 @@ func flattenDSVpcSettings(
 		return nil
 	}
 
-	settings["subnet_ids"] = schema.NewSet(schema.HashString, flattenStringList(s.SubnetIds))
+	settings["subnet_ids"] = flattenStringSet(s.SubnetIds)
 	settings["vpc_id"] = *s.VpcId
 
 	return []map[string]interface{}{settings}
@@ -904,8 +916,8 @@ func flattenLambdaVpcConfigResponse(s *lambda.VpcConfigResponse) []map[string]in
 		return nil
 	}
 
-	settings["subnet_ids"] = schema.NewSet(schema.HashString, flattenStringList(s.SubnetIds))
-	settings["security_group_ids"] = schema.NewSet(schema.HashString, flattenStringList(s.SecurityGroupIds))
+	settings["subnet_ids"] = flattenStringSet(s.SubnetIds)
+	settings["security_group_ids"] = flattenStringSet(s.SecurityGroupIds)
 	if s.VpcId != nil {
 		settings["vpc_id"] = *s.VpcId
 	}
@@ -922,10 +934,10 @@ func flattenDSConnectSettings(
 
 	settings := make(map[string]interface{}, 0)
 
-	settings["customer_dns_ips"] = schema.NewSet(schema.HashString, flattenStringList(customerDnsIps))
-	settings["connect_ips"] = schema.NewSet(schema.HashString, flattenStringList(s.ConnectIps))
+	settings["customer_dns_ips"] = flattenStringSet(customerDnsIps)
+	settings["connect_ips"] = flattenStringSet(s.ConnectIps)
 	settings["customer_username"] = *s.CustomerUserName
-	settings["subnet_ids"] = schema.NewSet(schema.HashString, flattenStringList(s.SubnetIds))
+	settings["subnet_ids"] = flattenStringSet(s.SubnetIds)
 	settings["vpc_id"] = *s.VpcId
 
 	return []map[string]interface{}{settings}
@@ -995,6 +1007,16 @@ func flattenAsgEnabledMetrics(list []*autoscaling.EnabledMetric) []string {
 	return strs
 }
 
+func flattenAsgSuspendedProcesses(list []*autoscaling.SuspendedProcess) []string {
+	strs := make([]string, 0, len(list))
+	for _, p := range list {
+		if p.ProcessName != nil {
+			strs = append(strs, *p.ProcessName)
+		}
+	}
+	return strs
+}
+
 func expandApiGatewayStageKeys(d *schema.ResourceData) []*apigateway.StageKey {
 	var stageKeys []*apigateway.StageKey
 
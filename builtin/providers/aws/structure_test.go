@@ -432,6 +432,61 @@ func TestFlattenHealthCheck(t *testing.T) {
 	}
 }
 
+func TestFlattenAccessLog(t *testing.T) {
+	cases := []struct {
+		Input  *elb.AccessLog
+		Output []map[string]interface{}
+	}{
+		{
+			Input:  nil,
+			Output: []map[string]interface{}{},
+		},
+		{
+			Input: &elb.AccessLog{
+				Enabled:        aws.Bool(true),
+				S3BucketName:   aws.String("logs"),
+				S3BucketPrefix: aws.String("elb"),
+				EmitInterval:   aws.Int64(int64(5)),
+			},
+			Output: []map[string]interface{}{
+				map[string]interface{}{
+					"enabled":       true,
+					"bucket":        "logs",
+					"bucket_prefix": "elb",
+					"interval":      int64(5),
+				},
+			},
+		},
+		{
+			Input: &elb.AccessLog{
+				Enabled:      aws.Bool(false),
+				S3BucketName: aws.String("logs"),
+				EmitInterval: aws.Int64(int64(60)),
+			},
+			Output: []map[string]interface{}{
+				map[string]interface{}{
+					"enabled":  false,
+					"bucket":   "logs",
+					"interval": int64(60),
+				},
+			},
+		},
+		{
+			Input: &elb.AccessLog{
+				Enabled: aws.Bool(false),
+			},
+			Output: []map[string]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		output := flattenAccessLog(tc.Input)
+		if !reflect.DeepEqual(output, tc.Output) {
+			t.Fatalf("Got:\n\n%#v\n\nExpected:\n\n%#v", output, tc.Output)
+		}
+	}
+}
+
 func TestExpandStringList(t *testing.T) {
 	expanded := flatmap.Expand(testConf(), "availability_zones").([]interface{})
 	stringList := expandStringList(expanded)
@@ -449,6 +504,45 @@ func TestExpandStringList(t *testing.T) {
 
 }
 
+func TestExpandStringSet(t *testing.T) {
+	set := schema.NewSet(schema.HashString, []interface{}{"us-east-1a"})
+	expected := []*string{aws.String("us-east-1a")}
+
+	if actual := expandStringSet(set); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestFlattenStringList(t *testing.T) {
+	list := []*string{aws.String("us-east-1a"), aws.String("us-east-1b")}
+	expected := []interface{}{"us-east-1a", "us-east-1b"}
+
+	if actual := flattenStringList(list); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestFlattenStringSet(t *testing.T) {
+	list := []*string{aws.String("us-east-1a"), aws.String("us-east-1b")}
+	expected := []interface{}{"us-east-1a", "us-east-1b"}
+
+	set := flattenStringSet(list)
+	if set.Len() != 2 {
+		t.Fatalf("bad: %#v", set.List())
+	}
+	for _, v := range expected {
+		if !set.Contains(v) {
+			t.Fatalf("missing %q: %#v", v, set.List())
+		}
+	}
+
+	// Flattening and expanding is symmetric
+	actual := expandStringSet(set)
+	if len(actual) != len(list) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
 func TestExpandParameters(t *testing.T) {
 	expanded := []interface{}{
 		map[string]interface{}{
@@ -1,11 +1,14 @@
 package aws
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"regexp"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/terraform/helper/schema"
 )
@@ -409,6 +412,35 @@ func validateLogGroupName(v interface{}, k string) (ws []string, errors []error)
 	return
 }
 
+func validateCloudWatchLogRetentionInDays(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(int)
+
+	// 0 means never expire, which is expressed as the absence of a policy
+	if value == 0 {
+		return
+	}
+
+	// http://docs.aws.amazon.com/AmazonCloudWatchLogs/latest/APIReference/API_PutRetentionPolicy.html
+	validDays := []int{1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1827, 3653}
+	for _, d := range validDays {
+		if value == d {
+			return
+		}
+	}
+
+	errors = append(errors, fmt.Errorf(
+		"%q must be one of %v: %d", k, validDays, value))
+	return
+}
+
+func validateJsonString(v interface{}, k string) (ws []string, errors []error) {
+	var j interface{}
+	if err := json.Unmarshal([]byte(v.(string)), &j); err != nil {
+		errors = append(errors, fmt.Errorf("%q contains an invalid JSON: %s", k, err))
+	}
+	return
+}
+
 func validateS3BucketLifecycleTimestamp(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	_, err := time.Parse(time.RFC3339, fmt.Sprintf("%sT00:00:00Z", value))
@@ -451,3 +483,50 @@ func validateDbEventSubscriptionName(v interface{}, k string) (ws []string, erro
 	}
 	return
 }
+
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf(
+			"%q cannot be parsed as a duration: %s", k, err))
+		return
+	}
+	if duration < 0 {
+		errors = append(errors, fmt.Errorf(
+			"%q cannot be negative", k))
+	}
+	return
+}
+
+func validateRoute53HealthCheckInsufficientDataHealthStatus(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != route53.InsufficientDataHealthStatusHealthy &&
+		value != route53.InsufficientDataHealthStatusUnhealthy &&
+		value != route53.InsufficientDataHealthStatusLastKnownStatus {
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of %q, %q, %q", k,
+			route53.InsufficientDataHealthStatusHealthy,
+			route53.InsufficientDataHealthStatusUnhealthy,
+			route53.InsufficientDataHealthStatusLastKnownStatus))
+	}
+	return
+}
+
+func validateAccountAlias(v interface{}, k string) (ws []string, errors []error) {
+	val := v.(string)
+
+	if (len(val) < 3) || (len(val) > 63) {
+		errors = append(errors, fmt.Errorf("%q must contain from 3 to 63 alphanumeric characters or hyphens", k))
+	}
+	if !regexp.MustCompile("^[a-z0-9][a-z0-9-]+$").MatchString(val) {
+		errors = append(errors, fmt.Errorf("%q must start with an alphanumeric character and only contain lowercase alphanumeric characters and hyphens", k))
+	}
+	if strings.Contains(val, "--") {
+		errors = append(errors, fmt.Errorf("%q must not contain consecutive hyphens", k))
+	}
+	if strings.HasSuffix(val, "-") {
+		errors = append(errors, fmt.Errorf("%q must not end in a hyphen", k))
+	}
+	return
+}
@@ -383,6 +383,50 @@ func TestValidateLogGroupName(t *testing.T) {
 	}
 }
 
+func TestValidateCloudWatchLogRetentionInDays(t *testing.T) {
+	validDays := []int{0, 1, 7, 30, 365, 3653}
+	for _, v := range validDays {
+		_, errors := validateCloudWatchLogRetentionInDays(v, "retention_in_days")
+		if len(errors) != 0 {
+			t.Fatalf("%d should be a valid retention period: %q", v, errors)
+		}
+	}
+
+	invalidDays := []int{-1, 2, 31, 366, 3654}
+	for _, v := range invalidDays {
+		_, errors := validateCloudWatchLogRetentionInDays(v, "retention_in_days")
+		if len(errors) == 0 {
+			t.Fatalf("%d should be an invalid retention period", v)
+		}
+	}
+}
+
+func TestValidateJsonString(t *testing.T) {
+	validJson := []string{
+		`{}`,
+		`{"abc":"def"}`,
+		`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"es:*"}]}`,
+	}
+	for _, v := range validJson {
+		_, errors := validateJsonString(v, "json")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid JSON: %q", v, errors)
+		}
+	}
+
+	invalidJson := []string{
+		``,
+		`{`,
+		`{"abc":}`,
+	}
+	for _, v := range invalidJson {
+		_, errors := validateJsonString(v, "json")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid JSON", v)
+		}
+	}
+}
+
 func TestValidateS3BucketLifecycleTimestamp(t *testing.T) {
 	validDates := []string{
 		"2016-01-01",
@@ -553,3 +597,88 @@ func TestValidateDbEventSubscriptionName(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateDuration(t *testing.T) {
+	validDurations := []string{
+		"0s",
+		"90s",
+		"5m",
+		"1h30m",
+	}
+	for _, v := range validDurations {
+		_, errors := validateDuration(v, "timeout")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid duration: %q", v, errors)
+		}
+	}
+
+	invalidDurations := []string{
+		"",
+		"5",
+		"five minutes",
+		"-5m",
+	}
+	for _, v := range invalidDurations {
+		_, errors := validateDuration(v, "timeout")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid duration", v)
+		}
+	}
+}
+
+func TestValidateRoute53HealthCheckInsufficientDataHealthStatus(t *testing.T) {
+	validStatuses := []string{
+		"Healthy",
+		"Unhealthy",
+		"LastKnownStatus",
+	}
+	for _, v := range validStatuses {
+		_, errors := validateRoute53HealthCheckInsufficientDataHealthStatus(v, "insufficient_data_health_status")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid status: %q", v, errors)
+		}
+	}
+
+	invalidStatuses := []string{
+		"healthy",
+		"Unknown",
+		"",
+	}
+	for _, v := range invalidStatuses {
+		_, errors := validateRoute53HealthCheckInsufficientDataHealthStatus(v, "insufficient_data_health_status")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid status", v)
+		}
+	}
+}
+
+func TestValidateAccountAlias(t *testing.T) {
+	validAliases := []string{
+		"tf-alias",
+		"0tf-alias1",
+	}
+
+	for _, s := range validAliases {
+		_, errors := validateAccountAlias(s, "account_alias")
+		if len(errors) > 0 {
+			t.Fatalf("%q should be a valid account alias: %v", s, errors)
+		}
+	}
+
+	invalidAliases := []string{
+		"tf",
+		"-tf",
+		"tf-",
+		"TF-Alias",
+		"tf--alias",
+		"tf_alias",
+		"tf-alias-that-is-far-too-long-to-be-used-as-an-aws-account-alias",
+	}
+
+	for _, s := range invalidAliases {
+		_, errors := validateAccountAlias(s, "account_alias")
+		if len(errors) == 0 {
+			t.Fatalf("%q should not be a valid account alias: %v", s, errors)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package cloudflare
 
 import (
+	"github.com/hashicorp/terraform/helper/mutexkv"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -25,7 +26,8 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"cloudflare_record": resourceCloudFlareRecord(),
+			"cloudflare_page_rule": resourceCloudFlarePageRule(),
+			"cloudflare_record":    resourceCloudFlareRecord(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -40,3 +42,6 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 
 	return config.Client()
 }
+
+// This is a global MutexKV for use within this plugin.
+var cloudflareMutexKV = mutexkv.NewMutexKV()
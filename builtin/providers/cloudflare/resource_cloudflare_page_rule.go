@@ -0,0 +1,419 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	// NOTE: Temporary until they merge my PR:
+	"github.com/mitchellh/cloudflare-go"
+)
+
+// Page rule actions whose value is "on" or "off".
+var pageRuleOnOffActions = []string{
+	"always_online",
+	"browser_check",
+	"email_obfuscation",
+	"ip_geolocation",
+	"mirage",
+	"server_side_exclude",
+	"smart_errors",
+	"waf",
+}
+
+// Page rule actions whose value is one of several strings.
+var pageRuleStringActions = []string{
+	"cache_level",
+	"rocket_loader",
+	"security_level",
+	"ssl",
+}
+
+// Page rule actions whose value is a number of seconds.
+var pageRuleIntActions = []string{
+	"browser_cache_ttl",
+	"edge_cache_ttl",
+}
+
+// Page rule actions that take no value, they are enabled by being present.
+var pageRuleBoolActions = []string{
+	"always_use_https",
+	"disable_apps",
+	"disable_performance",
+	"disable_security",
+}
+
+func resourceCloudFlarePageRule() *schema.Resource {
+	actionsSchema := map[string]*schema.Schema{
+		"forwarding_url": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"url": &schema.Schema{
+						Type:     schema.TypeString,
+						Required: true,
+					},
+
+					"status_code": &schema.Schema{
+						Type:         schema.TypeInt,
+						Required:     true,
+						ValidateFunc: validatePageRuleForwardingStatusCode,
+					},
+				},
+			},
+		},
+	}
+	for _, id := range pageRuleOnOffActions {
+		actionsSchema[id] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validatePageRuleOnOff,
+		}
+	}
+	for _, id := range pageRuleStringActions {
+		actionsSchema[id] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		}
+	}
+	for _, id := range pageRuleIntActions {
+		actionsSchema[id] = &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		}
+	}
+	for _, id := range pageRuleBoolActions {
+		actionsSchema[id] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+		}
+	}
+
+	return &schema.Resource{
+		Create: resourceCloudFlarePageRuleCreate,
+		Read:   resourceCloudFlarePageRuleRead,
+		Update: resourceCloudFlarePageRuleUpdate,
+		Delete: resourceCloudFlarePageRuleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"domain": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"target": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"actions": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: actionsSchema,
+				},
+			},
+
+			"priority": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+
+			"status": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "active",
+				ValidateFunc: validatePageRuleStatus,
+			},
+
+			"zone_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlarePageRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*cloudflare.API)
+	domain := d.Get("domain").(string)
+
+	zoneId, err := client.ZoneIDByName(domain)
+	if err != nil {
+		return fmt.Errorf("Error finding zone %q: %s", domain, err)
+	}
+	d.Set("zone_id", zoneId)
+
+	// The client doesn't return the rule it created, so the new rule is
+	// found by comparing the zone's rules before and after creating it.
+	// Creates in the same zone are serialized so that parallel creates
+	// don't see each other's rules, and the new rule must also match what
+	// we asked for in case something else created one in the meantime.
+	cloudflareMutexKV.Lock(zoneId)
+	defer cloudflareMutexKV.Unlock(zoneId)
+
+	existing, err := client.ListPageRules(zoneId)
+	if err != nil {
+		return fmt.Errorf("Error listing CloudFlare Page Rules: %s", err)
+	}
+	existingIds := make(map[string]bool, len(existing))
+	for _, r := range existing {
+		existingIds[r.ID] = true
+	}
+
+	newRule := expandCloudFlarePageRule(d)
+
+	log.Printf("[DEBUG] CloudFlare Page Rule create configuration: %#v", newRule)
+
+	if err := client.CreatePageRule(zoneId, newRule); err != nil {
+		return fmt.Errorf("Failed to create Page Rule: %s", err)
+	}
+
+	rules, err := client.ListPageRules(zoneId)
+	if err != nil {
+		return fmt.Errorf("Error listing CloudFlare Page Rules: %s", err)
+	}
+	for _, r := range rules {
+		if !existingIds[r.ID] && pageRuleMatches(r, newRule) {
+			d.SetId(r.ID)
+			break
+		}
+	}
+	if d.Id() == "" {
+		return fmt.Errorf("Created Page Rule for %q not found", newRule.Targets[0].Constraint.Value)
+	}
+
+	log.Printf("[INFO] CloudFlare Page Rule ID: %s", d.Id())
+
+	return resourceCloudFlarePageRuleRead(d, meta)
+}
+
+func resourceCloudFlarePageRuleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*cloudflare.API)
+	domain := d.Get("domain").(string)
+
+	zoneId, err := client.ZoneIDByName(domain)
+	if err != nil {
+		return fmt.Errorf("Error finding zone %q: %s", domain, err)
+	}
+
+	// Errors from the client don't carry the status code, so a deleted
+	// rule is detected by its absence from the list instead.
+	rules, err := client.ListPageRules(zoneId)
+	if err != nil {
+		return fmt.Errorf("Error listing CloudFlare Page Rules: %s", err)
+	}
+
+	var rule *cloudflare.PageRule
+	for i := range rules {
+		if rules[i].ID == d.Id() {
+			rule = &rules[i]
+			break
+		}
+	}
+	if rule == nil {
+		log.Printf("[WARN] CloudFlare Page Rule %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if len(rule.Targets) > 0 {
+		d.Set("target", rule.Targets[0].Constraint.Value)
+	}
+	if err := d.Set("actions", flattenCloudFlarePageRuleActions(rule.Actions)); err != nil {
+		return fmt.Errorf("Error setting actions: %s", err)
+	}
+	d.Set("priority", rule.Priority)
+	d.Set("status", rule.Status)
+	d.Set("zone_id", zoneId)
+
+	return nil
+}
+
+func resourceCloudFlarePageRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*cloudflare.API)
+	zoneId := d.Get("zone_id").(string)
+
+	updateRule := expandCloudFlarePageRule(d)
+
+	// UpdatePageRule doesn't send the rule, so all of its settings are
+	// changed through ChangePageRule instead.
+	log.Printf("[DEBUG] CloudFlare Page Rule update configuration: %#v", updateRule)
+	if err := client.ChangePageRule(zoneId, d.Id(), updateRule); err != nil {
+		return fmt.Errorf("Failed to update CloudFlare Page Rule: %s", err)
+	}
+
+	return resourceCloudFlarePageRuleRead(d, meta)
+}
+
+func resourceCloudFlarePageRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*cloudflare.API)
+	zoneId := d.Get("zone_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Page Rule: %s, %s", d.Get("domain").(string), d.Id())
+
+	if err := client.DeletePageRule(zoneId, d.Id()); err != nil {
+		return fmt.Errorf("Error deleting CloudFlare Page Rule: %s", err)
+	}
+
+	return nil
+}
+
+// pageRuleMatches returns true if the rule returned by the API has the
+// target, priority and actions of the rule we created.
+func pageRuleMatches(rule, want cloudflare.PageRule) bool {
+	if rule.Priority != want.Priority {
+		return false
+	}
+	if len(rule.Targets) != len(want.Targets) {
+		return false
+	}
+	for i := range rule.Targets {
+		if rule.Targets[i].Constraint.Value != want.Targets[i].Constraint.Value {
+			return false
+		}
+	}
+
+	// Values of the API's actions are decoded from JSON, so the wanted ones
+	// are passed through JSON too before comparing them.
+	raw, err := json.Marshal(want.Actions)
+	if err != nil {
+		return false
+	}
+	var wantActions []cloudflare.PageRuleAction
+	if err := json.Unmarshal(raw, &wantActions); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(
+		flattenCloudFlarePageRuleActions(rule.Actions),
+		flattenCloudFlarePageRuleActions(wantActions))
+}
+
+func expandCloudFlarePageRule(d *schema.ResourceData) cloudflare.PageRule {
+	target := cloudflare.PageRuleTarget{Target: "url"}
+	target.Constraint.Operator = "matches"
+	target.Constraint.Value = d.Get("target").(string)
+
+	var actions map[string]interface{}
+	if raw := d.Get("actions").([]interface{}); len(raw) > 0 && raw[0] != nil {
+		actions = raw[0].(map[string]interface{})
+	}
+
+	return cloudflare.PageRule{
+		Targets:  []cloudflare.PageRuleTarget{target},
+		Actions:  expandCloudFlarePageRuleActions(actions),
+		Priority: d.Get("priority").(int),
+		Status:   d.Get("status").(string),
+	}
+}
+
+func expandCloudFlarePageRuleActions(actions map[string]interface{}) []cloudflare.PageRuleAction {
+	result := make([]cloudflare.PageRuleAction, 0)
+
+	for _, id := range pageRuleOnOffActions {
+		if v, ok := actions[id].(string); ok && v != "" {
+			result = append(result, cloudflare.PageRuleAction{ID: id, Value: v})
+		}
+	}
+	for _, id := range pageRuleStringActions {
+		if v, ok := actions[id].(string); ok && v != "" {
+			result = append(result, cloudflare.PageRuleAction{ID: id, Value: v})
+		}
+	}
+	for _, id := range pageRuleIntActions {
+		if v, ok := actions[id].(int); ok && v != 0 {
+			result = append(result, cloudflare.PageRuleAction{ID: id, Value: v})
+		}
+	}
+	for _, id := range pageRuleBoolActions {
+		if v, ok := actions[id].(bool); ok && v {
+			result = append(result, cloudflare.PageRuleAction{ID: id})
+		}
+	}
+
+	if raw, ok := actions["forwarding_url"].([]interface{}); ok && len(raw) > 0 {
+		fwd := raw[0].(map[string]interface{})
+		result = append(result, cloudflare.PageRuleAction{
+			ID: "forwarding_url",
+			Value: map[string]interface{}{
+				"url":         fwd["url"].(string),
+				"status_code": fwd["status_code"].(int),
+			},
+		})
+	}
+
+	return result
+}
+
+func flattenCloudFlarePageRuleActions(actions []cloudflare.PageRuleAction) []map[string]interface{} {
+	result := make(map[string]interface{})
+
+	for _, action := range actions {
+		switch {
+		case containsString(pageRuleOnOffActions, action.ID),
+			containsString(pageRuleStringActions, action.ID):
+			result[action.ID] = fmt.Sprintf("%v", action.Value)
+		case containsString(pageRuleIntActions, action.ID):
+			// Numbers are decoded from JSON as float64.
+			if v, ok := action.Value.(float64); ok {
+				result[action.ID] = int(v)
+			}
+		case containsString(pageRuleBoolActions, action.ID):
+			result[action.ID] = true
+		case action.ID == "forwarding_url":
+			if v, ok := action.Value.(map[string]interface{}); ok {
+				fwd := map[string]interface{}{"url": v["url"]}
+				if code, ok := v["status_code"].(float64); ok {
+					fwd["status_code"] = int(code)
+				}
+				result["forwarding_url"] = []map[string]interface{}{fwd}
+			}
+		default:
+			log.Printf("[WARN] Ignoring unsupported CloudFlare Page Rule action %q", action.ID)
+		}
+	}
+
+	return []map[string]interface{}{result}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func validatePageRuleOnOff(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != "on" && value != "off" {
+		errors = append(errors, fmt.Errorf("%q must be \"on\" or \"off\", got %q", k, value))
+	}
+	return
+}
+
+func validatePageRuleStatus(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != "active" && value != "paused" {
+		errors = append(errors, fmt.Errorf("%q must be \"active\" or \"paused\", got %q", k, value))
+	}
+	return
+}
+
+func validatePageRuleForwardingStatusCode(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(int)
+	if value != 301 && value != 302 {
+		errors = append(errors, fmt.Errorf("%q must be 301 or 302, got %d", k, value))
+	}
+	return
+}
@@ -0,0 +1,223 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	// NOTE: Temporary until they merge my PR:
+	"github.com/mitchellh/cloudflare-go"
+)
+
+func TestAccCloudFlarePageRule_Basic(t *testing.T) {
+	var rule cloudflare.PageRule
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlarePageRuleDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlarePageRuleConfigBasic, domain, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFlarePageRuleExists("cloudflare_page_rule.foobar", &rule),
+					resource.TestCheckResourceAttr(
+						"cloudflare_page_rule.foobar", "target", fmt.Sprintf("%s/terraform/*", domain)),
+					resource.TestCheckResourceAttr(
+						"cloudflare_page_rule.foobar", "actions.0.always_online", "on"),
+					resource.TestCheckResourceAttr(
+						"cloudflare_page_rule.foobar", "actions.0.browser_cache_ttl", "3600"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlarePageRuleConfigUpdated, domain, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFlarePageRuleExists("cloudflare_page_rule.foobar", &rule),
+					resource.TestCheckResourceAttr(
+						"cloudflare_page_rule.foobar", "status", "paused"),
+					resource.TestCheckResourceAttr(
+						"cloudflare_page_rule.foobar", "actions.0.forwarding_url.0.status_code", "301"),
+				),
+			},
+		},
+	})
+}
+
+func TestCloudFlarePageRuleActions_roundTrip(t *testing.T) {
+	actions := map[string]interface{}{
+		"always_online":     "on",
+		"cache_level":       "aggressive",
+		"browser_cache_ttl": 3600,
+		"disable_apps":      true,
+		"forwarding_url": []interface{}{
+			map[string]interface{}{
+				"url":         "https://example.com/$1",
+				"status_code": 302,
+			},
+		},
+	}
+
+	expanded := expandCloudFlarePageRuleActions(actions)
+	if len(expanded) != 5 {
+		t.Fatalf("expected 5 actions, got %#v", expanded)
+	}
+
+	// Simulate the JSON decoding of the API response.
+	for i, a := range expanded {
+		switch v := a.Value.(type) {
+		case int:
+			expanded[i].Value = float64(v)
+		case map[string]interface{}:
+			expanded[i].Value = map[string]interface{}{
+				"url":         v["url"],
+				"status_code": float64(v["status_code"].(int)),
+			}
+		}
+	}
+
+	flattened := flattenCloudFlarePageRuleActions(expanded)
+	expected := []map[string]interface{}{
+		{
+			"always_online":     "on",
+			"cache_level":       "aggressive",
+			"browser_cache_ttl": 3600,
+			"disable_apps":      true,
+			"forwarding_url": []map[string]interface{}{
+				{
+					"url":         "https://example.com/$1",
+					"status_code": 302,
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(flattened, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, flattened)
+	}
+}
+
+func TestPageRuleMatches(t *testing.T) {
+	target := cloudflare.PageRuleTarget{Target: "url"}
+	target.Constraint.Operator = "matches"
+	target.Constraint.Value = "example.com/app/*"
+
+	want := cloudflare.PageRule{
+		Targets: []cloudflare.PageRuleTarget{target},
+		Actions: expandCloudFlarePageRuleActions(map[string]interface{}{
+			"always_online":  "on",
+			"edge_cache_ttl": 2592000,
+		}),
+		Priority: 2,
+	}
+
+	// As decoded from the API response
+	got := cloudflare.PageRule{
+		ID:      "abc123",
+		Targets: []cloudflare.PageRuleTarget{target},
+		Actions: []cloudflare.PageRuleAction{
+			{ID: "edge_cache_ttl", Value: float64(2592000)},
+			{ID: "always_online", Value: "on"},
+		},
+		Priority: 2,
+	}
+	if !pageRuleMatches(got, want) {
+		t.Fatalf("expected %#v to match %#v", got, want)
+	}
+
+	other := got
+	other.Priority = 1
+	if pageRuleMatches(other, want) {
+		t.Fatal("rule with another priority shouldn't match")
+	}
+
+	other = got
+	other.Actions = []cloudflare.PageRuleAction{{ID: "always_online", Value: "off"}}
+	if pageRuleMatches(other, want) {
+		t.Fatal("rule with other actions shouldn't match")
+	}
+
+	otherTarget := target
+	otherTarget.Constraint.Value = "example.com/other/*"
+	other = got
+	other.Targets = []cloudflare.PageRuleTarget{otherTarget}
+	if pageRuleMatches(other, want) {
+		t.Fatal("rule with another target shouldn't match")
+	}
+}
+
+func testAccCheckCloudFlarePageRuleDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*cloudflare.API)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_page_rule" {
+			continue
+		}
+
+		rules, err := client.ListPageRules(rs.Primary.Attributes["zone_id"])
+		if err != nil {
+			return err
+		}
+
+		for _, r := range rules {
+			if r.ID == rs.Primary.ID {
+				return fmt.Errorf("Page Rule still exists")
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckCloudFlarePageRuleExists(n string, rule *cloudflare.PageRule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Page Rule ID is set")
+		}
+
+		client := testAccProvider.Meta().(*cloudflare.API)
+		foundRule, err := client.PageRule(rs.Primary.Attributes["zone_id"], rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if foundRule.ID != rs.Primary.ID {
+			return fmt.Errorf("Page Rule not found")
+		}
+
+		*rule = foundRule
+
+		return nil
+	}
+}
+
+const testAccCheckCloudFlarePageRuleConfigBasic = `
+resource "cloudflare_page_rule" "foobar" {
+	domain = "%s"
+	target = "%s/terraform/*"
+	actions {
+		always_online = "on"
+		browser_cache_ttl = 3600
+	}
+}`
+
+const testAccCheckCloudFlarePageRuleConfigUpdated = `
+resource "cloudflare_page_rule" "foobar" {
+	domain = "%s"
+	target = "%s/terraform/*"
+	status = "paused"
+	actions {
+		forwarding_url {
+			url = "https://www.example.com/$1"
+			status_code = 301
+		}
+	}
+}`
@@ -10,6 +10,7 @@ type Config struct {
 	Datacenter string `mapstructure:"datacenter"`
 	Address    string `mapstructure:"address"`
 	Scheme     string `mapstructure:"scheme"`
+	Token      string `mapstructure:"token"`
 }
 
 // Client() returns a new client for accessing consul.
@@ -25,6 +26,9 @@ func (c *Config) Client() (*consulapi.Client, error) {
 	if c.Scheme != "" {
 		config.Scheme = c.Scheme
 	}
+	if c.Token != "" {
+		config.Token = c.Token
+	}
 	client, err := consulapi.NewClient(config)
 
 	log.Printf("[INFO] Consul Client configured with address: '%s', scheme: '%s', datacenter: '%s'",
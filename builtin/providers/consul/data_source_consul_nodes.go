@@ -0,0 +1,82 @@
+package consul
+
+import (
+	"fmt"
+	"sort"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceConsulNodes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceConsulNodesRead,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"token": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"node_names": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"node_addresses": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceConsulNodesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+	dc, err := getDC(d, client)
+	if err != nil {
+		return err
+	}
+
+	qo := &consulapi.QueryOptions{
+		Datacenter: dc,
+		Token:      d.Get("token").(string),
+	}
+
+	nodes, _, err := client.Catalog().Nodes(qo)
+	if err != nil {
+		return fmt.Errorf("Failed to read nodes from the catalog: %s", err)
+	}
+
+	sort.Sort(catalogNodesByName(nodes))
+
+	// The two lists line up, so the address of node_names[i] is
+	// node_addresses[i].
+	names := make([]string, 0, len(nodes))
+	addresses := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		names = append(names, n.Node)
+		addresses = append(addresses, n.Address)
+	}
+
+	d.SetId(dc)
+	d.Set("datacenter", dc)
+	d.Set("node_names", names)
+	d.Set("node_addresses", addresses)
+
+	return nil
+}
+
+type catalogNodesByName []*consulapi.Node
+
+func (n catalogNodesByName) Len() int           { return len(n) }
+func (n catalogNodesByName) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
+func (n catalogNodesByName) Less(i, j int) bool { return n[i].Node < n[j].Node }
@@ -0,0 +1,28 @@
+package consul
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataConsulNodes_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataConsulNodesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.consul_nodes.all", "datacenter", "dc1"),
+					resource.TestCheckResourceAttr("data.consul_nodes.all", "node_names.#", "1"),
+					resource.TestCheckResourceAttr("data.consul_nodes.all", "node_addresses.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataConsulNodesConfig = `
+data "consul_nodes" "all" {}
+`
@@ -0,0 +1,133 @@
+package consul
+
+import (
+	"fmt"
+	"sort"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceConsulService() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceConsulServiceRead,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"token": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"tag": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"service": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"node_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"node_address": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"address": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"tags": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceConsulServiceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+	dc, err := getDC(d, client)
+	if err != nil {
+		return err
+	}
+
+	qo := &consulapi.QueryOptions{
+		Datacenter: dc,
+		Token:      d.Get("token").(string),
+	}
+
+	name := d.Get("name").(string)
+	tag := d.Get("tag").(string)
+
+	services, _, err := client.Catalog().Service(name, tag, qo)
+	if err != nil {
+		return fmt.Errorf("Failed to read service %s from the catalog: %s", name, err)
+	}
+
+	// Sort by node so that the list doesn't reorder between runs.
+	sort.Sort(catalogServicesByNode(services))
+
+	l := make([]map[string]interface{}, 0, len(services))
+	for _, s := range services {
+		// Services registered without an address use their node's.
+		address := s.ServiceAddress
+		if address == "" {
+			address = s.Address
+		}
+
+		l = append(l, map[string]interface{}{
+			"id":           s.ServiceID,
+			"node_name":    s.Node,
+			"node_address": s.Address,
+			"address":      address,
+			"port":         s.ServicePort,
+			"tags":         s.ServiceTags,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s-%s-%s", dc, name, tag))
+	d.Set("datacenter", dc)
+	if err := d.Set("service", l); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type catalogServicesByNode []*consulapi.CatalogService
+
+func (s catalogServicesByNode) Len() int      { return len(s) }
+func (s catalogServicesByNode) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s catalogServicesByNode) Less(i, j int) bool {
+	if s[i].Node != s[j].Node {
+		return s[i].Node < s[j].Node
+	}
+	return s[i].ServiceID < s[j].ServiceID
+}
@@ -0,0 +1,31 @@
+package consul
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataConsulService_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataConsulServiceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.consul_service.consul", "datacenter", "dc1"),
+					resource.TestCheckResourceAttr("data.consul_service.consul", "service.#", "1"),
+					resource.TestCheckResourceAttr("data.consul_service.consul", "service.0.id", "consul"),
+					resource.TestCheckResourceAttr("data.consul_service.consul", "service.0.port", "8300"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataConsulServiceConfig = `
+data "consul_service" "consul" {
+	name = "consul"
+}
+`
@@ -0,0 +1,150 @@
+package consul
+
+import (
+	"fmt"
+	"log"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceConsulACLToken manages an ACL token together with the policy
+// rules attached to it. The token's ID is the secret that clients present.
+func resourceConsulACLToken() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceConsulACLTokenCreate,
+		Update: resourceConsulACLTokenUpdate,
+		Read:   resourceConsulACLTokenRead,
+		Delete: resourceConsulACLTokenDelete,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"token": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      consulapi.ACLClientType,
+				ValidateFunc: validateConsulACLTokenType,
+			},
+
+			"rules": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceConsulACLTokenCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+	dc, err := getDC(d, client)
+	if err != nil {
+		return err
+	}
+
+	wo := &consulapi.WriteOptions{
+		Datacenter: dc,
+		Token:      d.Get("token").(string),
+	}
+
+	acl := &consulapi.ACLEntry{
+		Name:  d.Get("name").(string),
+		Type:  d.Get("type").(string),
+		Rules: d.Get("rules").(string),
+	}
+
+	log.Printf("[DEBUG] Creating ACL token %q", acl.Name)
+	id, _, err := client.ACL().Create(acl, wo)
+	if err != nil {
+		return fmt.Errorf("Failed to create ACL token: %s", err)
+	}
+
+	d.SetId(id)
+	d.Set("datacenter", dc)
+
+	return resourceConsulACLTokenRead(d, meta)
+}
+
+func resourceConsulACLTokenUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+	wo := &consulapi.WriteOptions{
+		Datacenter: d.Get("datacenter").(string),
+		Token:      d.Get("token").(string),
+	}
+
+	acl := &consulapi.ACLEntry{
+		ID:    d.Id(),
+		Name:  d.Get("name").(string),
+		Type:  d.Get("type").(string),
+		Rules: d.Get("rules").(string),
+	}
+
+	if _, err := client.ACL().Update(acl, wo); err != nil {
+		return fmt.Errorf("Failed to update ACL token %s: %s", d.Id(), err)
+	}
+
+	return resourceConsulACLTokenRead(d, meta)
+}
+
+func resourceConsulACLTokenRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+	qo := &consulapi.QueryOptions{
+		Datacenter: d.Get("datacenter").(string),
+		Token:      d.Get("token").(string),
+	}
+
+	acl, _, err := client.ACL().Info(d.Id(), qo)
+	if err != nil {
+		return fmt.Errorf("Failed to read ACL token %s: %s", d.Id(), err)
+	}
+	if acl == nil {
+		log.Printf("[WARN] ACL token %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", acl.Name)
+	d.Set("type", acl.Type)
+	d.Set("rules", acl.Rules)
+
+	return nil
+}
+
+func resourceConsulACLTokenDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+	wo := &consulapi.WriteOptions{
+		Datacenter: d.Get("datacenter").(string),
+		Token:      d.Get("token").(string),
+	}
+
+	if _, err := client.ACL().Destroy(d.Id(), wo); err != nil {
+		return fmt.Errorf("Failed to delete ACL token %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func validateConsulACLTokenType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != consulapi.ACLClientType && value != consulapi.ACLManagementType {
+		errors = append(errors, fmt.Errorf(
+			"%q must be %q or %q, got %q", k, consulapi.ACLClientType, consulapi.ACLManagementType, value))
+	}
+	return
+}
@@ -0,0 +1,106 @@
+package consul
+
+import (
+	"fmt"
+	"testing"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccConsulACLToken_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckConsulACLTokenDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccConsulACLTokenConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConsulACLTokenRules(`key "" { policy = "read" }`),
+					resource.TestCheckResourceAttr("consul_acl_token.foo", "name", "foo"),
+					resource.TestCheckResourceAttr("consul_acl_token.foo", "type", "client"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccConsulACLTokenConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConsulACLTokenRules(`key "" { policy = "write" }`),
+					resource.TestCheckResourceAttr("consul_acl_token.foo", "name", "bar"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateConsulACLTokenType(t *testing.T) {
+	for _, v := range []string{"client", "management"} {
+		if _, errors := validateConsulACLTokenType(v, "type"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid ACL token type: %q", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "admin", "Client"} {
+		if _, errors := validateConsulACLTokenType(v, "type"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid ACL token type", v)
+		}
+	}
+}
+
+func testAccCheckConsulACLTokenDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*consulapi.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "consul_acl_token" {
+			continue
+		}
+
+		qo := &consulapi.QueryOptions{Datacenter: "dc1"}
+		acl, _, err := client.ACL().Info(rs.Primary.ID, qo)
+		if err != nil {
+			return err
+		}
+		if acl != nil {
+			return fmt.Errorf("ACL token %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckConsulACLTokenRules(rules string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs := s.RootModule().Resources["consul_acl_token.foo"]
+		if rs == nil {
+			return fmt.Errorf("Resource not found")
+		}
+
+		client := testAccProvider.Meta().(*consulapi.Client)
+		qo := &consulapi.QueryOptions{Datacenter: "dc1"}
+		acl, _, err := client.ACL().Info(rs.Primary.ID, qo)
+		if err != nil {
+			return err
+		}
+		if acl == nil {
+			return fmt.Errorf("ACL token %s not found", rs.Primary.ID)
+		}
+		if acl.Rules != rules {
+			return fmt.Errorf("ACL token rules %q != %q", acl.Rules, rules)
+		}
+		return nil
+	}
+}
+
+const testAccConsulACLTokenConfig = `
+resource "consul_acl_token" "foo" {
+	name = "foo"
+	rules = "key \"\" { policy = \"read\" }"
+}
+`
+
+const testAccConsulACLTokenConfigUpdate = `
+resource "consul_acl_token" "foo" {
+	name = "bar"
+	rules = "key \"\" { policy = \"write\" }"
+}
+`
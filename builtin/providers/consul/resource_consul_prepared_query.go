@@ -0,0 +1,208 @@
+package consul
+
+import (
+	"log"
+	"strings"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceConsulPreparedQuery() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceConsulPreparedQueryCreate,
+		Update: resourceConsulPreparedQueryUpdate,
+		Read:   resourceConsulPreparedQueryRead,
+		Delete: resourceConsulPreparedQueryDelete,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"session": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"token": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"stored_token": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"service": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"tags": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"only_passing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"failover_nearest_n": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"failover_datacenters": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"dns_ttl": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceConsulPreparedQueryCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+	dc, err := getDC(d, client)
+	if err != nil {
+		return err
+	}
+
+	wo := &consulapi.WriteOptions{
+		Datacenter: dc,
+		Token:      d.Get("token").(string),
+	}
+
+	pq := preparedQueryDefinitionFromResourceData(d)
+
+	log.Printf("[DEBUG] Creating prepared query %q", pq.Name)
+	id, _, err := client.PreparedQuery().Create(pq, wo)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(id)
+	d.Set("datacenter", dc)
+
+	return resourceConsulPreparedQueryRead(d, meta)
+}
+
+func resourceConsulPreparedQueryUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+	wo := &consulapi.WriteOptions{
+		Datacenter: d.Get("datacenter").(string),
+		Token:      d.Get("token").(string),
+	}
+
+	pq := preparedQueryDefinitionFromResourceData(d)
+
+	if _, err := client.PreparedQuery().Update(pq, wo); err != nil {
+		return err
+	}
+
+	return resourceConsulPreparedQueryRead(d, meta)
+}
+
+func resourceConsulPreparedQueryRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+	qo := &consulapi.QueryOptions{
+		Datacenter: d.Get("datacenter").(string),
+		Token:      d.Get("token").(string),
+	}
+
+	queries, _, err := client.PreparedQuery().Get(d.Id(), qo)
+	if err != nil {
+		// The API client doesn't expose status codes, only the message.
+		if strings.Contains(err.Error(), "Unexpected response code: 404") {
+			log.Printf("[WARN] Prepared query %q not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	if len(queries) != 1 {
+		log.Printf("[WARN] Prepared query %q not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	pq := queries[0]
+
+	d.Set("name", pq.Name)
+	d.Set("session", pq.Session)
+	d.Set("stored_token", pq.Token)
+	d.Set("service", pq.Service.Service)
+	d.Set("tags", pq.Service.Tags)
+	d.Set("only_passing", pq.Service.OnlyPassing)
+	d.Set("failover_nearest_n", pq.Service.Failover.NearestN)
+	d.Set("failover_datacenters", pq.Service.Failover.Datacenters)
+	d.Set("dns_ttl", pq.DNS.TTL)
+
+	return nil
+}
+
+func resourceConsulPreparedQueryDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+	qo := &consulapi.QueryOptions{
+		Datacenter: d.Get("datacenter").(string),
+		Token:      d.Get("token").(string),
+	}
+
+	if _, err := client.PreparedQuery().Delete(d.Id(), qo); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func preparedQueryDefinitionFromResourceData(d *schema.ResourceData) *consulapi.PreparedQueryDefinition {
+	pq := &consulapi.PreparedQueryDefinition{
+		ID:      d.Id(),
+		Name:    d.Get("name").(string),
+		Session: d.Get("session").(string),
+		Token:   d.Get("stored_token").(string),
+		Service: consulapi.ServiceQuery{
+			Service:     d.Get("service").(string),
+			OnlyPassing: d.Get("only_passing").(bool),
+			Failover: consulapi.QueryDatacenterOptions{
+				NearestN: d.Get("failover_nearest_n").(int),
+			},
+		},
+		DNS: consulapi.QueryDNSOptions{
+			TTL: d.Get("dns_ttl").(string),
+		},
+	}
+
+	tags := d.Get("tags").(*schema.Set).List()
+	pq.Service.Tags = make([]string, len(tags))
+	for i, v := range tags {
+		pq.Service.Tags[i] = v.(string)
+	}
+
+	dcs := d.Get("failover_datacenters").([]interface{})
+	pq.Service.Failover.Datacenters = make([]string, len(dcs))
+	for i, v := range dcs {
+		pq.Service.Failover.Datacenters[i] = v.(string)
+	}
+
+	return pq
+}
@@ -0,0 +1,131 @@
+package consul
+
+import (
+	"fmt"
+	"testing"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccConsulPreparedQuery_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckConsulPreparedQueryDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccConsulPreparedQueryConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConsulPreparedQueryExists(),
+					testAccCheckConsulPreparedQueryAttrValue("name", "foo"),
+					testAccCheckConsulPreparedQueryAttrValue("stored_token", "pq-token"),
+					testAccCheckConsulPreparedQueryAttrValue("service", "redis"),
+					testAccCheckConsulPreparedQueryAttrValue("only_passing", "true"),
+					testAccCheckConsulPreparedQueryAttrValue("tags.#", "1"),
+					testAccCheckConsulPreparedQueryAttrValue("failover_nearest_n", "3"),
+					testAccCheckConsulPreparedQueryAttrValue("failover_datacenters.#", "2"),
+					testAccCheckConsulPreparedQueryAttrValue("dns_ttl", "8m"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccConsulPreparedQueryConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConsulPreparedQueryExists(),
+					testAccCheckConsulPreparedQueryAttrValue("name", "baz"),
+					testAccCheckConsulPreparedQueryAttrValue("service", "memcached"),
+					testAccCheckConsulPreparedQueryAttrValue("only_passing", "false"),
+					testAccCheckConsulPreparedQueryAttrValue("tags.#", "0"),
+					testAccCheckConsulPreparedQueryAttrValue("failover_nearest_n", "2"),
+					testAccCheckConsulPreparedQueryAttrValue("failover_datacenters.#", "1"),
+					testAccCheckConsulPreparedQueryAttrValue("dns_ttl", "16m"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckConsulPreparedQueryDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*consulapi.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "consul_prepared_query" {
+			continue
+		}
+
+		qo := &consulapi.QueryOptions{Datacenter: "dc1"}
+		queries, _, err := client.PreparedQuery().Get(rs.Primary.ID, qo)
+		if err == nil && len(queries) > 0 {
+			return fmt.Errorf("Prepared query %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckConsulPreparedQueryExists() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs := s.RootModule().Resources["consul_prepared_query.foo"]
+		if rs == nil {
+			return fmt.Errorf("Resource not found")
+		}
+
+		client := testAccProvider.Meta().(*consulapi.Client)
+		qo := &consulapi.QueryOptions{Datacenter: "dc1"}
+		queries, _, err := client.PreparedQuery().Get(rs.Primary.ID, qo)
+		if err != nil {
+			return err
+		}
+		if len(queries) != 1 {
+			return fmt.Errorf("Prepared query %s not found", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckConsulPreparedQueryAttrValue(attr, val string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs := s.RootModule().Resources["consul_prepared_query.foo"]
+		if rs == nil {
+			return fmt.Errorf("Resource not found")
+		}
+		out, ok := rs.Primary.Attributes[attr]
+		if !ok {
+			return fmt.Errorf("Attribute '%s' not found: %#v", attr, rs.Primary.Attributes)
+		}
+		if out != val {
+			return fmt.Errorf("Attribute '%s' value '%s' != '%s'", attr, out, val)
+		}
+		return nil
+	}
+}
+
+const testAccConsulPreparedQueryConfig = `
+resource "consul_prepared_query" "foo" {
+	name = "foo"
+	token = "client-token"
+	stored_token = "pq-token"
+	service = "redis"
+	tags = ["prod"]
+	only_passing = true
+
+	failover_nearest_n = 3
+	failover_datacenters = ["dc1", "dc2"]
+
+	dns_ttl = "8m"
+}
+`
+
+const testAccConsulPreparedQueryConfigUpdate = `
+resource "consul_prepared_query" "foo" {
+	name = "baz"
+	token = "client-token"
+	stored_token = "pq-token"
+	service = "memcached"
+
+	failover_nearest_n = 2
+	failover_datacenters = ["dc2"]
+
+	dns_ttl = "16m"
+}
+`
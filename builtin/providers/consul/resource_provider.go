@@ -26,11 +26,24 @@ func Provider() terraform.ResourceProvider {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+
+			"token": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CONSUL_HTTP_TOKEN", ""),
+			},
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"consul_nodes":   dataSourceConsulNodes(),
+			"consul_service": dataSourceConsulService(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"consul_keys":       resourceConsulKeys(),
-			"consul_key_prefix": resourceConsulKeyPrefix(),
+			"consul_acl_token":      resourceConsulACLToken(),
+			"consul_keys":           resourceConsulKeys(),
+			"consul_key_prefix":     resourceConsulKeyPrefix(),
+			"consul_prepared_query": resourceConsulPreparedQuery(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -0,0 +1,215 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// dockerHubRegistry is the registry used for images that don't name one.
+const dockerHubRegistry = "registry.hub.docker.com"
+
+func dataSourceDockerRegistryImage() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDockerRegistryImageRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"sha256_digest": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDockerRegistryImageRead(d *schema.ResourceData, meta interface{}) error {
+	registry, repository, tag := parseRegistryImageName(d.Get("name").(string))
+
+	digest, err := getImageDigest(http.DefaultClient, registry, repository, tag)
+	if err != nil {
+		return fmt.Errorf("Unable to read digest of image %s from registry: %s", d.Get("name").(string), err)
+	}
+
+	d.SetId(digest)
+	d.Set("sha256_digest", digest)
+
+	return nil
+}
+
+// parseRegistryImageName splits an image name into the registry host, the
+// repository within that registry and the tag, filling in the defaults
+// Docker itself uses for each of them.
+func parseRegistryImageName(name string) (string, string, string) {
+	pullOpts := parseImageOptions(name)
+	registry := pullOpts.Registry
+	repository := pullOpts.Repository
+	tag := pullOpts.Tag
+
+	if registry == "" {
+		// A registry without a port is only recognizable by its first path
+		// component looking like a host name.
+		parts := strings.SplitN(repository, "/", 2)
+		if len(parts) == 2 && (strings.Contains(parts[0], ".") || parts[0] == "localhost") {
+			registry = parts[0]
+			repository = parts[1]
+		} else {
+			registry = dockerHubRegistry
+		}
+	} else {
+		repository = strings.TrimPrefix(repository, registry+"/")
+	}
+
+	// Official images on the Docker Hub live in the "library" namespace.
+	if registry == dockerHubRegistry && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+
+	if tag == "" {
+		tag = "latest"
+	}
+
+	return registry, repository, tag
+}
+
+// getImageDigest asks the registry for the manifest of repository:tag and
+// returns its content digest. Registries that require a token, such as the
+// Docker Hub even for public images, are retried with an anonymous one.
+func getImageDigest(client *http.Client, registry, repository, tag string) (string, error) {
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", registry, repository, tag)
+
+	resp, err := doManifestRequest(client, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := getRegistryToken(client, resp.Header.Get("Www-Authenticate"))
+		if err != nil {
+			return "", err
+		}
+
+		resp, err = doManifestRequest(client, manifestURL, token)
+		if err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Got bad response from registry: %s", resp.Status)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("Registry did not return a content digest")
+	}
+
+	return digest, nil
+}
+
+func doManifestRequest(client *http.Client, manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequest("HEAD", manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ask for the v2 manifest, as the digest of the v1 one changes with
+	// every request.
+	req.Header.Set("Accept", "application/vnd.docker.distribution.manifest.v2+json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return client.Do(req)
+}
+
+// getRegistryToken requests an anonymous token from the authorization
+// server named in a Www-Authenticate challenge.
+func getRegistryToken(client *http.Client, challenge string) (string, error) {
+	params, err := parseBearerChallenge(challenge)
+	if err != nil {
+		return "", err
+	}
+
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("No realm in authentication challenge %q", challenge)
+	}
+
+	query := url.Values{}
+	for _, k := range []string{"service", "scope"} {
+		if v, ok := params[k]; ok {
+			query.Set(k, v)
+		}
+	}
+
+	resp, err := client.Get(realm + "?" + query.Encode())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Got bad response from token server: %s", resp.Status)
+	}
+
+	var body struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("Error decoding registry token: %s", err)
+	}
+
+	return body.Token, nil
+}
+
+// parseBearerChallenge parses a challenge of the form
+// Bearer realm="...",service="...",scope="..." into its parameters.
+func parseBearerChallenge(challenge string) (map[string]string, error) {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return nil, fmt.Errorf("Unsupported authentication challenge %q", challenge)
+	}
+
+	params := make(map[string]string)
+	rest := strings.TrimPrefix(challenge, "Bearer ")
+	for rest != "" {
+		eq := strings.Index(rest, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("Malformed authentication challenge %q", challenge)
+		}
+		key := strings.TrimSpace(rest[:eq])
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				return nil, fmt.Errorf("Malformed authentication challenge %q", challenge)
+			}
+			value = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else {
+			end := strings.Index(rest, ",")
+			if end < 0 {
+				end = len(rest)
+			}
+			value = rest[:end]
+			rest = rest[end:]
+		}
+
+		params[key] = value
+		rest = strings.TrimPrefix(strings.TrimSpace(rest), ",")
+	}
+
+	return params, nil
+}
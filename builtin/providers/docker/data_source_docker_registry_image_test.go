@@ -0,0 +1,158 @@
+package docker
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDockerRegistryImage_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDockerImageDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.docker_registry_image.foo", "sha256_digest", contentDigestRegexp),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDockerRegistryImage_private(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDockerImageDataSourcePrivateConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.docker_registry_image.bar", "sha256_digest", contentDigestRegexp),
+				),
+			},
+		},
+	})
+}
+
+func TestParseRegistryImageName(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Registry   string
+		Repository string
+		Tag        string
+	}{
+		{"alpine", dockerHubRegistry, "library/alpine", "latest"},
+		{"alpine:3.1", dockerHubRegistry, "library/alpine", "3.1"},
+		{"hashicorp/consul:0.7.0", dockerHubRegistry, "hashicorp/consul", "0.7.0"},
+		{"gcr.io/google_containers/pause", "gcr.io", "google_containers/pause", "latest"},
+		{"gcr.io:443/google_containers/pause:0.8.0", "gcr.io:443", "google_containers/pause", "0.8.0"},
+		{"localhost:5000/foo", "localhost:5000", "foo", "latest"},
+	}
+
+	for _, tc := range cases {
+		registry, repository, tag := parseRegistryImageName(tc.Name)
+		if registry != tc.Registry || repository != tc.Repository || tag != tc.Tag {
+			t.Fatalf("%s: expected %s, %s, %s, got %s, %s, %s",
+				tc.Name, tc.Registry, tc.Repository, tc.Tag, registry, repository, tag)
+		}
+	}
+}
+
+func TestParseBearerChallenge(t *testing.T) {
+	params, err := parseBearerChallenge(
+		`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/alpine:pull"`)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/alpine:pull",
+	}
+	if !reflect.DeepEqual(params, expected) {
+		t.Fatalf("bad: %#v", params)
+	}
+
+	for _, challenge := range []string{
+		`Basic realm="registry"`,
+		`Bearer realm`,
+		`Bearer realm="unterminated`,
+	} {
+		if _, err := parseBearerChallenge(challenge); err == nil {
+			t.Fatalf("expected error for %q", challenge)
+		}
+	}
+}
+
+func TestGetImageDigest(t *testing.T) {
+	const digest = "sha256:4e3d1a8b9b3f7d2a1c5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c"
+
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			if r.URL.Query().Get("scope") != "repository:library/alpine:pull" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"token": "secret"}`)
+
+		case "/v2/library/alpine/manifests/3.1":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.Header().Set("Www-Authenticate", fmt.Sprintf(
+					`Bearer realm="%s/token",service="test",scope="repository:library/alpine:pull"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if !strings.Contains(r.Header.Get("Accept"), "manifest.v2") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", digest)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	registry := strings.TrimPrefix(server.URL, "https://")
+
+	actual, err := getImageDigest(client, registry, "library/alpine", "3.1")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual != digest {
+		t.Fatalf("expected digest %s, got %s", digest, actual)
+	}
+
+	if _, err := getImageDigest(client, registry, "library/missing", "latest"); err == nil {
+		t.Fatal("expected error for missing image")
+	}
+}
+
+const testAccDockerImageDataSourceConfig = `
+data "docker_registry_image" "foo" {
+	name = "alpine:latest"
+}
+`
+
+const testAccDockerImageDataSourcePrivateConfig = `
+data "docker_registry_image" "bar" {
+	name = "gcr.io:443/google_containers/pause:0.8.0"
+}
+`
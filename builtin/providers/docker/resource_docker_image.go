@@ -31,6 +31,12 @@ func resourceDockerImage() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+
+			"pull_trigger": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
 		},
 	}
 }
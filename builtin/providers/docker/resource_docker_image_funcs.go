@@ -117,6 +117,18 @@ func pullImage(data *Data, client *dc.Client, image string) error {
 	// TODO: Test local registry handling. It should be working
 	// based on the code that was ported over
 
+	pullOpts := parseImageOptions(image)
+
+	if err := client.PullImage(pullOpts, dc.AuthConfiguration{}); err != nil {
+		return fmt.Errorf("Error pulling image %s: %s\n", image, err)
+	}
+
+	return fetchLocalImages(data, client)
+}
+
+// parseImageOptions splits an image name into the registry, repository and
+// tag used to pull it. The registry is left empty for the default registry.
+func parseImageOptions(image string) dc.PullImageOptions {
 	pullOpts := dc.PullImageOptions{}
 
 	splitImageName := strings.Split(image, ":")
@@ -151,11 +163,7 @@ func pullImage(data *Data, client *dc.Client, image string) error {
 		pullOpts.Repository = image
 	}
 
-	if err := client.PullImage(pullOpts, dc.AuthConfiguration{}); err != nil {
-		return fmt.Errorf("Error pulling image %s: %s\n", image, err)
-	}
-
-	return fetchLocalImages(data, client)
+	return pullOpts
 }
 
 func getImageTag(image string) string {
@@ -192,7 +200,11 @@ func findImage(d *schema.ResourceData, client *dc.Client) (*dc.APIImages, error)
 
 	foundImage := searchLocalImages(data, imageName)
 
-	if d.Get("keep_updated").(bool) || foundImage == nil {
+	// A change of pull_trigger recreates the resource, and the new image
+	// must be pulled even if an older one is still around locally.
+	pullTriggered := d.Id() == "" && d.Get("pull_trigger").(string) != ""
+
+	if d.Get("keep_updated").(bool) || pullTriggered || foundImage == nil {
 		if err := pullImage(&data, client, imageName); err != nil {
 			return nil, fmt.Errorf("Unable to pull image %s: %s", imageName, err)
 		}
@@ -439,6 +439,77 @@ func resourceServiceV1() *schema.Resource {
 				},
 			},
 
+			"papertrail": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// Required fields
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Unique name to refer to this logging setup",
+						},
+						"address": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The address of the papertrail service",
+						},
+						"port": &schema.Schema{
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The port of the papertrail service",
+						},
+						// Optional fields
+						"format": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "%h %l %u %t %r %>s",
+							Description: "Apache-style string or VCL variables to use for log formatting",
+						},
+						"response_condition": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Name of a condition to apply this logging",
+						},
+					},
+				},
+			},
+
+			"sumologic": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// Required fields
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Unique name to refer to this logging setup",
+						},
+						"url": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The URL to POST to",
+						},
+						// Optional fields
+						"format": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "%h %l %u %t %r %>s",
+							Description: "Apache-style string or VCL variables to use for log formatting",
+						},
+						"response_condition": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Name of a condition to apply this logging",
+						},
+					},
+				},
+			},
+
 			"request_setting": &schema.Schema{
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -598,6 +669,8 @@ func resourceServiceV1Update(d *schema.ResourceData, meta interface{}) error {
 		"header",
 		"gzip",
 		"s3logging",
+		"papertrail",
+		"sumologic",
 		"condition",
 		"request_setting",
 		"cache_setting",
@@ -1013,6 +1086,111 @@ func resourceServiceV1Update(d *schema.ResourceData, meta interface{}) error {
 			}
 		}
 
+		// find difference in Papertrail
+		if d.HasChange("papertrail") {
+			os, ns := d.GetChange("papertrail")
+			if os == nil {
+				os = new(schema.Set)
+			}
+			if ns == nil {
+				ns = new(schema.Set)
+			}
+
+			oss := os.(*schema.Set)
+			nss := ns.(*schema.Set)
+			removePapertrail := oss.Difference(nss).List()
+			addPapertrail := nss.Difference(oss).List()
+
+			// DELETE old papertrail configurations
+			for _, pRaw := range removePapertrail {
+				pf := pRaw.(map[string]interface{})
+				opts := gofastly.DeletePapertrailInput{
+					Service: d.Id(),
+					Version: latestVersion,
+					Name:    pf["name"].(string),
+				}
+
+				log.Printf("[DEBUG] Fastly Papertrail removal opts: %#v", opts)
+				err := conn.DeletePapertrail(&opts)
+				if err != nil {
+					return err
+				}
+			}
+
+			// POST new/updated Papertrail
+			for _, pRaw := range addPapertrail {
+				pf := pRaw.(map[string]interface{})
+
+				opts := gofastly.CreatePapertrailInput{
+					Service:           d.Id(),
+					Version:           latestVersion,
+					Name:              pf["name"].(string),
+					Address:           pf["address"].(string),
+					Port:              uint(pf["port"].(int)),
+					Format:            pf["format"].(string),
+					ResponseCondition: pf["response_condition"].(string),
+				}
+
+				log.Printf("[DEBUG] Create Papertrail Opts: %#v", opts)
+				_, err := conn.CreatePapertrail(&opts)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// find difference in Sumologic
+		if d.HasChange("sumologic") {
+			os, ns := d.GetChange("sumologic")
+			if os == nil {
+				os = new(schema.Set)
+			}
+			if ns == nil {
+				ns = new(schema.Set)
+			}
+
+			oss := os.(*schema.Set)
+			nss := ns.(*schema.Set)
+			removeSumologic := oss.Difference(nss).List()
+			addSumologic := nss.Difference(oss).List()
+
+			// DELETE old sumologic configurations
+			for _, sRaw := range removeSumologic {
+				sf := sRaw.(map[string]interface{})
+				opts := gofastly.DeleteSumologicInput{
+					Service: d.Id(),
+					Version: latestVersion,
+					Name:    sf["name"].(string),
+				}
+
+				log.Printf("[DEBUG] Fastly Sumologic removal opts: %#v", opts)
+				err := conn.DeleteSumologic(&opts)
+				if err != nil {
+					return err
+				}
+			}
+
+			// POST new/updated Sumologic
+			for _, sRaw := range addSumologic {
+				sf := sRaw.(map[string]interface{})
+
+				opts := gofastly.CreateSumologicInput{
+					Service:           d.Id(),
+					Version:           latestVersion,
+					Name:              sf["name"].(string),
+					URL:               sf["url"].(string),
+					Format:            sf["format"].(string),
+					ResponseCondition: sf["response_condition"].(string),
+				}
+
+				log.Printf("[DEBUG] Create Sumologic Opts: %#v", opts)
+				_, err := conn.CreateSumologic(&opts)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
 		// find difference in request settings
 		if d.HasChange("request_setting") {
 			os, ns := d.GetChange("request_setting")
@@ -1341,6 +1519,40 @@ func resourceServiceV1Read(d *schema.ResourceData, meta interface{}) error {
 			log.Printf("[WARN] Error setting S3 Logging for (%s): %s", d.Id(), err)
 		}
 
+		// refresh Papertrail Logging
+		log.Printf("[DEBUG] Refreshing Papertrail for (%s)", d.Id())
+		papertrailList, err := conn.ListPapertrails(&gofastly.ListPapertrailsInput{
+			Service: d.Id(),
+			Version: s.ActiveVersion.Number,
+		})
+
+		if err != nil {
+			return fmt.Errorf("[ERR] Error looking up Papertrail for (%s), version (%s): %s", d.Id(), s.ActiveVersion.Number, err)
+		}
+
+		pl := flattenPapertrails(papertrailList)
+
+		if err := d.Set("papertrail", pl); err != nil {
+			log.Printf("[WARN] Error setting Papertrail for (%s): %s", d.Id(), err)
+		}
+
+		// refresh Sumologic Logging
+		log.Printf("[DEBUG] Refreshing Sumologic for (%s)", d.Id())
+		sumologicList, err := conn.ListSumologics(&gofastly.ListSumologicsInput{
+			Service: d.Id(),
+			Version: s.ActiveVersion.Number,
+		})
+
+		if err != nil {
+			return fmt.Errorf("[ERR] Error looking up Sumologic for (%s), version (%s): %s", d.Id(), s.ActiveVersion.Number, err)
+		}
+
+		sul := flattenSumologics(sumologicList)
+
+		if err := d.Set("sumologic", sul); err != nil {
+			log.Printf("[WARN] Error setting Sumologic for (%s): %s", d.Id(), err)
+		}
+
 		// refresh Conditions
 		log.Printf("[DEBUG] Refreshing Conditions for (%s)", d.Id())
 		conditionList, err := conn.ListConditions(&gofastly.ListConditionsInput{
@@ -1701,6 +1913,55 @@ func flattenS3s(s3List []*gofastly.S3) []map[string]interface{} {
 	return sl
 }
 
+func flattenPapertrails(papertrailList []*gofastly.Papertrail) []map[string]interface{} {
+	var pl []map[string]interface{}
+	for _, p := range papertrailList {
+		// Convert Papertrails to a map for saving to state.
+		ns := map[string]interface{}{
+			"name":               p.Name,
+			"address":            p.Address,
+			"port":               p.Port,
+			"format":             p.Format,
+			"response_condition": p.ResponseCondition,
+		}
+
+		// prune any empty values that come from the default string value in structs
+		for k, v := range ns {
+			if v == "" {
+				delete(ns, k)
+			}
+		}
+
+		pl = append(pl, ns)
+	}
+
+	return pl
+}
+
+func flattenSumologics(sumologicList []*gofastly.Sumologic) []map[string]interface{} {
+	var l []map[string]interface{}
+	for _, p := range sumologicList {
+		// Convert Sumologic to a map for saving to state.
+		ns := map[string]interface{}{
+			"name":               p.Name,
+			"url":                p.URL,
+			"format":             p.Format,
+			"response_condition": p.ResponseCondition,
+		}
+
+		// prune any empty values that come from the default string value in structs
+		for k, v := range ns {
+			if v == "" {
+				delete(ns, k)
+			}
+		}
+
+		l = append(l, ns)
+	}
+
+	return l
+}
+
 func flattenConditions(conditionList []*gofastly.Condition) []map[string]interface{} {
 	var cl []map[string]interface{}
 	for _, c := range conditionList {
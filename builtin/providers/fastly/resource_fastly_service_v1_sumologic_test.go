@@ -0,0 +1,162 @@
+package fastly
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	gofastly "github.com/sethvargo/go-fastly"
+)
+
+func TestAccFastlyServiceV1_sumologic_basic(t *testing.T) {
+	var service gofastly.ServiceDetail
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	domainName1 := fmt.Sprintf("%s.notadomain.com", acctest.RandString(10))
+
+	log1 := gofastly.Sumologic{
+		Version:           "1",
+		Name:              "sumologiclogger",
+		URL:               "https://collectors.sumologic.com/receiver/1",
+		Format:            "%h %l %u %t %r %>s",
+		ResponseCondition: "",
+	}
+
+	log2 := gofastly.Sumologic{
+		Version:           "1",
+		Name:              "sumologiclogger2",
+		URL:               "https://collectors.sumologic.com/receiver/2",
+		Format:            "%h %l %u %t %r %>s",
+		ResponseCondition: "",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckServiceV1Destroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccServiceV1SumologicConfig(name, domainName1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceV1Exists("fastly_service_v1.foo", &service),
+					testAccCheckFastlyServiceV1SumologicAttributes(&service, []*gofastly.Sumologic{&log1}),
+					resource.TestCheckResourceAttr(
+						"fastly_service_v1.foo", "name", name),
+					resource.TestCheckResourceAttr(
+						"fastly_service_v1.foo", "sumologic.#", "1"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccServiceV1SumologicConfig_update(name, domainName1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceV1Exists("fastly_service_v1.foo", &service),
+					testAccCheckFastlyServiceV1SumologicAttributes(&service, []*gofastly.Sumologic{&log1, &log2}),
+					resource.TestCheckResourceAttr(
+						"fastly_service_v1.foo", "name", name),
+					resource.TestCheckResourceAttr(
+						"fastly_service_v1.foo", "sumologic.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckFastlyServiceV1SumologicAttributes(service *gofastly.ServiceDetail, sumologics []*gofastly.Sumologic) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		conn := testAccProvider.Meta().(*FastlyClient).conn
+		sumologicList, err := conn.ListSumologics(&gofastly.ListSumologicsInput{
+			Service: service.ID,
+			Version: service.ActiveVersion.Number,
+		})
+
+		if err != nil {
+			return fmt.Errorf("[ERR] Error looking up Sumologic for (%s), version (%s): %s", service.Name, service.ActiveVersion.Number, err)
+		}
+
+		if len(sumologicList) != len(sumologics) {
+			return fmt.Errorf("Sumologic List count mismatch, expected (%d), got (%d)", len(sumologics), len(sumologicList))
+		}
+
+		var found int
+		for _, p := range sumologics {
+			for _, lp := range sumologicList {
+				if p.Name == lp.Name {
+					// we don't know these things ahead of time, so populate them now
+					p.ServiceID = service.ID
+					p.Version = service.ActiveVersion.Number
+					// We don't track these, so clear them out because we also wont know
+					// these ahead of time
+					lp.CreatedAt = nil
+					lp.UpdatedAt = nil
+					if !reflect.DeepEqual(p, lp) {
+						return fmt.Errorf("Bad match Sumologic match, expected (%#v), got (%#v)", p, lp)
+					}
+					found++
+				}
+			}
+		}
+
+		if found != len(sumologics) {
+			return fmt.Errorf("Error matching Sumologic rules")
+		}
+
+		return nil
+	}
+}
+
+func testAccServiceV1SumologicConfig(name, domain string) string {
+	return fmt.Sprintf(`
+resource "fastly_service_v1" "foo" {
+  name = "%s"
+
+  domain {
+    name    = "%s"
+    comment = "tf-testing-domain"
+  }
+
+  backend {
+    address = "aws.amazon.com"
+    name    = "amazon docs"
+  }
+
+  sumologic {
+    name    = "sumologiclogger"
+    url     = "https://collectors.sumologic.com/receiver/1"
+  }
+
+  force_destroy = true
+}`, name, domain)
+}
+
+func testAccServiceV1SumologicConfig_update(name, domain string) string {
+	return fmt.Sprintf(`
+resource "fastly_service_v1" "foo" {
+  name = "%s"
+
+  domain {
+    name    = "%s"
+    comment = "tf-testing-domain"
+  }
+
+  backend {
+    address = "aws.amazon.com"
+    name    = "amazon docs"
+  }
+
+  sumologic {
+    name    = "sumologiclogger"
+    url     = "https://collectors.sumologic.com/receiver/1"
+  }
+
+  sumologic {
+    name    = "sumologiclogger2"
+    url     = "https://collectors.sumologic.com/receiver/2"
+  }
+
+  force_destroy = true
+}`, name, domain)
+}
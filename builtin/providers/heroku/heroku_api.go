@@ -0,0 +1,57 @@
+package heroku
+
+import (
+	"github.com/cyberdelia/heroku-go/v3"
+)
+
+// The vendored client predates the endpoints below, so the resources that
+// use them go through its generic Get, Post, Patch and Delete methods with
+// these types.
+
+type pipeline struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type pipelineCoupling struct {
+	ID  string `json:"id"`
+	App struct {
+		ID string `json:"id"`
+	} `json:"app"`
+	Pipeline struct {
+		ID string `json:"id"`
+	} `json:"pipeline"`
+	Stage string `json:"stage"`
+}
+
+type addonAttachment struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Addon struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"addon"`
+	App struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"app"`
+}
+
+type space struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	State        string `json:"state"`
+	Organization struct {
+		Name string `json:"name"`
+	} `json:"organization"`
+	Region struct {
+		Name string `json:"name"`
+	} `json:"region"`
+}
+
+// isHerokuNotFound returns true if err is the API's error for a missing
+// object.
+func isHerokuNotFound(err error) bool {
+	herr, ok := err.(heroku.Error)
+	return ok && herr.ID == "not_found"
+}
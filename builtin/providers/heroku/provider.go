@@ -25,11 +25,15 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"heroku_app":    resourceHerokuApp(),
-			"heroku_addon":  resourceHerokuAddon(),
-			"heroku_domain": resourceHerokuDomain(),
-			"heroku_drain":  resourceHerokuDrain(),
-			"heroku_cert":   resourceHerokuCert(),
+			"heroku_app":               resourceHerokuApp(),
+			"heroku_addon":             resourceHerokuAddon(),
+			"heroku_addon_attachment":  resourceHerokuAddonAttachment(),
+			"heroku_domain":            resourceHerokuDomain(),
+			"heroku_drain":             resourceHerokuDrain(),
+			"heroku_cert":              resourceHerokuCert(),
+			"heroku_pipeline":          resourceHerokuPipeline(),
+			"heroku_pipeline_coupling": resourceHerokuPipelineCoupling(),
+			"heroku_space":             resourceHerokuSpace(),
 		},
 
 		ConfigureFunc: providerConfigure,
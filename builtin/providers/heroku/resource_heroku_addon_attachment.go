@@ -0,0 +1,97 @@
+package heroku
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/cyberdelia/heroku-go/v3"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceHerokuAddonAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceHerokuAddonAttachmentCreate,
+		Read:   resourceHerokuAddonAttachmentRead,
+		Delete: resourceHerokuAddonAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"app": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"addon_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceHerokuAddonAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	opts := map[string]string{
+		"app":   d.Get("app").(string),
+		"addon": d.Get("addon_id").(string),
+	}
+	if v, ok := d.GetOk("name"); ok {
+		opts["name"] = v.(string)
+	}
+
+	log.Printf("[DEBUG] Addon attachment create configuration: %#v", opts)
+
+	var a addonAttachment
+	if err := client.Post(&a, "/addon-attachments", opts); err != nil {
+		return fmt.Errorf("Error creating addon attachment: %s", err)
+	}
+
+	d.SetId(a.ID)
+	log.Printf("[INFO] Addon attachment ID: %s", d.Id())
+
+	return resourceHerokuAddonAttachmentRead(d, meta)
+}
+
+func resourceHerokuAddonAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	var a addonAttachment
+	if err := client.Get(&a, "/addon-attachments/"+d.Id(), nil); err != nil {
+		if isHerokuNotFound(err) {
+			log.Printf("[WARN] Addon attachment %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving addon attachment: %s", err)
+	}
+
+	d.Set("app", a.App.Name)
+	d.Set("addon_id", a.Addon.ID)
+	d.Set("name", a.Name)
+
+	return nil
+}
+
+func resourceHerokuAddonAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	log.Printf("[INFO] Deleting addon attachment: %s", d.Id())
+
+	if err := client.Delete("/addon-attachments/" + d.Id()); err != nil {
+		return fmt.Errorf("Error deleting addon attachment: %s", err)
+	}
+
+	return nil
+}
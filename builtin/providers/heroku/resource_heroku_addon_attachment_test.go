@@ -0,0 +1,105 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cyberdelia/heroku-go/v3"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccHerokuAddonAttachment_Basic(t *testing.T) {
+	var attachment addonAttachment
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	appName2 := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuAddonAttachmentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckHerokuAddonAttachmentConfig_basic(appName, appName2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuAddonAttachmentExists("heroku_addon_attachment.foobar", &attachment),
+					resource.TestCheckResourceAttr(
+						"heroku_addon_attachment.foobar", "app", appName2),
+					resource.TestCheckResourceAttr(
+						"heroku_addon_attachment.foobar", "name", "SHARED_DB"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuAddonAttachmentExists(n string, a *addonAttachment) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No addon attachment ID set")
+		}
+
+		client := testAccProvider.Meta().(*heroku.Service)
+
+		var foundAttachment addonAttachment
+		if err := client.Get(&foundAttachment, "/addon-attachments/"+rs.Primary.ID, nil); err != nil {
+			return err
+		}
+
+		if foundAttachment.ID != rs.Primary.ID {
+			return fmt.Errorf("Addon attachment not found")
+		}
+
+		*a = foundAttachment
+
+		return nil
+	}
+}
+
+func testAccCheckHerokuAddonAttachmentDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*heroku.Service)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "heroku_addon_attachment" {
+			continue
+		}
+
+		var a addonAttachment
+		if err := client.Get(&a, "/addon-attachments/"+rs.Primary.ID, nil); err == nil {
+			return fmt.Errorf("Addon attachment still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckHerokuAddonAttachmentConfig_basic(appName, appName2 string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+    name = "%s"
+    region = "us"
+}
+
+resource "heroku_app" "foobaz" {
+    name = "%s"
+    region = "us"
+}
+
+resource "heroku_addon" "foobar" {
+    app = "${heroku_app.foobar.name}"
+    plan = "heroku-postgresql:hobby-dev"
+}
+
+resource "heroku_addon_attachment" "foobar" {
+    app = "${heroku_app.foobaz.name}"
+    addon_id = "${heroku_addon.foobar.id}"
+    name = "SHARED_DB"
+}`, appName, appName2)
+}
@@ -0,0 +1,91 @@
+package heroku
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/cyberdelia/heroku-go/v3"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceHerokuPipeline() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceHerokuPipelineCreate,
+		Read:   resourceHerokuPipelineRead,
+		Update: resourceHerokuPipelineUpdate,
+		Delete: resourceHerokuPipelineDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceHerokuPipelineCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	opts := map[string]string{"name": d.Get("name").(string)}
+
+	log.Printf("[DEBUG] Pipeline create configuration: %#v", opts)
+
+	var p pipeline
+	if err := client.Post(&p, "/pipelines", opts); err != nil {
+		return fmt.Errorf("Error creating pipeline: %s", err)
+	}
+
+	d.SetId(p.ID)
+	log.Printf("[INFO] Pipeline ID: %s", d.Id())
+
+	return resourceHerokuPipelineRead(d, meta)
+}
+
+func resourceHerokuPipelineUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	if d.HasChange("name") {
+		opts := map[string]string{"name": d.Get("name").(string)}
+
+		var p pipeline
+		if err := client.Patch(&p, "/pipelines/"+d.Id(), opts); err != nil {
+			return fmt.Errorf("Error updating pipeline: %s", err)
+		}
+	}
+
+	return resourceHerokuPipelineRead(d, meta)
+}
+
+func resourceHerokuPipelineRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	var p pipeline
+	if err := client.Get(&p, "/pipelines/"+d.Id(), nil); err != nil {
+		if isHerokuNotFound(err) {
+			log.Printf("[WARN] Pipeline %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving pipeline: %s", err)
+	}
+
+	d.Set("name", p.Name)
+
+	return nil
+}
+
+func resourceHerokuPipelineDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	log.Printf("[INFO] Deleting pipeline: %s", d.Id())
+
+	if err := client.Delete("/pipelines/" + d.Id()); err != nil {
+		return fmt.Errorf("Error deleting pipeline: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package heroku
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/cyberdelia/heroku-go/v3"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceHerokuPipelineCoupling() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceHerokuPipelineCouplingCreate,
+		Read:   resourceHerokuPipelineCouplingRead,
+		Update: resourceHerokuPipelineCouplingUpdate,
+		Delete: resourceHerokuPipelineCouplingDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"app": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"pipeline": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"stage": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validatePipelineStageName,
+			},
+
+			"app_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceHerokuPipelineCouplingCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	opts := map[string]string{
+		"app":      d.Get("app").(string),
+		"pipeline": d.Get("pipeline").(string),
+		"stage":    d.Get("stage").(string),
+	}
+
+	log.Printf("[DEBUG] Pipeline coupling create configuration: %#v", opts)
+
+	var pc pipelineCoupling
+	if err := client.Post(&pc, "/pipeline-couplings", opts); err != nil {
+		return fmt.Errorf("Error creating pipeline coupling: %s", err)
+	}
+
+	d.SetId(pc.ID)
+	log.Printf("[INFO] Pipeline coupling ID: %s", d.Id())
+
+	return resourceHerokuPipelineCouplingRead(d, meta)
+}
+
+func resourceHerokuPipelineCouplingUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	if d.HasChange("stage") {
+		opts := map[string]string{"stage": d.Get("stage").(string)}
+
+		var pc pipelineCoupling
+		if err := client.Patch(&pc, "/pipeline-couplings/"+d.Id(), opts); err != nil {
+			return fmt.Errorf("Error updating pipeline coupling: %s", err)
+		}
+	}
+
+	return resourceHerokuPipelineCouplingRead(d, meta)
+}
+
+func resourceHerokuPipelineCouplingRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	var pc pipelineCoupling
+	if err := client.Get(&pc, "/pipeline-couplings/"+d.Id(), nil); err != nil {
+		if isHerokuNotFound(err) {
+			log.Printf("[WARN] Pipeline coupling %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving pipeline coupling: %s", err)
+	}
+
+	// The API only returns the app's ID, while it is usually configured by
+	// name, so the name is only filled in with the ID when importing.
+	if d.Get("app").(string) == "" {
+		d.Set("app", pc.App.ID)
+	}
+	d.Set("app_id", pc.App.ID)
+	d.Set("pipeline", pc.Pipeline.ID)
+	d.Set("stage", pc.Stage)
+
+	return nil
+}
+
+func resourceHerokuPipelineCouplingDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	log.Printf("[INFO] Deleting pipeline coupling: %s", d.Id())
+
+	if err := client.Delete("/pipeline-couplings/" + d.Id()); err != nil {
+		return fmt.Errorf("Error deleting pipeline coupling: %s", err)
+	}
+
+	return nil
+}
+
+func validatePipelineStageName(v interface{}, k string) (ws []string, errors []error) {
+	validPipelineStageNames := []string{
+		"review",
+		"development",
+		"staging",
+		"production",
+	}
+
+	value := v.(string)
+	for _, s := range validPipelineStageNames {
+		if value == s {
+			return
+		}
+	}
+
+	errors = append(errors, fmt.Errorf(
+		"%q must be one of %v, got %q", k, validPipelineStageNames, value))
+	return
+}
@@ -0,0 +1,127 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cyberdelia/heroku-go/v3"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccHerokuPipelineCoupling_Basic(t *testing.T) {
+	var coupling pipelineCoupling
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	pipelineName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuPipelineCouplingDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckHerokuPipelineCouplingConfig_basic(appName, pipelineName, "development"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuPipelineCouplingExists("heroku_pipeline_coupling.default", &coupling),
+					testAccCheckHerokuPipelineCouplingAttributes(&coupling, "development"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckHerokuPipelineCouplingConfig_basic(appName, pipelineName, "staging"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuPipelineCouplingExists("heroku_pipeline_coupling.default", &coupling),
+					testAccCheckHerokuPipelineCouplingAttributes(&coupling, "staging"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidatePipelineStageName(t *testing.T) {
+	for _, v := range []string{"review", "development", "staging", "production"} {
+		if _, errs := validatePipelineStageName(v, "stage"); len(errs) != 0 {
+			t.Fatalf("%q should be a valid stage: %v", v, errs)
+		}
+	}
+
+	for _, v := range []string{"", "test", "Production"} {
+		if _, errs := validatePipelineStageName(v, "stage"); len(errs) == 0 {
+			t.Fatalf("%q should be an invalid stage", v)
+		}
+	}
+}
+
+func testAccCheckHerokuPipelineCouplingExists(n string, pc *pipelineCoupling) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No coupling ID set")
+		}
+
+		client := testAccProvider.Meta().(*heroku.Service)
+
+		var foundCoupling pipelineCoupling
+		if err := client.Get(&foundCoupling, "/pipeline-couplings/"+rs.Primary.ID, nil); err != nil {
+			return err
+		}
+
+		if foundCoupling.ID != rs.Primary.ID {
+			return fmt.Errorf("Coupling not found: %s != %s", foundCoupling.ID, rs.Primary.ID)
+		}
+
+		*pc = foundCoupling
+
+		return nil
+	}
+}
+
+func testAccCheckHerokuPipelineCouplingAttributes(pc *pipelineCoupling, stage string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if pc.Stage != stage {
+			return fmt.Errorf("Bad stage: %s", pc.Stage)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckHerokuPipelineCouplingDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*heroku.Service)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "heroku_pipeline_coupling" {
+			continue
+		}
+
+		var pc pipelineCoupling
+		if err := client.Get(&pc, "/pipeline-couplings/"+rs.Primary.ID, nil); err == nil {
+			return fmt.Errorf("Coupling still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckHerokuPipelineCouplingConfig_basic(appName, pipelineName, stage string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "default" {
+    name = "%s"
+    region = "us"
+}
+
+resource "heroku_pipeline" "default" {
+    name = "%s"
+}
+
+resource "heroku_pipeline_coupling" "default" {
+    app = "${heroku_app.default.name}"
+    pipeline = "${heroku_pipeline.default.id}"
+    stage = "%s"
+}`, appName, pipelineName, stage)
+}
@@ -0,0 +1,93 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cyberdelia/heroku-go/v3"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccHerokuPipeline_Basic(t *testing.T) {
+	var p pipeline
+	pipelineName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	pipelineName2 := fmt.Sprintf("%s-2", pipelineName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuPipelineDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckHerokuPipelineConfig_basic(pipelineName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuPipelineExists("heroku_pipeline.foobar", &p),
+					resource.TestCheckResourceAttr(
+						"heroku_pipeline.foobar", "name", pipelineName),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckHerokuPipelineConfig_basic(pipelineName2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"heroku_pipeline.foobar", "name", pipelineName2),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuPipelineExists(n string, p *pipeline) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No pipeline ID set")
+		}
+
+		client := testAccProvider.Meta().(*heroku.Service)
+
+		var foundPipeline pipeline
+		if err := client.Get(&foundPipeline, "/pipelines/"+rs.Primary.ID, nil); err != nil {
+			return err
+		}
+
+		if foundPipeline.ID != rs.Primary.ID {
+			return fmt.Errorf("Pipeline not found: %s != %s", foundPipeline.ID, rs.Primary.ID)
+		}
+
+		*p = foundPipeline
+
+		return nil
+	}
+}
+
+func testAccCheckHerokuPipelineDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*heroku.Service)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "heroku_pipeline" {
+			continue
+		}
+
+		var p pipeline
+		if err := client.Get(&p, "/pipelines/"+rs.Primary.ID, nil); err == nil {
+			return fmt.Errorf("Pipeline still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckHerokuPipelineConfig_basic(pipelineName string) string {
+	return fmt.Sprintf(`
+resource "heroku_pipeline" "foobar" {
+    name = "%s"
+}`, pipelineName)
+}
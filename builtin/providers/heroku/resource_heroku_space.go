@@ -0,0 +1,142 @@
+package heroku
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cyberdelia/heroku-go/v3"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceHerokuSpace() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceHerokuSpaceCreate,
+		Read:   resourceHerokuSpaceRead,
+		Update: resourceHerokuSpaceUpdate,
+		Delete: resourceHerokuSpaceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"organization": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceHerokuSpaceCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	opts := map[string]string{
+		"name":         d.Get("name").(string),
+		"organization": d.Get("organization").(string),
+	}
+	if v, ok := d.GetOk("region"); ok {
+		opts["region"] = v.(string)
+	}
+
+	log.Printf("[DEBUG] Space create configuration: %#v", opts)
+
+	var s space
+	if err := client.Post(&s, "/spaces", opts); err != nil {
+		return fmt.Errorf("Error creating space: %s", err)
+	}
+
+	d.SetId(s.ID)
+	log.Printf("[INFO] Space ID: %s", d.Id())
+
+	// Allocating a space's network takes several minutes, and apps can't
+	// be created in it until that's done.
+	log.Printf("[DEBUG] Waiting for space (%s) to be allocated", d.Id())
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"allocating"},
+		Target:  []string{"allocated"},
+		Refresh: spaceStateRefreshFunc(client, d.Id()),
+		Timeout: 20 * time.Minute,
+		Delay:   10 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for space (%s) to be allocated: %s", d.Id(), err)
+	}
+
+	return resourceHerokuSpaceRead(d, meta)
+}
+
+func resourceHerokuSpaceUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	if d.HasChange("name") {
+		opts := map[string]string{"name": d.Get("name").(string)}
+
+		var s space
+		if err := client.Patch(&s, "/spaces/"+d.Id(), opts); err != nil {
+			return fmt.Errorf("Error updating space: %s", err)
+		}
+	}
+
+	return resourceHerokuSpaceRead(d, meta)
+}
+
+func resourceHerokuSpaceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	var s space
+	if err := client.Get(&s, "/spaces/"+d.Id(), nil); err != nil {
+		if isHerokuNotFound(err) {
+			log.Printf("[WARN] Space %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving space: %s", err)
+	}
+
+	d.Set("name", s.Name)
+	d.Set("organization", s.Organization.Name)
+	d.Set("region", s.Region.Name)
+
+	return nil
+}
+
+func resourceHerokuSpaceDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Service)
+
+	log.Printf("[INFO] Deleting space: %s", d.Id())
+
+	if err := client.Delete("/spaces/" + d.Id()); err != nil {
+		return fmt.Errorf("Error deleting space: %s", err)
+	}
+
+	return nil
+}
+
+// spaceStateRefreshFunc returns a resource.StateRefreshFunc that is used to
+// watch the allocation of a space.
+func spaceStateRefreshFunc(client *heroku.Service, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var s space
+		if err := client.Get(&s, "/spaces/"+id, nil); err != nil {
+			return nil, "", err
+		}
+
+		return &s, s.State, nil
+	}
+}
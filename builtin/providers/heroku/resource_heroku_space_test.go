@@ -0,0 +1,100 @@
+package heroku
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cyberdelia/heroku-go/v3"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccHerokuSpace_Basic(t *testing.T) {
+	var s space
+	spaceName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	org := os.Getenv("HEROKU_ORGANIZATION")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if org == "" {
+				t.Skip("HEROKU_ORGANIZATION is not set; skipping test.")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuSpaceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckHerokuSpaceConfig_basic(spaceName, org),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuSpaceExists("heroku_space.foobar", &s),
+					resource.TestCheckResourceAttr(
+						"heroku_space.foobar", "name", spaceName),
+					resource.TestCheckResourceAttr(
+						"heroku_space.foobar", "organization", org),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuSpaceExists(n string, s *space) resource.TestCheckFunc {
+	return func(st *terraform.State) error {
+		rs, ok := st.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No space ID set")
+		}
+
+		client := testAccProvider.Meta().(*heroku.Service)
+
+		var foundSpace space
+		if err := client.Get(&foundSpace, "/spaces/"+rs.Primary.ID, nil); err != nil {
+			return err
+		}
+
+		if foundSpace.ID != rs.Primary.ID {
+			return fmt.Errorf("Space not found")
+		}
+
+		if foundSpace.State != "allocated" {
+			return fmt.Errorf("Space not allocated: %s", foundSpace.State)
+		}
+
+		*s = foundSpace
+
+		return nil
+	}
+}
+
+func testAccCheckHerokuSpaceDestroy(st *terraform.State) error {
+	client := testAccProvider.Meta().(*heroku.Service)
+
+	for _, rs := range st.RootModule().Resources {
+		if rs.Type != "heroku_space" {
+			continue
+		}
+
+		var s space
+		if err := client.Get(&s, "/spaces/"+rs.Primary.ID, nil); err == nil {
+			return fmt.Errorf("Space still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckHerokuSpaceConfig_basic(spaceName, orgName string) string {
+	return fmt.Sprintf(`
+resource "heroku_space" "foobar" {
+    name = "%s"
+    organization = "%s"
+    region = "virginia"
+}`, spaceName, orgName)
+}
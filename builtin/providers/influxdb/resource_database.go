@@ -1,8 +1,13 @@
 package influxdb
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/influxdata/influxdb/client"
 )
@@ -11,6 +16,7 @@ func ResourceDatabase() *schema.Resource {
 	return &schema.Resource{
 		Create: CreateDatabase,
 		Read:   ReadDatabase,
+		Update: UpdateDatabase,
 		Delete: DeleteDatabase,
 
 		Schema: map[string]*schema.Schema{
@@ -19,6 +25,43 @@ func ResourceDatabase() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+
+			// The server returns the policies in its own order, so they
+			// are a set identified by their name.
+			"retention_policies": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      retentionPolicyHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						// Durations are kept in the form the server reports
+						// them in, so that e.g. "7d" and "1w" don't differ.
+						"duration": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateInfluxDuration,
+							StateFunc:    normalizeInfluxDuration,
+						},
+
+						"replication": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  1,
+						},
+
+						"default": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -42,7 +85,62 @@ func CreateDatabase(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(name)
 
-	return nil
+	for _, raw := range d.Get("retention_policies").(*schema.Set).List() {
+		rp := raw.(map[string]interface{})
+		if err := execRetentionPolicyQuery(conn, "CREATE", name, rp); err != nil {
+			return err
+		}
+	}
+
+	return ReadDatabase(d, meta)
+}
+
+func UpdateDatabase(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+	name := d.Id()
+
+	if d.HasChange("retention_policies") {
+		o, n := d.GetChange("retention_policies")
+
+		oldPolicies := make(map[string]map[string]interface{})
+		for _, raw := range o.(*schema.Set).List() {
+			rp := raw.(map[string]interface{})
+			oldPolicies[rp["name"].(string)] = rp
+		}
+
+		for _, raw := range n.(*schema.Set).List() {
+			rp := raw.(map[string]interface{})
+			rpName := rp["name"].(string)
+
+			oldRp, exists := oldPolicies[rpName]
+			delete(oldPolicies, rpName)
+
+			command := "CREATE"
+			if exists {
+				if oldRp["duration"] == rp["duration"] &&
+					oldRp["replication"] == rp["replication"] &&
+					oldRp["default"] == rp["default"] {
+					continue
+				}
+				command = "ALTER"
+			}
+
+			if err := execRetentionPolicyQuery(conn, command, name, rp); err != nil {
+				return err
+			}
+		}
+
+		// Whatever is left over was removed from the configuration.
+		for rpName := range oldPolicies {
+			queryStr := fmt.Sprintf(
+				"DROP RETENTION POLICY %s ON %s", quoteIdentifier(rpName), quoteIdentifier(name))
+			if err := execQuery(conn, queryStr); err != nil {
+				return err
+			}
+		}
+	}
+
+	return ReadDatabase(d, meta)
 }
 
 func ReadDatabase(d *schema.ResourceData, meta interface{}) error {
@@ -66,7 +164,7 @@ func ReadDatabase(d *schema.ResourceData, meta interface{}) error {
 
 	for _, result := range resp.Results[0].Series[0].Values {
 		if result[0] == name {
-			return nil
+			return readRetentionPolicies(d, conn)
 		}
 	}
 
@@ -76,6 +174,174 @@ func ReadDatabase(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+func readRetentionPolicies(d *schema.ResourceData, conn *client.Client) error {
+	query := client.Query{
+		Command: fmt.Sprintf("SHOW RETENTION POLICIES ON %s", quoteIdentifier(d.Id())),
+	}
+
+	resp, err := conn.Query(query)
+	if err != nil {
+		return err
+	}
+	if resp.Err != nil {
+		return resp.Err
+	}
+
+	policies := make([]map[string]interface{}, 0)
+	for _, series := range resp.Results[0].Series {
+		// The columns are name, duration, shardGroupDuration, replicaN and
+		// default.
+		for _, result := range series.Values {
+			// Every database gets an "autogen" policy when it is created,
+			// which isn't managed by Terraform.
+			if result[0] == "autogen" {
+				continue
+			}
+
+			replication, err := result[3].(json.Number).Int64()
+			if err != nil {
+				return fmt.Errorf("invalid replication for retention policy %s: %s", result[0], err)
+			}
+
+			policies = append(policies, map[string]interface{}{
+				"name":        result[0],
+				"duration":    normalizeInfluxDuration(result[1]),
+				"replication": int(replication),
+				"default":     result[4],
+			})
+		}
+	}
+
+	d.Set("retention_policies", policies)
+
+	return nil
+}
+
+// retentionPolicyHash identifies a retention policy by its name, so that
+// changing its settings alters the policy instead of replacing it.
+func retentionPolicyHash(v interface{}) int {
+	return hashcode.String(v.(map[string]interface{})["name"].(string))
+}
+
+// execRetentionPolicyQuery creates or alters (depending on command) a
+// retention policy on the given database.
+func execRetentionPolicyQuery(conn *client.Client, command, database string, rp map[string]interface{}) error {
+	duration, err := parseInfluxDuration(rp["duration"].(string))
+	if err != nil {
+		return err
+	}
+
+	queryStr := fmt.Sprintf(
+		"%s RETENTION POLICY %s ON %s DURATION %s REPLICATION %d",
+		command,
+		quoteIdentifier(rp["name"].(string)),
+		quoteIdentifier(database),
+		formatInfluxDuration(duration),
+		rp["replication"].(int),
+	)
+	if rp["default"].(bool) {
+		queryStr += " DEFAULT"
+	}
+
+	return execQuery(conn, queryStr)
+}
+
+func execQuery(conn *client.Client, queryStr string) error {
+	resp, err := conn.Query(client.Query{Command: queryStr})
+	if err != nil {
+		return err
+	}
+	return resp.Err
+}
+
+var influxDurationRegexp = regexp.MustCompile(`(\d+)(ns|ms|u|µ|s|m|h|d|w)`)
+
+var influxDurationUnits = []struct {
+	Suffix string
+	Unit   time.Duration
+}{
+	{"w", 7 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"u", time.Microsecond},
+	{"µ", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+// parseInfluxDuration parses a duration as written in InfluxQL, such as
+// "1w" or "1h30m", or as reported by the server, such as "168h0m0s". The
+// infinite duration, "INF", is returned as zero just like the server does.
+func parseInfluxDuration(s string) (time.Duration, error) {
+	if s == "INF" || s == "inf" || s == "0" {
+		return 0, nil
+	}
+
+	matches := influxDurationRegexp.FindAllStringSubmatchIndex(s, -1)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var d time.Duration
+	end := 0
+	for _, m := range matches {
+		// The parts must follow each other without anything in between.
+		if m[0] != end {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		end = m[1]
+
+		n, err := strconv.ParseInt(s[m[2]:m[3]], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %s", s, err)
+		}
+
+		suffix := s[m[4]:m[5]]
+		for _, u := range influxDurationUnits {
+			if u.Suffix == suffix {
+				d += time.Duration(n) * u.Unit
+				break
+			}
+		}
+	}
+	if end != len(s) {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	return d, nil
+}
+
+// formatInfluxDuration returns d as an InfluxQL duration literal in a
+// single unit, which all server versions understand.
+func formatInfluxDuration(d time.Duration) string {
+	if d == 0 {
+		return "INF"
+	}
+	for _, u := range influxDurationUnits {
+		if d%u.Unit == 0 {
+			return fmt.Sprintf("%d%s", d/u.Unit, u.Suffix)
+		}
+	}
+	return fmt.Sprintf("%dns", d)
+}
+
+func normalizeInfluxDuration(v interface{}) string {
+	d, err := parseInfluxDuration(v.(string))
+	if err != nil {
+		return v.(string)
+	}
+	return d.String()
+}
+
+func validateInfluxDuration(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := parseInfluxDuration(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q: %s", k, err))
+	}
+	return
+}
+
 func DeleteDatabase(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*client.Client)
 	name := d.Id()
@@ -1,8 +1,11 @@
 package influxdb
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
@@ -22,6 +25,102 @@ func TestAccDatabase(t *testing.T) {
 	})
 }
 
+func TestAccDatabase_retentionPolicies(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDatabaseRetentionPoliciesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"influxdb_database.test", "retention_policies.#", "2",
+					),
+					resource.TestCheckResourceAttr(
+						"influxdb_database.test", testAccRetentionPolicyAttr("recent", "duration"), "168h0m0s",
+					),
+					resource.TestCheckResourceAttr(
+						"influxdb_database.test", testAccRetentionPolicyAttr("forever", "duration"), "0s",
+					),
+				),
+			},
+			resource.TestStep{
+				Config: testAccDatabaseRetentionPoliciesConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"influxdb_database.test", "retention_policies.#", "1",
+					),
+					resource.TestCheckResourceAttr(
+						"influxdb_database.test", testAccRetentionPolicyAttr("recent", "duration"), "24h0m0s",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestRetentionPolicyHash(t *testing.T) {
+	a := map[string]interface{}{"name": "recent", "duration": "7d"}
+	b := map[string]interface{}{"name": "recent", "duration": "1d"}
+	c := map[string]interface{}{"name": "forever", "duration": "7d"}
+
+	if retentionPolicyHash(a) != retentionPolicyHash(b) {
+		t.Fatal("policies with the same name should have the same hash")
+	}
+	if retentionPolicyHash(a) == retentionPolicyHash(c) {
+		t.Fatal("policies with different names should have different hashes")
+	}
+}
+
+func TestParseInfluxDuration(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected time.Duration
+		Err      bool
+	}{
+		{"INF", 0, false},
+		{"0s", 0, false},
+		{"1w", 7 * 24 * time.Hour, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"168h0m0s", 7 * 24 * time.Hour, false},
+		{"500ms", 500 * time.Millisecond, false},
+		{"", 0, true},
+		{"1y", 0, true},
+		{"1h 30m", 0, true},
+		{"h", 0, true},
+	}
+
+	for _, tc := range cases {
+		d, err := parseInfluxDuration(tc.Input)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%q: expected error %t, got %v", tc.Input, tc.Err, err)
+		}
+		if d != tc.Expected {
+			t.Fatalf("%q: expected %s, got %s", tc.Input, tc.Expected, d)
+		}
+	}
+}
+
+func TestFormatInfluxDuration(t *testing.T) {
+	cases := map[time.Duration]string{
+		0:                       "INF",
+		7 * 24 * time.Hour:      "1w",
+		48 * time.Hour:          "2d",
+		90 * time.Minute:        "90m",
+		1500 * time.Millisecond: "1500ms",
+	}
+
+	for d, expected := range cases {
+		if actual := formatInfluxDuration(d); actual != expected {
+			t.Fatalf("%s: expected %q, got %q", d, expected, actual)
+		}
+	}
+}
+
+func testAccRetentionPolicyAttr(name, attr string) string {
+	return fmt.Sprintf("retention_policies.%d.%s", hashcode.String(name), attr)
+}
+
 var testAccDatabaseConfig = `
 
 resource "influxdb_database" "test" {
@@ -29,3 +128,35 @@ resource "influxdb_database" "test" {
 }
 
 `
+
+var testAccDatabaseRetentionPoliciesConfig = `
+
+resource "influxdb_database" "test" {
+    name = "terraform-test"
+    retention_policies {
+        name = "recent"
+        duration = "7d"
+        replication = 1
+        default = true
+    }
+    retention_policies {
+        name = "forever"
+        duration = "INF"
+    }
+}
+
+`
+
+var testAccDatabaseRetentionPoliciesConfig_update = `
+
+resource "influxdb_database" "test" {
+    name = "terraform-test"
+    retention_policies {
+        name = "recent"
+        duration = "1d"
+        replication = 1
+        default = true
+    }
+}
+
+`
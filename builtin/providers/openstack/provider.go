@@ -98,6 +98,7 @@ func Provider() terraform.ResourceProvider {
 			"openstack_compute_secgroup_v2":            resourceComputeSecGroupV2(),
 			"openstack_compute_servergroup_v2":         resourceComputeServerGroupV2(),
 			"openstack_compute_floatingip_v2":          resourceComputeFloatingIPV2(),
+			"openstack_compute_volume_attach_v2":       resourceComputeVolumeAttachV2(),
 			"openstack_fw_firewall_v1":                 resourceFWFirewallV1(),
 			"openstack_fw_policy_v1":                   resourceFWPolicyV1(),
 			"openstack_fw_rule_v1":                     resourceFWRuleV1(),
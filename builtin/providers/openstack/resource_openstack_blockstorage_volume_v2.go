@@ -86,6 +86,10 @@ func resourceBlockStorageVolumeV2() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"multiattach": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 			"attachment": &schema.Schema{
 				Type:     schema.TypeSet,
 				Computed: true,
@@ -188,6 +192,7 @@ func resourceBlockStorageVolumeV2Read(d *schema.ResourceData, meta interface{})
 	d.Set("source_vol_id", v.SourceVolID)
 	d.Set("volume_type", v.VolumeType)
 	d.Set("metadata", v.Metadata)
+	d.Set("multiattach", v.Multiattach)
 
 	if len(v.Attachments) > 0 {
 		attachments := make([]map[string]interface{}, len(v.Attachments))
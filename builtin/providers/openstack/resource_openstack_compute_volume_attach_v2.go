@@ -0,0 +1,220 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/blockstorage/v2/volumes"
+	"github.com/rackspace/gophercloud/openstack/compute/v2/extensions/volumeattach"
+)
+
+func resourceComputeVolumeAttachV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceComputeVolumeAttachV2Create,
+		Read:   resourceComputeVolumeAttachV2Read,
+		Delete: resourceComputeVolumeAttachV2Delete,
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_REGION_NAME", ""),
+			},
+			"instance_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"volume_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"device": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceComputeVolumeAttachV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	computeClient, err := config.computeV2Client(d.Get("region").(string))
+	if err != nil {
+		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+	}
+	blockStorageClient, err := config.blockStorageV2Client(d.Get("region").(string))
+	if err != nil {
+		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+	}
+
+	instanceId := d.Get("instance_id").(string)
+	volumeId := d.Get("volume_id").(string)
+
+	// A volume can only be attached to several instances at once if it was
+	// created as multiattach capable. Catch this before Nova does, as its
+	// error doesn't say why the attachment was refused.
+	v, err := volumes.Get(blockStorageClient, volumeId).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving OpenStack volume %s: %s", volumeId, err)
+	}
+	if len(v.Attachments) > 0 && !v.Multiattach {
+		return fmt.Errorf(
+			"Volume %s is already attached and does not support multiattach", volumeId)
+	}
+
+	attachOpts := &volumeattach.CreateOpts{
+		Device:   d.Get("device").(string),
+		VolumeID: volumeId,
+	}
+
+	log.Printf("[DEBUG] Attachment Options: %#v", attachOpts)
+	attachment, err := volumeattach.Create(computeClient, instanceId, attachOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error attaching OpenStack volume: %s", err)
+	}
+
+	// The ID is a combination of the instance and attachment IDs, as both
+	// are needed to look the attachment up again. It is set before waiting
+	// so that an attachment that never completes is still recorded in the
+	// state and detached again on the next apply.
+	d.SetId(fmt.Sprintf("%s/%s", instanceId, attachment.ID))
+
+	log.Printf("[DEBUG] Waiting for volume (%s) to attach to instance (%s)", volumeId, instanceId)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"DETACHED", "ATTACHING"},
+		Target:     []string{"ATTACHED"},
+		Refresh:    resourceComputeVolumeAttachV2AttachFunc(blockStorageClient, volumeId, instanceId),
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf(
+			"Error waiting for volume (%s) to attach to instance (%s): %s",
+			volumeId, instanceId, err)
+	}
+
+	return resourceComputeVolumeAttachV2Read(d, meta)
+}
+
+func resourceComputeVolumeAttachV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	computeClient, err := config.computeV2Client(d.Get("region").(string))
+	if err != nil {
+		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+	}
+
+	instanceId, attachmentId, err := parseComputeVolumeAttachmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	attachment, err := volumeattach.Get(computeClient, instanceId, attachmentId).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "compute_volume_attach")
+	}
+
+	log.Printf("[DEBUG] Retrieved volume attachment %s: %#v", d.Id(), attachment)
+
+	d.Set("instance_id", attachment.ServerID)
+	d.Set("volume_id", attachment.VolumeID)
+	d.Set("device", attachment.Device)
+
+	return nil
+}
+
+func resourceComputeVolumeAttachV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	computeClient, err := config.computeV2Client(d.Get("region").(string))
+	if err != nil {
+		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+	}
+	blockStorageClient, err := config.blockStorageV2Client(d.Get("region").(string))
+	if err != nil {
+		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+	}
+
+	instanceId, attachmentId, err := parseComputeVolumeAttachmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := volumeattach.Delete(computeClient, instanceId, attachmentId).ExtractErr(); err != nil {
+		return CheckDeleted(d, err, "compute_volume_attach")
+	}
+
+	volumeId := d.Get("volume_id").(string)
+	log.Printf("[DEBUG] Waiting for volume (%s) to detach from instance (%s)", volumeId, instanceId)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ATTACHED", "ATTACHING"},
+		Target:     []string{"DETACHED"},
+		Refresh:    resourceComputeVolumeAttachV2AttachFunc(blockStorageClient, volumeId, instanceId),
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf(
+			"Error waiting for volume (%s) to detach from instance (%s): %s",
+			volumeId, instanceId, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceComputeVolumeAttachV2AttachFunc returns a resource.StateRefreshFunc
+// that reports whether a volume is attached to the given instance. The
+// volume's own status can't be used for this, as a multiattach volume stays
+// "in-use" while it is attached to another instance.
+func resourceComputeVolumeAttachV2AttachFunc(
+	client *gophercloud.ServiceClient, volumeId, instanceId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		v, err := volumes.Get(client, volumeId).Extract()
+		if err != nil {
+			errCode, ok := err.(*gophercloud.UnexpectedResponseCodeError)
+			if ok && errCode.Actual == 404 {
+				return v, "DETACHED", nil
+			}
+			return nil, "", err
+		}
+
+		for _, attachment := range v.Attachments {
+			if serverId, ok := attachment["server_id"].(string); ok && serverId == instanceId {
+				return v, "ATTACHED", nil
+			}
+		}
+
+		switch v.Status {
+		case "error", "error_attaching":
+			return nil, "", fmt.Errorf("Volume %s is in status %s", volumeId, v.Status)
+		case "attaching":
+			return v, "ATTACHING", nil
+		}
+		return v, "DETACHED", nil
+	}
+}
+
+// parseComputeVolumeAttachmentId takes an ID of the form
+// INSTANCE_ID/ATTACHMENT_ID and returns the two parts.
+func parseComputeVolumeAttachmentId(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid volume attachment ID %q, expected INSTANCE_ID/ATTACHMENT_ID", id)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,325 @@
+package rabbitmq
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// Client talks to the RabbitMQ HTTP management API.
+type Client struct {
+	// Endpoint of the management plugin, e.g. http://localhost:15672
+	Endpoint string
+	Username string
+	Password string
+	Http     *http.Client
+}
+
+// NewClient returns a new RabbitMQ management API client
+func NewClient(endpoint, username, password string, insecure bool) (*Client, error) {
+	if _, err := url.Parse(endpoint); err != nil {
+		return nil, fmt.Errorf("Invalid endpoint %q: %s", endpoint, err)
+	}
+
+	httpClient := cleanhttp.DefaultClient()
+	if insecure {
+		transport := cleanhttp.DefaultTransport()
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		httpClient.Transport = transport
+	}
+
+	client := Client{
+		Endpoint: strings.TrimRight(endpoint, "/"),
+		Username: username,
+		Password: password,
+		Http:     httpClient,
+	}
+	return &client, nil
+}
+
+// APIError is returned for responses with an unexpected status code.
+type APIError struct {
+	StatusCode int
+	Reason     string
+}
+
+func (e *APIError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("RabbitMQ API error %d: %s", e.StatusCode, e.Reason)
+	}
+	return fmt.Sprintf("RabbitMQ API error %d", e.StatusCode)
+}
+
+// isNotFound returns true if err is a 404 response from the API.
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+type VHost struct {
+	Name    string `json:"name"`
+	Tracing bool   `json:"tracing"`
+}
+
+type User struct {
+	Name     string `json:"name"`
+	Password string `json:"password,omitempty"`
+	// Tags is a comma separated list, e.g. "administrator,management".
+	Tags string `json:"tags"`
+}
+
+type Permissions struct {
+	User      string `json:"user,omitempty"`
+	VHost     string `json:"vhost,omitempty"`
+	Configure string `json:"configure"`
+	Write     string `json:"write"`
+	Read      string `json:"read"`
+}
+
+type Exchange struct {
+	Name       string                 `json:"name,omitempty"`
+	VHost      string                 `json:"vhost,omitempty"`
+	Type       string                 `json:"type"`
+	Durable    bool                   `json:"durable"`
+	AutoDelete bool                   `json:"auto_delete"`
+	Internal   bool                   `json:"internal"`
+	Arguments  map[string]interface{} `json:"arguments"`
+}
+
+type Queue struct {
+	Name       string                 `json:"name,omitempty"`
+	VHost      string                 `json:"vhost,omitempty"`
+	Durable    bool                   `json:"durable"`
+	AutoDelete bool                   `json:"auto_delete"`
+	Arguments  map[string]interface{} `json:"arguments"`
+}
+
+type Binding struct {
+	Source          string                 `json:"source,omitempty"`
+	VHost           string                 `json:"vhost,omitempty"`
+	Destination     string                 `json:"destination,omitempty"`
+	DestinationType string                 `json:"destination_type,omitempty"`
+	RoutingKey      string                 `json:"routing_key"`
+	Arguments       map[string]interface{} `json:"arguments"`
+	PropertiesKey   string                 `json:"properties_key,omitempty"`
+}
+
+type errorResponse struct {
+	Error  string `json:"error"`
+	Reason string `json:"reason"`
+}
+
+// escapePath escapes every segment for use in an API path. Names, and the
+// default vhost "/" in particular, may contain slashes.
+func escapePath(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, s := range segments {
+		escaped[i] = strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+	}
+	return strings.Join(escaped, "/")
+}
+
+// do sends a request to the API, decoding the response into out if it is
+// not nil, and returns the response for its headers.
+func (c *Client) do(method, path string, in, out interface{}) (*http.Response, error) {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, c.Endpoint+"/api/"+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("Error during creation of request: %s", err)
+	}
+
+	req.SetBasicAuth(c.Username, c.Password)
+	req.Header.Add("Accept", "application/json")
+	if in != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	resp, err := c.Http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		errorResp := new(errorResponse)
+		json.NewDecoder(resp.Body).Decode(errorResp)
+		return resp, &APIError{StatusCode: resp.StatusCode, Reason: errorResp.Reason}
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp, fmt.Errorf("Error decoding response: %s", err)
+		}
+	}
+
+	return resp, nil
+}
+
+func (c *Client) PutVHost(name string) error {
+	_, err := c.do("PUT", "vhosts/"+escapePath(name), map[string]interface{}{}, nil)
+	return err
+}
+
+func (c *Client) GetVHost(name string) (*VHost, error) {
+	vhost := new(VHost)
+	if _, err := c.do("GET", "vhosts/"+escapePath(name), nil, vhost); err != nil {
+		return nil, err
+	}
+	return vhost, nil
+}
+
+func (c *Client) DeleteVHost(name string) error {
+	_, err := c.do("DELETE", "vhosts/"+escapePath(name), nil, nil)
+	return err
+}
+
+func (c *Client) PutUser(user *User) error {
+	_, err := c.do("PUT", "users/"+escapePath(user.Name), user, nil)
+	return err
+}
+
+func (c *Client) GetUser(name string) (*User, error) {
+	user := new(User)
+	if _, err := c.do("GET", "users/"+escapePath(name), nil, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (c *Client) DeleteUser(name string) error {
+	_, err := c.do("DELETE", "users/"+escapePath(name), nil, nil)
+	return err
+}
+
+func (c *Client) PutPermissions(vhost, user string, perms *Permissions) error {
+	_, err := c.do("PUT", "permissions/"+escapePath(vhost, user), perms, nil)
+	return err
+}
+
+func (c *Client) GetPermissions(vhost, user string) (*Permissions, error) {
+	perms := new(Permissions)
+	if _, err := c.do("GET", "permissions/"+escapePath(vhost, user), nil, perms); err != nil {
+		return nil, err
+	}
+	return perms, nil
+}
+
+func (c *Client) DeletePermissions(vhost, user string) error {
+	_, err := c.do("DELETE", "permissions/"+escapePath(vhost, user), nil, nil)
+	return err
+}
+
+func (c *Client) PutExchange(vhost, name string, exchange *Exchange) error {
+	_, err := c.do("PUT", "exchanges/"+escapePath(vhost, name), exchange, nil)
+	return err
+}
+
+func (c *Client) GetExchange(vhost, name string) (*Exchange, error) {
+	exchange := new(Exchange)
+	if _, err := c.do("GET", "exchanges/"+escapePath(vhost, name), nil, exchange); err != nil {
+		return nil, err
+	}
+	return exchange, nil
+}
+
+func (c *Client) DeleteExchange(vhost, name string) error {
+	_, err := c.do("DELETE", "exchanges/"+escapePath(vhost, name), nil, nil)
+	return err
+}
+
+func (c *Client) PutQueue(vhost, name string, queue *Queue) error {
+	_, err := c.do("PUT", "queues/"+escapePath(vhost, name), queue, nil)
+	return err
+}
+
+func (c *Client) GetQueue(vhost, name string) (*Queue, error) {
+	queue := new(Queue)
+	if _, err := c.do("GET", "queues/"+escapePath(vhost, name), nil, queue); err != nil {
+		return nil, err
+	}
+	return queue, nil
+}
+
+func (c *Client) DeleteQueue(vhost, name string) error {
+	_, err := c.do("DELETE", "queues/"+escapePath(vhost, name), nil, nil)
+	return err
+}
+
+// bindingPath returns the path of the bindings between a source exchange
+// and a destination queue ("q") or exchange ("e").
+func bindingPath(vhost, source, destination, destinationType string) (string, error) {
+	var t string
+	switch destinationType {
+	case "queue":
+		t = "q"
+	case "exchange":
+		t = "e"
+	default:
+		return "", fmt.Errorf("Invalid binding destination type %q", destinationType)
+	}
+	return "bindings/" + escapePath(vhost) + "/e/" + escapePath(source) + "/" + t + "/" + escapePath(destination), nil
+}
+
+// PostBinding creates a binding and returns its properties key, which
+// identifies it among the bindings between the same source and destination.
+func (c *Client) PostBinding(binding *Binding) (string, error) {
+	path, err := bindingPath(binding.VHost, binding.Source, binding.Destination, binding.DestinationType)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.do("POST", path, binding, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// The Location header points at the new binding, ending in its
+	// properties key.
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", fmt.Errorf("No location returned for the new binding")
+	}
+	propertiesKey, err := url.QueryUnescape(location[strings.LastIndex(location, "/")+1:])
+	if err != nil {
+		return "", fmt.Errorf("Invalid location %q returned for the new binding: %s", location, err)
+	}
+	return propertiesKey, nil
+}
+
+func (c *Client) ListBindings(vhost, source, destination, destinationType string) ([]Binding, error) {
+	path, err := bindingPath(vhost, source, destination, destinationType)
+	if err != nil {
+		return nil, err
+	}
+
+	var bindings []Binding
+	if _, err := c.do("GET", path, nil, &bindings); err != nil {
+		return nil, err
+	}
+	return bindings, nil
+}
+
+func (c *Client) DeleteBinding(binding *Binding) error {
+	path, err := bindingPath(binding.VHost, binding.Source, binding.Destination, binding.DestinationType)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do("DELETE", path+"/"+escapePath(binding.PropertiesKey), nil, nil)
+	return err
+}
@@ -0,0 +1,26 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"log"
+)
+
+type Config struct {
+	Endpoint string
+	Username string
+	Password string
+	Insecure bool
+}
+
+// Client returns a new client for accessing the RabbitMQ management API
+func (c *Config) Client() (*Client, error) {
+	client, err := NewClient(c.Endpoint, c.Username, c.Password, c.Insecure)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error setting up RabbitMQ client: %s", err)
+	}
+
+	log.Printf("[INFO] RabbitMQ Client configured for server %s", c.Endpoint)
+
+	return client, nil
+}
@@ -0,0 +1,60 @@
+package rabbitmq
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"endpoint": {
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("RABBITMQ_ENDPOINT", nil),
+				Description: "Location of the RabbitMQ management API",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("RABBITMQ_USERNAME", nil),
+				Description: "Username for the management API",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("RABBITMQ_PASSWORD", nil),
+				Description: "Password for the management API",
+			},
+			"insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("RABBITMQ_INSECURE", false),
+				Description: "Skip verification of the endpoint's TLS certificate",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"rabbitmq_binding":     resourceRabbitMQBinding(),
+			"rabbitmq_exchange":    resourceRabbitMQExchange(),
+			"rabbitmq_permissions": resourceRabbitMQPermissions(),
+			"rabbitmq_queue":       resourceRabbitMQQueue(),
+			"rabbitmq_user":        resourceRabbitMQUser(),
+			"rabbitmq_vhost":       resourceRabbitMQVHost(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(data *schema.ResourceData) (interface{}, error) {
+	config := Config{
+		Endpoint: data.Get("endpoint").(string),
+		Username: data.Get("username").(string),
+		Password: data.Get("password").(string),
+		Insecure: data.Get("insecure").(bool),
+	}
+
+	return config.Client()
+}
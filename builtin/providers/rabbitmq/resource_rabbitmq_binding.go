@@ -0,0 +1,185 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceRabbitMQBinding() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRabbitMQBindingCreate,
+		Read:   resourceRabbitMQBindingRead,
+		Delete: resourceRabbitMQBindingDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"source": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vhost": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+				ForceNew: true,
+			},
+
+			"destination": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"destination_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRabbitMQBindingDestinationType,
+			},
+
+			"routing_key": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"arguments": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"properties_key": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceRabbitMQBindingCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	binding := &Binding{
+		Source:          d.Get("source").(string),
+		VHost:           d.Get("vhost").(string),
+		Destination:     d.Get("destination").(string),
+		DestinationType: d.Get("destination_type").(string),
+		RoutingKey:      d.Get("routing_key").(string),
+		Arguments:       d.Get("arguments").(map[string]interface{}),
+	}
+
+	log.Printf("[DEBUG] Creating RabbitMQ binding: %#v", binding)
+	propertiesKey, err := client.PostBinding(binding)
+	if err != nil {
+		return fmt.Errorf("Error creating RabbitMQ binding from %q to %s %q: %s",
+			binding.Source, binding.DestinationType, binding.Destination, err)
+	}
+	binding.PropertiesKey = propertiesKey
+
+	d.SetId(rabbitMQBindingId(binding))
+
+	return resourceRabbitMQBindingRead(d, meta)
+}
+
+func resourceRabbitMQBindingRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	id, err := parseRabbitMQBindingId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	bindings, err := client.ListBindings(id.VHost, id.Source, id.Destination, id.DestinationType)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Error reading RabbitMQ binding %q: %s", d.Id(), err)
+	}
+
+	var binding *Binding
+	for i := range bindings {
+		if bindings[i].PropertiesKey == id.PropertiesKey {
+			binding = &bindings[i]
+			break
+		}
+	}
+	if binding == nil {
+		log.Printf("[WARN] RabbitMQ binding %q not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("source", id.Source)
+	d.Set("vhost", id.VHost)
+	d.Set("destination", id.Destination)
+	d.Set("destination_type", id.DestinationType)
+	d.Set("routing_key", binding.RoutingKey)
+	d.Set("arguments", flattenRabbitMQArguments(binding.Arguments))
+	d.Set("properties_key", binding.PropertiesKey)
+
+	return nil
+}
+
+func resourceRabbitMQBindingDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	id, err := parseRabbitMQBindingId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Deleting RabbitMQ binding %q", d.Id())
+	if err := client.DeleteBinding(id); err != nil && !isNotFound(err) {
+		return fmt.Errorf("Error deleting RabbitMQ binding %q: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// rabbitMQBindingId returns an ID of the form
+// VHOST/SOURCE/DESTINATION/DESTINATION_TYPE/PROPERTIES_KEY, with every part
+// escaped as the vhost in particular usually contains a slash.
+func rabbitMQBindingId(b *Binding) string {
+	return escapePath(b.VHost, b.Source, b.Destination, b.DestinationType, b.PropertiesKey)
+}
+
+func parseRabbitMQBindingId(id string) (*Binding, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 5 {
+		return nil, fmt.Errorf(
+			"Invalid binding ID %q, expected VHOST/SOURCE/DESTINATION/DESTINATION_TYPE/PROPERTIES_KEY", id)
+	}
+
+	for i, p := range parts {
+		unescaped, err := url.QueryUnescape(p)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid binding ID %q: %s", id, err)
+		}
+		parts[i] = unescaped
+	}
+
+	return &Binding{
+		VHost:           parts[0],
+		Source:          parts[1],
+		Destination:     parts[2],
+		DestinationType: parts[3],
+		PropertiesKey:   parts[4],
+	}, nil
+}
+
+func validateRabbitMQBindingDestinationType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != "queue" && value != "exchange" {
+		errors = append(errors, fmt.Errorf(
+			"%q must be \"queue\" or \"exchange\", got %q", k, value))
+	}
+	return
+}
@@ -0,0 +1,148 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccRabbitMQBinding_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRabbitMQBindingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRabbitMQBindingConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRabbitMQBindingExists("rabbitmq_binding.test"),
+					resource.TestCheckResourceAttr(
+						"rabbitmq_binding.test", "properties_key", "tf-key"),
+				),
+			},
+		},
+	})
+}
+
+func TestRabbitMQBindingId(t *testing.T) {
+	binding := &Binding{
+		VHost:           "/",
+		Source:          "test exchange",
+		Destination:     "test/queue",
+		DestinationType: "queue",
+		PropertiesKey:   "~",
+	}
+
+	id := rabbitMQBindingId(binding)
+	if id != "%2F/test%20exchange/test%2Fqueue/queue/~" {
+		t.Fatalf("bad ID: %s", id)
+	}
+
+	parsed, err := parseRabbitMQBindingId(id)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(parsed, binding) {
+		t.Fatalf("expected %#v, got %#v", binding, parsed)
+	}
+
+	if _, err := parseRabbitMQBindingId("%2F/test/queue"); err == nil {
+		t.Fatal("expected error for ID with missing parts")
+	}
+}
+
+func testAccCheckRabbitMQBindingDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "rabbitmq_binding" {
+			continue
+		}
+
+		id, err := parseRabbitMQBindingId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		bindings, err := client.ListBindings(id.VHost, id.Source, id.Destination, id.DestinationType)
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		for _, b := range bindings {
+			if b.PropertiesKey == id.PropertiesKey {
+				return fmt.Errorf("Binding still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRabbitMQBindingExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Binding ID is set")
+		}
+
+		id, err := parseRabbitMQBindingId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		bindings, err := client.ListBindings(id.VHost, id.Source, id.Destination, id.DestinationType)
+		if err != nil {
+			return err
+		}
+
+		for _, b := range bindings {
+			if b.PropertiesKey == id.PropertiesKey {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Binding not found: %s", rs.Primary.ID)
+	}
+}
+
+const testAccRabbitMQBindingConfig_basic = `
+resource "rabbitmq_vhost" "test" {
+    name = "tf-test"
+}
+
+resource "rabbitmq_exchange" "test" {
+    name = "tf-test"
+    vhost = "${rabbitmq_vhost.test.name}"
+    settings {
+        type = "direct"
+    }
+}
+
+resource "rabbitmq_queue" "test" {
+    name = "tf-test"
+    vhost = "${rabbitmq_vhost.test.name}"
+    settings {
+        durable = true
+    }
+}
+
+resource "rabbitmq_binding" "test" {
+    source = "${rabbitmq_exchange.test.name}"
+    vhost = "${rabbitmq_vhost.test.name}"
+    destination = "${rabbitmq_queue.test.name}"
+    destination_type = "queue"
+    routing_key = "tf-key"
+}
+`
@@ -0,0 +1,163 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceRabbitMQExchange() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRabbitMQExchangeCreate,
+		Read:   resourceRabbitMQExchangeRead,
+		Delete: resourceRabbitMQExchangeDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vhost": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+				ForceNew: true,
+			},
+
+			// Exchanges can't be changed once declared, so every setting
+			// forces a new one.
+			"settings": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+
+						"durable": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+							ForceNew: true,
+						},
+
+						"auto_delete": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+							ForceNew: true,
+						},
+
+						"internal": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+							ForceNew: true,
+						},
+
+						"arguments": &schema.Schema{
+							Type:     schema.TypeMap,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceRabbitMQExchangeCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	name := d.Get("name").(string)
+	vhost := d.Get("vhost").(string)
+
+	settings := d.Get("settings").([]interface{})[0].(map[string]interface{})
+	exchange := &Exchange{
+		Type:       settings["type"].(string),
+		Durable:    settings["durable"].(bool),
+		AutoDelete: settings["auto_delete"].(bool),
+		Internal:   settings["internal"].(bool),
+		Arguments:  settings["arguments"].(map[string]interface{}),
+	}
+
+	log.Printf("[DEBUG] Declaring RabbitMQ exchange %q in vhost %q: %#v", name, vhost, exchange)
+	if err := client.PutExchange(vhost, name, exchange); err != nil {
+		return fmt.Errorf("Error declaring RabbitMQ exchange %q in vhost %q: %s", name, vhost, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s", name, vhost))
+
+	return resourceRabbitMQExchangeRead(d, meta)
+}
+
+func resourceRabbitMQExchangeRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	name, vhost, err := parseRabbitMQResourceId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	exchange, err := client.GetExchange(vhost, name)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] RabbitMQ exchange %q not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading RabbitMQ exchange %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", name)
+	d.Set("vhost", vhost)
+	d.Set("settings", []map[string]interface{}{
+		{
+			"type":        exchange.Type,
+			"durable":     exchange.Durable,
+			"auto_delete": exchange.AutoDelete,
+			"internal":    exchange.Internal,
+			"arguments":   flattenRabbitMQArguments(exchange.Arguments),
+		},
+	})
+
+	return nil
+}
+
+func resourceRabbitMQExchangeDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	name, vhost, err := parseRabbitMQResourceId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Deleting RabbitMQ exchange %q", d.Id())
+	if err := client.DeleteExchange(vhost, name); err != nil && !isNotFound(err) {
+		return fmt.Errorf("Error deleting RabbitMQ exchange %q: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// flattenRabbitMQArguments converts the arguments returned by the API,
+// which may be numbers or booleans, to the strings kept in the state.
+func flattenRabbitMQArguments(args map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		result[k] = fmt.Sprintf("%v", v)
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccRabbitMQExchange_basic(t *testing.T) {
+	var exchange Exchange
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRabbitMQExchangeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRabbitMQExchangeConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRabbitMQExchangeExists("rabbitmq_exchange.test", &exchange),
+					testAccCheckRabbitMQExchangeAttributes(&exchange),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckRabbitMQExchangeDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "rabbitmq_exchange" {
+			continue
+		}
+
+		name, vhost, err := parseRabbitMQResourceId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.GetExchange(vhost, name)
+		if err == nil {
+			return fmt.Errorf("Exchange still exists: %s", rs.Primary.ID)
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRabbitMQExchangeExists(n string, exchange *Exchange) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Exchange ID is set")
+		}
+
+		name, vhost, err := parseRabbitMQResourceId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.GetExchange(vhost, name)
+		if err != nil {
+			return err
+		}
+
+		*exchange = *found
+		return nil
+	}
+}
+
+func testAccCheckRabbitMQExchangeAttributes(exchange *Exchange) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if exchange.Type != "fanout" {
+			return fmt.Errorf("Bad type: %s", exchange.Type)
+		}
+
+		if !exchange.Durable {
+			return fmt.Errorf("Exchange is not durable")
+		}
+
+		if exchange.AutoDelete {
+			return fmt.Errorf("Exchange is auto-delete")
+		}
+
+		return nil
+	}
+}
+
+const testAccRabbitMQExchangeConfig_basic = `
+resource "rabbitmq_vhost" "test" {
+    name = "tf-test"
+}
+
+resource "rabbitmq_exchange" "test" {
+    name = "tf-test"
+    vhost = "${rabbitmq_vhost.test.name}"
+    settings {
+        type = "fanout"
+        durable = true
+        auto_delete = false
+    }
+}
+`
@@ -0,0 +1,154 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceRabbitMQPermissions() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRabbitMQPermissionsCreate,
+		Update: resourceRabbitMQPermissionsUpdate,
+		Read:   resourceRabbitMQPermissionsRead,
+		Delete: resourceRabbitMQPermissionsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vhost": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+				ForceNew: true,
+			},
+
+			"permissions": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"configure": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"write": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"read": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceRabbitMQPermissionsCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	user := d.Get("user").(string)
+	vhost := d.Get("vhost").(string)
+
+	log.Printf("[DEBUG] Granting RabbitMQ user %q permissions on vhost %q", user, vhost)
+	if err := client.PutPermissions(vhost, user, rabbitMQPermissionsFromResourceData(d)); err != nil {
+		return fmt.Errorf("Error granting RabbitMQ user %q permissions on vhost %q: %s", user, vhost, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s", user, vhost))
+
+	return resourceRabbitMQPermissionsRead(d, meta)
+}
+
+func resourceRabbitMQPermissionsUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	user := d.Get("user").(string)
+	vhost := d.Get("vhost").(string)
+
+	if err := client.PutPermissions(vhost, user, rabbitMQPermissionsFromResourceData(d)); err != nil {
+		return fmt.Errorf("Error updating RabbitMQ permissions %q: %s", d.Id(), err)
+	}
+
+	return resourceRabbitMQPermissionsRead(d, meta)
+}
+
+func resourceRabbitMQPermissionsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	user, vhost, err := parseRabbitMQResourceId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	perms, err := client.GetPermissions(vhost, user)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] RabbitMQ permissions %q not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading RabbitMQ permissions %q: %s", d.Id(), err)
+	}
+
+	d.Set("user", user)
+	d.Set("vhost", vhost)
+	d.Set("permissions", []map[string]interface{}{
+		{
+			"configure": perms.Configure,
+			"write":     perms.Write,
+			"read":      perms.Read,
+		},
+	})
+
+	return nil
+}
+
+func resourceRabbitMQPermissionsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	user, vhost, err := parseRabbitMQResourceId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Revoking RabbitMQ permissions %q", d.Id())
+	if err := client.DeletePermissions(vhost, user); err != nil && !isNotFound(err) {
+		return fmt.Errorf("Error revoking RabbitMQ permissions %q: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func rabbitMQPermissionsFromResourceData(d *schema.ResourceData) *Permissions {
+	perms := d.Get("permissions").([]interface{})[0].(map[string]interface{})
+	return &Permissions{
+		Configure: perms["configure"].(string),
+		Write:     perms["write"].(string),
+		Read:      perms["read"].(string),
+	}
+}
+
+// parseRabbitMQResourceId takes an ID of the form NAME@VHOST and returns
+// the two parts. Names may contain "@", vhosts are assumed not to.
+func parseRabbitMQResourceId(id string) (string, string, error) {
+	i := strings.LastIndex(id, "@")
+	if i <= 0 || i == len(id)-1 {
+		return "", "", fmt.Errorf("Invalid ID %q, expected NAME@VHOST", id)
+	}
+	return id[:i], id[i+1:], nil
+}
@@ -0,0 +1,155 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccRabbitMQPermissions_basic(t *testing.T) {
+	var perms Permissions
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRabbitMQPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRabbitMQPermissionsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRabbitMQPermissionsExists("rabbitmq_permissions.test", &perms),
+					resource.TestCheckResourceAttr(
+						"rabbitmq_permissions.test", "permissions.0.configure", ".*"),
+				),
+			},
+			{
+				Config: testAccRabbitMQPermissionsConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRabbitMQPermissionsExists("rabbitmq_permissions.test", &perms),
+					resource.TestCheckResourceAttr(
+						"rabbitmq_permissions.test", "permissions.0.configure", "^tf-.*"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseRabbitMQResourceId(t *testing.T) {
+	cases := []struct {
+		Id    string
+		Name  string
+		VHost string
+		Err   bool
+	}{
+		{"guest@/", "guest", "/", false},
+		{"user@example.com@test", "user@example.com", "test", false},
+		{"guest", "", "", true},
+		{"@/", "", "", true},
+		{"guest@", "", "", true},
+	}
+
+	for _, tc := range cases {
+		name, vhost, err := parseRabbitMQResourceId(tc.Id)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%q: expected error %t, got %v", tc.Id, tc.Err, err)
+		}
+		if name != tc.Name || vhost != tc.VHost {
+			t.Fatalf("%q: expected %q and %q, got %q and %q", tc.Id, tc.Name, tc.VHost, name, vhost)
+		}
+	}
+}
+
+func testAccCheckRabbitMQPermissionsDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "rabbitmq_permissions" {
+			continue
+		}
+
+		user, vhost, err := parseRabbitMQResourceId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.GetPermissions(vhost, user)
+		if err == nil {
+			return fmt.Errorf("Permissions still exist: %s", rs.Primary.ID)
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRabbitMQPermissionsExists(n string, perms *Permissions) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Permissions ID is set")
+		}
+
+		user, vhost, err := parseRabbitMQResourceId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.GetPermissions(vhost, user)
+		if err != nil {
+			return err
+		}
+
+		*perms = *found
+		return nil
+	}
+}
+
+const testAccRabbitMQPermissionsConfig_basic = `
+resource "rabbitmq_vhost" "test" {
+    name = "tf-test"
+}
+
+resource "rabbitmq_user" "test" {
+    name = "tf-test"
+    password = "foobar"
+}
+
+resource "rabbitmq_permissions" "test" {
+    user = "${rabbitmq_user.test.name}"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+`
+
+const testAccRabbitMQPermissionsConfig_update = `
+resource "rabbitmq_vhost" "test" {
+    name = "tf-test"
+}
+
+resource "rabbitmq_user" "test" {
+    name = "tf-test"
+    password = "foobar"
+}
+
+resource "rabbitmq_permissions" "test" {
+    user = "${rabbitmq_user.test.name}"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = "^tf-.*"
+        write = ".*"
+        read = ""
+    }
+}
+`
@@ -0,0 +1,135 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceRabbitMQQueue() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRabbitMQQueueCreate,
+		Read:   resourceRabbitMQQueueRead,
+		Delete: resourceRabbitMQQueueDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vhost": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+				ForceNew: true,
+			},
+
+			// Like exchanges, queues can't be changed once declared.
+			"settings": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"durable": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+							ForceNew: true,
+						},
+
+						"auto_delete": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+							ForceNew: true,
+						},
+
+						"arguments": &schema.Schema{
+							Type:     schema.TypeMap,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceRabbitMQQueueCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	name := d.Get("name").(string)
+	vhost := d.Get("vhost").(string)
+
+	settings := d.Get("settings").([]interface{})[0].(map[string]interface{})
+	queue := &Queue{
+		Durable:    settings["durable"].(bool),
+		AutoDelete: settings["auto_delete"].(bool),
+		Arguments:  settings["arguments"].(map[string]interface{}),
+	}
+
+	log.Printf("[DEBUG] Declaring RabbitMQ queue %q in vhost %q: %#v", name, vhost, queue)
+	if err := client.PutQueue(vhost, name, queue); err != nil {
+		return fmt.Errorf("Error declaring RabbitMQ queue %q in vhost %q: %s", name, vhost, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s", name, vhost))
+
+	return resourceRabbitMQQueueRead(d, meta)
+}
+
+func resourceRabbitMQQueueRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	name, vhost, err := parseRabbitMQResourceId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	queue, err := client.GetQueue(vhost, name)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] RabbitMQ queue %q not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading RabbitMQ queue %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", name)
+	d.Set("vhost", vhost)
+	d.Set("settings", []map[string]interface{}{
+		{
+			"durable":     queue.Durable,
+			"auto_delete": queue.AutoDelete,
+			"arguments":   flattenRabbitMQArguments(queue.Arguments),
+		},
+	})
+
+	return nil
+}
+
+func resourceRabbitMQQueueDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	name, vhost, err := parseRabbitMQResourceId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Deleting RabbitMQ queue %q", d.Id())
+	if err := client.DeleteQueue(vhost, name); err != nil && !isNotFound(err) {
+		return fmt.Errorf("Error deleting RabbitMQ queue %q: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,97 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccRabbitMQQueue_basic(t *testing.T) {
+	var queue Queue
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRabbitMQQueueDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRabbitMQQueueConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRabbitMQQueueExists("rabbitmq_queue.test", &queue),
+					resource.TestCheckResourceAttr(
+						"rabbitmq_queue.test", "settings.0.durable", "false"),
+					resource.TestCheckResourceAttr(
+						"rabbitmq_queue.test", "settings.0.auto_delete", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckRabbitMQQueueDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "rabbitmq_queue" {
+			continue
+		}
+
+		name, vhost, err := parseRabbitMQResourceId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.GetQueue(vhost, name)
+		if err == nil {
+			return fmt.Errorf("Queue still exists: %s", rs.Primary.ID)
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRabbitMQQueueExists(n string, queue *Queue) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Queue ID is set")
+		}
+
+		name, vhost, err := parseRabbitMQResourceId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.GetQueue(vhost, name)
+		if err != nil {
+			return err
+		}
+
+		*queue = *found
+		return nil
+	}
+}
+
+const testAccRabbitMQQueueConfig_basic = `
+resource "rabbitmq_vhost" "test" {
+    name = "tf-test"
+}
+
+resource "rabbitmq_queue" "test" {
+    name = "tf-test"
+    vhost = "${rabbitmq_vhost.test.name}"
+    settings {
+        durable = false
+        auto_delete = true
+    }
+}
+`
@@ -0,0 +1,119 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceRabbitMQUser() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRabbitMQUserCreate,
+		Update: resourceRabbitMQUserUpdate,
+		Read:   resourceRabbitMQUserRead,
+		Delete: resourceRabbitMQUserDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// The API only returns a hash of the password, so changes made
+			// outside of Terraform can't be detected.
+			"password": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+
+			"tags": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceRabbitMQUserCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] Creating RabbitMQ user %q", name)
+	if err := client.PutUser(rabbitMQUserFromResourceData(d)); err != nil {
+		return fmt.Errorf("Error creating RabbitMQ user %q: %s", name, err)
+	}
+
+	d.SetId(name)
+
+	return resourceRabbitMQUserRead(d, meta)
+}
+
+func resourceRabbitMQUserUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	// The password is always sent, as the API clears it otherwise.
+	log.Printf("[DEBUG] Updating RabbitMQ user %q", d.Id())
+	if err := client.PutUser(rabbitMQUserFromResourceData(d)); err != nil {
+		return fmt.Errorf("Error updating RabbitMQ user %q: %s", d.Id(), err)
+	}
+
+	return resourceRabbitMQUserRead(d, meta)
+}
+
+func resourceRabbitMQUserRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	user, err := client.GetUser(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] RabbitMQ user %q not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading RabbitMQ user %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", user.Name)
+
+	var tags []string
+	if user.Tags != "" {
+		tags = strings.Split(user.Tags, ",")
+	}
+	d.Set("tags", tags)
+
+	return nil
+}
+
+func resourceRabbitMQUserDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[DEBUG] Deleting RabbitMQ user %q", d.Id())
+	if err := client.DeleteUser(d.Id()); err != nil && !isNotFound(err) {
+		return fmt.Errorf("Error deleting RabbitMQ user %q: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func rabbitMQUserFromResourceData(d *schema.ResourceData) *User {
+	rawTags := d.Get("tags").([]interface{})
+	tags := make([]string, len(rawTags))
+	for i, v := range rawTags {
+		tags[i] = v.(string)
+	}
+
+	return &User{
+		Name:     d.Get("name").(string),
+		Password: d.Get("password").(string),
+		Tags:     strings.Join(tags, ","),
+	}
+}
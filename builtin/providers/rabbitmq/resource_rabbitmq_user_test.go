@@ -0,0 +1,116 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccRabbitMQUser_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRabbitMQUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRabbitMQUserConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRabbitMQUserTags("rabbitmq_user.test", "administrator,management"),
+					resource.TestCheckResourceAttr(
+						"rabbitmq_user.test", "tags.#", "2"),
+				),
+			},
+			{
+				Config: testAccRabbitMQUserConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRabbitMQUserTags("rabbitmq_user.test", "management"),
+					resource.TestCheckResourceAttr(
+						"rabbitmq_user.test", "tags.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRabbitMQUser_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRabbitMQUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRabbitMQUserConfig_basic,
+			},
+			{
+				ResourceName:      "rabbitmq_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The API only returns a hash of the password.
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func testAccCheckRabbitMQUserDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "rabbitmq_user" {
+			continue
+		}
+
+		_, err := client.GetUser(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("User still exists: %s", rs.Primary.ID)
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRabbitMQUserTags(n, tags string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No User ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		user, err := client.GetUser(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if user.Tags != tags {
+			return fmt.Errorf("Expected tags %q, got %q", tags, user.Tags)
+		}
+
+		return nil
+	}
+}
+
+const testAccRabbitMQUserConfig_basic = `
+resource "rabbitmq_user" "test" {
+    name = "tf-test"
+    password = "foobar"
+    tags = ["administrator", "management"]
+}
+`
+
+const testAccRabbitMQUserConfig_update = `
+resource "rabbitmq_user" "test" {
+    name = "tf-test"
+    password = "foobarbaz"
+    tags = ["management"]
+}
+`
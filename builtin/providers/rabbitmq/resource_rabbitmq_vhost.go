@@ -0,0 +1,71 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceRabbitMQVHost() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRabbitMQVHostCreate,
+		Read:   resourceRabbitMQVHostRead,
+		Delete: resourceRabbitMQVHostDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceRabbitMQVHostCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] Creating RabbitMQ vhost %q", name)
+	if err := client.PutVHost(name); err != nil {
+		return fmt.Errorf("Error creating RabbitMQ vhost %q: %s", name, err)
+	}
+
+	d.SetId(name)
+
+	return resourceRabbitMQVHostRead(d, meta)
+}
+
+func resourceRabbitMQVHostRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	vhost, err := client.GetVHost(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] RabbitMQ vhost %q not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading RabbitMQ vhost %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", vhost.Name)
+
+	return nil
+}
+
+func resourceRabbitMQVHostDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[DEBUG] Deleting RabbitMQ vhost %q", d.Id())
+	if err := client.DeleteVHost(d.Id()); err != nil && !isNotFound(err) {
+		return fmt.Errorf("Error deleting RabbitMQ vhost %q: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,88 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccRabbitMQVHost_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRabbitMQVHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRabbitMQVHostConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRabbitMQVHostExists("rabbitmq_vhost.test"),
+					resource.TestCheckResourceAttr(
+						"rabbitmq_vhost.test", "name", "tf-test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRabbitMQVHost_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRabbitMQVHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRabbitMQVHostConfig_basic,
+			},
+			{
+				ResourceName:      "rabbitmq_vhost.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckRabbitMQVHostDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "rabbitmq_vhost" {
+			continue
+		}
+
+		_, err := client.GetVHost(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("VHost still exists: %s", rs.Primary.ID)
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRabbitMQVHostExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No VHost ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		_, err := client.GetVHost(rs.Primary.ID)
+		return err
+	}
+}
+
+const testAccRabbitMQVHostConfig_basic = `
+resource "rabbitmq_vhost" "test" {
+    name = "tf-test"
+}
+`
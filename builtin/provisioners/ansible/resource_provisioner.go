@@ -0,0 +1,427 @@
+package ansible
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/armon/circbuf"
+	"github.com/hashicorp/terraform/communicator"
+	"github.com/hashicorp/terraform/helper/pathorcontents"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/go-homedir"
+	"github.com/mitchellh/go-linereader"
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	// defaultCommand is the command used to run the playbook if no
+	// other command is configured.
+	defaultCommand = "ansible-playbook"
+
+	// maxBufSize limits how much output we collect from the playbook run
+	// to include in the error if it fails.
+	maxBufSize = 8 * 1024
+)
+
+// Provisioner represents a specificly configured ansible provisioner
+type Provisioner struct {
+	Playbook       string      `mapstructure:"playbook"`
+	Command        string      `mapstructure:"command"`
+	Groups         []string    `mapstructure:"groups"`
+	HostVars       interface{} `mapstructure:"host_vars"`
+	ExtraVars      interface{} `mapstructure:"extra_vars"`
+	ExtraArguments []string    `mapstructure:"extra_arguments"`
+
+	hostVars  map[string]interface{}
+	extraVars map[string]interface{}
+}
+
+// connectionInfo holds the keys of the resource's ConnInfo that are
+// needed to let Ansible connect to it.
+type connectionInfo struct {
+	Type        string
+	User        string
+	Password    string
+	PrivateKey  string `mapstructure:"private_key"`
+	Host        string
+	Port        int
+	HTTPS       bool
+	Insecure    bool
+	BastionHost string `mapstructure:"bastion_host"`
+
+	// Deprecated
+	KeyFile string `mapstructure:"key_file"`
+}
+
+// ResourceProvisioner represents a generic ansible provisioner
+type ResourceProvisioner struct{}
+
+// Apply runs the playbook against the resource
+func (r *ResourceProvisioner) Apply(
+	o terraform.UIOutput,
+	s *terraform.InstanceState,
+	c *terraform.ResourceConfig) error {
+	// Decode the raw config for this provisioner
+	p, err := r.decodeConfig(c)
+	if err != nil {
+		return err
+	}
+
+	connInfo, err := parseConnectionInfo(s)
+	if err != nil {
+		return err
+	}
+
+	// Wait and retry until the resource accepts connections, so Ansible
+	// doesn't fail on a machine that is still booting.
+	comm, err := communicator.New(s)
+	if err != nil {
+		return err
+	}
+	err = retryFunc(comm.Timeout(), func() error {
+		return comm.Connect(o)
+	})
+	if err != nil {
+		return err
+	}
+	comm.Disconnect()
+
+	tmpDir, err := ioutil.TempDir("", "terraform-ansible")
+	if err != nil {
+		return fmt.Errorf("Error creating temporary directory: %s", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	var keyFile string
+	if connInfo.PrivateKey != "" {
+		key, _, err := pathorcontents.Read(connInfo.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("Error reading private key: %s", err)
+		}
+		keyFile = filepath.Join(tmpDir, "private_key")
+		if err := ioutil.WriteFile(keyFile, []byte(key), 0600); err != nil {
+			return fmt.Errorf("Error writing private key: %s", err)
+		}
+	}
+
+	inventory, err := p.inventory(connInfo, keyFile)
+	if err != nil {
+		return err
+	}
+	inventoryFile := filepath.Join(tmpDir, "inventory")
+	if err := ioutil.WriteFile(inventoryFile, []byte(inventory), 0600); err != nil {
+		return fmt.Errorf("Error writing inventory: %s", err)
+	}
+
+	args, err := p.arguments(inventoryFile)
+	if err != nil {
+		return err
+	}
+
+	return p.runPlaybook(o, args)
+}
+
+// Validate checks if the required arguments are configured
+func (r *ResourceProvisioner) Validate(c *terraform.ResourceConfig) (ws []string, es []error) {
+	p, err := r.decodeConfig(c)
+	if err != nil {
+		es = append(es, err)
+		return ws, es
+	}
+
+	if p.Playbook == "" {
+		es = append(es, fmt.Errorf("Key not found: playbook"))
+	}
+
+	return ws, es
+}
+
+func (r *ResourceProvisioner) decodeConfig(c *terraform.ResourceConfig) (*Provisioner, error) {
+	p := new(Provisioner)
+
+	decConf := &mapstructure.DecoderConfig{
+		ErrorUnused:      true,
+		WeaklyTypedInput: true,
+		Result:           p,
+	}
+	dec, err := mapstructure.NewDecoder(decConf)
+	if err != nil {
+		return nil, err
+	}
+
+	// Merge both configs so that values which still need to be
+	// interpolated are there during validation, preferring the
+	// interpolated ones.
+	m := make(map[string]interface{})
+
+	for k, v := range c.Raw {
+		m[k] = v
+	}
+
+	for k, v := range c.Config {
+		m[k] = v
+	}
+
+	if err := dec.Decode(m); err != nil {
+		return nil, err
+	}
+
+	if p.Command == "" {
+		p.Command = defaultCommand
+	}
+
+	if p.Playbook != "" {
+		p.Playbook, err = homedir.Expand(p.Playbook)
+		if err != nil {
+			return nil, fmt.Errorf("Error expanding the path %s: %v", p.Playbook, err)
+		}
+	}
+
+	if vars, ok := c.Config["host_vars"]; ok {
+		p.hostVars, err = rawToMap(vars)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing host_vars: %v", err)
+		}
+	}
+
+	if vars, ok := c.Config["extra_vars"]; ok {
+		p.extraVars, err = rawToMap(vars)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing extra_vars: %v", err)
+		}
+	}
+
+	return p, nil
+}
+
+// rawToMap converts a map from the configuration, which may be given as a
+// list of maps, into a single map.
+func rawToMap(raw interface{}) (map[string]interface{}, error) {
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		return v, nil
+	case []map[string]interface{}:
+		m := make(map[string]interface{})
+		for _, part := range v {
+			for k, v := range part {
+				m[k] = v
+			}
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("expected a map, got %T", raw)
+	}
+}
+
+// parseConnectionInfo decodes the ConnInfo of the InstanceState, applying
+// the same defaults as the communicators.
+func parseConnectionInfo(s *terraform.InstanceState) (*connectionInfo, error) {
+	connInfo := &connectionInfo{}
+	decConf := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           connInfo,
+	}
+	dec, err := mapstructure.NewDecoder(decConf)
+	if err != nil {
+		return nil, err
+	}
+	if err := dec.Decode(s.Ephemeral.ConnInfo); err != nil {
+		return nil, err
+	}
+
+	if connInfo.Host == "" {
+		return nil, fmt.Errorf("No host given in the connection info")
+	}
+
+	switch connInfo.Type {
+	case "ssh", "": // The default connection type is ssh
+		connInfo.Type = "ssh"
+		if connInfo.BastionHost != "" {
+			return nil, fmt.Errorf("Connecting through a bastion host is not supported by the ansible provisioner")
+		}
+		if connInfo.User == "" {
+			connInfo.User = "root"
+		}
+		if connInfo.Port == 0 {
+			connInfo.Port = 22
+		}
+		if connInfo.PrivateKey == "" && connInfo.KeyFile != "" {
+			connInfo.PrivateKey = connInfo.KeyFile
+		}
+	case "winrm":
+		if connInfo.User == "" {
+			connInfo.User = "Administrator"
+		}
+		if connInfo.Port == 0 {
+			connInfo.Port = 5985
+		}
+	default:
+		return nil, fmt.Errorf("Unsupported connection type: %s", connInfo.Type)
+	}
+
+	return connInfo, nil
+}
+
+// inventory generates an inventory containing only the resource, with the
+// variables Ansible needs to connect to it and the configured host_vars.
+func (p *Provisioner) inventory(connInfo *connectionInfo, keyFile string) (string, error) {
+	vars := map[string]interface{}{
+		"ansible_host": connInfo.Host,
+		"ansible_port": connInfo.Port,
+		"ansible_user": connInfo.User,
+	}
+
+	switch connInfo.Type {
+	case "ssh":
+		vars["ansible_connection"] = "ssh"
+		if keyFile != "" {
+			vars["ansible_ssh_private_key_file"] = keyFile
+		}
+		if connInfo.Password != "" {
+			vars["ansible_ssh_pass"] = connInfo.Password
+		}
+	case "winrm":
+		vars["ansible_connection"] = "winrm"
+		vars["ansible_password"] = connInfo.Password
+		if connInfo.HTTPS {
+			vars["ansible_winrm_scheme"] = "https"
+		} else {
+			vars["ansible_winrm_scheme"] = "http"
+		}
+		if connInfo.Insecure {
+			vars["ansible_winrm_server_cert_validation"] = "ignore"
+		}
+	}
+
+	for k, v := range p.hostVars {
+		if _, ok := vars[k]; ok {
+			return "", fmt.Errorf("host_vars may not override the connection variable %q", k)
+		}
+		vars[k] = v
+	}
+
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString(connInfo.Host)
+	for _, k := range keys {
+		v, err := inventoryValue(vars[k])
+		if err != nil {
+			return "", fmt.Errorf("Invalid value for host variable %q: %s", k, err)
+		}
+		buf.WriteString(fmt.Sprintf(" %s=%s", k, v))
+	}
+	buf.WriteString("\n")
+
+	for _, g := range p.Groups {
+		buf.WriteString(fmt.Sprintf("\n[%s]\n%s\n", g, connInfo.Host))
+	}
+
+	return buf.String(), nil
+}
+
+// inventoryValue quotes a host variable for an INI style inventory.
+func inventoryValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return strconv.Quote(string(b)), nil
+	}
+}
+
+// arguments returns the arguments to run the playbook with.
+func (p *Provisioner) arguments(inventoryFile string) ([]string, error) {
+	args := []string{"-i", inventoryFile}
+
+	if len(p.extraVars) > 0 {
+		extraVars, err := json.Marshal(p.extraVars)
+		if err != nil {
+			return nil, fmt.Errorf("Error encoding extra_vars: %s", err)
+		}
+		args = append(args, "--extra-vars", string(extraVars))
+	}
+
+	args = append(args, p.ExtraArguments...)
+	args = append(args, p.Playbook)
+
+	return args, nil
+}
+
+func (p *Provisioner) runPlaybook(o terraform.UIOutput, args []string) error {
+	// Setup the reader that will read the lines from the command
+	pr, pw := io.Pipe()
+	copyDoneCh := make(chan struct{})
+	go p.copyOutput(o, pr, copyDoneCh)
+
+	cmd := exec.Command(p.Command, args...)
+	output, _ := circbuf.NewBuffer(maxBufSize)
+	cmd.Stderr = io.MultiWriter(output, pw)
+	cmd.Stdout = io.MultiWriter(output, pw)
+
+	// The host was just created, so its key can't be known yet.
+	cmd.Env = append(os.Environ(), "ANSIBLE_HOST_KEY_CHECKING=False")
+
+	o.Output(fmt.Sprintf("Executing: %s %s", p.Command, strings.Join(args, " ")))
+
+	err := cmd.Run()
+
+	// Close the write-end of the pipe so that the goroutine mirroring output
+	// ends properly.
+	pw.Close()
+	<-copyDoneCh
+
+	if err != nil {
+		return fmt.Errorf("Error running playbook '%s': %v. Output: %s",
+			p.Playbook, err, output.Bytes())
+	}
+
+	return nil
+}
+
+func (p *Provisioner) copyOutput(o terraform.UIOutput, r io.Reader, doneCh chan<- struct{}) {
+	defer close(doneCh)
+	lr := linereader.New(r)
+	for line := range lr.Ch {
+		o.Output(line)
+	}
+}
+
+// retryFunc is used to retry a function for a given duration
+func retryFunc(timeout time.Duration, f func() error) error {
+	finish := time.After(timeout)
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		log.Printf("Retryable error: %v", err)
+
+		select {
+		case <-finish:
+			return err
+		case <-time.After(3 * time.Second):
+		}
+	}
+}
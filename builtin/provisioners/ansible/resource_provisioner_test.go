@@ -0,0 +1,216 @@
+package ansible
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceProvisioner_impl(t *testing.T) {
+	var _ terraform.ResourceProvisioner = new(ResourceProvisioner)
+}
+
+func TestResourceProvider_Validate_good(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"playbook": "site.yml",
+		"groups":   []interface{}{"web"},
+	})
+	p := new(ResourceProvisioner)
+	warn, errs := p.Validate(c)
+	if len(warn) > 0 {
+		t.Fatalf("Warnings: %v", warn)
+	}
+	if len(errs) > 0 {
+		t.Fatalf("Errors: %v", errs)
+	}
+}
+
+func TestResourceProvider_Validate_bad(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"invalid": "nope",
+	})
+	p := new(ResourceProvisioner)
+	warn, errs := p.Validate(c)
+	if len(warn) > 0 {
+		t.Fatalf("Warnings: %v", warn)
+	}
+	if len(errs) == 0 {
+		t.Fatalf("Should have errors")
+	}
+}
+
+func TestResourceProvider_Validate_missing(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{})
+	p := new(ResourceProvisioner)
+	warn, errs := p.Validate(c)
+	if len(warn) > 0 {
+		t.Fatalf("Warnings: %v", warn)
+	}
+	if len(errs) == 0 {
+		t.Fatalf("Should have errors")
+	}
+}
+
+func TestResourceProvider_inventory(t *testing.T) {
+	cases := map[string]struct {
+		Config    map[string]interface{}
+		ConnInfo  map[string]string
+		KeyFile   string
+		Inventory string
+	}{
+		"SSH": {
+			Config: map[string]interface{}{
+				"playbook": "site.yml",
+				"groups":   []interface{}{"web", "app"},
+				"host_vars": []map[string]interface{}{
+					map[string]interface{}{
+						"role": "web server",
+					},
+				},
+			},
+
+			ConnInfo: map[string]string{
+				"type":        "ssh",
+				"host":        "10.0.0.1",
+				"private_key": "secret",
+			},
+
+			KeyFile: "/tmp/key",
+
+			Inventory: `10.0.0.1 ansible_connection="ssh" ansible_host="10.0.0.1" ansible_port=22 ` +
+				`ansible_ssh_private_key_file="/tmp/key" ansible_user="root" role="web server"
+
+[web]
+10.0.0.1
+
+[app]
+10.0.0.1
+`,
+		},
+
+		"WinRM": {
+			Config: map[string]interface{}{
+				"playbook": "site.yml",
+			},
+
+			ConnInfo: map[string]string{
+				"type":     "winrm",
+				"host":     "10.0.0.2",
+				"password": "pass",
+				"https":    "true",
+				"insecure": "true",
+				"port":     "5986",
+			},
+
+			Inventory: `10.0.0.2 ansible_connection="winrm" ansible_host="10.0.0.2" ` +
+				`ansible_password="pass" ansible_port=5986 ansible_user="Administrator" ` +
+				`ansible_winrm_scheme="https" ansible_winrm_server_cert_validation="ignore"
+`,
+		},
+	}
+
+	r := new(ResourceProvisioner)
+	for k, tc := range cases {
+		p, err := r.decodeConfig(testConfig(t, tc.Config))
+		if err != nil {
+			t.Fatalf("%s: error decoding config: %v", k, err)
+		}
+
+		connInfo, err := parseConnectionInfo(&terraform.InstanceState{
+			Ephemeral: terraform.EphemeralState{ConnInfo: tc.ConnInfo},
+		})
+		if err != nil {
+			t.Fatalf("%s: error parsing connection info: %v", k, err)
+		}
+
+		inventory, err := p.inventory(connInfo, tc.KeyFile)
+		if err != nil {
+			t.Fatalf("%s: error generating inventory: %v", k, err)
+		}
+		if inventory != tc.Inventory {
+			t.Fatalf("%s: bad inventory, expected:\n%s\ngot:\n%s", k, tc.Inventory, inventory)
+		}
+	}
+}
+
+func TestResourceProvider_inventoryConnectionVar(t *testing.T) {
+	r := new(ResourceProvisioner)
+	p, err := r.decodeConfig(testConfig(t, map[string]interface{}{
+		"playbook": "site.yml",
+		"host_vars": map[string]interface{}{
+			"ansible_user": "admin",
+		},
+	}))
+	if err != nil {
+		t.Fatalf("error decoding config: %v", err)
+	}
+
+	connInfo, err := parseConnectionInfo(&terraform.InstanceState{
+		Ephemeral: terraform.EphemeralState{
+			ConnInfo: map[string]string{"host": "10.0.0.1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error parsing connection info: %v", err)
+	}
+
+	if _, err := p.inventory(connInfo, ""); err == nil {
+		t.Fatalf("Should have an error for overriding ansible_user")
+	}
+}
+
+func TestResourceProvider_parseConnectionInfo_bastion(t *testing.T) {
+	_, err := parseConnectionInfo(&terraform.InstanceState{
+		Ephemeral: terraform.EphemeralState{
+			ConnInfo: map[string]string{
+				"host":         "10.0.0.1",
+				"bastion_host": "bastion.example.com",
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("Should have an error for the bastion host")
+	}
+}
+
+func TestResourceProvider_arguments(t *testing.T) {
+	r := new(ResourceProvisioner)
+	p, err := r.decodeConfig(testConfig(t, map[string]interface{}{
+		"playbook":        "site.yml",
+		"extra_arguments": []interface{}{"--tags", "deploy"},
+		"extra_vars": []map[string]interface{}{
+			map[string]interface{}{
+				"version": "1.2.3",
+			},
+		},
+	}))
+	if err != nil {
+		t.Fatalf("error decoding config: %v", err)
+	}
+
+	args, err := p.arguments("/tmp/inventory")
+	if err != nil {
+		t.Fatalf("error building arguments: %v", err)
+	}
+
+	expected := []string{
+		"-i", "/tmp/inventory",
+		"--extra-vars", `{"version":"1.2.3"}`,
+		"--tags", "deploy",
+		"site.yml",
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Fatalf("bad arguments, expected %#v, got %#v", expected, args)
+	}
+}
+
+func testConfig(t *testing.T, c map[string]interface{}) *terraform.ResourceConfig {
+	r, err := config.NewRawConfig(c)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+
+	return terraform.NewResourceConfig(r)
+}
@@ -1,12 +1,20 @@
 package file
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform/communicator"
+	"github.com/hashicorp/terraform/communicator/remote"
 	"github.com/hashicorp/terraform/helper/config"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/go-homedir"
@@ -15,6 +23,24 @@ import (
 // ResourceProvisioner represents a file provisioner
 type ResourceProvisioner struct{}
 
+// uploadOptions holds the optional settings of an upload
+type uploadOptions struct {
+	// Exclude holds glob patterns of files and directories that are not
+	// uploaded when uploading a directory.
+	Exclude []string
+
+	// Mode and DirectoryMode are the octal permissions set on the uploaded
+	// files and directories.
+	Mode          string
+	DirectoryMode string
+
+	// Owner is set as the owner of everything uploaded.
+	Owner string
+
+	// Windows is true for winrm connections.
+	Windows bool
+}
+
 // Apply executes the file provisioner
 func (p *ResourceProvisioner) Apply(
 	o terraform.UIOutput,
@@ -43,7 +69,17 @@ func (p *ResourceProvisioner) Apply(
 	if !ok {
 		return fmt.Errorf("Unsupported 'destination' type! Must be string.")
 	}
-	return p.copyFiles(comm, src, dst)
+
+	opts, err := p.decodeOptions(c)
+	if err != nil {
+		return err
+	}
+	opts.Windows = s.Ephemeral.ConnInfo["type"] == "winrm"
+	if opts.Windows && (opts.Mode != "" || opts.DirectoryMode != "") {
+		return fmt.Errorf("'mode' and 'directory_mode' are not supported for winrm connections")
+	}
+
+	return p.copyFiles(comm, src, dst, opts)
 }
 
 // Validate checks if the required arguments are configured
@@ -53,12 +89,79 @@ func (p *ResourceProvisioner) Validate(c *terraform.ResourceConfig) (ws []string
 			"source",
 			"destination",
 		},
+		Optional: []string{
+			"exclude.*",
+			"mode",
+			"directory_mode",
+			"owner",
+		},
+	}
+	ws, es = v.Validate(c)
+
+	if _, err := p.decodeOptions(c); err != nil {
+		es = append(es, err)
+	}
+
+	return ws, es
+}
+
+// decodeOptions reads the optional settings from the configuration
+func (p *ResourceProvisioner) decodeOptions(c *terraform.ResourceConfig) (*uploadOptions, error) {
+	opts := new(uploadOptions)
+
+	if raw, ok := c.Config["exclude"]; ok {
+		switch e := raw.(type) {
+		case []string:
+			opts.Exclude = e
+		case []interface{}:
+			for _, v := range e {
+				pattern, ok := v.(string)
+				if !ok {
+					return nil, fmt.Errorf("Unsupported 'exclude' type! Must be list of strings.")
+				}
+				opts.Exclude = append(opts.Exclude, pattern)
+			}
+		default:
+			return nil, fmt.Errorf("Unsupported 'exclude' type! Must be list of strings.")
+		}
+
+		// Catch malformed patterns before anything is uploaded
+		for _, pattern := range opts.Exclude {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("Invalid 'exclude' pattern %q: %v", pattern, err)
+			}
+		}
+	}
+
+	for key, mode := range map[string]*string{"mode": &opts.Mode, "directory_mode": &opts.DirectoryMode} {
+		raw, ok := c.Config[key]
+		if !ok {
+			continue
+		}
+		v, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("Unsupported '%s' type! Must be string.", key)
+		}
+		if _, err := strconv.ParseUint(v, 8, 32); err != nil {
+			return nil, fmt.Errorf("'%s' must be an octal mode like \"0644\", got %q", key, v)
+		}
+		*mode = v
+	}
+
+	if raw, ok := c.Config["owner"]; ok {
+		v, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("Unsupported 'owner' type! Must be string.")
+		}
+		opts.Owner = v
 	}
-	return v.Validate(c)
+
+	return opts, nil
 }
 
 // copyFiles is used to copy the files from a source to a destination
-func (p *ResourceProvisioner) copyFiles(comm communicator.Communicator, src, dst string) error {
+func (p *ResourceProvisioner) copyFiles(
+	comm communicator.Communicator, src, dst string, opts *uploadOptions) error {
 	// Wait and retry until we establish the connection
 	err := retryFunc(comm.Timeout(), func() error {
 		err := comm.Connect(nil)
@@ -74,26 +177,206 @@ func (p *ResourceProvisioner) copyFiles(comm communicator.Communicator, src, dst
 		return err
 	}
 
-	// If we're uploading a directory, short circuit and do that
+	// If we're uploading a directory, upload it as a whole
 	if info.IsDir() {
+		if len(opts.Exclude) > 0 {
+			// Upload a copy of the directory without the excluded files,
+			// keeping its name so it ends up in the same place.
+			tmpDir, err := ioutil.TempDir("", "terraform-file")
+			if err != nil {
+				return fmt.Errorf("Error creating temporary directory: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			trailingSlash := strings.HasSuffix(src, "/")
+			copyDst := filepath.Join(tmpDir, filepath.Base(filepath.Clean(src)))
+			if err := copyDirExcluding(src, copyDst, opts.Exclude); err != nil {
+				return fmt.Errorf("Error copying %s: %v", src, err)
+			}
+
+			src = copyDst
+			if trailingSlash {
+				src += "/"
+			}
+		}
+
 		if err := comm.UploadDir(dst, src); err != nil {
 			return fmt.Errorf("Upload failed: %v", err)
 		}
+	} else {
+		// We're uploading a file...
+		f, err := os.Open(src)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if err := comm.Upload(dst, f); err != nil {
+			return fmt.Errorf("Upload failed: %v", err)
+		}
+	}
+
+	if opts.Mode == "" && opts.DirectoryMode == "" && opts.Owner == "" {
 		return nil
 	}
 
-	// We're uploading a file...
-	f, err := os.Open(src)
+	targets, err := uploadedPaths(src, dst, info.IsDir())
 	if err != nil {
 		return err
 	}
-	defer f.Close()
+	for _, target := range targets {
+		for _, cmd := range permissionCommands(target, opts) {
+			if err := runCommand(comm, cmd); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
 
-	err = comm.Upload(dst, f)
+// copyDirExcluding copies the directory src to dst, skipping files and
+// directories that match one of the patterns. Patterns are matched against
+// both the name and the slash separated path relative to src.
+func copyDirExcluding(src, dst string, exclude []string) error {
+	src = filepath.Clean(src)
+
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if rel != "." && isExcluded(filepath.ToSlash(rel), exclude) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm())
+		}
+		if !info.Mode().IsRegular() {
+			log.Printf("[WARN] Skipping %s, it is not a regular file", p)
+			return nil
+		}
+
+		return copyFile(p, target, info.Mode().Perm())
+	})
+}
+
+// isExcluded returns true if the relative path rel matches one of the
+// patterns
+func isExcluded(rel string, exclude []string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, path.Base(rel)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
 	if err != nil {
-		return fmt.Errorf("Upload failed: %v", err)
+		return err
 	}
-	return err
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// uploadedPaths returns the remote paths of what was uploaded from src.
+// A directory without a trailing slash is uploaded into dst, while only
+// the contents of one with a trailing slash are.
+func uploadedPaths(src, dst string, isDir bool) ([]string, error) {
+	if !isDir {
+		return []string{dst}, nil
+	}
+
+	if !strings.HasSuffix(src, "/") {
+		return []string{path.Join(dst, filepath.Base(src))}, nil
+	}
+
+	entries, err := ioutil.ReadDir(src)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		paths[i] = path.Join(dst, entry.Name())
+	}
+	return paths, nil
+}
+
+// permissionCommands returns the commands that set the configured
+// permissions and owner on the remote path target
+func permissionCommands(target string, opts *uploadOptions) []string {
+	var cmds []string
+
+	if opts.Windows {
+		if opts.Owner != "" {
+			cmds = append(cmds, fmt.Sprintf(`icacls "%s" /setowner "%s" /T /C /Q`, target, opts.Owner))
+		}
+		return cmds
+	}
+
+	quoted := shellQuote(target)
+	if opts.Mode != "" {
+		cmds = append(cmds, fmt.Sprintf("find %s -type f -exec chmod %s {} +", quoted, opts.Mode))
+	}
+	if opts.DirectoryMode != "" {
+		cmds = append(cmds, fmt.Sprintf("find %s -type d -exec chmod %s {} +", quoted, opts.DirectoryMode))
+	}
+	if opts.Owner != "" {
+		cmds = append(cmds, fmt.Sprintf("chown -R %s %s", shellQuote(opts.Owner), quoted))
+	}
+	return cmds
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'"'"'`, -1) + "'"
+}
+
+// runCommand runs cmd on the remote machine, returning its output in the
+// error if it fails
+func runCommand(comm communicator.Communicator, command string) error {
+	var stdout, stderr bytes.Buffer
+	cmd := &remote.Cmd{
+		Command: command,
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	if err := comm.Start(cmd); err != nil {
+		return fmt.Errorf("Error executing command %q: %v", command, err)
+	}
+
+	cmd.Wait()
+	if cmd.ExitStatus != 0 {
+		return fmt.Errorf("Command %q exited with non-zero exit status: %d. Output: %s%s",
+			command, cmd.ExitStatus, stdout.String(), stderr.String())
+	}
+
+	return nil
 }
 
 // retryFunc is used to retry a function for a given duration
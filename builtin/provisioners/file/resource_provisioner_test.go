@@ -1,8 +1,14 @@
 package file
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
 	"testing"
 
+	"github.com/hashicorp/terraform/communicator"
 	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -40,6 +46,169 @@ func TestResourceProvider_Validate_bad(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_Validate_options(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"source":         "/tmp/foo",
+		"destination":    "/tmp/bar",
+		"exclude":        []interface{}{"*.log", ".git"},
+		"mode":           "0644",
+		"directory_mode": "0755",
+		"owner":          "app:app",
+	})
+	p := new(ResourceProvisioner)
+	warn, errs := p.Validate(c)
+	if len(warn) > 0 {
+		t.Fatalf("Warnings: %v", warn)
+	}
+	if len(errs) > 0 {
+		t.Fatalf("Errors: %v", errs)
+	}
+}
+
+func TestResourceProvider_Validate_badMode(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"source":      "/tmp/foo",
+		"destination": "/tmp/bar",
+		"mode":        "rw-r--r--",
+	})
+	p := new(ResourceProvisioner)
+	_, errs := p.Validate(c)
+	if len(errs) == 0 {
+		t.Fatalf("Should have errors")
+	}
+}
+
+func TestResourceProvider_copyDirExcluding(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "tf-file-test")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	dst := filepath.Join(tmpDir, "dir")
+	if err := copyDirExcluding("test-fixtures/dir", dst, []string{"*.log", "sub"}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	var copied []string
+	filepath.Walk(dst, func(p string, info os.FileInfo, err error) error {
+		rel, _ := filepath.Rel(dst, p)
+		copied = append(copied, filepath.ToSlash(rel))
+		return nil
+	})
+	sort.Strings(copied)
+
+	expected := []string{".", "a.txt"}
+	if !reflect.DeepEqual(copied, expected) {
+		t.Fatalf("bad: %#v", copied)
+	}
+}
+
+func TestResourceProvider_isExcluded(t *testing.T) {
+	cases := []struct {
+		Path     string
+		Exclude  []string
+		Expected bool
+	}{
+		{"a.txt", []string{"*.log"}, false},
+		{"b.log", []string{"*.log"}, true},
+		{"sub/b.log", []string{"*.log"}, true},
+		{"sub/c.txt", []string{"sub/*"}, true},
+		{"other/c.txt", []string{"sub/*"}, false},
+	}
+
+	for _, tc := range cases {
+		if actual := isExcluded(tc.Path, tc.Exclude); actual != tc.Expected {
+			t.Fatalf("%s with %v: expected %t, got %t", tc.Path, tc.Exclude, tc.Expected, actual)
+		}
+	}
+}
+
+func TestResourceProvider_uploadedPaths(t *testing.T) {
+	cases := []struct {
+		Src      string
+		IsDir    bool
+		Expected []string
+	}{
+		{"test-fixtures/dir/a.txt", false, []string{"/tmp"}},
+		{"test-fixtures/dir", true, []string{"/tmp/dir"}},
+		{"test-fixtures/dir/", true, []string{"/tmp/a.txt", "/tmp/b.log", "/tmp/sub"}},
+	}
+
+	for _, tc := range cases {
+		actual, err := uploadedPaths(tc.Src, "/tmp", tc.IsDir)
+		if err != nil {
+			t.Fatalf("%s: err: %v", tc.Src, err)
+		}
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%s: expected %#v, got %#v", tc.Src, tc.Expected, actual)
+		}
+	}
+}
+
+func TestResourceProvider_permissionCommands(t *testing.T) {
+	cases := map[string]struct {
+		Opts     *uploadOptions
+		Expected []string
+	}{
+		"None": {
+			Opts: &uploadOptions{},
+		},
+
+		"SSH": {
+			Opts: &uploadOptions{
+				Mode:          "0644",
+				DirectoryMode: "0755",
+				Owner:         "app:app",
+			},
+			Expected: []string{
+				"find '/opt/app' -type f -exec chmod 0644 {} +",
+				"find '/opt/app' -type d -exec chmod 0755 {} +",
+				"chown -R 'app:app' '/opt/app'",
+			},
+		},
+
+		"WinRM": {
+			Opts: &uploadOptions{
+				Owner:   "Administrators",
+				Windows: true,
+			},
+			Expected: []string{
+				`icacls "/opt/app" /setowner "Administrators" /T /C /Q`,
+			},
+		},
+	}
+
+	for k, tc := range cases {
+		actual := permissionCommands("/opt/app", tc.Opts)
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%s: expected %#v, got %#v", k, tc.Expected, actual)
+		}
+	}
+}
+
+func TestResourceProvider_copyFiles(t *testing.T) {
+	c := &communicator.MockCommunicator{
+		Commands: map[string]bool{
+			"find '/opt/dir' -type f -exec chmod 0600 {} +": true,
+			"chown -R 'app' '/opt/dir'":                     true,
+		},
+		UploadDirs: map[string]string{
+			"test-fixtures/dir": "/opt",
+		},
+	}
+
+	opts := &uploadOptions{
+		Mode:  "0600",
+		Owner: "app",
+	}
+
+	p := new(ResourceProvisioner)
+	if err := p.copyFiles(c, "test-fixtures/dir", "/opt", opts); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+}
+
 func testConfig(
 	t *testing.T,
 	c map[string]interface{}) *terraform.ResourceConfig {
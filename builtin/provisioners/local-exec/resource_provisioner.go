@@ -3,11 +3,13 @@ package localexec
 import (
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"runtime"
+	"sort"
+	"strings"
 
 	"github.com/armon/circbuf"
-	"github.com/hashicorp/terraform/helper/config"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/go-linereader"
 )
@@ -36,14 +38,29 @@ func (p *ResourceProvisioner) Apply(
 		return fmt.Errorf("local-exec provisioner command must be a string")
 	}
 
-	// Execute the command using a shell
-	var shell, flag string
-	if runtime.GOOS == "windows" {
-		shell = "cmd"
-		flag = "/C"
-	} else {
-		shell = "/bin/sh"
-		flag = "-c"
+	// Execute the command with the interpreter, or using a shell by default
+	interpreter, err := p.collectInterpreter(c)
+	if err != nil {
+		return err
+	}
+	if len(interpreter) == 0 {
+		if runtime.GOOS == "windows" {
+			interpreter = []string{"cmd", "/C"}
+		} else {
+			interpreter = []string{"/bin/sh", "-c"}
+		}
+	}
+
+	env, err := p.collectEnvironment(c)
+	if err != nil {
+		return err
+	}
+
+	var workingDir string
+	if raw, ok := c.Config["working_dir"]; ok {
+		if workingDir, ok = raw.(string); !ok {
+			return fmt.Errorf("local-exec provisioner working_dir must be a string")
+		}
 	}
 
 	// Setup the reader that will read the lines from the command
@@ -52,18 +69,23 @@ func (p *ResourceProvisioner) Apply(
 	go p.copyOutput(o, pr, copyDoneCh)
 
 	// Setup the command
-	cmd := exec.Command(shell, flag, command)
+	args := append(append([]string{}, interpreter[1:]...), command)
+	cmd := exec.Command(interpreter[0], args...)
+	cmd.Dir = workingDir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	output, _ := circbuf.NewBuffer(maxBufSize)
 	cmd.Stderr = io.MultiWriter(output, pw)
 	cmd.Stdout = io.MultiWriter(output, pw)
 
 	// Output what we're about to run
 	o.Output(fmt.Sprintf(
-		"Executing: %s %s \"%s\"",
-		shell, flag, command))
+		"Executing: %s \"%s\"",
+		strings.Join(interpreter, " "), command))
 
 	// Run the command to completion
-	err := cmd.Run()
+	err = cmd.Run()
 
 	// Close the write-end of the pipe so that the goroutine mirroring output
 	// ends properly.
@@ -78,11 +100,95 @@ func (p *ResourceProvisioner) Apply(
 	return nil
 }
 
-func (p *ResourceProvisioner) Validate(c *terraform.ResourceConfig) ([]string, []error) {
-	validator := config.Validator{
-		Required: []string{"command"},
+func (p *ResourceProvisioner) Validate(c *terraform.ResourceConfig) (ws []string, es []error) {
+	// The environment map has arbitrary keys, which the config validator
+	// can't describe, so the keys are checked here and unknown ones, such
+	// as a misspelled working_dir, are rejected.
+	for name := range c.Raw {
+		switch name {
+		case "command", "environment", "interpreter", "working_dir":
+		default:
+			es = append(es, fmt.Errorf("Unknown configuration '%s'", name))
+		}
 	}
-	return validator.Validate(c)
+	if _, ok := c.Raw["command"]; !ok {
+		es = append(es, fmt.Errorf("local-exec provisioner missing 'command'"))
+	}
+
+	if _, err := p.collectInterpreter(c); err != nil {
+		es = append(es, err)
+	}
+	if _, err := p.collectEnvironment(c); err != nil {
+		es = append(es, err)
+	}
+
+	return ws, es
+}
+
+// collectInterpreter returns the interpreter and its arguments the
+// command is passed to, or nil if it isn't set
+func (p *ResourceProvisioner) collectInterpreter(c *terraform.ResourceConfig) ([]string, error) {
+	raw, ok := c.Config["interpreter"]
+	if !ok {
+		return nil, nil
+	}
+
+	var interpreter []string
+	switch i := raw.(type) {
+	case []string:
+		interpreter = i
+	case []interface{}:
+		for _, v := range i {
+			arg, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("local-exec provisioner interpreter must be a list of strings")
+			}
+			interpreter = append(interpreter, arg)
+		}
+	default:
+		return nil, fmt.Errorf("local-exec provisioner interpreter must be a list of strings")
+	}
+
+	if len(interpreter) == 0 {
+		return nil, fmt.Errorf("local-exec provisioner interpreter must not be empty")
+	}
+
+	return interpreter, nil
+}
+
+// collectEnvironment returns the environment variables set for the
+// command as a sorted list of KEY=value pairs
+func (p *ResourceProvisioner) collectEnvironment(c *terraform.ResourceConfig) ([]string, error) {
+	raw, ok := c.Config["environment"]
+	if !ok {
+		return nil, nil
+	}
+
+	var maps []map[string]interface{}
+	switch e := raw.(type) {
+	case map[string]interface{}:
+		maps = append(maps, e)
+	case []map[string]interface{}:
+		maps = e
+	default:
+		return nil, fmt.Errorf("local-exec provisioner environment must be a map")
+	}
+
+	var env []string
+	for _, m := range maps {
+		for k, v := range m {
+			switch v.(type) {
+			case string, int, bool, float64:
+				env = append(env, fmt.Sprintf("%s=%v", k, v))
+			default:
+				return nil, fmt.Errorf(
+					"local-exec provisioner environment variable '%s' must be a string", k)
+			}
+		}
+	}
+	sort.Strings(env)
+
+	return env, nil
 }
 
 func (p *ResourceProvisioner) copyOutput(
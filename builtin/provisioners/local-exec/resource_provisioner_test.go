@@ -3,6 +3,7 @@ package localexec
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -39,6 +40,43 @@ func TestResourceProvider_Apply(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_Apply_options(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "tf-local-exec")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	c := testConfig(t, map[string]interface{}{
+		"command":     "echo $FOO > test_out",
+		"interpreter": []interface{}{"/bin/sh", "-c"},
+		"working_dir": tmpDir,
+		"environment": []map[string]interface{}{
+			map[string]interface{}{
+				"FOO": "bar",
+			},
+		},
+	})
+
+	output := new(terraform.MockUIOutput)
+	p := new(ResourceProvisioner)
+	if err := p.Apply(output, nil, c); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// The file is written to the working directory
+	raw, err := ioutil.ReadFile(filepath.Join(tmpDir, "test_out"))
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	actual := strings.TrimSpace(string(raw))
+	expected := "bar"
+	if actual != expected {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
 func TestResourceProvider_Validate_good(t *testing.T) {
 	c := testConfig(t, map[string]interface{}{
 		"command": "echo foo",
@@ -65,6 +103,37 @@ func TestResourceProvider_Validate_missing(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_Validate_options(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"command":     "Write-Host $env:FOO",
+		"interpreter": []interface{}{"PowerShell", "-Command"},
+		"working_dir": "/tmp",
+		"environment": map[string]interface{}{
+			"FOO": "bar",
+		},
+	})
+	p := new(ResourceProvisioner)
+	warn, errs := p.Validate(c)
+	if len(warn) > 0 {
+		t.Fatalf("Warnings: %v", warn)
+	}
+	if len(errs) > 0 {
+		t.Fatalf("Errors: %v", errs)
+	}
+}
+
+func TestResourceProvider_Validate_emptyInterpreter(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"command":     "echo foo",
+		"interpreter": []interface{}{},
+	})
+	p := new(ResourceProvisioner)
+	_, errs := p.Validate(c)
+	if len(errs) == 0 {
+		t.Fatalf("Should have errors")
+	}
+}
+
 func testConfig(
 	t *testing.T,
 	c map[string]interface{}) *terraform.ResourceConfig {
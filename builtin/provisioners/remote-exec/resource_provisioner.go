@@ -7,15 +7,24 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/armon/circbuf"
 	"github.com/hashicorp/terraform/communicator"
 	"github.com/hashicorp/terraform/communicator/remote"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/go-linereader"
 )
 
+const (
+	// maxBufSize limits how much output of a failed script is included
+	// in the error.
+	maxBufSize = 8 * 1024
+)
+
 // ResourceProvisioner represents a remote exec provisioner
 type ResourceProvisioner struct{}
 
@@ -39,8 +48,28 @@ func (p *ResourceProvisioner) Apply(
 		defer s.Close()
 	}
 
+	// Collect the environment and the script arguments
+	env, err := p.collectEnvironment(c)
+	if err != nil {
+		return err
+	}
+	args, err := p.collectScriptArgs(c)
+	if err != nil {
+		return err
+	}
+
+	windows := s.Ephemeral.ConnInfo["type"] == "winrm"
+	if windows {
+		if err := validateWindowsEnvironment(env); err != nil {
+			return err
+		}
+	}
+	command := func(remotePath string) string {
+		return buildCommand(windows, remotePath, env, args)
+	}
+
 	// Copy and execute each script
-	if err := p.runScripts(o, comm, scripts); err != nil {
+	if err := p.runScripts(o, comm, scripts, command); err != nil {
 		return err
 	}
 	return nil
@@ -53,6 +82,7 @@ func (p *ResourceProvisioner) Validate(c *terraform.ResourceConfig) (ws []string
 		switch name {
 		case "scripts", "script", "inline":
 			num++
+		case "environment", "script_args", "inline_shebang":
 		default:
 			es = append(es, fmt.Errorf("Unknown configuration '%s'", name))
 		}
@@ -60,6 +90,13 @@ func (p *ResourceProvisioner) Validate(c *terraform.ResourceConfig) (ws []string
 	if num != 1 {
 		es = append(es, fmt.Errorf("Must provide one of 'scripts', 'script' or 'inline' to remote-exec"))
 	}
+	if _, ok := c.Raw["inline"]; ok {
+		if _, ok := c.Raw["script_args"]; ok {
+			es = append(es, fmt.Errorf("'script_args' can only be used with 'script' or 'scripts'"))
+		}
+	} else if _, ok := c.Raw["inline_shebang"]; ok {
+		es = append(es, fmt.Errorf("'inline_shebang' can only be used with 'inline'"))
+	}
 	return
 }
 
@@ -67,6 +104,13 @@ func (p *ResourceProvisioner) Validate(c *terraform.ResourceConfig) (ws []string
 // from the inline configs
 func (p *ResourceProvisioner) generateScript(c *terraform.ResourceConfig) (string, error) {
 	var lines []string
+	if shebang, ok := c.Config["inline_shebang"]; ok {
+		shebangStr, ok := shebang.(string)
+		if !ok {
+			return "", fmt.Errorf("Unsupported 'inline_shebang' type! Must be a string.")
+		}
+		lines = append(lines, "#!"+strings.TrimPrefix(shebangStr, "#!"))
+	}
 	command, ok := c.Config["inline"]
 	if ok {
 		switch cmd := command.(type) {
@@ -152,11 +196,138 @@ func (p *ResourceProvisioner) collectScripts(c *terraform.ResourceConfig) ([]io.
 	return fhs, nil
 }
 
+// collectEnvironment is used to collect the environment variables the
+// scripts are executed with.
+func (p *ResourceProvisioner) collectEnvironment(c *terraform.ResourceConfig) (map[string]string, error) {
+	env := make(map[string]string)
+
+	raw, ok := c.Config["environment"]
+	if !ok {
+		return env, nil
+	}
+
+	var maps []map[string]interface{}
+	switch e := raw.(type) {
+	case map[string]interface{}:
+		maps = append(maps, e)
+	case []map[string]interface{}:
+		maps = e
+	default:
+		return nil, fmt.Errorf("Unsupported 'environment' type! Must be a map.")
+	}
+
+	for _, m := range maps {
+		for k, v := range m {
+			switch v.(type) {
+			case string, int, bool, float64:
+				env[k] = fmt.Sprintf("%v", v)
+			default:
+				return nil, fmt.Errorf("Unsupported value for environment variable '%s'! Must be a string.", k)
+			}
+		}
+	}
+
+	return env, nil
+}
+
+// collectScriptArgs is used to collect the arguments passed to each script.
+func (p *ResourceProvisioner) collectScriptArgs(c *terraform.ResourceConfig) ([]string, error) {
+	var args []string
+
+	raw, ok := c.Config["script_args"]
+	if !ok {
+		return args, nil
+	}
+
+	switch a := raw.(type) {
+	case []string:
+		args = append(args, a...)
+	case []interface{}:
+		for _, v := range a {
+			switch v.(type) {
+			case string, int, bool, float64:
+				args = append(args, fmt.Sprintf("%v", v))
+			default:
+				return nil, fmt.Errorf("Unsupported 'script_args' type! Must be list of strings.")
+			}
+		}
+	default:
+		return nil, fmt.Errorf("Unsupported 'script_args' type! Must be list of strings.")
+	}
+
+	return args, nil
+}
+
+// buildCommand returns the command that executes the script at remotePath
+// with the given environment and arguments. Windows commands are run by
+// cmd.exe, all others by a POSIX shell.
+func buildCommand(windows bool, remotePath string, env map[string]string, args []string) string {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	if windows {
+		for _, k := range keys {
+			parts = append(parts, fmt.Sprintf("set \"%s=%s\" &&", k, env[k]))
+		}
+		parts = append(parts, remotePath)
+		for _, a := range args {
+			parts = append(parts, "\""+strings.Replace(a, "\"", "\"\"", -1)+"\"")
+		}
+	} else {
+		for _, k := range keys {
+			parts = append(parts, k+"="+shellQuote(env[k]))
+		}
+		parts = append(parts, remotePath)
+		for _, a := range args {
+			parts = append(parts, shellQuote(a))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// validateWindowsEnvironment checks that the environment can be set with
+// cmd.exe. There is no way to escape these characters in a quoted set
+// command line, so values containing them are rejected instead.
+func validateWindowsEnvironment(env map[string]string) error {
+	for k, v := range env {
+		if strings.ContainsAny(v, "\"%&\r\n") {
+			return fmt.Errorf(
+				"environment variable %q can't be set over WinRM: values can't "+
+					"contain double quotes, %%, & or newlines", k)
+		}
+	}
+	return nil
+}
+
+// syncWriter serializes the writes to w, since stdout and stderr are
+// copied to the same buffer by different goroutines.
+type syncWriter struct {
+	sync.Mutex
+	w io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+	return w.w.Write(p)
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'"'"'`, -1) + "'"
+}
+
 // runScripts is used to copy and execute a set of scripts
 func (p *ResourceProvisioner) runScripts(
 	o terraform.UIOutput,
 	comm communicator.Communicator,
-	scripts []io.ReadCloser) error {
+	scripts []io.ReadCloser,
+	command func(remotePath string) string) error {
 	// Wait and retry until we establish the connection
 	err := retryFunc(comm.Timeout(), func() error {
 		err := comm.Connect(o)
@@ -171,6 +342,13 @@ func (p *ResourceProvisioner) runScripts(
 		var cmd *remote.Cmd
 		outR, outW := io.Pipe()
 		errR, errW := io.Pipe()
+
+		// Keep the last part of the output to include it in the error if
+		// the script fails.
+		output, _ := circbuf.NewBuffer(maxBufSize)
+		outputW := &syncWriter{w: output}
+		stdout := io.MultiWriter(outputW, outW)
+		stderr := io.MultiWriter(outputW, errW)
 		outDoneCh := make(chan struct{})
 		errDoneCh := make(chan struct{})
 		go p.copyOutput(o, outR, outDoneCh)
@@ -184,9 +362,9 @@ func (p *ResourceProvisioner) runScripts(
 			}
 
 			cmd = &remote.Cmd{
-				Command: remotePath,
-				Stdout:  outW,
-				Stderr:  errW,
+				Command: command(remotePath),
+				Stdout:  stdout,
+				Stderr:  stderr,
 			}
 			if err := comm.Start(cmd); err != nil {
 				return fmt.Errorf("Error starting script: %v", err)
@@ -207,6 +385,10 @@ func (p *ResourceProvisioner) runScripts(
 		<-outDoneCh
 		<-errDoneCh
 
+		if err != nil && cmd != nil && cmd.ExitStatus != 0 {
+			err = fmt.Errorf("%s. Output: %s", err, output.Bytes())
+		}
+
 		// Upload a blank follow up file in the same path to prevent residual
 		// script contents from remaining on remote machine
 		empty := bytes.NewReader([]byte(""))
@@ -3,6 +3,8 @@ package remoteexec
 import (
 	"bytes"
 	"io"
+	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform/config"
@@ -41,6 +43,48 @@ func TestResourceProvider_Validate_bad(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_Validate_scriptArgsInline(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"inline":      "echo foo",
+		"script_args": []interface{}{"bar"},
+	})
+	p := new(ResourceProvisioner)
+	_, errs := p.Validate(c)
+	if len(errs) == 0 {
+		t.Fatalf("Should have errors")
+	}
+}
+
+func TestResourceProvider_Validate_shebangScript(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"script":         "test-fixtures/script1.sh",
+		"inline_shebang": "/bin/bash",
+	})
+	p := new(ResourceProvisioner)
+	_, errs := p.Validate(c)
+	if len(errs) == 0 {
+		t.Fatalf("Should have errors")
+	}
+}
+
+func TestResourceProvider_Validate_environment(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"script":      "test-fixtures/script1.sh",
+		"script_args": []interface{}{"bar"},
+		"environment": map[string]interface{}{
+			"FOO": "bar",
+		},
+	})
+	p := new(ResourceProvisioner)
+	warn, errs := p.Validate(c)
+	if len(warn) > 0 {
+		t.Fatalf("Warnings: %v", warn)
+	}
+	if len(errs) > 0 {
+		t.Fatalf("Errors: %v", errs)
+	}
+}
+
 var expectedScriptOut = `cd /tmp
 wget http://foobar
 exit 0
@@ -65,6 +109,26 @@ func TestResourceProvider_generateScript(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_generateScriptShebang(t *testing.T) {
+	p := new(ResourceProvisioner)
+	conf := testConfig(t, map[string]interface{}{
+		"inline": []interface{}{
+			"cd /tmp",
+			"wget http://foobar",
+			"exit 0",
+		},
+		"inline_shebang": "/bin/bash -e",
+	})
+	out, err := p.generateScript(conf)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	if out != "#!/bin/bash -e\n"+expectedScriptOut {
+		t.Fatalf("bad: %v", out)
+	}
+}
+
 func TestResourceProvider_CollectScripts_inline(t *testing.T) {
 	p := new(ResourceProvisioner)
 	conf := testConfig(t, map[string]interface{}{
@@ -153,6 +217,124 @@ func TestResourceProvider_CollectScripts_scripts(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_collectEnvironment(t *testing.T) {
+	p := new(ResourceProvisioner)
+	conf := testConfig(t, map[string]interface{}{
+		"environment": []map[string]interface{}{
+			map[string]interface{}{
+				"FOO":   "bar",
+				"DEBUG": true,
+			},
+		},
+	})
+
+	env, err := p.collectEnvironment(conf)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	expected := map[string]string{
+		"FOO":   "bar",
+		"DEBUG": "true",
+	}
+	if !reflect.DeepEqual(env, expected) {
+		t.Fatalf("bad: %#v", env)
+	}
+}
+
+func TestResourceProvider_collectScriptArgs(t *testing.T) {
+	p := new(ResourceProvisioner)
+	conf := testConfig(t, map[string]interface{}{
+		"script_args": []interface{}{"foo", "bar baz"},
+	})
+
+	args, err := p.collectScriptArgs(conf)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	expected := []string{"foo", "bar baz"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Fatalf("bad: %#v", args)
+	}
+}
+
+func TestResourceProvider_buildCommand(t *testing.T) {
+	cases := map[string]struct {
+		Windows  bool
+		Env      map[string]string
+		Args     []string
+		Expected string
+	}{
+		"Plain": {
+			Expected: "/tmp/script.sh",
+		},
+
+		"Unix": {
+			Env: map[string]string{
+				"FOO": "bar",
+				"BAZ": "it's",
+			},
+			Args:     []string{"one", "two words"},
+			Expected: `BAZ='it'"'"'s' FOO='bar' /tmp/script.sh 'one' 'two words'`,
+		},
+
+		"Windows": {
+			Windows: true,
+			Env: map[string]string{
+				"FOO": "bar",
+			},
+			Args:     []string{"one", `say "hi"`},
+			Expected: `set "FOO=bar" && /tmp/script.sh "one" "say ""hi"""`,
+		},
+	}
+
+	for k, tc := range cases {
+		actual := buildCommand(tc.Windows, "/tmp/script.sh", tc.Env, tc.Args)
+		if actual != tc.Expected {
+			t.Fatalf("%s: expected %q, got %q", k, tc.Expected, actual)
+		}
+	}
+}
+
+func TestResourceProvider_validateWindowsEnvironment(t *testing.T) {
+	ok := map[string]string{
+		"FOO": "bar",
+		"DIR": `C:\Program Files (x86)\app`,
+	}
+	if err := validateWindowsEnvironment(ok); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, v := range []string{`say "hi"`, "%PATH%", "a & b", "one\ntwo"} {
+		err := validateWindowsEnvironment(map[string]string{"FOO": v})
+		if err == nil {
+			t.Fatalf("expected error for %q", v)
+		}
+	}
+}
+
+func TestSyncWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &syncWriter{w: &buf}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				w.Write([]byte("x"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if buf.Len() != 1000 {
+		t.Fatalf("expected 1000 bytes, got %d", buf.Len())
+	}
+}
+
 func testConfig(
 	t *testing.T,
 	c map[string]interface{}) *terraform.ResourceConfig {
@@ -0,0 +1,399 @@
+package saltmasterless
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/communicator"
+	"github.com/hashicorp/terraform/communicator/remote"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/go-homedir"
+	"github.com/mitchellh/go-linereader"
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	bootstrapScript   = "install_salt.sh"
+	bootstrapURL      = "https://bootstrap.saltstack.com"
+	defaultLogLevel   = "info"
+	defaultPillarRoot = "/srv/pillar"
+	defaultStateTree  = "/srv/salt"
+	defaultTempDir    = "/tmp/salt"
+	minionConfDir     = "/etc/salt"
+)
+
+// Provisioner represents a specificly configured salt-masterless provisioner
+type Provisioner struct {
+	BootstrapArgs     string `mapstructure:"bootstrap_args"`
+	LocalPillarRoots  string `mapstructure:"local_pillar_roots"`
+	LocalStateTree    string `mapstructure:"local_state_tree"`
+	LogLevel          string `mapstructure:"log_level"`
+	MinionConfig      string `mapstructure:"minion_config"`
+	PreventSudo       bool   `mapstructure:"prevent_sudo"`
+	RemotePillarRoots string `mapstructure:"remote_pillar_roots"`
+	RemoteStateTree   string `mapstructure:"remote_state_tree"`
+	SaltCallArgs      string `mapstructure:"salt_call_args"`
+	SkipBootstrap     bool   `mapstructure:"skip_bootstrap"`
+	TempConfigDir     string `mapstructure:"temp_config_dir"`
+
+	useSudo bool
+}
+
+// ResourceProvisioner represents a generic salt-masterless provisioner
+type ResourceProvisioner struct{}
+
+// Apply executes the salt-masterless provisioner
+func (r *ResourceProvisioner) Apply(
+	o terraform.UIOutput,
+	s *terraform.InstanceState,
+	c *terraform.ResourceConfig) error {
+	// Decode the raw config for this provisioner
+	p, err := r.decodeConfig(c)
+	if err != nil {
+		return err
+	}
+
+	switch s.Ephemeral.ConnInfo["type"] {
+	case "ssh", "": // The default connection type is ssh, so if the type is empty assume ssh
+	default:
+		return fmt.Errorf("Unsupported connection type: %s", s.Ephemeral.ConnInfo["type"])
+	}
+	p.useSudo = !p.PreventSudo && s.Ephemeral.ConnInfo["user"] != "root"
+
+	// Check the local directories before connecting, so a typo fails fast
+	if err := validateDir(p.LocalStateTree); err != nil {
+		return fmt.Errorf("Invalid local_state_tree: %s", err)
+	}
+	if p.LocalPillarRoots != "" {
+		if err := validateDir(p.LocalPillarRoots); err != nil {
+			return fmt.Errorf("Invalid local_pillar_roots: %s", err)
+		}
+	}
+
+	// Get a new communicator
+	comm, err := communicator.New(s)
+	if err != nil {
+		return err
+	}
+
+	// Wait and retry until we establish the connection
+	err = retryFunc(comm.Timeout(), func() error {
+		err := comm.Connect(o)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	defer comm.Disconnect()
+
+	if !p.SkipBootstrap {
+		o.Output("Bootstrapping Salt...")
+		if err := p.bootstrapSalt(o, comm); err != nil {
+			return err
+		}
+	}
+
+	o.Output("Uploading the state tree...")
+	if err := p.uploadFiles(o, comm); err != nil {
+		return err
+	}
+
+	o.Output("Running the highstate...")
+	if err := p.runHighstate(o, comm); err != nil {
+		return err
+	}
+
+	// Remove the temporary directory, the files were moved out of it
+	return p.runCommand(o, comm, fmt.Sprintf("rm -rf %q", p.TempConfigDir))
+}
+
+// Validate checks if the required arguments are configured
+func (r *ResourceProvisioner) Validate(c *terraform.ResourceConfig) (ws []string, es []error) {
+	p, err := r.decodeConfig(c)
+	if err != nil {
+		es = append(es, err)
+		return ws, es
+	}
+
+	if p.LocalStateTree == "" {
+		es = append(es, fmt.Errorf("Key not found: local_state_tree"))
+	}
+	if p.SkipBootstrap && p.BootstrapArgs != "" {
+		ws = append(ws, "bootstrap_args is ignored when skip_bootstrap is set")
+	}
+
+	return ws, es
+}
+
+func (r *ResourceProvisioner) decodeConfig(c *terraform.ResourceConfig) (*Provisioner, error) {
+	p := new(Provisioner)
+
+	decConf := &mapstructure.DecoderConfig{
+		ErrorUnused:      true,
+		WeaklyTypedInput: true,
+		Result:           p,
+	}
+	dec, err := mapstructure.NewDecoder(decConf)
+	if err != nil {
+		return nil, err
+	}
+
+	// We need to merge both configs into a single map first. Order is
+	// important as we need to make sure interpolated values are used
+	// over raw values. This makes sure that all values are there even
+	// if some still need to be interpolated later on.
+	m := make(map[string]interface{})
+
+	for k, v := range c.Raw {
+		m[k] = v
+	}
+
+	for k, v := range c.Config {
+		m[k] = v
+	}
+
+	if err := dec.Decode(m); err != nil {
+		return nil, err
+	}
+
+	if p.LogLevel == "" {
+		p.LogLevel = defaultLogLevel
+	}
+	if p.RemotePillarRoots == "" {
+		p.RemotePillarRoots = defaultPillarRoot
+	}
+	if p.RemoteStateTree == "" {
+		p.RemoteStateTree = defaultStateTree
+	}
+	if p.TempConfigDir == "" {
+		p.TempConfigDir = defaultTempDir
+	}
+
+	for _, dir := range []*string{&p.LocalStateTree, &p.LocalPillarRoots, &p.MinionConfig} {
+		if *dir == "" {
+			continue
+		}
+		expanded, err := homedir.Expand(*dir)
+		if err != nil {
+			return nil, fmt.Errorf("Error expanding the path %s: %v", *dir, err)
+		}
+		*dir = expanded
+	}
+
+	return p, nil
+}
+
+// bootstrapSalt installs Salt using the official bootstrap script
+func (p *Provisioner) bootstrapSalt(
+	o terraform.UIOutput,
+	comm communicator.Communicator) error {
+	// First download the bootstrap script
+	err := p.runCommand(o, comm, fmt.Sprintf("curl -L %s -o %s", bootstrapURL, bootstrapScript))
+	if err != nil {
+		return err
+	}
+
+	// Then execute it to install Salt
+	cmd := "sh ./" + bootstrapScript
+	if p.BootstrapArgs != "" {
+		cmd += " " + p.BootstrapArgs
+	}
+	if err := p.runCommand(o, comm, cmd); err != nil {
+		return err
+	}
+
+	// And finally cleanup the bootstrap script again
+	return p.runCommand(o, comm, "rm -f "+bootstrapScript)
+}
+
+// uploadFiles copies the minion config, the state tree and the pillar roots
+// to a temporary directory and then moves them into place
+func (p *Provisioner) uploadFiles(
+	o terraform.UIOutput,
+	comm communicator.Communicator) error {
+	// The temporary directory is created without sudo, so we are allowed to
+	// upload into it
+	if err := p.runCommandNoSudo(o, comm, fmt.Sprintf("mkdir -p %q", p.TempConfigDir)); err != nil {
+		return err
+	}
+
+	if p.MinionConfig != "" {
+		f, err := os.Open(p.MinionConfig)
+		if err != nil {
+			return fmt.Errorf("Error opening minion_config: %s", err)
+		}
+		defer f.Close()
+
+		tmpMinion := path.Join(p.TempConfigDir, "minion")
+		if err := comm.Upload(tmpMinion, f); err != nil {
+			return fmt.Errorf("Uploading minion config failed: %v", err)
+		}
+		if err := p.runCommand(o, comm, fmt.Sprintf("mkdir -p %q", minionConfDir)); err != nil {
+			return err
+		}
+		if err := p.runCommand(o, comm, fmt.Sprintf(
+			"mv %q %q", tmpMinion, path.Join(minionConfDir, "minion"))); err != nil {
+			return err
+		}
+	}
+
+	if err := p.uploadDir(o, comm, p.LocalStateTree, "states", p.RemoteStateTree); err != nil {
+		return err
+	}
+
+	if p.LocalPillarRoots != "" {
+		if err := p.uploadDir(o, comm, p.LocalPillarRoots, "pillar", p.RemotePillarRoots); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uploadDir uploads the contents of a local directory to a temporary
+// directory named name, and then replaces dst with it
+func (p *Provisioner) uploadDir(
+	o terraform.UIOutput,
+	comm communicator.Communicator,
+	src, name, dst string) error {
+	tmpDir := path.Join(p.TempConfigDir, name)
+	if err := p.runCommandNoSudo(o, comm, fmt.Sprintf("mkdir -p %q", tmpDir)); err != nil {
+		return err
+	}
+
+	// A trailing slash uploads only the contents of the directory
+	if err := comm.UploadDir(tmpDir, strings.TrimSuffix(src, "/")+"/"); err != nil {
+		return fmt.Errorf("Uploading %s failed: %v", src, err)
+	}
+
+	if err := p.runCommand(o, comm, fmt.Sprintf("rm -rf %q", dst)); err != nil {
+		return err
+	}
+	if err := p.runCommand(o, comm, fmt.Sprintf("mkdir -p %q", path.Dir(dst))); err != nil {
+		return err
+	}
+	return p.runCommand(o, comm, fmt.Sprintf("mv %q %q", tmpDir, dst))
+}
+
+// runHighstate applies the state tree with a masterless salt-call
+func (p *Provisioner) runHighstate(
+	o terraform.UIOutput,
+	comm communicator.Communicator) error {
+	cmd := "salt-call --local state.highstate --retcode-passthrough"
+
+	// A minion config sets its own file and pillar roots
+	if p.MinionConfig == "" {
+		cmd += fmt.Sprintf(" --file-root=%q", p.RemoteStateTree)
+		if p.LocalPillarRoots != "" {
+			cmd += fmt.Sprintf(" --pillar-root=%q", p.RemotePillarRoots)
+		}
+	}
+
+	cmd += fmt.Sprintf(" -l %s", p.LogLevel)
+	if p.SaltCallArgs != "" {
+		cmd += " " + p.SaltCallArgs
+	}
+
+	return p.runCommand(o, comm, cmd)
+}
+
+// runCommand is used to run already prepared commands
+func (p *Provisioner) runCommand(
+	o terraform.UIOutput,
+	comm communicator.Communicator,
+	command string) error {
+	// Unless prevented, prefix the command with sudo
+	if p.useSudo {
+		command = "sudo " + command
+	}
+
+	return p.runCommandNoSudo(o, comm, command)
+}
+
+// runCommandNoSudo is used to run commands that should run as the
+// connection user
+func (p *Provisioner) runCommandNoSudo(
+	o terraform.UIOutput,
+	comm communicator.Communicator,
+	command string) error {
+	var err error
+
+	outR, outW := io.Pipe()
+	errR, errW := io.Pipe()
+	outDoneCh := make(chan struct{})
+	errDoneCh := make(chan struct{})
+	go p.copyOutput(o, outR, outDoneCh)
+	go p.copyOutput(o, errR, errDoneCh)
+
+	cmd := &remote.Cmd{
+		Command: command,
+		Stdout:  outW,
+		Stderr:  errW,
+	}
+
+	if err := comm.Start(cmd); err != nil {
+		return fmt.Errorf("Error executing command %q: %v", cmd.Command, err)
+	}
+
+	cmd.Wait()
+	if cmd.ExitStatus != 0 {
+		err = fmt.Errorf(
+			"Command %q exited with non-zero exit status: %d", cmd.Command, cmd.ExitStatus)
+	}
+
+	// Wait for output to clean up
+	outW.Close()
+	errW.Close()
+	<-outDoneCh
+	<-errDoneCh
+
+	// If we have an error, return it out now that we've cleaned up
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *Provisioner) copyOutput(o terraform.UIOutput, r io.Reader, doneCh chan<- struct{}) {
+	defer close(doneCh)
+	lr := linereader.New(r)
+	for line := range lr.Ch {
+		o.Output(line)
+	}
+}
+
+// validateDir checks that dir exists and is a directory
+func validateDir(dir string) error {
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
+
+// retryFunc is used to retry a function for a given duration
+func retryFunc(timeout time.Duration, f func() error) error {
+	finish := time.After(timeout)
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		log.Printf("Retryable error: %v", err)
+
+		select {
+		case <-finish:
+			return err
+		case <-time.After(3 * time.Second):
+		}
+	}
+}
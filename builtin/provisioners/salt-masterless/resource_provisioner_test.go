@@ -0,0 +1,297 @@
+package saltmasterless
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/communicator"
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceProvisioner_impl(t *testing.T) {
+	var _ terraform.ResourceProvisioner = new(ResourceProvisioner)
+}
+
+func TestResourceProvider_Validate_good(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"local_state_tree": "salt",
+	})
+	p := new(ResourceProvisioner)
+	warn, errs := p.Validate(c)
+	if len(warn) > 0 {
+		t.Fatalf("Warnings: %v", warn)
+	}
+	if len(errs) > 0 {
+		t.Fatalf("Errors: %v", errs)
+	}
+}
+
+func TestResourceProvider_Validate_bad(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"invalid": "nope",
+	})
+	p := new(ResourceProvisioner)
+	warn, errs := p.Validate(c)
+	if len(warn) > 0 {
+		t.Fatalf("Warnings: %v", warn)
+	}
+	if len(errs) == 0 {
+		t.Fatalf("Should have errors")
+	}
+}
+
+func TestResourceProvider_Validate_missing(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{})
+	p := new(ResourceProvisioner)
+	warn, errs := p.Validate(c)
+	if len(warn) > 0 {
+		t.Fatalf("Warnings: %v", warn)
+	}
+	if len(errs) == 0 {
+		t.Fatalf("Should have errors")
+	}
+}
+
+func testConfig(t *testing.T, c map[string]interface{}) *terraform.ResourceConfig {
+	r, err := config.NewRawConfig(c)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+
+	return terraform.NewResourceConfig(r)
+}
+
+func TestResourceProvider_bootstrapSalt(t *testing.T) {
+	cases := map[string]struct {
+		Config   *terraform.ResourceConfig
+		Commands map[string]bool
+	}{
+		"Sudo": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_state_tree": "salt",
+			}),
+
+			Commands: map[string]bool{
+				"sudo curl -L https://bootstrap.saltstack.com -o install_salt.sh": true,
+				"sudo sh ./install_salt.sh":                                       true,
+				"sudo rm -f install_salt.sh":                                      true,
+			},
+		},
+
+		"NoSudo": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_state_tree": "salt",
+				"prevent_sudo":     true,
+			}),
+
+			Commands: map[string]bool{
+				"curl -L https://bootstrap.saltstack.com -o install_salt.sh": true,
+				"sh ./install_salt.sh":  true,
+				"rm -f install_salt.sh": true,
+			},
+		},
+
+		"BootstrapArgs": {
+			Config: testConfig(t, map[string]interface{}{
+				"bootstrap_args":   "-P git v2016.3.4",
+				"local_state_tree": "salt",
+				"prevent_sudo":     true,
+			}),
+
+			Commands: map[string]bool{
+				"curl -L https://bootstrap.saltstack.com -o install_salt.sh": true,
+				"sh ./install_salt.sh -P git v2016.3.4":                      true,
+				"rm -f install_salt.sh":                                      true,
+			},
+		},
+	}
+
+	r := new(ResourceProvisioner)
+	o := new(terraform.MockUIOutput)
+	c := new(communicator.MockCommunicator)
+
+	for k, tc := range cases {
+		c.Commands = tc.Commands
+
+		p, err := r.decodeConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		p.useSudo = !p.PreventSudo
+
+		err = p.bootstrapSalt(o, c)
+		if err != nil {
+			t.Fatalf("Test %q failed: %v", k, err)
+		}
+	}
+}
+
+func TestResourceProvider_uploadFiles(t *testing.T) {
+	cases := map[string]struct {
+		Config     *terraform.ResourceConfig
+		Commands   map[string]bool
+		Uploads    map[string]string
+		UploadDirs map[string]string
+	}{
+		"StateTree": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_state_tree": "salt",
+			}),
+
+			Commands: map[string]bool{
+				`mkdir -p "/tmp/salt"`:                   true,
+				`mkdir -p "/tmp/salt/states"`:            true,
+				`sudo rm -rf "/srv/salt"`:                true,
+				`sudo mkdir -p "/srv"`:                   true,
+				`sudo mv "/tmp/salt/states" "/srv/salt"`: true,
+			},
+
+			UploadDirs: map[string]string{
+				"salt/": "/tmp/salt/states",
+			},
+		},
+
+		"PillarRoots": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_pillar_roots":  "pillar/",
+				"local_state_tree":    "salt",
+				"prevent_sudo":        true,
+				"remote_pillar_roots": "/opt/pillar",
+				"temp_config_dir":     "/tmp/upload",
+			}),
+
+			Commands: map[string]bool{
+				`mkdir -p "/tmp/upload"`:                true,
+				`mkdir -p "/tmp/upload/states"`:         true,
+				`rm -rf "/srv/salt"`:                    true,
+				`mkdir -p "/srv"`:                       true,
+				`mv "/tmp/upload/states" "/srv/salt"`:   true,
+				`mkdir -p "/tmp/upload/pillar"`:         true,
+				`rm -rf "/opt/pillar"`:                  true,
+				`mkdir -p "/opt"`:                       true,
+				`mv "/tmp/upload/pillar" "/opt/pillar"`: true,
+			},
+
+			UploadDirs: map[string]string{
+				"salt/":   "/tmp/upload/states",
+				"pillar/": "/tmp/upload/pillar",
+			},
+		},
+
+		"MinionConfig": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_state_tree": "salt",
+				"minion_config":    "test-fixtures/minion",
+				"prevent_sudo":     true,
+			}),
+
+			Commands: map[string]bool{
+				`mkdir -p "/tmp/salt"`:                     true,
+				`mkdir -p "/etc/salt"`:                     true,
+				`mv "/tmp/salt/minion" "/etc/salt/minion"`: true,
+				`mkdir -p "/tmp/salt/states"`:              true,
+				`rm -rf "/srv/salt"`:                       true,
+				`mkdir -p "/srv"`:                          true,
+				`mv "/tmp/salt/states" "/srv/salt"`:        true,
+			},
+
+			Uploads: map[string]string{
+				"/tmp/salt/minion": "file_client: local",
+			},
+
+			UploadDirs: map[string]string{
+				"salt/": "/tmp/salt/states",
+			},
+		},
+	}
+
+	r := new(ResourceProvisioner)
+	o := new(terraform.MockUIOutput)
+	c := new(communicator.MockCommunicator)
+
+	for k, tc := range cases {
+		c.Commands = tc.Commands
+		c.Uploads = tc.Uploads
+		c.UploadDirs = tc.UploadDirs
+
+		p, err := r.decodeConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		p.useSudo = !p.PreventSudo
+
+		err = p.uploadFiles(o, c)
+		if err != nil {
+			t.Fatalf("Test %q failed: %v", k, err)
+		}
+	}
+}
+
+func TestResourceProvider_runHighstate(t *testing.T) {
+	cases := map[string]struct {
+		Config   *terraform.ResourceConfig
+		Commands map[string]bool
+	}{
+		"Sudo": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_state_tree": "salt",
+			}),
+
+			Commands: map[string]bool{
+				`sudo salt-call --local state.highstate --retcode-passthrough ` +
+					`--file-root="/srv/salt" -l info`: true,
+			},
+		},
+
+		"PillarRoots": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_pillar_roots": "pillar",
+				"local_state_tree":   "salt",
+				"log_level":          "debug",
+				"prevent_sudo":       true,
+			}),
+
+			Commands: map[string]bool{
+				`salt-call --local state.highstate --retcode-passthrough ` +
+					`--file-root="/srv/salt" --pillar-root="/srv/pillar" -l debug`: true,
+			},
+		},
+
+		"MinionConfig": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_pillar_roots": "pillar",
+				"local_state_tree":   "salt",
+				"minion_config":      "test-fixtures/minion",
+				"prevent_sudo":       true,
+				"salt_call_args":     "--state-output=terse",
+			}),
+
+			Commands: map[string]bool{
+				`salt-call --local state.highstate --retcode-passthrough ` +
+					`-l info --state-output=terse`: true,
+			},
+		},
+	}
+
+	r := new(ResourceProvisioner)
+	o := new(terraform.MockUIOutput)
+	c := new(communicator.MockCommunicator)
+
+	for k, tc := range cases {
+		c.Commands = tc.Commands
+
+		p, err := r.decodeConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		p.useSudo = !p.PreventSudo
+
+		err = p.runHighstate(o, c)
+		if err != nil {
+			t.Fatalf("Test %q failed: %v", k, err)
+		}
+	}
+}
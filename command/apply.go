@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -27,7 +28,7 @@ type ApplyCommand struct {
 }
 
 func (c *ApplyCommand) Run(args []string) int {
-	var destroyForce, refresh bool
+	var destroyForce, refresh, resume bool
 	args = c.Meta.process(args, true)
 
 	cmdName := "apply"
@@ -38,6 +39,9 @@ func (c *ApplyCommand) Run(args []string) int {
 	cmdFlags := c.Meta.flagSet(cmdName)
 	if c.Destroy {
 		cmdFlags.BoolVar(&destroyForce, "force", false, "force")
+	} else {
+		cmdFlags.Var((*FlagStringSlice)(&c.Meta.replace), "replace", "resource to replace")
+		cmdFlags.BoolVar(&resume, "resume", false, "resume")
 	}
 	cmdFlags.BoolVar(&refresh, "refresh", true, "refresh")
 	cmdFlags.IntVar(
@@ -45,10 +49,24 @@ func (c *ApplyCommand) Run(args []string) int {
 	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
 	cmdFlags.StringVar(&c.Meta.stateOutPath, "state-out", "", "path")
 	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
+	cmdFlags.BoolVar(&c.Meta.json, "json", false, "json")
+	cmdFlags.StringVar(&c.Meta.policyDir, "policy-dir", "", "path")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
 	}
+	c.Meta.setupJSON()
+
+	if err := c.Meta.validateReplace(); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	if c.Destroy && c.Meta.json && !destroyForce {
+		c.Ui.Error("The -json flag of destroy requires -force, since the\n" +
+			"confirmation can't be asked for.")
+		return 1
+	}
 
 	pwd, err := os.Getwd()
 	if err != nil {
@@ -70,10 +88,27 @@ func (c *ApplyCommand) Run(args []string) int {
 		maybeInit = false
 	}
 
+	checkpointPath := filepath.Join(c.DataDir(), DefaultApplyCheckpointFilename)
+	if resume {
+		if len(args) > 0 || len(c.Meta.targets) > 0 || len(c.Meta.replace) > 0 {
+			c.Ui.Error("The -resume flag can't be used with a path, -target or -replace.")
+			cmdFlags.Usage()
+			return 1
+		}
+		if err := c.checkApplyCheckpoint(checkpointPath); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		// The checkpoint is a plan file, so it's applied like one
+		configPath = checkpointPath
+	}
+
 	// Prepare the extra hooks to count resources
 	countHook := new(CountHook)
 	stateHook := new(StateHook)
-	c.Meta.extraHooks = []terraform.Hook{countHook, stateHook}
+	checkpointHook := new(CheckpointHook)
+	c.Meta.extraHooks = []terraform.Hook{countHook, stateHook, checkpointHook}
 
 	if !c.Destroy && maybeInit {
 		// Do a detect to determine if we need to do an init + apply.
@@ -95,13 +130,17 @@ func (c *ApplyCommand) Run(args []string) int {
 		}
 	}
 
+	defer c.Meta.closeLogs()
+
 	// Build the context based on the arguments given
 	ctx, planned, err := c.Context(contextOpts{
 		Destroy:     c.Destroy,
 		Path:        configPath,
 		StatePath:   c.Meta.statePath,
 		Parallelism: c.Meta.parallelism,
+		LockReason:  "apply",
 	})
+	defer c.Meta.unlockState()
 	if err != nil {
 		c.Ui.Error(err.Error())
 		return 1
@@ -111,6 +150,12 @@ func (c *ApplyCommand) Run(args []string) int {
 			"Destroy can't be called with a plan file."))
 		return 1
 	}
+	if len(c.Meta.replace) > 0 && planned {
+		c.Ui.Error(
+			"The -replace flag can't be used with a plan file. Pass it to\n" +
+				"\"terraform plan\" when creating the plan instead.")
+		return 1
+	}
 	if !destroyForce && c.Destroy {
 		// Default destroy message
 		desc := "Terraform will delete all your managed infrastructure.\n" +
@@ -167,6 +212,18 @@ func (c *ApplyCommand) Run(args []string) int {
 				"Error creating plan: %s", err))
 			return 1
 		}
+	} else if resume {
+		// Plan only the changes the checkpoint targets. The state already
+		// holds everything that was applied, so there is no refresh.
+		if _, err := ctx.Plan(); err != nil {
+			c.Ui.Error(fmt.Sprintf(
+				"Error creating plan: %s", err))
+			return 1
+		}
+	}
+
+	if !c.enforcePolicies(ctx.Diff()) {
+		return 1
 	}
 
 	// Setup the state hook for continuous state updates
@@ -220,6 +277,22 @@ func (c *ApplyCommand) Run(args []string) int {
 		}
 	}
 
+	if applyErr != nil && state != nil && !c.Destroy {
+		if err := writeApplyCheckpoint(checkpointPath, ctx.ResumePlan(checkpointHook.Failed)); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error saving apply checkpoint: %s", err))
+		} else {
+			c.Ui.Output(fmt.Sprintf(
+				"The changes that weren't applied were saved to %s.\n"+
+					"After fixing the error, run \"terraform apply -resume\" to apply\n"+
+					"only those changes, without refreshing and planning everything again.\n",
+				checkpointPath))
+		}
+	} else if applyErr == nil {
+		if err := os.Remove(checkpointPath); err != nil && !os.IsNotExist(err) {
+			c.Ui.Warn(fmt.Sprintf("Error removing apply checkpoint: %s", err))
+		}
+	}
+
 	if applyErr != nil {
 		c.Ui.Error(fmt.Sprintf(
 			"Error applying plan:\n\n"+
@@ -232,6 +305,21 @@ func (c *ApplyCommand) Run(args []string) int {
 		return 1
 	}
 
+	add, change, remove := countHook.Added, countHook.Changed, countHook.Removed
+	c.outputJSON(&jsonEvent{
+		Type:      "change_summary",
+		Operation: cmdName,
+		Add:       &add,
+		Change:    &change,
+		Remove:    &remove,
+	})
+	if !c.Destroy {
+		c.outputJSON(&jsonEvent{
+			Type:    "outputs",
+			Outputs: jsonOutputs(state, ctx.Module().Config().Outputs),
+		})
+	}
+
 	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
 		"[reset][bold][green]\n"+
 			"Apply complete! Resources: %d added, %d changed, %d destroyed.",
@@ -251,7 +339,7 @@ func (c *ApplyCommand) Run(args []string) int {
 	}
 
 	if !c.Destroy {
-		if outputs := outputsAsString(state, ctx.Module().Config().Outputs, true); outputs != "" {
+		if outputs := outputsAsString(state, terraform.RootModulePath, ctx.Module().Config().Outputs, true); outputs != "" {
 			c.Ui.Output(c.Colorize().Color(outputs))
 		}
 	}
@@ -259,6 +347,53 @@ func (c *ApplyCommand) Run(args []string) int {
 	return 0
 }
 
+// checkApplyCheckpoint makes sure the checkpoint of a failed apply exists
+// and that the state hasn't changed since it was saved.
+func (c *ApplyCommand) checkApplyCheckpoint(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf(
+				"There is no failed apply to resume. A checkpoint is only saved\n" +
+					"when an apply fails part way.")
+		}
+		return fmt.Errorf("Error reading apply checkpoint: %s", err)
+	}
+	plan, err := terraform.ReadPlan(f)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("Error reading apply checkpoint: %s", err)
+	}
+
+	state, err := c.State()
+	if err != nil {
+		return fmt.Errorf("Error reading state: %s", err)
+	}
+	if current := state.State(); current != nil && plan.State != nil &&
+		current.Serial != plan.State.Serial {
+		return fmt.Errorf(
+			"The state changed since the failed apply, so it can't be resumed.\n" +
+				"Run \"terraform plan\" and \"terraform apply\" as usual instead.")
+	}
+
+	return nil
+}
+
+// writeApplyCheckpoint saves the plan to resume a failed apply to path
+func writeApplyCheckpoint(path string, plan *terraform.Plan) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return terraform.WritePlan(plan, f)
+}
+
 func (c *ApplyCommand) Help() string {
 	if c.Destroy {
 		return c.helpDestroy()
@@ -300,15 +435,32 @@ Options:
                          ".backup" extension. Set to "-" to disable backup.
 
   -input=true            Ask for input for variables if not directly set.
+                         If false, missing required variables are an error.
+
+  -json                  Write the progress of the apply as JSON events, one
+                         per line, instead of human readable output.
 
   -no-color              If specified, output won't contain any color.
 
   -parallelism=n         Limit the number of concurrent operations.
                          Defaults to 10.
 
+  -policy-dir=path       Check the plan against the policies in this
+                         directory, and don't apply it if any policy
+                         denies it.
+
   -refresh=true          Update state prior to checking for differences. This
                          has no effect if a plan file is given to apply.
 
+  -replace=resource      Resource to replace. It will be destroyed and
+                         recreated, even if its configuration didn't change.
+                         This flag can be used multiple times, and can't be
+                         used with a plan file.
+
+  -resume                Resume the last apply that failed part way. Only the
+                         changes that weren't applied are planned and applied,
+                         without refreshing the state.
+
   -state=path            Path to read and save state (unless state-out
                          is specified). Defaults to "terraform.tfstate".
 
@@ -324,8 +476,8 @@ Options:
                          flag can be set multiple times.
 
   -var-file=foo          Set variables in the Terraform configuration from
-                         a file. If "terraform.tfvars" is present, it will be
-                         automatically loaded if this flag is not specified.
+                         a file. If "terraform.tfvars" or any ".auto.tfvars"
+                         files are present, they will be automatically loaded.
 
 
 `
@@ -346,11 +498,19 @@ Options:
 
   -force                 Don't ask for input for destroy confirmation.
 
+  -json                  Write the progress of the destroy as JSON events, one
+                         per line, instead of human readable output. This
+                         requires -force.
+
   -no-color              If specified, output won't contain any color.
 
   -parallelism=n         Limit the number of concurrent operations.
                          Defaults to 10.
 
+  -policy-dir=path       Check the plan against the policies in this
+                         directory, and don't destroy anything if any
+                         policy denies it.
+
   -refresh=true          Update state prior to checking for differences. This
                          has no effect if a plan file is given to apply.
 
@@ -369,20 +529,25 @@ Options:
                          flag can be set multiple times.
 
   -var-file=foo          Set variables in the Terraform configuration from
-                         a file. If "terraform.tfvars" is present, it will be
-                         automatically loaded if this flag is not specified.
+                         a file. If "terraform.tfvars" or any ".auto.tfvars"
+                         files are present, they will be automatically loaded.
 
 
 `
 	return strings.TrimSpace(helpText)
 }
 
-func outputsAsString(state *terraform.State, schema []*config.Output, includeHeader bool) string {
+func outputsAsString(state *terraform.State, modPath []string, schema []*config.Output, includeHeader bool) string {
 	if state == nil {
 		return ""
 	}
 
-	outputs := state.RootModule().Outputs
+	ms := state.ModuleByPath(modPath)
+	if ms == nil {
+		return ""
+	}
+
+	outputs := ms.Outputs
 	outputBuf := new(bytes.Buffer)
 	if len(outputs) > 0 {
 		schemaMap := make(map[string]*config.Output)
@@ -59,6 +59,122 @@ func TestApply(t *testing.T) {
 	}
 }
 
+func TestApply_json(t *testing.T) {
+	statePath := testTempFile(t)
+
+	p := testProvider()
+	p.ApplyReturn = &terraform.InstanceState{ID: "foo"}
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-json",
+		"-state", statePath,
+		testFixturePath("apply"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+
+	var types []string
+	for _, e := range testJSONEvents(t, ui.OutputWriter.String()) {
+		switch e.Type {
+		case "apply_start":
+			if e.Resource != "test_instance.foo" || e.Action != "create" {
+				t.Fatalf("bad: %#v", e)
+			}
+		case "apply_complete":
+			if e.Resource != "test_instance.foo" || e.ID != "foo" {
+				t.Fatalf("bad: %#v", e)
+			}
+		case "change_summary":
+			if e.Operation != "apply" || *e.Add != 1 {
+				t.Fatalf("bad: %#v", e)
+			}
+		case "log":
+			continue
+		}
+		types = append(types, e.Type)
+	}
+
+	expected := []string{"apply_start", "apply_complete", "change_summary", "outputs"}
+	if !reflect.DeepEqual(types, expected) {
+		t.Fatalf("bad: %#v", types)
+	}
+}
+
+func TestApply_policyDir(t *testing.T) {
+	statePath := testTempFile(t)
+
+	// The create is allowed by the fixture policies
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-policy-dir", testFixturePath("policies"),
+		testFixturePath("apply"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if !p.ApplyCalled {
+		t.Fatal("apply should be called")
+	}
+}
+
+func TestApply_policyDirDenied(t *testing.T) {
+	statePath := testTempFile(t)
+
+	policyDir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(policyDir)
+
+	policy := "#!/bin/sh\necho 'no instances allowed'\nexit 1\n"
+	err = ioutil.WriteFile(filepath.Join(policyDir, "deny"), []byte(policy), 0755)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-policy-dir", policyDir,
+		testFixturePath("apply"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if p.ApplyCalled {
+		t.Fatal("apply should not be called")
+	}
+
+	if errStr := ui.ErrorWriter.String(); !strings.Contains(errStr, "deny: no instances allowed") {
+		t.Fatalf("bad: %s", errStr)
+	}
+}
+
 func TestApply_parallelism(t *testing.T) {
 	provider := testProvider()
 	statePath := testTempFile(t)
@@ -189,6 +305,11 @@ func TestApply_defaultState(t *testing.T) {
 }
 
 func TestApply_error(t *testing.T) {
+	// The checkpoint of the failed apply is saved in the data directory
+	// of the working directory.
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
 	statePath := testTempFile(t)
 
 	p := testProvider()
@@ -257,6 +378,145 @@ func TestApply_error(t *testing.T) {
 	if len(state.RootModule().Resources) == 0 {
 		t.Fatal("no resources in state")
 	}
+
+	checkpointPath := filepath.Join(DefaultDataDir, DefaultApplyCheckpointFilename)
+	if _, err := os.Stat(checkpointPath); err != nil {
+		t.Fatalf("checkpoint should be saved: %s", err)
+	}
+}
+
+func TestApply_resume(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	statePath := testTempFile(t)
+
+	p := testProvider()
+	p.DiffFn = func(
+		*terraform.InstanceInfo,
+		*terraform.InstanceState,
+		*terraform.ResourceConfig) (*terraform.InstanceDiff, error) {
+		return &terraform.InstanceDiff{
+			Attributes: map[string]*terraform.ResourceAttrDiff{
+				"ami": &terraform.ResourceAttrDiff{
+					New: "bar",
+				},
+			},
+		}, nil
+	}
+
+	// The first apply fails for test_instance.bar
+	var lock sync.Mutex
+	var applied []string
+	fail := true
+	p.ApplyFn = func(
+		info *terraform.InstanceInfo,
+		s *terraform.InstanceState,
+		d *terraform.InstanceDiff) (*terraform.InstanceState, error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if fail && info.Id == "test_instance.bar" {
+			return nil, fmt.Errorf("error")
+		}
+
+		applied = append(applied, info.Id)
+		return &terraform.InstanceState{ID: "foo"}, nil
+	}
+
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		testFixturePath("apply-error"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if !reflect.DeepEqual(applied, []string{"test_instance.foo"}) {
+		t.Fatalf("bad: %#v", applied)
+	}
+
+	// Resuming only applies what failed
+	fail = false
+	applied = nil
+	p.RefreshCalled = false
+
+	ui = new(cli.MockUi)
+	c = &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args = []string{
+		"-resume",
+		"-state", statePath,
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if !reflect.DeepEqual(applied, []string{"test_instance.bar"}) {
+		t.Fatalf("bad: %#v", applied)
+	}
+	if p.RefreshCalled {
+		t.Fatal("resume shouldn't refresh")
+	}
+
+	f, err := os.Open(statePath)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer f.Close()
+
+	state, err := terraform.ReadState(f)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(state.RootModule().Resources) != 2 {
+		t.Fatalf("bad: %s", state)
+	}
+
+	// The checkpoint is removed once the apply succeeded
+	checkpointPath := filepath.Join(DefaultDataDir, DefaultApplyCheckpointFilename)
+	if _, err := os.Stat(checkpointPath); !os.IsNotExist(err) {
+		t.Fatalf("checkpoint should be removed: %v", err)
+	}
+}
+
+func TestApply_resumeNoCheckpoint(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-resume",
+		"-state", testTempFile(t),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "no failed apply to resume") {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+	if p.ApplyCalled {
+		t.Fatal("apply shouldn't be called")
+	}
 }
 
 func TestApply_init(t *testing.T) {
@@ -19,6 +19,10 @@ const DefaultStateFilename = "terraform.tfstate"
 // DefaultVarsFilename is the default filename used for vars
 const DefaultVarsFilename = "terraform.tfvars"
 
+// DefaultApplyCheckpointFilename is the file in the data directory that
+// the remaining changes of a failed apply are saved to.
+const DefaultApplyCheckpointFilename = "apply-checkpoint.tfplan"
+
 // DefaultBackupExtension is added to the state file to form the path
 const DefaultBackupExtension = ".backup"
 
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -9,6 +11,7 @@ import (
 
 	"github.com/hashicorp/go-getter"
 	"github.com/hashicorp/terraform/config/module"
+	"github.com/hashicorp/terraform/state"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -207,6 +210,47 @@ func testStateOutput(t *testing.T, path string, expected string) {
 	}
 }
 
+// testLockingState is an in-memory state that can be locked. Locking
+// fails if it's already locked, as it would for a state that is locked by
+// another process.
+type testLockingState struct {
+	state.InmemState
+
+	reason string
+}
+
+func (s *testLockingState) Lock(reason string) error {
+	if s.reason != "" {
+		return fmt.Errorf("state is locked for %s", s.reason)
+	}
+
+	s.reason = reason
+	return nil
+}
+
+func (s *testLockingState) Unlock() error {
+	s.reason = ""
+	return nil
+}
+
+// testJSONEvents parses the output of a command run with -json. Every
+// line has to be a JSON event.
+func testJSONEvents(t *testing.T, output string) []*jsonEvent {
+	var result []*jsonEvent
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var e jsonEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("bad event %q: %s", line, err)
+		}
+		if e.Type == "" || e.Timestamp == "" {
+			t.Fatalf("bad event %q", line)
+		}
+		result = append(result, &e)
+	}
+
+	return result
+}
+
 func testProvider() *terraform.MockResourceProvider {
 	p := new(terraform.MockResourceProvider)
 	p.DiffReturn = &terraform.InstanceDiff{}
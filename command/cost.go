@@ -0,0 +1,289 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/colorstring"
+)
+
+// costFunc returns the estimated monthly cost of a resource from its
+// attributes. If the cost can't be estimated, for example because the
+// price of an instance type isn't known, ok is false.
+type costFunc func(attrs map[string]string) (cost float64, ok bool)
+
+// costFuncs are the resource types that costs can be estimated for
+var costFuncs = map[string]costFunc{
+	"aws_instance":            costAWSInstance,
+	"aws_ebs_volume":          costAWSEBSVolume,
+	"aws_db_instance":         costAWSDBInstance,
+	"aws_elasticache_cluster": costAWSElastiCacheCluster,
+}
+
+// costEstimate is the estimated change of the monthly cost of a single
+// resource in a plan.
+type costEstimate struct {
+	Resource string
+	Action   string
+
+	// Old and New are the monthly costs before and after the plan is
+	// applied.
+	Old, New float64
+
+	// Unknown is true if the cost of the resource after the plan is
+	// applied can't be estimated, for example because it depends on
+	// a computed value.
+	Unknown bool
+}
+
+// Delta is the change of the monthly cost
+func (e *costEstimate) Delta() float64 {
+	return e.New - e.Old
+}
+
+// estimateCosts estimates the change of the monthly cost of every
+// resource in the plan that costs can be estimated for, ordered by their
+// address.
+func estimateCosts(plan *terraform.Plan) []*costEstimate {
+	var result []*costEstimate
+	if plan.Diff == nil {
+		return result
+	}
+
+	for _, m := range plan.Diff.Modules {
+		prefix := ""
+		for _, name := range m.Path[1:] {
+			prefix += "module." + name + "."
+		}
+
+		for name, d := range m.Resources {
+			action := jsonDiffAction(d)
+			if action == "" {
+				continue
+			}
+
+			key, err := terraform.ParseResourceStateKey(name)
+			if err != nil {
+				continue
+			}
+			f, ok := costFuncs[key.Type]
+			if !ok {
+				continue
+			}
+
+			var old map[string]string
+			if plan.State != nil {
+				if ms := plan.State.ModuleByPath(m.Path); ms != nil {
+					if rs, ok := ms.Resources[name]; ok && rs.Primary != nil {
+						old = rs.Primary.Attributes
+					}
+				}
+			}
+
+			e := &costEstimate{Resource: prefix + name, Action: action}
+			if old != nil && action != "create" {
+				if cost, ok := f(old); ok {
+					e.Old = cost
+				}
+			}
+			if action != "destroy" {
+				cost, ok := f(costNewAttributes(old, d))
+				e.New = cost
+				e.Unknown = !ok
+			}
+
+			result = append(result, e)
+		}
+	}
+
+	sort.Sort(costEstimatesByResource(result))
+	return result
+}
+
+// costNewAttributes returns the attributes of a resource after the diff
+// is applied to its attributes in the state. Computed attributes have the
+// unknown value, so that estimates depending on them fail.
+func costNewAttributes(old map[string]string, d *terraform.InstanceDiff) map[string]string {
+	result := make(map[string]string, len(old)+len(d.Attributes))
+	for k, v := range old {
+		result[k] = v
+	}
+
+	for k, attr := range d.Attributes {
+		switch {
+		case attr.NewRemoved:
+			delete(result, k)
+		case attr.NewComputed:
+			result[k] = config.UnknownVariableValue
+		default:
+			result[k] = attr.New
+		}
+	}
+
+	return result
+}
+
+type costEstimatesByResource []*costEstimate
+
+func (s costEstimatesByResource) Len() int           { return len(s) }
+func (s costEstimatesByResource) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s costEstimatesByResource) Less(i, j int) bool { return s[i].Resource < s[j].Resource }
+
+// formatCostEstimates formats the estimates for the plan output
+func formatCostEstimates(estimates []*costEstimate, color *colorstring.Colorize) string {
+	var buf bytes.Buffer
+	var total float64
+	var unknown bool
+	for _, e := range estimates {
+		total += e.Delta()
+		unknown = unknown || e.Unknown
+
+		delta := formatCostDelta(e.Delta()) + "/month"
+		if e.Unknown {
+			delta = "unknown"
+		}
+
+		colorCode := "[reset]"
+		switch {
+		case e.Unknown:
+			colorCode = "[yellow]"
+		case e.Delta() > 0:
+			colorCode = "[red]"
+		case e.Delta() < 0:
+			colorCode = "[green]"
+		}
+
+		buf.WriteString(fmt.Sprintf(
+			"  %s%s (%s): %s[reset]\n", colorCode, e.Resource, e.Action, delta))
+	}
+
+	summary := formatCostDelta(total)
+	if unknown {
+		summary += ", plus unknown changes"
+	}
+
+	return color.Color(fmt.Sprintf(
+		"[reset][bold]Estimated monthly cost change:[reset] %s\n\n%s",
+		summary, buf.String()))
+}
+
+// formatCostDelta formats a change of a cost as "+$1,234.56"
+func formatCostDelta(delta float64) string {
+	sign := "+"
+	if delta < 0 {
+		sign = "-"
+	}
+
+	cents := int64(math.Abs(delta)*100 + 0.5)
+	dollars := strconv.FormatInt(cents/100, 10)
+	for i := len(dollars) - 3; i > 0; i -= 3 {
+		dollars = dollars[:i] + "," + dollars[i:]
+	}
+
+	return fmt.Sprintf("%s$%s.%02d", sign, dollars, cents%100)
+}
+
+// costAttrString returns the value of an attribute, or the default if it
+// isn't set or computed. This is used for attributes where AWS picks the
+// default if they aren't set.
+func costAttrString(attrs map[string]string, k string, def string) string {
+	v := attrs[k]
+	if v == "" || v == config.UnknownVariableValue {
+		return def
+	}
+
+	return v
+}
+
+// costAttrInt returns the integer value of an attribute, or the default
+// if it isn't set.
+func costAttrInt(attrs map[string]string, k string, def int) (int, bool) {
+	v, ok := attrs[k]
+	if !ok || v == "" {
+		return def, true
+	}
+
+	n, err := strconv.Atoi(v)
+	return n, err == nil
+}
+
+func costAWSInstance(attrs map[string]string) (float64, bool) {
+	hourly, ok := costEC2Hourly[attrs["instance_type"]]
+	return hourly * costHoursPerMonth, ok
+}
+
+func costAWSEBSVolume(attrs map[string]string) (float64, bool) {
+	volumeType := costAttrString(attrs, "type", "standard")
+	price, ok := costEBSMonthly[volumeType]
+	if !ok {
+		return 0, false
+	}
+
+	size, ok := costAttrInt(attrs, "size", 0)
+	if !ok {
+		return 0, false
+	}
+	cost := price * float64(size)
+
+	if volumeType == "io1" {
+		iops, ok := costAttrInt(attrs, "iops", 0)
+		if !ok {
+			return 0, false
+		}
+		cost += costEBSIOPSMonthly * float64(iops)
+	}
+
+	return cost, true
+}
+
+func costAWSDBInstance(attrs map[string]string) (float64, bool) {
+	hourly, ok := costRDSHourly[attrs["instance_class"]]
+	if !ok {
+		return 0, false
+	}
+
+	storageType := costAttrString(attrs, "storage_type", "standard")
+	price, ok := costRDSStorageMonthly[storageType]
+	if !ok {
+		return 0, false
+	}
+	storage, ok := costAttrInt(attrs, "allocated_storage", 0)
+	if !ok {
+		return 0, false
+	}
+
+	cost := hourly*costHoursPerMonth + price*float64(storage)
+	if storageType == "io1" {
+		iops, ok := costAttrInt(attrs, "iops", 0)
+		if !ok {
+			return 0, false
+		}
+		cost += costRDSIOPSMonthly * float64(iops)
+	}
+
+	// Multi-AZ instances have a standby with its own storage
+	if attrs["multi_az"] == "true" {
+		cost *= 2
+	}
+
+	return cost, true
+}
+
+func costAWSElastiCacheCluster(attrs map[string]string) (float64, bool) {
+	hourly, ok := costElastiCacheHourly[attrs["node_type"]]
+	if !ok {
+		return 0, false
+	}
+
+	nodes, ok := costAttrInt(attrs, "num_cache_nodes", 1)
+	if !ok {
+		return 0, false
+	}
+
+	return hourly * costHoursPerMonth * float64(nodes), true
+}
@@ -0,0 +1,149 @@
+package command
+
+// The prices used to estimate costs are the on-demand prices in
+// us-east-1 in US dollars. They're only meant to show the magnitude of
+// a change, so they aren't updated for every price change and don't
+// consider the region, reserved instances or data transfer.
+
+// costHoursPerMonth is the number of hours that hourly prices are
+// multiplied with to get a monthly price.
+const costHoursPerMonth = 730
+
+// costEC2Hourly is the hourly price of a Linux EC2 instance by type
+var costEC2Hourly = map[string]float64{
+	"t1.micro": 0.02,
+	"m1.small": 0.044,
+
+	"t2.nano":    0.0059,
+	"t2.micro":   0.012,
+	"t2.small":   0.023,
+	"t2.medium":  0.047,
+	"t2.large":   0.094,
+	"t2.xlarge":  0.188,
+	"t2.2xlarge": 0.376,
+
+	"m3.medium":  0.067,
+	"m3.large":   0.133,
+	"m3.xlarge":  0.266,
+	"m3.2xlarge": 0.532,
+
+	"m4.large":    0.108,
+	"m4.xlarge":   0.215,
+	"m4.2xlarge":  0.431,
+	"m4.4xlarge":  0.862,
+	"m4.10xlarge": 2.155,
+	"m4.16xlarge": 3.447,
+
+	"c3.large":   0.105,
+	"c3.xlarge":  0.21,
+	"c3.2xlarge": 0.42,
+	"c3.4xlarge": 0.84,
+	"c3.8xlarge": 1.68,
+
+	"c4.large":   0.1,
+	"c4.xlarge":  0.199,
+	"c4.2xlarge": 0.398,
+	"c4.4xlarge": 0.796,
+	"c4.8xlarge": 1.591,
+
+	"r3.large":   0.166,
+	"r3.xlarge":  0.333,
+	"r3.2xlarge": 0.665,
+	"r3.4xlarge": 1.33,
+	"r3.8xlarge": 2.66,
+
+	"r4.large":    0.133,
+	"r4.xlarge":   0.266,
+	"r4.2xlarge":  0.532,
+	"r4.4xlarge":  1.064,
+	"r4.8xlarge":  2.128,
+	"r4.16xlarge": 4.256,
+
+	"i2.xlarge":  0.853,
+	"i2.2xlarge": 1.705,
+	"i2.4xlarge": 3.41,
+	"i2.8xlarge": 6.82,
+
+	"x1.16xlarge": 6.669,
+	"x1.32xlarge": 13.338,
+
+	"p2.xlarge":   0.9,
+	"p2.8xlarge":  7.2,
+	"p2.16xlarge": 14.4,
+}
+
+// costEBSMonthly is the monthly price of an EBS volume per GB by type
+var costEBSMonthly = map[string]float64{
+	"standard": 0.05,
+	"gp2":      0.1,
+	"io1":      0.125,
+	"st1":      0.045,
+	"sc1":      0.025,
+}
+
+// costEBSIOPSMonthly is the monthly price of a provisioned IOPS of an
+// io1 EBS volume.
+const costEBSIOPSMonthly = 0.065
+
+// costRDSHourly is the hourly price of a single-AZ MySQL RDS instance by
+// instance class. Multi-AZ instances cost twice as much.
+var costRDSHourly = map[string]float64{
+	"db.t2.micro":  0.017,
+	"db.t2.small":  0.034,
+	"db.t2.medium": 0.068,
+	"db.t2.large":  0.136,
+
+	"db.m3.medium":  0.09,
+	"db.m3.large":   0.185,
+	"db.m3.xlarge":  0.37,
+	"db.m3.2xlarge": 0.74,
+
+	"db.m4.large":    0.175,
+	"db.m4.xlarge":   0.35,
+	"db.m4.2xlarge":  0.7,
+	"db.m4.4xlarge":  1.401,
+	"db.m4.10xlarge": 3.502,
+
+	"db.r3.large":   0.24,
+	"db.r3.xlarge":  0.475,
+	"db.r3.2xlarge": 0.945,
+	"db.r3.4xlarge": 1.89,
+	"db.r3.8xlarge": 3.78,
+}
+
+// costRDSStorageMonthly is the monthly price of RDS storage per GB by
+// storage type.
+var costRDSStorageMonthly = map[string]float64{
+	"standard": 0.1,
+	"gp2":      0.115,
+	"io1":      0.125,
+}
+
+// costRDSIOPSMonthly is the monthly price of a provisioned IOPS of RDS
+// io1 storage.
+const costRDSIOPSMonthly = 0.1
+
+// costElastiCacheHourly is the hourly price of an ElastiCache node by
+// node type.
+var costElastiCacheHourly = map[string]float64{
+	"cache.t2.micro":  0.017,
+	"cache.t2.small":  0.034,
+	"cache.t2.medium": 0.068,
+
+	"cache.m3.medium":  0.09,
+	"cache.m3.large":   0.182,
+	"cache.m3.xlarge":  0.364,
+	"cache.m3.2xlarge": 0.728,
+
+	"cache.m4.large":    0.156,
+	"cache.m4.xlarge":   0.311,
+	"cache.m4.2xlarge":  0.623,
+	"cache.m4.4xlarge":  1.245,
+	"cache.m4.10xlarge": 3.112,
+
+	"cache.r3.large":   0.228,
+	"cache.r3.xlarge":  0.455,
+	"cache.r3.2xlarge": 0.91,
+	"cache.r3.4xlarge": 1.82,
+	"cache.r3.8xlarge": 3.64,
+}
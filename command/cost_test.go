@@ -0,0 +1,158 @@
+package command
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/colorstring"
+)
+
+func TestEstimateCosts(t *testing.T) {
+	plan := &terraform.Plan{
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						// Created
+						"aws_instance.web": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"instance_type": &terraform.ResourceAttrDiff{
+									New:         "m4.10xlarge",
+									RequiresNew: true,
+								},
+							},
+						},
+
+						// Resized in place
+						"aws_db_instance.db": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"instance_class": &terraform.ResourceAttrDiff{
+									Old: "db.m4.large",
+									New: "db.t2.micro",
+								},
+							},
+						},
+
+						// Destroyed
+						"aws_ebs_volume.data": &terraform.InstanceDiff{
+							Destroy: true,
+						},
+
+						// Type depends on a computed value
+						"aws_elasticache_cluster.cache": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"node_type": &terraform.ResourceAttrDiff{
+									NewComputed: true,
+									RequiresNew: true,
+								},
+							},
+						},
+
+						// Not supported
+						"aws_vpc.main": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"cidr_block": &terraform.ResourceAttrDiff{
+									New:         "10.0.0.0/16",
+									RequiresNew: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		State: &terraform.State{
+			Modules: []*terraform.ModuleState{
+				&terraform.ModuleState{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.ResourceState{
+						"aws_db_instance.db": &terraform.ResourceState{
+							Type: "aws_db_instance",
+							Primary: &terraform.InstanceState{
+								ID: "db",
+								Attributes: map[string]string{
+									"instance_class":    "db.m4.large",
+									"storage_type":      "gp2",
+									"allocated_storage": "100",
+									"multi_az":          "true",
+								},
+							},
+						},
+						"aws_ebs_volume.data": &terraform.ResourceState{
+							Type: "aws_ebs_volume",
+							Primary: &terraform.InstanceState{
+								ID: "vol-abc123",
+								Attributes: map[string]string{
+									"type": "io1",
+									"size": "100",
+									"iops": "1000",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var actual []string
+	for _, e := range estimateCosts(plan) {
+		delta := formatCostDelta(e.Delta())
+		if e.Unknown {
+			delta = "unknown"
+		}
+		actual = append(actual, e.Resource+" "+e.Action+" "+delta)
+	}
+
+	expected := []string{
+		"aws_db_instance.db update -$230.68",
+		"aws_ebs_volume.data destroy -$77.50",
+		"aws_elasticache_cluster.cache create unknown",
+		"aws_instance.web create +$1,573.15",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestFormatCostDelta(t *testing.T) {
+	cases := map[float64]string{
+		0:          "+$0.00",
+		2.5:        "+$2.50",
+		-77.5:      "-$77.50",
+		1573.15:    "+$1,573.15",
+		-1234567.8: "-$1,234,567.80",
+	}
+
+	for input, expected := range cases {
+		if actual := formatCostDelta(input); actual != expected {
+			t.Fatalf("%v: expected %s, got %s", input, expected, actual)
+		}
+	}
+}
+
+func TestFormatCostEstimates(t *testing.T) {
+	estimates := []*costEstimate{
+		&costEstimate{Resource: "aws_instance.web", Action: "create", New: 78.84},
+		&costEstimate{Resource: "aws_instance.old", Action: "destroy", Old: 10},
+		&costEstimate{Resource: "aws_instance.new", Action: "create", Unknown: true},
+	}
+
+	actual := formatCostEstimates(estimates, &colorstring.Colorize{
+		Colors:  colorstring.DefaultColors,
+		Disable: true,
+	})
+
+	expected := strings.TrimSpace(`
+Estimated monthly cost change: +$68.84, plus unknown changes
+
+  aws_instance.web (create): +$78.84/month
+  aws_instance.old (destroy): -$10.00/month
+  aws_instance.new (create): unknown`)
+	if actual := strings.TrimSpace(actual); actual != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
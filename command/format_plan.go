@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform/terraform"
@@ -21,6 +22,70 @@ type FormatPlanOpts struct {
 	// ModuleDepth is the depth of the modules to expand. By default this
 	// is zero which will not expand modules at all.
 	ModuleDepth int
+
+	// Renderer turns the plan into text. This is optional and defaults to
+	// the human-readable format used by "terraform plan".
+	Renderer PlanRenderer
+}
+
+// PlanRenderer renders the changes in a plan. FormatPlan builds the list of
+// changes the same way for every renderer, so alternative frontends only
+// need to decide how to present them.
+type PlanRenderer interface {
+	// RenderPlan renders the given changes, which are sorted by name. The
+	// colorizer is never nil; renderers that don't produce colored output
+	// may ignore it.
+	RenderPlan(changes []*PlanChange, color *colorstring.Colorize) string
+}
+
+// PlanAction is the kind of change planned for a resource or module.
+type PlanAction byte
+
+const (
+	PlanActionUpdate PlanAction = iota
+	PlanActionCreate
+	PlanActionRead
+	PlanActionReplace
+	PlanActionDestroy
+)
+
+// PlanChange is a single resource, or a module that isn't expanded, that
+// the plan will change.
+type PlanChange struct {
+	// Name is the resource address, or "module.NAME" for modules that
+	// are summarized rather than expanded.
+	Name   string
+	Action PlanAction
+
+	// Tainted is set if the resource is being replaced because it
+	// is tainted.
+	Tainted bool
+
+	// Module is set if this change summarizes a whole module, in which
+	// case ResourceCount is set instead of Attributes.
+	Module        bool
+	ResourceCount int
+
+	// Attributes are the changing attributes of a resource, sorted by key.
+	Attributes []*PlanAttributeChange
+}
+
+// PlanAttributeChange is a single attribute change within a PlanChange.
+// Values of sensitive attributes are already masked.
+type PlanAttributeChange struct {
+	Key string
+	Old string
+	New string
+
+	// NewComputed is set if the new value won't be known until apply.
+	NewComputed bool
+
+	// Sensitive is set if Old and New have been masked.
+	Sensitive bool
+
+	// ForcesNew is set if this change is the reason the resource is
+	// replaced.
+	ForcesNew bool
 }
 
 // FormatPlan takes a plan and returns a
@@ -37,25 +102,29 @@ func FormatPlan(opts *FormatPlanOpts) string {
 		}
 	}
 
-	buf := new(bytes.Buffer)
+	renderer := opts.Renderer
+	if renderer == nil {
+		renderer = &textPlanRenderer{}
+	}
+
+	var changes []*PlanChange
 	for _, m := range p.Diff.Modules {
 		if len(m.Path)-1 <= opts.ModuleDepth || opts.ModuleDepth == -1 {
-			formatPlanModuleExpand(buf, m, opts)
-		} else {
-			formatPlanModuleSingle(buf, m, opts)
+			changes = append(changes, planModuleExpand(m)...)
+		} else if c := planModuleSingle(m); c != nil {
+			changes = append(changes, c)
 		}
 	}
 
-	return strings.TrimSpace(buf.String())
+	return strings.TrimSpace(renderer.RenderPlan(changes, opts.Color))
 }
 
-// formatPlanModuleExpand will output the given module and all of its
+// planModuleExpand returns the changes to all of the given module's
 // resources.
-func formatPlanModuleExpand(
-	buf *bytes.Buffer, m *terraform.ModuleDiff, opts *FormatPlanOpts) {
+func planModuleExpand(m *terraform.ModuleDiff) []*PlanChange {
 	// Ignore empty diffs
 	if m.Empty() {
-		return
+		return nil
 	}
 
 	var moduleName string
@@ -71,7 +140,7 @@ func formatPlanModuleExpand(
 	}
 	sort.Strings(names)
 
-	// Go through each sorted name and start building the output
+	var changes []*PlanChange
 	for _, name := range names {
 		rdiff := m.Resources[name]
 		if rdiff.Empty() {
@@ -82,20 +151,16 @@ func formatPlanModuleExpand(
 			name = moduleName + "." + name
 		}
 
-		// Determine the color for the text (green for adding, yellow
-		// for change, red for delete), and symbol, and output the
-		// resource header.
-		color := "yellow"
-		symbol := "~"
-		oldValues := true
+		change := &PlanChange{
+			Name:    name,
+			Action:  PlanActionUpdate,
+			Tainted: rdiff.DestroyTainted,
+		}
 		switch rdiff.ChangeType() {
 		case terraform.DiffDestroyCreate:
-			color = "green"
-			symbol = "-/+"
+			change.Action = PlanActionReplace
 		case terraform.DiffCreate:
-			color = "green"
-			symbol = "+"
-			oldValues = false
+			change.Action = PlanActionCreate
 
 			// If we're "creating" a data resource then we'll present it
 			// to the user as a "read" operation, so it's clear that this
@@ -104,26 +169,13 @@ func formatPlanModuleExpand(
 			// to work with, so we need to cheat and exploit knowledge of the
 			// naming scheme for data resources.
 			if strings.HasPrefix(name, "data.") {
-				symbol = "<="
-				color = "cyan"
+				change.Action = PlanActionRead
 			}
 		case terraform.DiffDestroy:
-			color = "red"
-			symbol = "-"
-		}
-
-		taintStr := ""
-		if rdiff.DestroyTainted {
-			taintStr = " (tainted)"
+			change.Action = PlanActionDestroy
 		}
 
-		buf.WriteString(opts.Color.Color(fmt.Sprintf(
-			"[%s]%s %s%s\n",
-			color, symbol, name, taintStr)))
-
-		// Get all the attributes that are changing, and sort them. Also
-		// determine the longest key so that we can align them all.
-		keyLen := 0
+		// Get all the attributes that are changing, and sort them.
 		keys := make([]string, 0, len(rdiff.Attributes))
 		for key, _ := range rdiff.Attributes {
 			// Skip the ID since we do that specially
@@ -132,91 +184,174 @@ func formatPlanModuleExpand(
 			}
 
 			keys = append(keys, key)
-			if len(key) > keyLen {
-				keyLen = len(key)
-			}
 		}
 		sort.Strings(keys)
 
-		// Go through and output each attribute
 		for _, attrK := range keys {
 			attrDiff := rdiff.Attributes[attrK]
 
-			v := attrDiff.New
-			if attrDiff.NewComputed {
-				v = "<computed>"
-			}
-
-			if attrDiff.Sensitive {
-				v = "<sensitive>"
+			attr := &PlanAttributeChange{
+				Key:         attrK,
+				Old:         attrDiff.Old,
+				New:         attrDiff.New,
+				NewComputed: attrDiff.NewComputed,
+				Sensitive:   attrDiff.Sensitive,
+				ForcesNew:   attrDiff.RequiresNew && rdiff.Destroy,
 			}
-
-			updateMsg := ""
-			if attrDiff.RequiresNew && rdiff.Destroy {
-				updateMsg = opts.Color.Color(" [red](forces new resource)")
-			} else if attrDiff.Sensitive && oldValues {
-				updateMsg = opts.Color.Color(" [yellow](attribute changed)")
+			if attr.Sensitive {
+				attr.Old = "<sensitive>"
+				attr.New = "<sensitive>"
 			}
 
-			if oldValues {
-				var u string
-				if attrDiff.Sensitive {
-					u = "<sensitive>"
-				} else {
-					u = attrDiff.Old
-				}
-				buf.WriteString(fmt.Sprintf(
-					"    %s:%s %#v => %#v%s\n",
-					attrK,
-					strings.Repeat(" ", keyLen-len(attrK)),
-					u,
-					v,
-					updateMsg))
-			} else {
-				buf.WriteString(fmt.Sprintf(
-					"    %s:%s %#v%s\n",
-					attrK,
-					strings.Repeat(" ", keyLen-len(attrK)),
-					v,
-					updateMsg))
-			}
+			change.Attributes = append(change.Attributes, attr)
 		}
 
-		// Write the reset color so we don't overload the user's terminal
-		buf.WriteString(opts.Color.Color("[reset]\n"))
+		changes = append(changes, change)
 	}
+
+	return changes
 }
 
-// formatPlanModuleSingle will output the given module and all of its
-// resources.
-func formatPlanModuleSingle(
-	buf *bytes.Buffer, m *terraform.ModuleDiff, opts *FormatPlanOpts) {
+// planModuleSingle returns a single change summarizing the given module.
+func planModuleSingle(m *terraform.ModuleDiff) *PlanChange {
 	// Ignore empty diffs
 	if m.Empty() {
-		return
+		return nil
+	}
+
+	change := &PlanChange{
+		Name:          fmt.Sprintf("module.%s", strings.Join(m.Path[1:], ".")),
+		Action:        PlanActionUpdate,
+		Module:        true,
+		ResourceCount: len(m.Resources),
+	}
+	switch m.ChangeType() {
+	case terraform.DiffCreate:
+		change.Action = PlanActionCreate
+	case terraform.DiffDestroy:
+		change.Action = PlanActionDestroy
 	}
 
-	moduleName := fmt.Sprintf("module.%s", strings.Join(m.Path[1:], "."))
+	return change
+}
+
+// textPlanRenderer is the default PlanRenderer, producing the output of
+// "terraform plan". When colors are disabled it also escapes any non-ASCII
+// characters in attribute values, so that the output is safe for log files
+// and terminals that can't display them.
+type textPlanRenderer struct{}
+
+func (r *textPlanRenderer) RenderPlan(changes []*PlanChange, color *colorstring.Colorize) string {
+	buf := new(bytes.Buffer)
+	for _, c := range changes {
+		if c.Module {
+			r.renderModule(buf, c, color)
+		} else {
+			r.renderResource(buf, c, color)
+		}
+	}
+	return buf.String()
+}
 
+func (r *textPlanRenderer) renderResource(
+	buf *bytes.Buffer, c *PlanChange, color *colorstring.Colorize) {
 	// Determine the color for the text (green for adding, yellow
 	// for change, red for delete), and symbol, and output the
 	// resource header.
-	color := "yellow"
-	symbol := "~"
-	switch m.ChangeType() {
-	case terraform.DiffCreate:
-		color = "green"
-		symbol = "+"
-	case terraform.DiffDestroy:
-		color = "red"
-		symbol = "-"
+	colorName, symbol := planActionStyle(c.Action)
+	oldValues := c.Action != PlanActionCreate && c.Action != PlanActionRead
+
+	taintStr := ""
+	if c.Tainted {
+		taintStr = " (tainted)"
+	}
+
+	buf.WriteString(color.Color(fmt.Sprintf(
+		"[%s]%s %s%s\n",
+		colorName, symbol, c.Name, taintStr)))
+
+	// Determine the longest key so that we can align them all.
+	keyLen := 0
+	for _, attr := range c.Attributes {
+		if len(attr.Key) > keyLen {
+			keyLen = len(attr.Key)
+		}
+	}
+
+	// Go through and output each attribute
+	for _, attr := range c.Attributes {
+		v := r.quote(attr.New, color)
+		if attr.NewComputed {
+			v = r.quote("<computed>", color)
+		}
+
+		if attr.Sensitive {
+			v = r.quote("<sensitive>", color)
+		}
+
+		updateMsg := ""
+		if attr.ForcesNew {
+			updateMsg = color.Color(" [red](forces new resource)")
+		} else if attr.Sensitive && oldValues {
+			updateMsg = color.Color(" [yellow](attribute changed)")
+		}
+
+		if oldValues {
+			buf.WriteString(fmt.Sprintf(
+				"    %s:%s %s => %s%s\n",
+				attr.Key,
+				strings.Repeat(" ", keyLen-len(attr.Key)),
+				r.quote(attr.Old, color),
+				v,
+				updateMsg))
+		} else {
+			buf.WriteString(fmt.Sprintf(
+				"    %s:%s %s%s\n",
+				attr.Key,
+				strings.Repeat(" ", keyLen-len(attr.Key)),
+				v,
+				updateMsg))
+		}
 	}
 
-	buf.WriteString(opts.Color.Color(fmt.Sprintf(
+	// Write the reset color so we don't overload the user's terminal
+	buf.WriteString(color.Color("[reset]\n"))
+}
+
+func (r *textPlanRenderer) renderModule(
+	buf *bytes.Buffer, c *PlanChange, color *colorstring.Colorize) {
+	colorName, symbol := planActionStyle(c.Action)
+
+	buf.WriteString(color.Color(fmt.Sprintf(
 		"[%s]%s %s\n",
-		color, symbol, moduleName)))
+		colorName, symbol, c.Name)))
 	buf.WriteString(fmt.Sprintf(
 		"    %d resource(s)",
-		len(m.Resources)))
-	buf.WriteString(opts.Color.Color("[reset]\n"))
+		c.ResourceCount))
+	buf.WriteString(color.Color("[reset]\n"))
+}
+
+// quote quotes an attribute value for display. Without colors, anything
+// outside of printable ASCII is escaped as well.
+func (r *textPlanRenderer) quote(v string, color *colorstring.Colorize) string {
+	if color.Disable {
+		return strconv.QuoteToASCII(v)
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+// planActionStyle returns the color and symbol used to display an action.
+func planActionStyle(a PlanAction) (string, string) {
+	switch a {
+	case PlanActionCreate:
+		return "green", "+"
+	case PlanActionRead:
+		return "cyan", "<="
+	case PlanActionReplace:
+		return "green", "-/+"
+	case PlanActionDestroy:
+		return "red", "-"
+	default:
+		return "yellow", "~"
+	}
 }
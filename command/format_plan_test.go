@@ -0,0 +1,141 @@
+package command
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/colorstring"
+)
+
+func testFormatPlan() *terraform.Plan {
+	return &terraform.Plan{
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: terraform.RootModulePath,
+					Resources: map[string]*terraform.InstanceDiff{
+						"test_instance.foo": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"ami": &terraform.ResourceAttrDiff{
+									Old: "ami-1",
+									New: "ami-2",
+								},
+								"description": &terraform.ResourceAttrDiff{
+									Old: "café",
+									New: "☃",
+								},
+								"password": &terraform.ResourceAttrDiff{
+									Old:       "hunter2",
+									New:       "hunter3",
+									Sensitive: true,
+								},
+							},
+						},
+						"data.test_data_source.bar": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"id": &terraform.ResourceAttrDiff{
+									NewComputed: true,
+									RequiresNew: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestFormatPlan(t *testing.T) {
+	actual := FormatPlan(&FormatPlanOpts{
+		Plan: testFormatPlan(),
+	})
+
+	expected := strings.TrimSpace(
+		"\x1b[36m<= data.test_data_source.bar\n" +
+			"\x1b[0m\n" +
+			"\x1b[33m~ test_instance.foo\n" +
+			"    ami:         \"ami-1\" => \"ami-2\"\n" +
+			"    description: \"café\" => \"☃\"\n" +
+			"    password:    \"<sensitive>\" => \"<sensitive>\" \x1b[33m(attribute changed)\n" +
+			"\x1b[0m")
+	if actual != expected {
+		t.Fatalf("bad:\n\n%s\n\nexpected:\n\n%s", actual, expected)
+	}
+}
+
+func TestFormatPlan_noColor(t *testing.T) {
+	actual := FormatPlan(&FormatPlanOpts{
+		Plan: testFormatPlan(),
+		Color: &colorstring.Colorize{
+			Colors:  colorstring.DefaultColors,
+			Disable: true,
+		},
+	})
+
+	expected := strings.TrimSpace(`
+<= data.test_data_source.bar
+
+~ test_instance.foo
+    ami:         "ami-1" => "ami-2"
+    description: "caf\u00e9" => "\u2603"
+    password:    "<sensitive>" => "<sensitive>" (attribute changed)
+`)
+	if actual != expected {
+		t.Fatalf("bad:\n\n%s\n\nexpected:\n\n%s", actual, expected)
+	}
+}
+
+type testPlanRenderer struct {
+	changes []*PlanChange
+}
+
+func (r *testPlanRenderer) RenderPlan(changes []*PlanChange, color *colorstring.Colorize) string {
+	r.changes = changes
+	return "rendered"
+}
+
+func TestFormatPlan_renderer(t *testing.T) {
+	renderer := new(testPlanRenderer)
+	actual := FormatPlan(&FormatPlanOpts{
+		Plan:     testFormatPlan(),
+		Renderer: renderer,
+	})
+	if actual != "rendered" {
+		t.Fatalf("bad: %q", actual)
+	}
+
+	expected := []*PlanChange{
+		&PlanChange{
+			Name:   "data.test_data_source.bar",
+			Action: PlanActionRead,
+		},
+		&PlanChange{
+			Name:   "test_instance.foo",
+			Action: PlanActionUpdate,
+			Attributes: []*PlanAttributeChange{
+				&PlanAttributeChange{
+					Key: "ami",
+					Old: "ami-1",
+					New: "ami-2",
+				},
+				&PlanAttributeChange{
+					Key: "description",
+					Old: "café",
+					New: "☃",
+				},
+				&PlanAttributeChange{
+					Key:       "password",
+					Old:       "<sensitive>",
+					New:       "<sensitive>",
+					Sensitive: true,
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(renderer.changes, expected) {
+		t.Fatalf("bad: %#v", renderer.changes)
+	}
+}
@@ -48,6 +48,8 @@ func (c *GetCommand) Run(args []string) int {
 		mode = module.GetModeUpdate
 	}
 
+	defer c.Meta.closeLogs()
+
 	_, _, err := c.Context(contextOpts{
 		Path:    path,
 		GetMode: mode,
@@ -47,6 +47,8 @@ func (c *GraphCommand) Run(args []string) int {
 		}
 	}
 
+	defer c.Meta.closeLogs()
+
 	ctx, _, err := c.Context(contextOpts{
 		Path:      path,
 		StatePath: "",
@@ -0,0 +1,145 @@
+package command
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// auditEvent is a single entry of the audit log enabled with TF_AUDIT_LOG.
+// Every entry is written as one line of JSON.
+type auditEvent struct {
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+	Resource  string `json:"resource"`
+	Action    string `json:"action"`
+
+	// BeforeDigest and AfterDigest are the digests of the state of the
+	// resource before and after the change. They're empty if the resource
+	// doesn't exist.
+	BeforeDigest string `json:"before_digest,omitempty"`
+	AfterDigest  string `json:"after_digest,omitempty"`
+
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// AuditHook is a hook that writes an audit trail of every change applied
+// to a resource. An "apply_start" entry is written before the change and
+// an "apply_complete" or "apply_errored" entry after it.
+//
+// The attributes of the resources are only written as digests, so the log
+// doesn't hold sensitive values but still shows whether a resource changed.
+type AuditHook struct {
+	terraform.NilHook
+
+	Writer io.Writer
+
+	l       sync.Mutex
+	actions map[string]string
+
+	// now is used to get the time of entries, and can be replaced in tests
+	now func() time.Time
+}
+
+func (h *AuditHook) PreApply(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	d *terraform.InstanceDiff) (terraform.HookAction, error) {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	action := jsonDiffAction(d)
+	if h.actions == nil {
+		h.actions = make(map[string]string)
+	}
+	h.actions[n.HumanId()] = action
+
+	h.write(&auditEvent{
+		Type:         "apply_start",
+		Resource:     n.HumanId(),
+		Action:       action,
+		BeforeDigest: auditDigest(s),
+	})
+
+	return terraform.HookActionContinue, nil
+}
+
+func (h *AuditHook) PostApply(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	applyerr error) (terraform.HookAction, error) {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	id := n.HumanId()
+	e := &auditEvent{
+		Type:        "apply_complete",
+		Resource:    id,
+		Action:      h.actions[id],
+		AfterDigest: auditDigest(s),
+	}
+	delete(h.actions, id)
+
+	if s != nil {
+		e.ID = s.ID
+	}
+	if applyerr != nil {
+		e.Type = "apply_errored"
+		e.Error = applyerr.Error()
+	}
+
+	h.write(e)
+
+	return terraform.HookActionContinue, nil
+}
+
+func (h *AuditHook) write(e *auditEvent) {
+	now := time.Now
+	if h.now != nil {
+		now = h.now
+	}
+	e.Timestamp = now().UTC().Format(time.RFC3339)
+
+	// The audit log is written on a best effort basis, so failing to
+	// write it shouldn't fail the apply.
+	raw, err := json.Marshal(e)
+	if err != nil {
+		log.Printf("[ERROR] Failed to encode audit event: %s", err)
+		return
+	}
+	fmt.Fprintf(h.Writer, "%s\n", raw)
+}
+
+// auditDigest returns the SHA-256 digest of the ID and attributes of the
+// state of a resource, or an empty string if the resource doesn't exist.
+func auditDigest(s *terraform.InstanceState) string {
+	if s == nil || s.ID == "" {
+		return ""
+	}
+
+	keys := make([]string, 0, len(s.Attributes))
+	for k := range s.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// The lengths are written so that different attributes can't result
+	// in the same input.
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d:%s\n", len(s.ID), s.ID)
+	for _, k := range keys {
+		v := s.Attributes[k]
+		fmt.Fprintf(hash, "%d:%s=%d:%s\n", len(k), k, len(v), v)
+	}
+
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,86 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAuditHook_impl(t *testing.T) {
+	var _ terraform.Hook = new(AuditHook)
+}
+
+func TestAuditHook(t *testing.T) {
+	buf := new(bytes.Buffer)
+	h := &AuditHook{
+		Writer: buf,
+		now: func() time.Time {
+			return time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+		},
+	}
+
+	n := &terraform.InstanceInfo{
+		Id:         "aws_instance.foo",
+		ModulePath: []string{"root", "child"},
+	}
+	before := &terraform.InstanceState{
+		ID:         "i-abc123",
+		Attributes: map[string]string{"ami": "ami-1"},
+	}
+	after := &terraform.InstanceState{
+		ID:         "i-abc123",
+		Attributes: map[string]string{"ami": "ami-2"},
+	}
+	d := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"ami": &terraform.ResourceAttrDiff{Old: "ami-1", New: "ami-2"},
+		},
+	}
+	h.PreApply(n, before, d)
+	h.PostApply(n, after, nil)
+
+	created := &terraform.InstanceInfo{Id: "aws_instance.bar"}
+	h.PreApply(created, nil, &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"ami": &terraform.ResourceAttrDiff{New: "ami-1", RequiresNew: true},
+		},
+	})
+	h.PostApply(created, nil, errors.New("quota exceeded"))
+
+	expected := strings.TrimSpace(`
+{"type":"apply_start","timestamp":"2016-08-01T10:00:00Z","resource":"module.child.aws_instance.foo","action":"update","before_digest":"` + auditDigest(before) + `"}
+{"type":"apply_complete","timestamp":"2016-08-01T10:00:00Z","resource":"module.child.aws_instance.foo","action":"update","after_digest":"` + auditDigest(after) + `","id":"i-abc123"}
+{"type":"apply_start","timestamp":"2016-08-01T10:00:00Z","resource":"aws_instance.bar","action":"create"}
+{"type":"apply_errored","timestamp":"2016-08-01T10:00:00Z","resource":"aws_instance.bar","action":"create","error":"quota exceeded"}`)
+	if actual := strings.TrimSpace(buf.String()); actual != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
+
+func TestAuditDigest(t *testing.T) {
+	s := &terraform.InstanceState{
+		ID:         "foo",
+		Attributes: map[string]string{"a": "b=c", "d": ""},
+	}
+	digest := auditDigest(s)
+	if !strings.HasPrefix(digest, "sha256:") || len(digest) != 71 {
+		t.Fatalf("bad: %s", digest)
+	}
+
+	// Moving a character between a key and a value changes the digest
+	other := &terraform.InstanceState{
+		ID:         "foo",
+		Attributes: map[string]string{"a=b": "c", "d": ""},
+	}
+	if auditDigest(other) == digest {
+		t.Fatal("digests should differ")
+	}
+
+	if auditDigest(nil) != "" || auditDigest(&terraform.InstanceState{}) != "" {
+		t.Fatal("digest of a missing resource should be empty")
+	}
+}
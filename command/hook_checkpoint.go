@@ -0,0 +1,31 @@
+package command
+
+import (
+	"sync"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// CheckpointHook is a hook that keeps track of the resources that failed
+// during an apply, so the apply can be resumed from a checkpoint.
+type CheckpointHook struct {
+	Failed []*terraform.InstanceInfo
+
+	sync.Mutex
+	terraform.NilHook
+}
+
+func (h *CheckpointHook) PostApply(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	e error) (terraform.HookAction, error) {
+	if e == nil {
+		return terraform.HookActionContinue, nil
+	}
+
+	h.Lock()
+	defer h.Unlock()
+	h.Failed = append(h.Failed, n)
+
+	return terraform.HookActionContinue, nil
+}
@@ -0,0 +1,25 @@
+package command
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestCheckpointHook_impl(t *testing.T) {
+	var _ terraform.Hook = new(CheckpointHook)
+}
+
+func TestCheckpointHookPostApply(t *testing.T) {
+	h := new(CheckpointHook)
+
+	foo := &terraform.InstanceInfo{Id: "aws_instance.foo"}
+	bar := &terraform.InstanceInfo{Id: "aws_instance.bar"}
+	h.PostApply(foo, &terraform.InstanceState{ID: "foo"}, nil)
+	h.PostApply(bar, nil, errors.New("error"))
+
+	if len(h.Failed) != 1 || h.Failed[0] != bar {
+		t.Fatalf("bad: %#v", h.Failed)
+	}
+}
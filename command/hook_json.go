@@ -0,0 +1,382 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+// jsonEvent is a single event of the machine readable output enabled with
+// the -json flag. Every event is written as one line of JSON.
+type jsonEvent struct {
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+
+	Resource    string `json:"resource,omitempty"`
+	Action      string `json:"action,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Provisioner string `json:"provisioner,omitempty"`
+	Severity    string `json:"severity,omitempty"`
+	Message     string `json:"message,omitempty"`
+
+	ElapsedSeconds float64 `json:"elapsed_seconds,omitempty"`
+
+	Operation string `json:"operation,omitempty"`
+	Add       *int   `json:"add,omitempty"`
+	Change    *int   `json:"change,omitempty"`
+	Remove    *int   `json:"remove,omitempty"`
+
+	Outputs map[string]jsonOutput `json:"outputs,omitempty"`
+}
+
+// jsonOutput is the value of a single output in an "outputs" event
+type jsonOutput struct {
+	Sensitive bool        `json:"sensitive"`
+	Type      string      `json:"type"`
+	Value     interface{} `json:"value,omitempty"`
+}
+
+// JSONUi is a cli.Ui that writes everything as JSON events. Errors and
+// warnings become "diagnostic" events and all other output "log" events,
+// so that nothing but JSON is written to stdout.
+type JSONUi struct {
+	// Ui is the underlying Ui the events are written to
+	Ui cli.Ui
+
+	// now is used to get the time of events, and can be replaced in tests
+	now func() time.Time
+}
+
+func (u *JSONUi) Ask(query string) (string, error) {
+	return u.Ui.Ask(query)
+}
+
+func (u *JSONUi) AskSecret(query string) (string, error) {
+	return u.Ui.AskSecret(query)
+}
+
+func (u *JSONUi) Output(message string) {
+	u.log(message)
+}
+
+func (u *JSONUi) Info(message string) {
+	u.log(message)
+}
+
+func (u *JSONUi) Error(message string) {
+	u.diagnostic("error", message)
+}
+
+func (u *JSONUi) Warn(message string) {
+	u.diagnostic("warning", message)
+}
+
+func (u *JSONUi) log(message string) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return
+	}
+
+	u.Event(&jsonEvent{Type: "log", Message: message})
+}
+
+func (u *JSONUi) diagnostic(severity, message string) {
+	u.Event(&jsonEvent{
+		Type:     "diagnostic",
+		Severity: severity,
+		Message:  strings.TrimSpace(message),
+	})
+}
+
+// Event writes a single event, setting its timestamp
+func (u *JSONUi) Event(e *jsonEvent) {
+	now := time.Now
+	if u.now != nil {
+		now = u.now
+	}
+	e.Timestamp = now().UTC().Format(time.RFC3339)
+
+	raw, err := json.Marshal(e)
+	if err != nil {
+		// Output values are the only fields that could fail to encode.
+		// The operation shouldn't fail because of its output, so the
+		// event is replaced by an error that only holds strings.
+		log.Printf("[ERROR] Failed to encode JSON %s event: %s", e.Type, err)
+		raw, _ = json.Marshal(&jsonEvent{
+			Type:      "diagnostic",
+			Timestamp: e.Timestamp,
+			Severity:  "error",
+			Message:   fmt.Sprintf("Failed to encode %s event: %s", e.Type, err),
+		})
+	}
+	u.Ui.Output(string(raw))
+}
+
+// JSONHook is a terraform.Hook that reports the progress of an operation
+// as JSON events, like UiHook does for humans.
+type JSONHook struct {
+	terraform.NilHook
+
+	Ui *JSONUi
+
+	l         sync.Mutex
+	once      sync.Once
+	resources map[string]uiResourceState
+}
+
+func (h *JSONHook) PreApply(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	d *terraform.InstanceDiff) (terraform.HookAction, error) {
+	h.once.Do(h.init)
+
+	id := n.HumanId()
+
+	op := uiResourceModify
+	if d.Destroy {
+		op = uiResourceDestroy
+	} else if s.ID == "" {
+		op = uiResourceCreate
+	}
+
+	h.l.Lock()
+	h.resources[id] = uiResourceState{
+		Op:    op,
+		Start: time.Now(),
+	}
+	h.l.Unlock()
+
+	h.Ui.Event(&jsonEvent{
+		Type:     "apply_start",
+		Resource: id,
+		Action:   jsonAction(op),
+	})
+
+	// Set a timer to show an operation is still happening
+	time.AfterFunc(periodicUiTimer, func() { h.stillApplying(id) })
+
+	return terraform.HookActionContinue, nil
+}
+
+func (h *JSONHook) stillApplying(id string) {
+	// Hold the lock so the progress can't show up after the completion
+	h.l.Lock()
+	defer h.l.Unlock()
+	state, ok := h.resources[id]
+
+	// If the resource is out of the map it means we're done with it
+	if !ok {
+		return
+	}
+
+	h.Ui.Event(&jsonEvent{
+		Type:           "apply_progress",
+		Resource:       id,
+		Action:         jsonAction(state.Op),
+		ElapsedSeconds: elapsedSeconds(state.Start),
+	})
+
+	// Reschedule
+	time.AfterFunc(periodicUiTimer, func() { h.stillApplying(id) })
+}
+
+func (h *JSONHook) PostApply(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	applyerr error) (terraform.HookAction, error) {
+	h.once.Do(h.init)
+
+	id := n.HumanId()
+
+	h.l.Lock()
+	state, ok := h.resources[id]
+	delete(h.resources, id)
+	h.l.Unlock()
+
+	if !ok {
+		return terraform.HookActionContinue, nil
+	}
+
+	e := &jsonEvent{
+		Type:           "apply_complete",
+		Resource:       id,
+		Action:         jsonAction(state.Op),
+		ElapsedSeconds: elapsedSeconds(state.Start),
+	}
+	if s != nil {
+		e.ID = s.ID
+	}
+	if applyerr != nil {
+		e.Type = "apply_errored"
+		e.Message = applyerr.Error()
+	}
+	h.Ui.Event(e)
+
+	if s != nil {
+		for _, w := range s.Ephemeral.Warnings {
+			h.Ui.Event(&jsonEvent{
+				Type:     "diagnostic",
+				Resource: id,
+				Severity: "warning",
+				Message:  w,
+			})
+		}
+	}
+
+	return terraform.HookActionContinue, nil
+}
+
+func (h *JSONHook) PreProvision(
+	n *terraform.InstanceInfo,
+	provId string) (terraform.HookAction, error) {
+	h.Ui.Event(&jsonEvent{
+		Type:        "provision_start",
+		Resource:    n.HumanId(),
+		Provisioner: provId,
+	})
+	return terraform.HookActionContinue, nil
+}
+
+func (h *JSONHook) ProvisionOutput(
+	n *terraform.InstanceInfo,
+	provId string,
+	msg string) {
+	for _, line := range strings.Split(msg, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			continue
+		}
+
+		h.Ui.Event(&jsonEvent{
+			Type:        "provision_output",
+			Resource:    n.HumanId(),
+			Provisioner: provId,
+			Message:     line,
+		})
+	}
+}
+
+func (h *JSONHook) PreRefresh(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	e := &jsonEvent{
+		Type:     "refresh_start",
+		Resource: n.HumanId(),
+	}
+	if s != nil {
+		e.ID = s.ID
+	}
+	h.Ui.Event(e)
+	return terraform.HookActionContinue, nil
+}
+
+func (h *JSONHook) init() {
+	h.resources = make(map[string]uiResourceState)
+}
+
+// jsonAction returns the action of a resource operation in JSON events
+func jsonAction(op uiResourceOp) string {
+	switch op {
+	case uiResourceCreate:
+		return "create"
+	case uiResourceModify:
+		return "update"
+	case uiResourceDestroy:
+		return "destroy"
+	default:
+		return ""
+	}
+}
+
+// jsonDiffAction returns the action of a planned change in JSON events
+func jsonDiffAction(d *terraform.InstanceDiff) string {
+	switch d.ChangeType() {
+	case terraform.DiffCreate:
+		return "create"
+	case terraform.DiffUpdate:
+		return "update"
+	case terraform.DiffDestroy:
+		return "destroy"
+	case terraform.DiffDestroyCreate:
+		return "replace"
+	default:
+		return ""
+	}
+}
+
+// jsonPlanEvents returns a "planned_change" event for every resource that
+// changes in the plan, ordered by their address.
+func jsonPlanEvents(plan *terraform.Plan) []*jsonEvent {
+	var events []*jsonEvent
+	if plan.Diff == nil {
+		return events
+	}
+
+	for _, m := range plan.Diff.Modules {
+		prefix := ""
+		for _, name := range m.Path[1:] {
+			prefix += "module." + name + "."
+		}
+
+		for name, d := range m.Resources {
+			action := jsonDiffAction(d)
+			if action == "" {
+				continue
+			}
+
+			events = append(events, &jsonEvent{
+				Type:     "planned_change",
+				Resource: prefix + name,
+				Action:   action,
+			})
+		}
+	}
+
+	sort.Sort(jsonEventsByResource(events))
+	return events
+}
+
+type jsonEventsByResource []*jsonEvent
+
+func (s jsonEventsByResource) Len() int           { return len(s) }
+func (s jsonEventsByResource) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s jsonEventsByResource) Less(i, j int) bool { return s[i].Resource < s[j].Resource }
+
+// jsonOutputs returns the root module outputs of the state for an
+// "outputs" event. The values of sensitive outputs are left out.
+func jsonOutputs(state *terraform.State, schema []*config.Output) map[string]jsonOutput {
+	if state == nil {
+		return nil
+	}
+
+	sensitive := make(map[string]bool)
+	for _, o := range schema {
+		sensitive[o.Name] = o.Sensitive
+	}
+
+	outputs := make(map[string]jsonOutput)
+	for k, o := range state.RootModule().Outputs {
+		out := jsonOutput{
+			Sensitive: o.Sensitive || sensitive[k],
+			Type:      o.Type,
+		}
+		if !out.Sensitive {
+			out.Value = o.Value
+		}
+		outputs[k] = out
+	}
+
+	return outputs
+}
+
+func elapsedSeconds(start time.Time) float64 {
+	return time.Now().Sub(start).Seconds()
+}
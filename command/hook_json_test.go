@@ -0,0 +1,224 @@
+package command
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestJSONHook_impl(t *testing.T) {
+	var _ terraform.Hook = new(JSONHook)
+}
+
+func TestJSONUi_impl(t *testing.T) {
+	var _ cli.Ui = new(JSONUi)
+}
+
+func testJSONUi() (*JSONUi, *cli.MockUi) {
+	mock := new(cli.MockUi)
+	ui := &JSONUi{
+		Ui: mock,
+		now: func() time.Time {
+			return time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+		},
+	}
+	return ui, mock
+}
+
+func TestJSONUi(t *testing.T) {
+	ui, mock := testJSONUi()
+
+	ui.Output("Apply complete!\n")
+	ui.Output("")
+	ui.Error("Error applying plan")
+	ui.Warn("Deprecated")
+
+	expected := `{"type":"log","timestamp":"2016-10-01T12:00:00Z","message":"Apply complete!"}
+{"type":"diagnostic","timestamp":"2016-10-01T12:00:00Z","severity":"error","message":"Error applying plan"}
+{"type":"diagnostic","timestamp":"2016-10-01T12:00:00Z","severity":"warning","message":"Deprecated"}
+`
+	if actual := mock.OutputWriter.String(); actual != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
+
+func TestJSONUi_encodeError(t *testing.T) {
+	ui, mock := testJSONUi()
+
+	ui.Event(&jsonEvent{
+		Type: "outputs",
+		Outputs: map[string]jsonOutput{
+			"bad": jsonOutput{Type: "string", Value: func() {}},
+		},
+	})
+
+	expected := `{"type":"diagnostic","timestamp":"2016-10-01T12:00:00Z","severity":"error","message":"Failed to encode outputs event: json: unsupported type: func()"}
+`
+	if actual := mock.OutputWriter.String(); actual != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
+
+func TestJSONHook_apply(t *testing.T) {
+	ui, mock := testJSONUi()
+	h := &JSONHook{Ui: ui}
+
+	n := &terraform.InstanceInfo{
+		Id:         "aws_instance.foo",
+		ModulePath: []string{"root", "child"},
+	}
+
+	h.PreApply(n, &terraform.InstanceState{}, &terraform.InstanceDiff{})
+	h.PostApply(n, &terraform.InstanceState{ID: "i-abc123"}, nil)
+	h.PreApply(n, &terraform.InstanceState{ID: "i-abc123"}, &terraform.InstanceDiff{Destroy: true})
+	h.PostApply(n, &terraform.InstanceState{ID: "i-abc123"}, errors.New("failed"))
+
+	lines := strings.Split(strings.TrimSpace(mock.OutputWriter.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("bad: %#v", lines)
+	}
+
+	expected := []string{
+		`{"type":"apply_start","timestamp":"2016-10-01T12:00:00Z","resource":"module.child.aws_instance.foo","action":"create"}`,
+		`{"type":"apply_complete","timestamp":"2016-10-01T12:00:00Z","resource":"module.child.aws_instance.foo","action":"create","id":"i-abc123"`,
+		`{"type":"apply_start","timestamp":"2016-10-01T12:00:00Z","resource":"module.child.aws_instance.foo","action":"destroy"}`,
+		`{"type":"apply_errored","timestamp":"2016-10-01T12:00:00Z","resource":"module.child.aws_instance.foo","action":"destroy","id":"i-abc123","message":"failed"`,
+	}
+	for i, line := range lines {
+		// The elapsed time isn't predictable, so only compare the start
+		if !strings.HasPrefix(line, expected[i]) {
+			t.Fatalf("line %d: expected prefix:\n%s\ngot:\n%s", i, expected[i], line)
+		}
+	}
+}
+
+func TestJSONHook_applyWarnings(t *testing.T) {
+	ui, mock := testJSONUi()
+	h := &JSONHook{Ui: ui}
+
+	n := &terraform.InstanceInfo{Id: "aws_instance.foo"}
+	s := &terraform.InstanceState{
+		ID: "i-abc123",
+		Ephemeral: terraform.EphemeralState{
+			Warnings: []string{"role has no permissions"},
+		},
+	}
+
+	h.PreApply(n, &terraform.InstanceState{}, &terraform.InstanceDiff{})
+	h.PostApply(n, s, nil)
+
+	lines := strings.Split(strings.TrimSpace(mock.OutputWriter.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("bad: %#v", lines)
+	}
+
+	expected := `{"type":"diagnostic","timestamp":"2016-10-01T12:00:00Z","resource":"aws_instance.foo","severity":"warning","message":"role has no permissions"}`
+	if lines[2] != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, lines[2])
+	}
+}
+
+func TestJSONHook_provisionOutput(t *testing.T) {
+	ui, mock := testJSONUi()
+	h := &JSONHook{Ui: ui}
+
+	n := &terraform.InstanceInfo{Id: "aws_instance.foo"}
+	h.ProvisionOutput(n, "remote-exec", "foo\r\n\nbar\n")
+
+	expected := `{"type":"provision_output","timestamp":"2016-10-01T12:00:00Z","resource":"aws_instance.foo","provisioner":"remote-exec","message":"foo"}
+{"type":"provision_output","timestamp":"2016-10-01T12:00:00Z","resource":"aws_instance.foo","provisioner":"remote-exec","message":"bar"}
+`
+	if actual := mock.OutputWriter.String(); actual != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
+
+func TestJSONPlanEvents(t *testing.T) {
+	plan := &terraform.Plan{
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_instance.foo": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"ami": &terraform.ResourceAttrDiff{
+									New:         "ami-123",
+									RequiresNew: true,
+								},
+							},
+						},
+						"aws_instance.bar": &terraform.InstanceDiff{Destroy: true},
+					},
+				},
+				&terraform.ModuleDiff{
+					Path: []string{"root", "child"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_instance.baz": &terraform.InstanceDiff{
+							Destroy: true,
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"ami": &terraform.ResourceAttrDiff{
+									Old:         "ami-123",
+									New:         "ami-456",
+									RequiresNew: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	events := jsonPlanEvents(plan)
+
+	expected := []struct {
+		Resource string
+		Action   string
+	}{
+		{"aws_instance.bar", "destroy"},
+		{"aws_instance.foo", "create"},
+		{"module.child.aws_instance.baz", "replace"},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("bad: %#v", events)
+	}
+	for i, e := range events {
+		if e.Type != "planned_change" || e.Resource != expected[i].Resource || e.Action != expected[i].Action {
+			t.Fatalf("event %d: expected %#v, got %#v", i, expected[i], e)
+		}
+	}
+}
+
+func TestJSONOutputs(t *testing.T) {
+	state := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]*terraform.OutputState{
+					"ip": &terraform.OutputState{
+						Type:  "string",
+						Value: "10.0.0.1",
+					},
+					"password": &terraform.OutputState{
+						Sensitive: true,
+						Type:      "string",
+						Value:     "secret",
+					},
+				},
+			},
+		},
+	}
+
+	outputs := jsonOutputs(state, nil)
+	if outputs["ip"].Value != "10.0.0.1" {
+		t.Fatalf("bad: %#v", outputs)
+	}
+	if !outputs["password"].Sensitive || outputs["password"].Value != nil {
+		t.Fatalf("bad: %#v", outputs)
+	}
+}
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// TimingHook is a hook that writes how long the refresh of every resource
+// took to a timing report, enabled with TF_LOG_TIMING. Each line of the
+// report holds the duration in seconds, the resource and its ID, separated
+// by tabs, so the slowest resources can be found with "sort -rn".
+type TimingHook struct {
+	terraform.NilHook
+
+	Writer io.Writer
+
+	l     sync.Mutex
+	start map[string]time.Time
+}
+
+func (h *TimingHook) PreRefresh(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	if h.start == nil {
+		h.start = make(map[string]time.Time)
+	}
+	h.start[n.HumanId()] = time.Now()
+
+	return terraform.HookActionContinue, nil
+}
+
+func (h *TimingHook) PostRefresh(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	id := n.HumanId()
+	start, ok := h.start[id]
+	if !ok {
+		return terraform.HookActionContinue, nil
+	}
+	delete(h.start, id)
+
+	var stateId string
+	if s != nil {
+		stateId = s.ID
+	}
+
+	// The report is only for diagnostics, so failing to write it
+	// shouldn't fail the refresh.
+	fmt.Fprintf(h.Writer, "%.3f\t%s\t%s\n",
+		time.Now().Sub(start).Seconds(), id, stateId)
+
+	return terraform.HookActionContinue, nil
+}
@@ -0,0 +1,33 @@
+package command
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestTimingHook_impl(t *testing.T) {
+	var _ terraform.Hook = new(TimingHook)
+}
+
+func TestTimingHook(t *testing.T) {
+	buf := new(bytes.Buffer)
+	h := &TimingHook{Writer: buf}
+
+	n := &terraform.InstanceInfo{
+		Id:         "aws_instance.foo",
+		ModulePath: []string{"root", "child"},
+	}
+	h.PreRefresh(n, &terraform.InstanceState{ID: "i-abc123"})
+	h.PostRefresh(n, &terraform.InstanceState{ID: "i-abc123"})
+
+	// Without a PreRefresh nothing is reported
+	h.PostRefresh(&terraform.InstanceInfo{Id: "aws_instance.bar"}, nil)
+
+	expected := regexp.MustCompile(`^\d+\.\d{3}\tmodule\.child\.aws_instance\.foo\ti-abc123\n$`)
+	if !expected.MatchString(buf.String()) {
+		t.Fatalf("bad: %q", buf.String())
+	}
+}
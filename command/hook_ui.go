@@ -198,16 +198,35 @@ func (h *UiHook) PostApply(
 
 	if applyerr != nil {
 		// Errors are collected and printed in ApplyCommand, no need to duplicate
+		h.warnings(id, s)
 		return terraform.HookActionContinue, nil
 	}
 
+	var stateIdSuffix string
+	if s != nil && s.ID != "" {
+		stateIdSuffix = fmt.Sprintf(" (ID: %s)", s.ID)
+	}
+
 	h.ui.Output(h.Colorize.Color(fmt.Sprintf(
-		"[reset][bold]%s: %s[reset_bold]",
-		id, msg)))
+		"[reset][bold]%s: %s after %s%s[reset_bold]",
+		id, msg, time.Now().Round(time.Second).Sub(state.Start), stateIdSuffix)))
+	h.warnings(id, s)
 
 	return terraform.HookActionContinue, nil
 }
 
+// warnings outputs the warnings the provider returned with the state
+func (h *UiHook) warnings(id string, s *terraform.InstanceState) {
+	if s == nil {
+		return
+	}
+
+	for _, w := range s.Ephemeral.Warnings {
+		h.ui.Output(h.Colorize.Color(fmt.Sprintf(
+			"[reset][yellow]%s: Warning: %s[reset]", id, w)))
+	}
+}
+
 func (h *UiHook) PreDiff(
 	n *terraform.InstanceInfo,
 	s *terraform.InstanceState) (terraform.HookAction, error) {
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/colorstring"
+)
+
+func TestUiHookPostApply(t *testing.T) {
+	cases := []struct {
+		Name     string
+		State    *terraform.InstanceState
+		Diff     *terraform.InstanceDiff
+		Expected string
+	}{
+		{
+			"create",
+			&terraform.InstanceState{ID: "i-abc123"},
+			&terraform.InstanceDiff{},
+			"aws_instance.foo: Creation complete after 1m30s (ID: i-abc123)",
+		},
+		{
+			"destroy",
+			nil,
+			&terraform.InstanceDiff{Destroy: true},
+			"aws_instance.foo: Destruction complete after 1m30s",
+		},
+	}
+
+	for _, tc := range cases {
+		ui := new(cli.MockUi)
+		h := &UiHook{
+			Colorize: &colorstring.Colorize{
+				Colors:  colorstring.DefaultColors,
+				Disable: true,
+			},
+			Ui: ui,
+		}
+
+		n := &terraform.InstanceInfo{Id: "aws_instance.foo"}
+		if _, err := h.PreApply(n, &terraform.InstanceState{}, tc.Diff); err != nil {
+			t.Fatalf("%s: err: %s", tc.Name, err)
+		}
+
+		// Pretend the operation started a while ago, so the elapsed time
+		// doesn't depend on how long the test takes.
+		h.l.Lock()
+		state := h.resources[n.HumanId()]
+		state.Start = time.Now().Round(time.Second).Add(-90 * time.Second)
+		h.resources[n.HumanId()] = state
+		h.l.Unlock()
+
+		if _, err := h.PostApply(n, tc.State, nil); err != nil {
+			t.Fatalf("%s: err: %s", tc.Name, err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(ui.OutputWriter.String()), "\n")
+		actual := lines[len(lines)-1]
+		if actual != tc.Expected {
+			t.Fatalf("%s: bad:\n\n%s\n\nexpected:\n\n%s", tc.Name, actual, tc.Expected)
+		}
+	}
+}
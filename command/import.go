@@ -34,11 +34,15 @@ func (c *ImportCommand) Run(args []string) int {
 		return 1
 	}
 
+	defer c.Meta.closeLogs()
+
 	// Build the context based on the arguments given
 	ctx, _, err := c.Context(contextOpts{
 		StatePath:   c.Meta.statePath,
 		Parallelism: c.Meta.parallelism,
+		LockReason:  "import",
 	})
+	defer c.Meta.unlockState()
 	if err != nil {
 		c.Ui.Error(err.Error())
 		return 1
@@ -36,6 +36,7 @@ import (
 	packetprovider "github.com/hashicorp/terraform/builtin/providers/packet"
 	postgresqlprovider "github.com/hashicorp/terraform/builtin/providers/postgresql"
 	powerdnsprovider "github.com/hashicorp/terraform/builtin/providers/powerdns"
+	rabbitmqprovider "github.com/hashicorp/terraform/builtin/providers/rabbitmq"
 	randomprovider "github.com/hashicorp/terraform/builtin/providers/random"
 	rundeckprovider "github.com/hashicorp/terraform/builtin/providers/rundeck"
 	softlayerprovider "github.com/hashicorp/terraform/builtin/providers/softlayer"
@@ -48,10 +49,12 @@ import (
 	ultradnsprovider "github.com/hashicorp/terraform/builtin/providers/ultradns"
 	vcdprovider "github.com/hashicorp/terraform/builtin/providers/vcd"
 	vsphereprovider "github.com/hashicorp/terraform/builtin/providers/vsphere"
+	ansibleresourceprovisioner "github.com/hashicorp/terraform/builtin/provisioners/ansible"
 	chefresourceprovisioner "github.com/hashicorp/terraform/builtin/provisioners/chef"
 	fileresourceprovisioner "github.com/hashicorp/terraform/builtin/provisioners/file"
 	localexecresourceprovisioner "github.com/hashicorp/terraform/builtin/provisioners/local-exec"
 	remoteexecresourceprovisioner "github.com/hashicorp/terraform/builtin/provisioners/remote-exec"
+	saltmasterlessresourceprovisioner "github.com/hashicorp/terraform/builtin/provisioners/salt-masterless"
 
 	"github.com/hashicorp/terraform/plugin"
 	"github.com/hashicorp/terraform/terraform"
@@ -88,6 +91,7 @@ var InternalProviders = map[string]plugin.ProviderFunc{
 	"packet":       packetprovider.Provider,
 	"postgresql":   postgresqlprovider.Provider,
 	"powerdns":     powerdnsprovider.Provider,
+	"rabbitmq":     rabbitmqprovider.Provider,
 	"random":       randomprovider.Provider,
 	"rundeck":      rundeckprovider.Provider,
 	"softlayer":    softlayerprovider.Provider,
@@ -103,8 +107,10 @@ var InternalProviders = map[string]plugin.ProviderFunc{
 }
 
 var InternalProvisioners = map[string]plugin.ProvisionerFunc{
-	"chef":        func() terraform.ResourceProvisioner { return new(chefresourceprovisioner.ResourceProvisioner) },
-	"file":        func() terraform.ResourceProvisioner { return new(fileresourceprovisioner.ResourceProvisioner) },
-	"local-exec":  func() terraform.ResourceProvisioner { return new(localexecresourceprovisioner.ResourceProvisioner) },
-	"remote-exec": func() terraform.ResourceProvisioner { return new(remoteexecresourceprovisioner.ResourceProvisioner) },
+	"ansible":         func() terraform.ResourceProvisioner { return new(ansibleresourceprovisioner.ResourceProvisioner) },
+	"chef":            func() terraform.ResourceProvisioner { return new(chefresourceprovisioner.ResourceProvisioner) },
+	"file":            func() terraform.ResourceProvisioner { return new(fileresourceprovisioner.ResourceProvisioner) },
+	"local-exec":      func() terraform.ResourceProvisioner { return new(localexecresourceprovisioner.ResourceProvisioner) },
+	"remote-exec":     func() terraform.ResourceProvisioner { return new(remoteexecresourceprovisioner.ResourceProvisioner) },
+	"salt-masterless": func() terraform.ResourceProvisioner { return new(saltmasterlessresourceprovisioner.ResourceProvisioner) },
 }
@@ -5,12 +5,17 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"log"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/config/module"
+	"github.com/hashicorp/terraform/helper/logging"
 	"github.com/hashicorp/terraform/state"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/cli"
@@ -28,9 +33,19 @@ type Meta struct {
 	state       state.State
 	stateResult *StateResult
 
+	// stateLocked is true if the state was locked by `Context`, and has
+	// to be unlocked with unlockState.
+	stateLocked bool
+
 	// This can be set by the command itself to provide extra hooks.
 	extraHooks []terraform.Hook
 
+	// The timing report and audit log opened by timingHook and auditHook.
+	// They're shared by all the contexts of the command, and closed by
+	// closeLogs.
+	timingFile *os.File
+	auditFile  *os.File
+
 	// This can be set by tests to change some directories
 	dataDir string
 
@@ -43,6 +58,9 @@ type Meta struct {
 	// Targets for this context (private)
 	targets []string
 
+	// Resources to replace when planning, set with -replace (private)
+	replace []string
+
 	color bool
 	oldUi cli.Ui
 
@@ -63,10 +81,22 @@ type Meta struct {
 	//
 	// parallelism is used to control the number of concurrent operations
 	// allowed when walking the graph
-	statePath    string
-	stateOutPath string
-	backupPath   string
-	parallelism  int
+	//
+	// json makes the command write JSON events instead of human readable
+	// output. See setupJSON.
+	//
+	// policyDir is the directory of the policies the plan is checked
+	// against before it can be applied. See enforcePolicies.
+	//
+	// stateReadOnly makes sure the state is never written, not even the
+	// remote state cache or a backup. See StateOpts.ReadOnly.
+	statePath     string
+	stateOutPath  string
+	backupPath    string
+	parallelism   int
+	json          bool
+	policyDir     string
+	stateReadOnly bool
 }
 
 // initStatePaths is used to initialize the default values for
@@ -108,6 +138,12 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 		plan, err := terraform.ReadPlan(f)
 		f.Close()
 		if err == nil {
+			if plan.Module != nil {
+				if err := m.checkRequiredVersion(plan.Module); err != nil {
+					return nil, false, err
+				}
+			}
+
 			// Setup our state
 			state, statePath, err := StateFromPlan(m.statePath, plan)
 			if err != nil {
@@ -118,6 +154,14 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 			m.state = state
 			m.stateOutPath = statePath
 
+			// The plan already holds the state it was created from, so it
+			// isn't refreshed after locking.
+			if copts.LockReason != "" {
+				if err := m.lockState(copts.LockReason, false); err != nil {
+					return nil, false, err
+				}
+			}
+
 			if len(m.variables) > 0 {
 				return nil, false, fmt.Errorf(
 					"You can't set variables with the '-var' or '-var-file' flag\n" +
@@ -139,12 +183,6 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 	// Tell the context if we're in a destroy plan / apply
 	opts.Destroy = copts.Destroy
 
-	// Store the loaded state
-	state, err := m.State()
-	if err != nil {
-		return nil, false, err
-	}
-
 	// Load the root module
 	var mod *module.Tree
 	if copts.Path != "" {
@@ -161,6 +199,23 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 		return nil, false, fmt.Errorf("Error downloading modules: %s", err)
 	}
 
+	// Check the required version before the state is read, since an older
+	// Terraform shouldn't touch state written for a newer one.
+	if err := m.checkRequiredVersion(mod); err != nil {
+		return nil, false, err
+	}
+
+	// Store the loaded state
+	state, err := m.State()
+	if err != nil {
+		return nil, false, err
+	}
+	if copts.LockReason != "" {
+		if err := m.lockState(copts.LockReason, true); err != nil {
+			return nil, false, err
+		}
+	}
+
 	opts.Module = mod
 	opts.Parallelism = copts.Parallelism
 	opts.State = state.State()
@@ -183,8 +238,28 @@ const (
 	// "0", causes terraform commands to behave as if the `-input=false` flag was
 	// specified.
 	InputModeEnvVar = "TF_INPUT"
+
+	// UpgradeURLEnvVar is the environment variable that can be set to a URL
+	// with instructions for upgrading Terraform, such as an internal wiki
+	// page. It is shown when a configuration requires a newer version.
+	UpgradeURLEnvVar = "TF_UPGRADE_URL"
 )
 
+// checkRequiredVersion checks the required_version constraints of the
+// given module tree, pointing to UpgradeURLEnvVar if it isn't satisfied.
+func (m *Meta) checkRequiredVersion(mod *module.Tree) error {
+	err := terraform.CheckRequiredVersion(mod)
+	if _, ok := err.(*terraform.VersionRequiredError); ok {
+		if url := os.Getenv(UpgradeURLEnvVar); url != "" {
+			err = fmt.Errorf(
+				"%s\n\nFor instructions on upgrading Terraform, see:\n  %s",
+				err, url)
+		}
+	}
+
+	return err
+}
+
 // InputMode returns the type of input we should ask for in the form of
 // terraform.InputMode which is passed directly to Context.Input.
 func (m *Meta) InputMode() terraform.InputMode {
@@ -254,6 +329,7 @@ func (m *Meta) StateOpts() *StateOpts {
 		RemotePath:    remotePath,
 		RemoteRefresh: true,
 		BackupPath:    m.backupPath,
+		ReadOnly:      m.stateReadOnly,
 	}
 }
 
@@ -264,6 +340,47 @@ func (m *Meta) UIInput() terraform.UIInput {
 	}
 }
 
+// lockState locks the state if it supports locking, so that no other
+// Terraform process changes it until it's unlocked with unlockState. If
+// refresh is true the state is read again after it's locked, since it
+// might have been changed before the lock was taken.
+func (m *Meta) lockState(reason string, refresh bool) error {
+	l, ok := m.state.(state.Locker)
+	if !ok {
+		return nil
+	}
+
+	if err := l.Lock(reason); err != nil {
+		return fmt.Errorf("Error locking the state: %s", err)
+	}
+	m.stateLocked = true
+
+	if refresh {
+		if err := m.state.RefreshState(); err != nil {
+			m.unlockState()
+			return fmt.Errorf("Error reading the state: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// unlockState unlocks the state if it was locked by lockState. Failing
+// to unlock the state is only reported, since the command has finished.
+func (m *Meta) unlockState() {
+	if !m.stateLocked {
+		return
+	}
+	m.stateLocked = false
+
+	if err := m.state.(state.Locker).Unlock(); err != nil {
+		m.Ui.Error(fmt.Sprintf(
+			"Error unlocking the state: %s\n\n"+
+				"The state may have to be unlocked manually before Terraform\n"+
+				"can change it again.", err))
+	}
+}
+
 // PersistState is used to write out the state, handling backup of
 // the existing state file and respecting path configurations.
 func (m *Meta) PersistState(s *terraform.State) error {
@@ -289,6 +406,12 @@ func (m *Meta) contextOpts() *terraform.ContextOpts {
 	opts.Hooks[0] = m.uiHook()
 	copy(opts.Hooks[1:], m.ContextOpts.Hooks)
 	copy(opts.Hooks[len(m.ContextOpts.Hooks)+1:], m.extraHooks)
+	if h := m.timingHook(); h != nil {
+		opts.Hooks = append(opts.Hooks, h)
+	}
+	if h := m.auditHook(); h != nil {
+		opts.Hooks = append(opts.Hooks, h)
+	}
 
 	vs := make(map[string]string)
 	for k, v := range opts.Variables {
@@ -302,6 +425,7 @@ func (m *Meta) contextOpts() *terraform.ContextOpts {
 	}
 	opts.Variables = vs
 	opts.Targets = m.targets
+	opts.Replace = m.replace
 	opts.UIInput = m.UIInput()
 
 	return &opts
@@ -338,6 +462,23 @@ func (m *Meta) flagSet(n string) *flag.FlagSet {
 	return f
 }
 
+// validateReplace checks the addresses given with -replace. Only managed
+// resources can be replaced, as data sources are read on every plan.
+func (m *Meta) validateReplace() error {
+	for _, v := range m.replace {
+		addr, err := terraform.ParseResourceAddress(v)
+		if err != nil {
+			return fmt.Errorf("Invalid -replace address %q: %s", v, err)
+		}
+		if addr.Mode == config.DataResourceMode {
+			return fmt.Errorf(
+				"Invalid -replace address %q: data sources can't be replaced", v)
+		}
+	}
+
+	return nil
+}
+
 // moduleStorage returns the module.Storage implementation used to store
 // modules for commands.
 func (m *Meta) moduleStorage(root string) getter.Storage {
@@ -383,38 +524,149 @@ func (m *Meta) process(args []string, vars bool) []string {
 		},
 	}
 
-	// If we support vars and the default var file exists, add it to
-	// the args...
+	// If we support vars and any var files are loaded automatically, add
+	// them to the args in order, so later files override earlier ones.
 	m.autoKey = ""
 	if vars {
-		if _, err := os.Stat(DefaultVarsFilename); err == nil {
+		files := autoVarFiles()
+		if len(files) > 0 {
 			m.autoKey = "var-file-default"
-			args = append(args, "", "")
-			copy(args[2:], args[0:])
-			args[0] = "-" + m.autoKey
-			args[1] = DefaultVarsFilename
+			autoArgs := make([]string, 0, len(files)*2+len(args))
+			for _, f := range files {
+				autoArgs = append(autoArgs, "-"+m.autoKey, f)
+			}
+			args = append(autoArgs, args...)
 		}
+	}
 
-		if _, err := os.Stat(DefaultVarsFilename + ".json"); err == nil {
-			m.autoKey = "var-file-default"
-			args = append(args, "", "")
-			copy(args[2:], args[0:])
-			args[0] = "-" + m.autoKey
-			args[1] = DefaultVarsFilename + ".json"
+	return args
+}
+
+// autoVarFiles returns the var files in the working directory that are
+// loaded automatically, from lowest to highest precedence:
+// "terraform.tfvars.json", "terraform.tfvars", and then every file ending
+// in ".auto.tfvars" or ".auto.tfvars.json" in lexical order.
+func autoVarFiles() []string {
+	var result []string
+	for _, name := range []string{DefaultVarsFilename + ".json", DefaultVarsFilename} {
+		if _, err := os.Stat(name); err == nil {
+			result = append(result, name)
 		}
 	}
 
-	return args
+	// ReadDir sorts the entries by name
+	entries, err := ioutil.ReadDir(".")
+	if err != nil {
+		return result
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+		if strings.HasSuffix(name, ".auto.tfvars") ||
+			strings.HasSuffix(name, ".auto.tfvars.json") {
+			result = append(result, name)
+		}
+	}
+
+	return result
 }
 
-// uiHook returns the UiHook to use with the context.
-func (m *Meta) uiHook() *UiHook {
+// uiHook returns the hook that reports progress to the user, which is a
+// JSONHook if the output is JSON and a UiHook otherwise.
+func (m *Meta) uiHook() terraform.Hook {
+	if ui, ok := m.Ui.(*JSONUi); ok {
+		return &JSONHook{Ui: ui}
+	}
+
 	return &UiHook{
 		Colorize: m.Colorize(),
 		Ui:       m.Ui,
 	}
 }
 
+// timingHook returns the hook that writes the timing report if it's
+// enabled with TF_LOG_TIMING, or nil.
+func (m *Meta) timingHook() terraform.Hook {
+	path := os.Getenv(logging.EnvLogTiming)
+	if path == "" {
+		return nil
+	}
+
+	// The report is appended to, since a command can create more than one
+	// context. The file is closed by closeLogs when the command finishes.
+	if m.timingFile == nil {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			log.Printf("[WARN] Error opening timing report %s: %s", path, err)
+			return nil
+		}
+		m.timingFile = f
+	}
+
+	return &TimingHook{Writer: m.timingFile}
+}
+
+// auditHook returns the hook that writes the audit log if it's enabled
+// with TF_AUDIT_LOG, or nil.
+func (m *Meta) auditHook() terraform.Hook {
+	path := os.Getenv(logging.EnvAuditLog)
+	if path == "" {
+		return nil
+	}
+
+	// The log is appended to, so it holds every apply. The file is closed
+	// by closeLogs when the command finishes.
+	if m.auditFile == nil {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			log.Printf("[WARN] Error opening audit log %s: %s", path, err)
+			return nil
+		}
+		m.auditFile = f
+	}
+
+	return &AuditHook{Writer: m.auditFile}
+}
+
+// closeLogs closes the files opened by the hooks of contextOpts. Commands
+// that create a context have to call it when they finish.
+func (m *Meta) closeLogs() {
+	if m.timingFile != nil {
+		if err := m.timingFile.Close(); err != nil {
+			log.Printf("[WARN] Error closing timing report: %s", err)
+		}
+		m.timingFile = nil
+	}
+	if m.auditFile != nil {
+		if err := m.auditFile.Close(); err != nil {
+			log.Printf("[WARN] Error closing audit log: %s", err)
+		}
+		m.auditFile = nil
+	}
+}
+
+// setupJSON switches the output of the command to JSON events if the
+// -json flag was given. It has to be called after the flags are parsed.
+func (m *Meta) setupJSON() {
+	if !m.json {
+		return
+	}
+
+	// Questions would end up between the events, so don't ask any
+	m.input = false
+	m.color = false
+	m.Ui = &JSONUi{Ui: &cli.ConcurrentUi{Ui: m.oldUi}}
+}
+
+// outputJSON writes an event if the output of the command is JSON
+func (m *Meta) outputJSON(e *jsonEvent) {
+	if ui, ok := m.Ui.(*JSONUi); ok {
+		ui.Event(e)
+	}
+}
+
 const (
 	// ModuleDepthDefault is the default value for
 	// module depth, which can be overridden by flag
@@ -453,4 +705,8 @@ type contextOpts struct {
 
 	// Number of concurrent operations allowed
 	Parallelism int
+
+	// LockReason is the operation the state is locked for. If this is
+	// empty, then the state isn't locked.
+	LockReason string
 }
@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/logging"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -180,6 +181,59 @@ func TestMetaInputMode_vars(t *testing.T) {
 	}
 }
 
+func TestMeta_processAutoVarFiles(t *testing.T) {
+	// Create a temporary directory for our cwd
+	d := tempDir(t)
+	if err := os.MkdirAll(d, 0755); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := os.Chdir(d); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Chdir(cwd)
+
+	files := map[string]string{
+		DefaultVarsFilename:   "foo = \"default\"\nbar = \"default\"\nqux = \"default\"",
+		"b.auto.tfvars":       "bar = \"b\"\nbaz = \"b\"",
+		"a.auto.tfvars.json":  `{"foo": "a", "baz": "a"}`,
+		"c.tfvars":            `qux = "c"`,
+		"d.auto.tfvars.extra": `qux = "d"`,
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(d, name), []byte(content), 0644); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	m := &Meta{ContextOpts: new(terraform.ContextOpts)}
+	args := []string{"-var", "baz=cli"}
+	args = m.process(args, true)
+
+	fs := m.flagSet("foo")
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]string{
+		"foo": "a",
+		"bar": "b",
+		"baz": "b",
+		"qux": "default",
+	}
+	if !reflect.DeepEqual(m.autoVariables, expected) {
+		t.Fatalf("bad: %#v", m.autoVariables)
+	}
+
+	// Variables from the command line override the files
+	if v := m.contextOpts().Variables["baz"]; v != "cli" {
+		t.Fatalf("bad: %#v", v)
+	}
+}
+
 func TestMeta_initStatePaths(t *testing.T) {
 	m := new(Meta)
 	m.initStatePaths()
@@ -268,3 +322,49 @@ func TestMeta_addModuleDepthFlag(t *testing.T) {
 		}
 	}
 }
+
+func TestMeta_timingHook(t *testing.T) {
+	path := testTempFile(t)
+	old := os.Getenv(logging.EnvLogTiming)
+	defer os.Setenv(logging.EnvLogTiming, old)
+	os.Setenv(logging.EnvLogTiming, path)
+
+	m := new(Meta)
+	h1 := m.timingHook().(*TimingHook)
+	h2 := m.timingHook().(*TimingHook)
+	if h1.Writer != h2.Writer {
+		t.Fatal("the timing report should be opened once")
+	}
+
+	f := m.timingFile
+	m.closeLogs()
+	if m.timingFile != nil {
+		t.Fatal("timing report should be reset")
+	}
+	if err := f.Close(); err == nil {
+		t.Fatal("timing report should be closed")
+	}
+}
+
+func TestMeta_auditHook(t *testing.T) {
+	path := testTempFile(t)
+	old := os.Getenv(logging.EnvAuditLog)
+	defer os.Setenv(logging.EnvAuditLog, old)
+	os.Setenv(logging.EnvAuditLog, path)
+
+	m := new(Meta)
+	h1 := m.auditHook().(*AuditHook)
+	h2 := m.auditHook().(*AuditHook)
+	if h1.Writer != h2.Writer {
+		t.Fatal("the audit log should be opened once")
+	}
+
+	f := m.auditFile
+	m.closeLogs()
+	if m.auditFile != nil {
+		t.Fatal("audit log should be reset")
+	}
+	if err := f.Close(); err == nil {
+		t.Fatal("audit log should be closed")
+	}
+}
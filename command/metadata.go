@@ -0,0 +1,314 @@
+package command
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// MetadataCommand is a Command implementation that outputs the symbols
+// that can be referenced within a Terraform configuration as JSON, for
+// use by editors and other tooling.
+type MetadataCommand struct {
+	Meta
+}
+
+// Metadata is the document output by the metadata command.
+type Metadata struct {
+	Resources []*MetadataResource `json:"resources"`
+	Variables []*MetadataVariable `json:"variables"`
+	Outputs   []*MetadataOutput   `json:"outputs"`
+	Modules   []*MetadataModule   `json:"modules"`
+	Providers []*MetadataProvider `json:"providers"`
+	Functions []string            `json:"functions"`
+}
+
+// MetadataResource is a resource or data source in the configuration.
+// Address is the name used to reference it in interpolations.
+type MetadataResource struct {
+	Address  string `json:"address"`
+	Mode     string `json:"mode"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+}
+
+// MetadataVariable is a variable declared in the configuration.
+type MetadataVariable struct {
+	Address     string      `json:"address"`
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Default     interface{} `json:"default,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required"`
+}
+
+// MetadataOutput is an output declared in the configuration.
+type MetadataOutput struct {
+	Name      string `json:"name"`
+	Sensitive bool   `json:"sensitive"`
+}
+
+// MetadataModule is a module call in the configuration.
+type MetadataModule struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+	Source  string `json:"source"`
+}
+
+// MetadataProvider is a provider used by the configuration, along with
+// the resource types and data sources it supports if the provider
+// plugin could be loaded.
+type MetadataProvider struct {
+	Name        string                  `json:"name"`
+	Aliases     []string                `json:"aliases,omitempty"`
+	Resources   []*MetadataResourceType `json:"resources"`
+	DataSources []string                `json:"data_sources"`
+}
+
+// MetadataResourceType is a resource type supported by a provider.
+type MetadataResourceType struct {
+	Type       string `json:"type"`
+	Importable bool   `json:"importable"`
+}
+
+func (c *MetadataCommand) Run(args []string) int {
+	args = c.Meta.process(args, false)
+
+	cmdFlags := flag.NewFlagSet("metadata", flag.ContinueOnError)
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	var path string
+	args = cmdFlags.Args()
+	if len(args) > 1 {
+		c.Ui.Error("The metadata command expects at most one argument.\n")
+		cmdFlags.Usage()
+		return 1
+	} else if len(args) == 1 {
+		path = args[0]
+	} else {
+		var err error
+		path, err = os.Getwd()
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error getting pwd: %s", err))
+			return 1
+		}
+	}
+
+	conf, err := config.LoadDir(path)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error loading config: %s", err))
+		return 1
+	}
+
+	var providers map[string]terraform.ResourceProviderFactory
+	if c.Meta.ContextOpts != nil {
+		providers = c.Meta.ContextOpts.Providers
+	}
+
+	data, err := json.MarshalIndent(configMetadata(conf, providers), "", "  ")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error encoding metadata: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(string(data))
+	return 0
+}
+
+// configMetadata builds the metadata for a single configuration. Provider
+// plugins are only started for providers the configuration actually uses.
+func configMetadata(
+	conf *config.Config,
+	providers map[string]terraform.ResourceProviderFactory) *Metadata {
+	m := &Metadata{
+		Resources: make([]*MetadataResource, 0, len(conf.Resources)),
+		Variables: make([]*MetadataVariable, 0, len(conf.Variables)),
+		Outputs:   make([]*MetadataOutput, 0, len(conf.Outputs)),
+		Modules:   make([]*MetadataModule, 0, len(conf.Modules)),
+		Providers: make([]*MetadataProvider, 0),
+		Functions: config.FuncNames(),
+	}
+
+	// Providers are collected both from explicit provider blocks and from
+	// the resources that use them implicitly.
+	aliases := make(map[string][]string)
+	for _, p := range conf.ProviderConfigs {
+		if _, ok := aliases[p.Name]; !ok {
+			aliases[p.Name] = nil
+		}
+		if p.Alias != "" {
+			aliases[p.Name] = append(aliases[p.Name], p.Alias)
+		}
+	}
+
+	for _, r := range conf.Resources {
+		provider := metadataResourceProvider(r)
+		if _, ok := aliases[provider]; !ok {
+			aliases[provider] = nil
+		}
+
+		mode := "managed"
+		if r.Mode == config.DataResourceMode {
+			mode = "data"
+		}
+
+		m.Resources = append(m.Resources, &MetadataResource{
+			Address:  r.Id(),
+			Mode:     mode,
+			Type:     r.Type,
+			Name:     r.Name,
+			Provider: provider,
+		})
+	}
+	sort.Sort(metadataResourcesByAddress(m.Resources))
+
+	for _, v := range conf.Variables {
+		m.Variables = append(m.Variables, &MetadataVariable{
+			Address:     "var." + v.Name,
+			Name:        v.Name,
+			Type:        v.Type().Printable(),
+			Default:     v.Default,
+			Description: v.Description,
+			Required:    v.Required(),
+		})
+	}
+	sort.Sort(metadataVariablesByName(m.Variables))
+
+	for _, o := range conf.Outputs {
+		// Sensitive is normally only set by validation, which is skipped
+		// so that configurations being edited can still be described.
+		sensitive := o.Sensitive
+		if v, ok := o.RawConfig.Raw["sensitive"].(bool); ok {
+			sensitive = v
+		}
+
+		m.Outputs = append(m.Outputs, &MetadataOutput{
+			Name:      o.Name,
+			Sensitive: sensitive,
+		})
+	}
+	sort.Sort(metadataOutputsByName(m.Outputs))
+
+	for _, mod := range conf.Modules {
+		m.Modules = append(m.Modules, &MetadataModule{
+			Address: "module." + mod.Name,
+			Name:    mod.Name,
+			Source:  mod.Source,
+		})
+	}
+	sort.Sort(metadataModulesByName(m.Modules))
+
+	names := make([]string, 0, len(aliases))
+	for name, _ := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		p := &MetadataProvider{
+			Name:        name,
+			Aliases:     aliases[name],
+			Resources:   make([]*MetadataResourceType, 0),
+			DataSources: make([]string, 0),
+		}
+		sort.Strings(p.Aliases)
+
+		// A provider that can't be loaded is still reported, just without
+		// the types it supports, so that a missing plugin doesn't hide the
+		// rest of the configuration.
+		if f, ok := providers[name]; ok {
+			if rp, err := f(); err == nil {
+				for _, rt := range rp.Resources() {
+					p.Resources = append(p.Resources, &MetadataResourceType{
+						Type:       rt.Name,
+						Importable: rt.Importable,
+					})
+				}
+				for _, ds := range rp.DataSources() {
+					p.DataSources = append(p.DataSources, ds.Name)
+				}
+				sort.Sort(metadataResourceTypesByType(p.Resources))
+				sort.Strings(p.DataSources)
+			}
+		}
+
+		m.Providers = append(m.Providers, p)
+	}
+
+	return m
+}
+
+// metadataResourceProvider returns the name of the provider for a
+// resource, which is the prefix of its type unless set explicitly.
+func metadataResourceProvider(r *config.Resource) string {
+	if r.Provider != "" {
+		// Aliased providers are referenced as "NAME.ALIAS"
+		return strings.SplitN(r.Provider, ".", 2)[0]
+	}
+
+	return strings.SplitN(r.Type, "_", 2)[0]
+}
+
+func (c *MetadataCommand) Help() string {
+	helpText := `
+Usage: terraform metadata [options] [DIR]
+
+  Outputs the resources, variables, outputs, modules, providers and
+  interpolation functions that can be referenced in the configuration
+  in DIR (or the current directory if omitted) as JSON.
+
+  This is intended for editors and other tooling that provide
+  completion or navigation for Terraform configurations. Modules are
+  not loaded, so only the calls to them in DIR are included.
+
+Options:
+
+  -no-color           If specified, output won't contain any color.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *MetadataCommand) Synopsis() string {
+	return "Output the symbols in a configuration as JSON"
+}
+
+type metadataResourcesByAddress []*MetadataResource
+
+func (s metadataResourcesByAddress) Len() int           { return len(s) }
+func (s metadataResourcesByAddress) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s metadataResourcesByAddress) Less(i, j int) bool { return s[i].Address < s[j].Address }
+
+type metadataVariablesByName []*MetadataVariable
+
+func (s metadataVariablesByName) Len() int           { return len(s) }
+func (s metadataVariablesByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s metadataVariablesByName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
+type metadataOutputsByName []*MetadataOutput
+
+func (s metadataOutputsByName) Len() int           { return len(s) }
+func (s metadataOutputsByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s metadataOutputsByName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
+type metadataModulesByName []*MetadataModule
+
+func (s metadataModulesByName) Len() int           { return len(s) }
+func (s metadataModulesByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s metadataModulesByName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
+type metadataResourceTypesByType []*MetadataResourceType
+
+func (s metadataResourceTypesByType) Len() int           { return len(s) }
+func (s metadataResourceTypesByType) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s metadataResourceTypesByType) Less(i, j int) bool { return s[i].Type < s[j].Type }
@@ -0,0 +1,132 @@
+package command
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestMetadata(t *testing.T) {
+	p := testProvider()
+	p.DataSourcesReturn = []terraform.DataSource{
+		terraform.DataSource{Name: "test_data_source"},
+	}
+
+	ui := new(cli.MockUi)
+	c := &MetadataCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		testFixturePath("metadata"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	var actual Metadata
+	if err := json.Unmarshal(ui.OutputWriter.Bytes(), &actual); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resources := []*MetadataResource{
+		&MetadataResource{
+			Address:  "data.test_data_source.bar",
+			Mode:     "data",
+			Type:     "test_data_source",
+			Name:     "bar",
+			Provider: "test",
+		},
+		&MetadataResource{
+			Address:  "test_instance.foo",
+			Mode:     "managed",
+			Type:     "test_instance",
+			Name:     "foo",
+			Provider: "test",
+		},
+	}
+	if !reflect.DeepEqual(actual.Resources, resources) {
+		t.Fatalf("bad resources: %#v", actual.Resources)
+	}
+
+	variables := []*MetadataVariable{
+		&MetadataVariable{
+			Address:  "var.amis",
+			Name:     "amis",
+			Type:     "map",
+			Required: true,
+		},
+		&MetadataVariable{
+			Address:     "var.region",
+			Name:        "region",
+			Type:        "string",
+			Default:     "us-east-1",
+			Description: "The region to deploy to",
+		},
+	}
+	if !reflect.DeepEqual(actual.Variables, variables) {
+		t.Fatalf("bad variables: %#v", actual.Variables)
+	}
+
+	outputs := []*MetadataOutput{
+		&MetadataOutput{Name: "id", Sensitive: true},
+	}
+	if !reflect.DeepEqual(actual.Outputs, outputs) {
+		t.Fatalf("bad outputs: %#v", actual.Outputs)
+	}
+
+	modules := []*MetadataModule{
+		&MetadataModule{Address: "module.child", Name: "child", Source: "./child"},
+	}
+	if !reflect.DeepEqual(actual.Modules, modules) {
+		t.Fatalf("bad modules: %#v", actual.Modules)
+	}
+
+	providers := []*MetadataProvider{
+		&MetadataProvider{
+			Name:    "test",
+			Aliases: []string{"east"},
+			Resources: []*MetadataResourceType{
+				&MetadataResourceType{Type: "test_instance"},
+			},
+			DataSources: []string{"test_data_source"},
+		},
+	}
+	if !reflect.DeepEqual(actual.Providers, providers) {
+		t.Fatalf("bad providers: %#v", actual.Providers)
+	}
+
+	found := false
+	for _, f := range actual.Functions {
+		if f == "lookup" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("lookup missing from functions: %#v", actual.Functions)
+	}
+}
+
+func TestMetadata_multipleArgs(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := &MetadataCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"bad",
+		"bad",
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: \n%s", ui.OutputWriter.String())
+	}
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"sort"
@@ -19,7 +20,9 @@ func (c *OutputCommand) Run(args []string) int {
 	args = c.Meta.process(args, false)
 
 	var module string
+	var jsonOutput bool
 	cmdFlags := flag.NewFlagSet("output", flag.ContinueOnError)
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
 	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
 	cmdFlags.StringVar(&module, "module", "", "module")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
@@ -47,20 +50,26 @@ func (c *OutputCommand) Run(args []string) int {
 		index = args[1]
 	}
 
+	if jsonOutput && index != "" {
+		c.Ui.Error("The -json flag can't be used with an index. Select\n" +
+			"the element from the JSON output instead.\n")
+		cmdFlags.Usage()
+		return 1
+	}
+
 	stateStore, err := c.Meta.State()
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error reading state: %s", err))
 		return 1
 	}
 
-	if module == "" {
-		module = "root"
-	} else {
-		module = "root." + module
-	}
-
 	// Get the proper module we want to get outputs for
-	modPath := strings.Split(module, ".")
+	modPath, err := outputModulePath(module)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		cmdFlags.Usage()
+		return 1
+	}
 
 	state := stateStore.State()
 	mod := state.ModuleByPath(modPath)
@@ -68,7 +77,7 @@ func (c *OutputCommand) Run(args []string) int {
 	if mod == nil {
 		c.Ui.Error(fmt.Sprintf(
 			"The module %s could not be found. There is nothing to output.",
-			module))
+			strings.Join(modPath, ".")))
 		return 1
 	}
 
@@ -81,7 +90,11 @@ func (c *OutputCommand) Run(args []string) int {
 	}
 
 	if name == "" {
-		c.Ui.Output(outputsAsString(state, nil, false))
+		if jsonOutput {
+			return c.outputJSON(mod.Outputs)
+		}
+
+		c.Ui.Output(outputsAsString(state, modPath, nil, false))
 		return 0
 	}
 
@@ -95,6 +108,10 @@ func (c *OutputCommand) Run(args []string) int {
 		return 1
 	}
 
+	if jsonOutput {
+		return c.outputJSON(v.Value)
+	}
+
 	switch output := v.Value.(type) {
 	case string:
 		c.Ui.Output(output)
@@ -144,6 +161,56 @@ func (c *OutputCommand) Run(args []string) int {
 	return 0
 }
 
+// outputJSON outputs the value encoded as JSON. All outputs are encoded
+// with their type and sensitivity, like in the state, so that lists and
+// maps can be told apart from strings.
+func (c *OutputCommand) outputJSON(v interface{}) int {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error encoding outputs as JSON: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(string(data))
+	return 0
+}
+
+// outputModulePath returns the state module path for the value of the
+// -module flag. Nested modules can be given either as "foo.bar" or in the
+// resource address form "module.foo.module.bar".
+func outputModulePath(module string) ([]string, error) {
+	path := []string{"root"}
+	if module == "" {
+		return path, nil
+	}
+
+	parts := strings.Split(module, ".")
+	for _, p := range parts {
+		if p == "" {
+			return nil, fmt.Errorf("Invalid module path %q: empty module name", module)
+		}
+	}
+
+	if parts[0] != "module" {
+		return append(path, parts...), nil
+	}
+
+	// In the address form every name must follow a "module" keyword.
+	if len(parts)%2 != 0 {
+		return nil, fmt.Errorf(
+			"Invalid module path %q: expected \"module.NAME\" for every module", module)
+	}
+	for i := 0; i < len(parts); i += 2 {
+		if parts[i] != "module" {
+			return nil, fmt.Errorf(
+				"Invalid module path %q: expected \"module.NAME\" for every module", module)
+		}
+		path = append(path, parts[i+1])
+	}
+
+	return path, nil
+}
+
 func formatListOutput(indent, outputName string, outputList []interface{}) string {
 	keyIndent := ""
 
@@ -198,6 +265,9 @@ Usage: terraform output [options] [NAME]
   Reads an output variable from a Terraform state file and prints
   the value.  If NAME is not specified, all outputs are printed.
 
+  The value of a single string output is printed as-is, without
+  quotes, so it can be used directly in shell scripts.
+
 Options:
 
   -state=path      Path to the state file to read. Defaults to
@@ -205,8 +275,14 @@ Options:
 
   -no-color        If specified, output won't contain any color.
 
-  -module=name     If specified, returns the outputs for a
-                   specific module
+  -json            If specified, the outputs are printed as JSON. With
+                   NAME, only the value is printed. Otherwise all
+                   outputs are printed with their type and whether
+                   they're sensitive.
+
+  -module=path     If specified, returns the outputs for a
+                   specific module. Nested modules can be given
+                   as "foo.bar" or "module.foo.module.bar".
 
 `
 	return strings.TrimSpace(helpText)
@@ -1,9 +1,11 @@
 package command
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -50,6 +52,145 @@ func TestOutput(t *testing.T) {
 	}
 }
 
+func TestOutput_json(t *testing.T) {
+	originalState := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]*terraform.OutputState{
+					"foo": &terraform.OutputState{
+						Value: "bar",
+						Type:  "string",
+					},
+					"list": &terraform.OutputState{
+						Value: []interface{}{"a", "b"},
+						Type:  "list",
+					},
+					"password": &terraform.OutputState{
+						Value:     "secret",
+						Type:      "string",
+						Sensitive: true,
+					},
+				},
+			},
+		},
+	}
+
+	statePath := testStateFile(t, originalState)
+
+	ui := new(cli.MockUi)
+	c := &OutputCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-json",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	var actual map[string]*terraform.OutputState
+	if err := json.Unmarshal(ui.OutputWriter.Bytes(), &actual); err != nil {
+		t.Fatalf("err: %s\n%s", err, ui.OutputWriter.String())
+	}
+
+	expected := originalState.Modules[0].Outputs
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %s", ui.OutputWriter.String())
+	}
+}
+
+func TestOutput_jsonName(t *testing.T) {
+	originalState := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]*terraform.OutputState{
+					"foo": &terraform.OutputState{
+						Value: "bar",
+						Type:  "string",
+					},
+					"map": &terraform.OutputState{
+						Value: map[string]interface{}{"key": "value"},
+						Type:  "map",
+					},
+				},
+			},
+		},
+	}
+
+	statePath := testStateFile(t, originalState)
+
+	cases := map[string]string{
+		"foo": `"bar"`,
+		"map": "{\n  \"key\": \"value\"\n}",
+	}
+	for name, expected := range cases {
+		ui := new(cli.MockUi)
+		c := &OutputCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(testProvider()),
+				Ui:          ui,
+			},
+		}
+
+		args := []string{
+			"-state", statePath,
+			"-json",
+			name,
+		}
+		if code := c.Run(args); code != 0 {
+			t.Fatalf("%s: bad: \n%s", name, ui.ErrorWriter.String())
+		}
+
+		actual := strings.TrimSpace(ui.OutputWriter.String())
+		if actual != expected {
+			t.Fatalf("%s: bad: %#v", name, actual)
+		}
+	}
+}
+
+func TestOutput_jsonIndex(t *testing.T) {
+	originalState := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]*terraform.OutputState{
+					"list": &terraform.OutputState{
+						Value: []interface{}{"a", "b"},
+						Type:  "list",
+					},
+				},
+			},
+		},
+	}
+
+	statePath := testStateFile(t, originalState)
+
+	ui := new(cli.MockUi)
+	c := &OutputCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-json",
+		"list",
+		"0",
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: \n%s", ui.OutputWriter.String())
+	}
+}
+
 func TestModuleOutput(t *testing.T) {
 	originalState := &terraform.State{
 		Modules: []*terraform.ModuleState{
@@ -100,6 +241,178 @@ func TestModuleOutput(t *testing.T) {
 	}
 }
 
+func TestModuleOutput_nested(t *testing.T) {
+	originalState := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]*terraform.OutputState{
+					"foo": &terraform.OutputState{
+						Value: "bar",
+						Type:  "string",
+					},
+				},
+			},
+			&terraform.ModuleState{
+				Path: []string{"root", "my_module", "child"},
+				Outputs: map[string]*terraform.OutputState{
+					"blah": &terraform.OutputState{
+						Value: "tastatur",
+						Type:  "string",
+					},
+				},
+			},
+		},
+	}
+
+	statePath := testStateFile(t, originalState)
+
+	for _, module := range []string{"my_module.child", "module.my_module.module.child"} {
+		ui := new(cli.MockUi)
+		c := &OutputCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(testProvider()),
+				Ui:          ui,
+			},
+		}
+
+		args := []string{
+			"-state", statePath,
+			"-module", module,
+			"blah",
+		}
+
+		if code := c.Run(args); code != 0 {
+			t.Fatalf("%s: bad: \n%s", module, ui.ErrorWriter.String())
+		}
+
+		actual := strings.TrimSpace(ui.OutputWriter.String())
+		if actual != "tastatur" {
+			t.Fatalf("%s: bad: %#v", module, actual)
+		}
+	}
+}
+
+func TestOutputModulePath(t *testing.T) {
+	cases := map[string][]string{
+		"":                               []string{"root"},
+		"foo":                            []string{"root", "foo"},
+		"foo.bar":                        []string{"root", "foo", "bar"},
+		"module.foo":                     []string{"root", "foo"},
+		"module.foo.module.bar":          []string{"root", "foo", "bar"},
+		"module.foo.module.bar.module.b": []string{"root", "foo", "bar", "b"},
+	}
+	for input, expected := range cases {
+		actual, err := outputModulePath(input)
+		if err != nil {
+			t.Fatalf("%q: err: %s", input, err)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("%q: expected %#v, got %#v", input, expected, actual)
+		}
+	}
+
+	for _, input := range []string{
+		"module",
+		"module.foo.bar",
+		"module.foo.module",
+		"module.foo.other.bar",
+		"foo..bar",
+		".foo",
+	} {
+		if _, err := outputModulePath(input); err == nil {
+			t.Fatalf("%q: expected error", input)
+		}
+	}
+}
+
+func TestModuleOutput_badModulePath(t *testing.T) {
+	originalState := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root", "foo"},
+				Outputs: map[string]*terraform.OutputState{
+					"blah": &terraform.OutputState{
+						Value: "tastatur",
+						Type:  "string",
+					},
+				},
+			},
+		},
+	}
+
+	statePath := testStateFile(t, originalState)
+
+	ui := new(cli.MockUi)
+	c := &OutputCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-module", "module.foo.bar",
+		"blah",
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: \n%s", ui.OutputWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "Invalid module path") {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+}
+
+func TestModuleOutput_all(t *testing.T) {
+	originalState := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]*terraform.OutputState{
+					"foo": &terraform.OutputState{
+						Value: "bar",
+						Type:  "string",
+					},
+				},
+			},
+			&terraform.ModuleState{
+				Path: []string{"root", "my_module"},
+				Outputs: map[string]*terraform.OutputState{
+					"blah": &terraform.OutputState{
+						Value: "tastatur",
+						Type:  "string",
+					},
+				},
+			},
+		},
+	}
+
+	statePath := testStateFile(t, originalState)
+
+	ui := new(cli.MockUi)
+	c := &OutputCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-module", "my_module",
+	}
+
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	actual := strings.TrimSpace(ui.OutputWriter.String())
+	if actual != "blah = tastatur" {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
 func TestMissingModuleOutput(t *testing.T) {
 	originalState := &terraform.State{
 		Modules: []*terraform.ModuleState{
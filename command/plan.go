@@ -16,7 +16,7 @@ type PlanCommand struct {
 }
 
 func (c *PlanCommand) Run(args []string) int {
-	var destroy, refresh, detailed bool
+	var destroy, refresh, refreshOnly, detailed, estimateCost bool
 	var outPath string
 	var moduleDepth int
 
@@ -25,16 +25,38 @@ func (c *PlanCommand) Run(args []string) int {
 	cmdFlags := c.Meta.flagSet("plan")
 	cmdFlags.BoolVar(&destroy, "destroy", false, "destroy")
 	cmdFlags.BoolVar(&refresh, "refresh", true, "refresh")
+	cmdFlags.BoolVar(&refreshOnly, "refresh-only", false, "refresh-only")
+	cmdFlags.Var((*FlagStringSlice)(&c.Meta.replace), "replace", "resource to replace")
 	c.addModuleDepthFlag(cmdFlags, &moduleDepth)
 	cmdFlags.StringVar(&outPath, "out", "", "path")
 	cmdFlags.IntVar(
 		&c.Meta.parallelism, "parallelism", DefaultParallelism, "parallelism")
 	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	cmdFlags.BoolVar(&c.Meta.stateReadOnly, "state-read-only", false, "state-read-only")
 	cmdFlags.BoolVar(&detailed, "detailed-exitcode", false, "detailed-exitcode")
+	cmdFlags.BoolVar(&estimateCost, "estimate-cost", false, "estimate-cost")
+	cmdFlags.BoolVar(&c.Meta.json, "json", false, "json")
+	cmdFlags.StringVar(&c.Meta.policyDir, "policy-dir", "", "path")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
 	}
+	c.Meta.setupJSON()
+
+	if refreshOnly && (destroy || !refresh) {
+		c.Ui.Error("The -refresh-only flag can't be used with -destroy or -refresh=false.\n")
+		cmdFlags.Usage()
+		return 1
+	}
+	if len(c.Meta.replace) > 0 && (destroy || refreshOnly) {
+		c.Ui.Error("The -replace flag can't be used with -destroy or -refresh-only.\n")
+		cmdFlags.Usage()
+		return 1
+	}
+	if err := c.Meta.validateReplace(); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
 
 	var path string
 	args = cmdFlags.Args()
@@ -57,6 +79,8 @@ func (c *PlanCommand) Run(args []string) int {
 	countHook := new(CountHook)
 	c.Meta.extraHooks = []terraform.Hook{countHook}
 
+	defer c.Meta.closeLogs()
+
 	ctx, _, err := c.Context(contextOpts{
 		Destroy:     destroy,
 		Path:        path,
@@ -77,6 +101,10 @@ func (c *PlanCommand) Run(args []string) int {
 		return 1
 	}
 
+	if refreshOnly {
+		return c.runRefreshOnly(ctx, outPath, moduleDepth, detailed)
+	}
+
 	if refresh {
 		c.Ui.Output("Refreshing Terraform state in-memory prior to plan...")
 		c.Ui.Output("The refreshed state will be used to calculate this plan, but")
@@ -95,6 +123,19 @@ func (c *PlanCommand) Run(args []string) int {
 		return 1
 	}
 
+	// A plan that violates the policies is still shown, but isn't saved
+	// so it can't be applied.
+	if !c.enforcePolicies(plan.Diff) {
+		if !plan.Diff.Empty() {
+			c.Ui.Output(FormatPlan(&FormatPlanOpts{
+				Plan:        plan,
+				Color:       c.Colorize(),
+				ModuleDepth: moduleDepth,
+			}))
+		}
+		return 1
+	}
+
 	if outPath != "" {
 		log.Printf("[INFO] Writing plan output to: %s", outPath)
 		f, err := os.Create(outPath)
@@ -108,6 +149,20 @@ func (c *PlanCommand) Run(args []string) int {
 		}
 	}
 
+	for _, e := range jsonPlanEvents(plan) {
+		c.outputJSON(e)
+	}
+	add := countHook.ToAdd + countHook.ToRemoveAndAdd
+	change := countHook.ToChange
+	remove := countHook.ToRemove + countHook.ToRemoveAndAdd
+	c.outputJSON(&jsonEvent{
+		Type:      "change_summary",
+		Operation: "plan",
+		Add:       &add,
+		Change:    &change,
+		Remove:    &remove,
+	})
+
 	if plan.Diff.Empty() {
 		c.Ui.Output(
 			"No changes. Infrastructure is up-to-date. This means that Terraform\n" +
@@ -134,9 +189,13 @@ func (c *PlanCommand) Run(args []string) int {
 	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
 		"[reset][bold]Plan:[reset] "+
 			"%d to add, %d to change, %d to destroy.",
-		countHook.ToAdd+countHook.ToRemoveAndAdd,
-		countHook.ToChange,
-		countHook.ToRemove+countHook.ToRemoveAndAdd)))
+		add, change, remove)))
+
+	if estimateCost {
+		if estimates := estimateCosts(plan); len(estimates) > 0 {
+			c.Ui.Output("\n" + formatCostEstimates(estimates, c.Colorize()))
+		}
+	}
 
 	if detailed {
 		return 2
@@ -144,6 +203,179 @@ func (c *PlanCommand) Run(args []string) int {
 	return 0
 }
 
+// runRefreshOnly refreshes the state and shows how it differs from the
+// state before the refresh, without planning any changes from the
+// configuration. The refreshed state is only persisted if the saved plan
+// is applied.
+func (c *PlanCommand) runRefreshOnly(
+	ctx *terraform.Context, outPath string, moduleDepth int, detailed bool) int {
+	state, err := c.State()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading state: %s", err))
+		return 1
+	}
+	prior := state.State()
+
+	c.Ui.Output("Refreshing Terraform state in-memory to detect changes made")
+	c.Ui.Output("outside of Terraform. The refreshed state will only be persisted")
+	c.Ui.Output("if this plan is saved with \"-out\" and applied.\n")
+	refreshed, err := ctx.Refresh()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error refreshing state: %s", err))
+		return 1
+	}
+	c.Ui.Output("")
+
+	plan := ctx.PlanRefreshOnly()
+	if outPath != "" {
+		log.Printf("[INFO] Writing plan output to: %s", outPath)
+		f, err := os.Create(outPath)
+		if err == nil {
+			defer f.Close()
+			err = terraform.WritePlan(plan, f)
+		}
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error writing plan file: %s", err))
+			return 1
+		}
+	}
+
+	// The drift is shown as a diff from the prior to the refreshed state
+	drift := &terraform.Plan{Diff: refreshDrift(prior, refreshed)}
+
+	var change, remove int
+	for _, m := range drift.Diff.Modules {
+		for _, d := range m.Resources {
+			if d.Destroy {
+				remove++
+			} else {
+				change++
+			}
+		}
+	}
+
+	for _, e := range jsonPlanEvents(drift) {
+		c.outputJSON(e)
+	}
+	add := 0
+	c.outputJSON(&jsonEvent{
+		Type:      "change_summary",
+		Operation: "refresh",
+		Add:       &add,
+		Change:    &change,
+		Remove:    &remove,
+	})
+
+	if drift.Diff.Empty() {
+		c.Ui.Output(
+			"No changes. Terraform could not detect any differences between\n" +
+				"the state and the real physical resources that exist.")
+		return 0
+	}
+
+	if outPath == "" {
+		c.Ui.Output(strings.TrimSpace(planRefreshOnlyHeaderNoOutput) + "\n")
+	} else {
+		c.Ui.Output(fmt.Sprintf(
+			strings.TrimSpace(planRefreshOnlyHeaderYesOutput)+"\n",
+			outPath))
+	}
+
+	c.Ui.Output(FormatPlan(&FormatPlanOpts{
+		Plan:        drift,
+		Color:       c.Colorize(),
+		ModuleDepth: moduleDepth,
+	}))
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"[reset][bold]Drift:[reset] "+
+			"%d changed, %d deleted outside of Terraform.",
+		change, remove)))
+
+	if detailed {
+		return 2
+	}
+	return 0
+}
+
+// refreshDrift returns the changes between the state before and after a
+// refresh as a diff. Resources that no longer exist are destroyed and
+// changed attributes are updated from their old to their new value.
+// Data sources are read on every refresh, so they're left out.
+func refreshDrift(prior, refreshed *terraform.State) *terraform.Diff {
+	diff := new(terraform.Diff)
+	if prior == nil {
+		return diff
+	}
+
+	for _, m := range prior.Modules {
+		var refreshedMod *terraform.ModuleState
+		if refreshed != nil {
+			refreshedMod = refreshed.ModuleByPath(m.Path)
+		}
+
+		for name, r := range m.Resources {
+			if r.Primary == nil || r.Primary.ID == "" || strings.HasPrefix(name, "data.") {
+				continue
+			}
+
+			var after *terraform.InstanceState
+			if refreshedMod != nil {
+				if rr, ok := refreshedMod.Resources[name]; ok {
+					after = rr.Primary
+				}
+			}
+
+			d := instanceDrift(r.Primary, after)
+			if d == nil {
+				continue
+			}
+
+			md := diff.ModuleByPath(m.Path)
+			if md == nil {
+				md = diff.AddModule(m.Path)
+			}
+			md.Resources[name] = d
+		}
+	}
+
+	return diff
+}
+
+// instanceDrift returns the diff from the instance before to the one after
+// a refresh, or nil if nothing changed.
+func instanceDrift(before, after *terraform.InstanceState) *terraform.InstanceDiff {
+	if after == nil || after.ID == "" {
+		return &terraform.InstanceDiff{Destroy: true}
+	}
+
+	attrs := make(map[string]*terraform.ResourceAttrDiff)
+	for k, v := range before.Attributes {
+		if after.Attributes[k] != v || !hasAttr(after, k) {
+			attrs[k] = &terraform.ResourceAttrDiff{
+				Old:        v,
+				New:        after.Attributes[k],
+				NewRemoved: !hasAttr(after, k),
+			}
+		}
+	}
+	for k, v := range after.Attributes {
+		if !hasAttr(before, k) {
+			attrs[k] = &terraform.ResourceAttrDiff{New: v}
+		}
+	}
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	return &terraform.InstanceDiff{Attributes: attrs}
+}
+
+func hasAttr(s *terraform.InstanceState, k string) bool {
+	_, ok := s.Attributes[k]
+	return ok
+}
+
 func (c *PlanCommand) Help() string {
 	helpText := `
 Usage: terraform plan [options] [dir]
@@ -170,25 +402,51 @@ Options:
                       1 - Errored
                       2 - Succeeded, there is a diff
 
+  -estimate-cost      Estimate the change of the monthly cost for the AWS
+                      resources that support it: instances, EBS volumes, RDS
+                      instances and ElastiCache clusters.
+
   -input=true         Ask for input for variables if not directly set.
+                      If false, missing required variables are an error.
+
+  -json               Write the planned changes as JSON events, one per line,
+                      instead of human readable output.
 
   -module-depth=n     Specifies the depth of modules to show in the output.
                       This does not affect the plan itself, only the output
                       shown. By default, this is -1, which will expand all.
 
-  -no-color           If specified, output won't contain any color.
+  -no-color           If specified, output won't contain any color, and
+                      non-ASCII characters in attribute values are escaped.
 
   -out=path           Write a plan file to the given path. This can be used as
                       input to the "apply" command.
 
   -parallelism=n      Limit the number of concurrent operations. Defaults to 10.
 
+  -policy-dir=path    Check the plan against the policies in this directory.
+                      If any policy denies the plan, the exit code is 1 and
+                      the plan isn't written to "-out".
+
   -refresh=true       Update state prior to checking for differences.
 
+  -refresh-only       Only refresh the state and show the changes made to
+                      resources outside of Terraform, without planning any
+                      changes from the configuration. Applying a plan saved
+                      with "-out" persists the refreshed state.
+
+  -replace=resource   Resource to replace. The plan will destroy and recreate
+                      this resource, even if its configuration didn't change.
+                      This flag can be used multiple times.
+
   -state=statefile    Path to a Terraform state file to use to look
                       up Terraform-managed resources. By default it will
                       use the state "terraform.tfstate" if it exists.
 
+  -state-read-only    Never write the state. The local remote state cache
+                      isn't updated and no backup is made, so the plan works
+                      with read-only access to the state storage.
+
   -target=resource    Resource to target. Operation will be limited to this
                       resource and its dependencies. This flag can be used
                       multiple times.
@@ -197,8 +455,8 @@ Options:
                       flag can be set multiple times.
 
   -var-file=foo       Set variables in the Terraform configuration from
-                      a file. If "terraform.tfvars" is present, it will be
-                      automatically loaded if this flag is not specified.
+                      a file. If "terraform.tfvars" or any ".auto.tfvars"
+                      files are present, they will be automatically loaded.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -231,3 +489,24 @@ plan.
 
 Path: %s
 `
+
+const planRefreshOnlyHeaderNoOutput = `
+Terraform detected the following changes made outside of Terraform since
+the state was last updated. Yellow resources have changed and red resources
+no longer exist. No changes to your infrastructure are planned.
+
+Note: You didn't specify an "-out" parameter to save this plan, so the
+refreshed state has not been persisted. Save the plan and apply it to
+update the state with these changes.
+`
+
+const planRefreshOnlyHeaderYesOutput = `
+Terraform detected the following changes made outside of Terraform since
+the state was last updated. Yellow resources have changed and red resources
+no longer exist. No changes to your infrastructure are planned.
+
+Your plan was also saved to the path below. Call the "apply" subcommand
+with this plan file to update the state with these changes.
+
+Path: %s
+`
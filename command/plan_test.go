@@ -2,7 +2,10 @@ package command
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -37,6 +40,90 @@ func TestPlan(t *testing.T) {
 	}
 }
 
+func TestPlan_json(t *testing.T) {
+	p := testProvider()
+	p.DiffReturn = &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"ami": &terraform.ResourceAttrDiff{New: "bar", RequiresNew: true},
+		},
+	}
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-json",
+		"-state", testTempFile(t),
+		testFixturePath("plan"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+
+	events := testJSONEvents(t, ui.OutputWriter.String())
+	var planned, summary *jsonEvent
+	for _, e := range events {
+		switch e.Type {
+		case "planned_change":
+			planned = e
+		case "change_summary":
+			summary = e
+		}
+	}
+
+	if planned == nil || planned.Resource != "test_instance.foo" || planned.Action != "create" {
+		t.Fatalf("bad: %#v", planned)
+	}
+	if summary == nil || summary.Operation != "plan" ||
+		*summary.Add != 1 || *summary.Change != 0 || *summary.Remove != 0 {
+		t.Fatalf("bad: %#v", summary)
+	}
+}
+
+func TestPlan_requiredVersion(t *testing.T) {
+	// The state is invalid, so that reading it would fail. The version
+	// check must happen first.
+	statePath := testTempFile(t)
+	if err := ioutil.WriteFile(statePath, []byte("not a state"), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	defer os.Setenv(UpgradeURLEnvVar, os.Getenv(UpgradeURLEnvVar))
+	os.Setenv(UpgradeURLEnvVar, "https://example.com/upgrade")
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		testFixturePath("plan-required-version"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+
+	output := ui.ErrorWriter.String()
+	if !strings.Contains(output, "Required version: >= 99.0.0") {
+		t.Fatalf("missing required version: %s", output)
+	}
+	if !strings.Contains(output, "https://example.com/upgrade") {
+		t.Fatalf("missing upgrade URL: %s", output)
+	}
+	if p.DiffCalled {
+		t.Fatal("diff should not be called")
+	}
+}
+
 func TestPlan_destroy(t *testing.T) {
 	originalState := &terraform.State{
 		Modules: []*terraform.ModuleState{
@@ -163,6 +250,50 @@ func TestPlan_outPath(t *testing.T) {
 	}
 }
 
+func TestPlan_policyDir(t *testing.T) {
+	tf, err := ioutil.TempFile("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	outPath := tf.Name()
+	os.Remove(tf.Name())
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	p.DiffReturn = &terraform.InstanceDiff{
+		Destroy: true,
+	}
+
+	args := []string{
+		"-out", outPath,
+		"-policy-dir", testFixturePath("policies"),
+		testFixturePath("plan"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+
+	errStr := ui.ErrorWriter.String()
+	if !strings.Contains(errStr, "deny-destroy: resources must not be destroyed") {
+		t.Fatalf("bad: %s", errStr)
+	}
+	if strings.Contains(errStr, "allow") || strings.Contains(errStr, "README") {
+		t.Fatalf("bad: %s", errStr)
+	}
+
+	// The denied plan must not be saved
+	if _, err := os.Stat(outPath); !os.IsNotExist(err) {
+		t.Fatalf("plan should not be written: %s", err)
+	}
+}
+
 func TestPlan_outPathNoChange(t *testing.T) {
 	originalState := &terraform.State{
 		Modules: []*terraform.ModuleState{
@@ -276,6 +407,76 @@ func TestPlan_state(t *testing.T) {
 	}
 }
 
+func TestPlan_stateReadOnly(t *testing.T) {
+	// The remote state cache is older than the remote state in the first
+	// case, so it would be updated, and newer in the second case, so it
+	// would be pushed.
+	for _, localSerial := range []int64{5, 20} {
+		tmp, cwd := testCwd(t)
+
+		remoteState := testState()
+		remoteState.Serial = 10
+		var remoteData bytes.Buffer
+		if err := json.NewEncoder(&remoteData).Encode(remoteState); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		var writes []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "GET" {
+				writes = append(writes, r.Method)
+			}
+			w.Write(remoteData.Bytes())
+		}))
+
+		localState := testState()
+		localState.Serial = localSerial
+		localState.Remote = &terraform.RemoteState{
+			Type:   "http",
+			Config: map[string]string{"address": srv.URL},
+		}
+		cachePath := testStateFileRemote(t, localState)
+		cache, err := ioutil.ReadFile(cachePath)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		p := testProvider()
+		ui := new(cli.MockUi)
+		c := &PlanCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(p),
+				Ui:          ui,
+			},
+		}
+
+		args := []string{
+			"-state-read-only",
+			testFixturePath("plan"),
+		}
+		if code := c.Run(args); code != 0 {
+			t.Fatalf("%d: bad: %d\n\n%s", localSerial, code, ui.ErrorWriter.String())
+		}
+
+		if len(writes) > 0 {
+			t.Fatalf("%d: remote state written: %v", localSerial, writes)
+		}
+		actual, err := ioutil.ReadFile(cachePath)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if !bytes.Equal(actual, cache) {
+			t.Fatalf("%d: remote state cache written:\n%s", localSerial, actual)
+		}
+		if _, err := os.Stat(cachePath + DefaultBackupExtension); err == nil {
+			t.Fatalf("%d: backup written", localSerial)
+		}
+
+		srv.Close()
+		testFixCwd(t, tmp, cwd)
+	}
+}
+
 func TestPlan_stateDefault(t *testing.T) {
 	originalState := testState()
 
@@ -593,6 +794,70 @@ func TestPlan_detailedExitcode_emptyDiff(t *testing.T) {
 	}
 }
 
+func TestRefreshDrift(t *testing.T) {
+	prior := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Resources: map[string]*terraform.ResourceState{
+					"test_instance.same": &terraform.ResourceState{
+						Primary: &terraform.InstanceState{
+							ID:         "a",
+							Attributes: map[string]string{"id": "a", "ami": "bar"},
+						},
+					},
+					"test_instance.changed": &terraform.ResourceState{
+						Primary: &terraform.InstanceState{
+							ID:         "b",
+							Attributes: map[string]string{"id": "b", "ami": "bar", "tag": "x"},
+						},
+					},
+					"test_instance.gone": &terraform.ResourceState{
+						Primary: &terraform.InstanceState{ID: "c"},
+					},
+					"data.test_data.foo": &terraform.ResourceState{
+						Primary: &terraform.InstanceState{ID: "d"},
+					},
+				},
+			},
+		},
+	}
+
+	refreshed := prior.DeepCopy()
+	resources := refreshed.RootModule().Resources
+	resources["test_instance.changed"].Primary.Attributes = map[string]string{
+		"id":  "b",
+		"ami": "baz",
+		"new": "y",
+	}
+	delete(resources, "test_instance.gone")
+	delete(resources, "data.test_data.foo")
+
+	diff := refreshDrift(prior, refreshed)
+	mod := diff.RootModule()
+	if mod == nil || len(mod.Resources) != 2 {
+		t.Fatalf("bad: %s", diff)
+	}
+
+	if !mod.Resources["test_instance.gone"].Destroy {
+		t.Fatalf("bad: %s", diff)
+	}
+
+	attrs := mod.Resources["test_instance.changed"].Attributes
+	if len(attrs) != 3 {
+		t.Fatalf("bad: %#v", attrs)
+	}
+	if a := attrs["ami"]; a.Old != "bar" || a.New != "baz" {
+		t.Fatalf("bad: %#v", a)
+	}
+	if a := attrs["tag"]; a.Old != "x" || !a.NewRemoved {
+		t.Fatalf("bad: %#v", a)
+	}
+	if a := attrs["new"]; a.New != "y" {
+		t.Fatalf("bad: %#v", a)
+	}
+}
+
 const planVarFile = `
 foo = "bar"
 `
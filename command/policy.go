@@ -0,0 +1,182 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// policyInput is the document every policy receives on stdin. It lists
+// every resource that changes in the plan, ordered by their address.
+type policyInput struct {
+	Resources []*policyResource `json:"resources"`
+}
+
+// policyResource is a single planned change given to policies
+type policyResource struct {
+	Address    string                      `json:"address"`
+	Type       string                      `json:"type"`
+	Action     string                      `json:"action"`
+	Attributes map[string]*policyAttribute `json:"attributes"`
+}
+
+// policyAttribute is the planned change of a single attribute. The
+// values of sensitive attributes are never given to policies.
+type policyAttribute struct {
+	Old       string `json:"old"`
+	New       string `json:"new"`
+	Computed  bool   `json:"computed,omitempty"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+}
+
+// policyViolation is a policy that denied a plan, with the output it
+// gave as the reason.
+type policyViolation struct {
+	Policy string
+	Output string
+}
+
+// newPolicyInput returns the policy input for the changes in the diff
+func newPolicyInput(diff *terraform.Diff) *policyInput {
+	input := &policyInput{Resources: make([]*policyResource, 0)}
+	if diff == nil {
+		return input
+	}
+
+	for _, m := range diff.Modules {
+		prefix := ""
+		for _, name := range m.Path[1:] {
+			prefix += "module." + name + "."
+		}
+
+		for name, d := range m.Resources {
+			action := jsonDiffAction(d)
+			if action == "" {
+				continue
+			}
+
+			r := &policyResource{
+				Address:    prefix + name,
+				Action:     action,
+				Attributes: make(map[string]*policyAttribute),
+			}
+			if key, err := terraform.ParseResourceStateKey(name); err == nil {
+				r.Type = key.Type
+			}
+
+			for k, attr := range d.Attributes {
+				a := &policyAttribute{
+					Old:       attr.Old,
+					New:       attr.New,
+					Computed:  attr.NewComputed,
+					Sensitive: attr.Sensitive,
+				}
+				if a.Sensitive {
+					a.Old, a.New = "", ""
+				}
+
+				r.Attributes[k] = a
+			}
+
+			input.Resources = append(input.Resources, r)
+		}
+	}
+
+	sort.Sort(policyResourcesByAddress(input.Resources))
+	return input
+}
+
+type policyResourcesByAddress []*policyResource
+
+func (s policyResourcesByAddress) Len() int           { return len(s) }
+func (s policyResourcesByAddress) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s policyResourcesByAddress) Less(i, j int) bool { return s[i].Address < s[j].Address }
+
+// checkPolicies runs every executable file in dir, in lexical order, with
+// the policy input for the diff on stdin. A policy that exits with a
+// non-zero status denies the plan.
+func checkPolicies(dir string, diff *terraform.Diff) ([]*policyViolation, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading policy directory: %s", err)
+	}
+
+	input, err := json.Marshal(newPolicyInput(diff))
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []*policyViolation
+	for _, entry := range entries {
+		// Only executable files are policies, so the directory can hold
+		// other files like data or documentation.
+		if !entry.Mode().IsRegular() || entry.Mode().Perm()&0111 == 0 {
+			continue
+		}
+
+		path, err := filepath.Abs(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var output bytes.Buffer
+		cmd := exec.Command(path)
+		cmd.Dir = dir
+		cmd.Stdin = bytes.NewReader(input)
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+		err = cmd.Run()
+		if err == nil {
+			continue
+		}
+		if _, ok := err.(*exec.ExitError); !ok {
+			return nil, fmt.Errorf(
+				"Error running policy %s: %s", entry.Name(), err)
+		}
+
+		violations = append(violations, &policyViolation{
+			Policy: entry.Name(),
+			Output: strings.TrimSpace(output.String()),
+		})
+	}
+
+	return violations, nil
+}
+
+// enforcePolicies checks the diff against the policies in the policy
+// directory of the command, if one is set, and outputs any violations.
+// It returns false if the diff must not be applied.
+func (m *Meta) enforcePolicies(diff *terraform.Diff) bool {
+	if m.policyDir == "" {
+		return true
+	}
+
+	violations, err := checkPolicies(m.policyDir, diff)
+	if err != nil {
+		m.Ui.Error(err.Error())
+		return false
+	}
+	if len(violations) == 0 {
+		return true
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("The plan violates the following policies:\n\n")
+	for _, v := range violations {
+		buf.WriteString(fmt.Sprintf("  * %s", v.Policy))
+		if v.Output != "" {
+			buf.WriteString(": " + strings.Replace(v.Output, "\n", "\n    ", -1))
+		}
+		buf.WriteString("\n")
+	}
+	m.Ui.Error(strings.TrimSpace(buf.String()))
+
+	return false
+}
@@ -0,0 +1,58 @@
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestNewPolicyInput(t *testing.T) {
+	diff := &terraform.Diff{
+		Modules: []*terraform.ModuleDiff{
+			&terraform.ModuleDiff{
+				Path: []string{"root"},
+				Resources: map[string]*terraform.InstanceDiff{
+					"aws_instance.foo": &terraform.InstanceDiff{
+						Attributes: map[string]*terraform.ResourceAttrDiff{
+							"instance_type": &terraform.ResourceAttrDiff{
+								New: "m4.large",
+							},
+							"id": &terraform.ResourceAttrDiff{
+								NewComputed: true,
+							},
+							"password": &terraform.ResourceAttrDiff{
+								New:       "secret",
+								Sensitive: true,
+							},
+						},
+					},
+					"aws_instance.unchanged": &terraform.InstanceDiff{},
+				},
+			},
+			&terraform.ModuleDiff{
+				Path: []string{"root", "child"},
+				Resources: map[string]*terraform.InstanceDiff{
+					"aws_instance.bar.0": &terraform.InstanceDiff{
+						Destroy: true,
+					},
+				},
+			},
+		},
+	}
+
+	raw, err := json.Marshal(newPolicyInput(diff))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := `{"resources":[` +
+		`{"address":"aws_instance.foo","type":"aws_instance","action":"update","attributes":{` +
+		`"id":{"old":"","new":"","computed":true},` +
+		`"instance_type":{"old":"","new":"m4.large"},` +
+		`"password":{"old":"","new":"","sensitive":true}}},` +
+		`{"address":"module.child.aws_instance.bar.0","type":"aws_instance","action":"destroy","attributes":{}}]}`
+	if actual := string(raw); actual != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
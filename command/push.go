@@ -1,6 +1,7 @@
 package command
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"os"
@@ -22,8 +23,8 @@ type PushCommand struct {
 }
 
 func (c *PushCommand) Run(args []string) int {
-	var atlasAddress, atlasToken string
-	var archiveVCS, moduleUpload bool
+	var atlasAddress, atlasToken, remoteAddress string
+	var archiveVCS, moduleUpload, streamLogs bool
 	var name string
 	var overwrite []string
 	args = c.Meta.process(args, true)
@@ -31,6 +32,8 @@ func (c *PushCommand) Run(args []string) int {
 	cmdFlags.StringVar(&atlasAddress, "atlas-address", "", "")
 	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
 	cmdFlags.StringVar(&atlasToken, "token", "", "")
+	cmdFlags.StringVar(&remoteAddress, "remote-address", "", "")
+	cmdFlags.BoolVar(&streamLogs, "stream-logs", true, "")
 	cmdFlags.BoolVar(&moduleUpload, "upload-modules", true, "")
 	cmdFlags.StringVar(&name, "name", "", "")
 	cmdFlags.BoolVar(&archiveVCS, "vcs", true, "")
@@ -40,6 +43,11 @@ func (c *PushCommand) Run(args []string) int {
 		return 1
 	}
 
+	if atlasAddress != "" && remoteAddress != "" {
+		c.Ui.Error("Only one of -atlas-address and -remote-address can be set.")
+		return 1
+	}
+
 	// Make a map of the set values
 	overwriteMap := make(map[string]struct{}, len(overwrite))
 	for _, v := range overwrite {
@@ -82,6 +90,8 @@ func (c *PushCommand) Run(args []string) int {
 		return 1
 	}
 
+	defer c.Meta.closeLogs()
+
 	// Build the context based on the arguments given
 	ctx, planned, err := c.Context(contextOpts{
 		Path:      configPath,
@@ -112,6 +122,18 @@ func (c *PushCommand) Run(args []string) int {
 	}
 
 	// Initialize the client if it isn't given.
+	if c.client == nil && remoteAddress != "" {
+		// Make sure to nil out our client so our token isn't sitting around
+		defer func() { c.client = nil }()
+
+		client, err := newHTTPPushClient(remoteAddress, atlasToken)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error initializing remote client: %s", err))
+			return 1
+		}
+
+		c.client = client
+	}
 	if c.client == nil {
 		// Make sure to nil out our client so our token isn't sitting around
 		defer func() { c.client = nil }()
@@ -223,9 +245,49 @@ func (c *PushCommand) Run(args []string) int {
 	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
 		"[reset][bold][green]Configuration %q uploaded! (v%d)",
 		name, vsn)))
+
+	// Stream the logs of the run back if the client supports it
+	if lc, ok := c.client.(pushLogsClient); ok && streamLogs {
+		if err := c.streamLogs(lc, name, vsn); err != nil {
+			c.Ui.Error(fmt.Sprintf(
+				"Error streaming the logs of the run:\n\n%s", err))
+			return 1
+		}
+	}
+
 	return 0
 }
 
+// streamLogs outputs the logs of the run of the given version line by
+// line until the run completes.
+func (c *PushCommand) streamLogs(lc pushLogsClient, name string, vsn int) error {
+	logs, err := lc.Logs(name, vsn)
+	if err != nil {
+		return err
+	}
+	if logs == nil {
+		return nil
+	}
+	defer logs.Close()
+
+	// Lines are read without a length limit, since a line of output of
+	// a provisioner or a plan can be long.
+	c.Ui.Output("")
+	r := bufio.NewReader(logs)
+	for {
+		line, err := r.ReadString('\n')
+		if line != "" {
+			c.Ui.Output(strings.TrimRight(line, "\r\n"))
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func (c *PushCommand) Help() string {
 	helpText := `
 Usage: terraform push [options] [DIR]
@@ -238,6 +300,14 @@ Options:
   -atlas-address=<url> An alternate address to an Atlas instance. Defaults
                        to https://atlas.hashicorp.com
 
+  -remote-address=<url> Address of a remote runner to upload to instead of
+                       Atlas. See the documentation for the HTTP API the
+                       runner must implement.
+
+  -stream-logs=true    If true (default) and the runner given with
+                       -remote-address supports it, the logs of the run
+                       started by the upload are streamed back.
+
   -upload-modules=true If true (default), then the modules are locked at
                        their current checkout and uploaded completely. This
                        prevents Atlas from running "terraform get".
@@ -247,7 +317,8 @@ Options:
                        typically: "username/name".
 
   -token=<token>       Access token to use to upload. If blank or unspecified,
-                       the ATLAS_TOKEN environmental variable will be used.
+                       the ATLAS_TOKEN environmental variable will be used,
+                       or TF_REMOTE_TOKEN with -remote-address.
 
   -overwrite=foo       Variable keys that should overwrite values in Atlas.
                        Otherwise, variables already set in Atlas will overwrite
@@ -257,8 +328,8 @@ Options:
                        flag can be set multiple times.
 
   -var-file=foo        Set variables in the Terraform configuration from
-                       a file. If "terraform.tfvars" is present, it will be
-                       automatically loaded if this flag is not specified.
+                       a file. If "terraform.tfvars" or any ".auto.tfvars"
+                       files are present, they will be automatically loaded.
 
   -vcs=true            If true (default), push will upload only files
                        committed to your VCS, if detected.
@@ -274,7 +345,7 @@ func (c *PushCommand) Synopsis() string {
 }
 
 // pushClient is implementd internally to control where pushes go. This is
-// either to Atlas or a mock for testing.
+// either to Atlas, a remote runner over HTTP or a mock for testing.
 type pushClient interface {
 	Get(string) (map[string]string, error)
 	Upsert(*pushUpsertOptions) (int, error)
@@ -0,0 +1,210 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// pushLogsClient is implemented by push clients that can stream the logs
+// of the run started by an upload back to the user.
+type pushLogsClient interface {
+	// Logs returns the logs of the run for the given version. The reader
+	// is read until the run completes. If the runner has no logs for the
+	// version, a nil reader is returned.
+	Logs(name string, version int) (io.ReadCloser, error)
+}
+
+// httpPushClient pushes configurations to a remote runner that isn't
+// Atlas, using a small HTTP protocol:
+//
+//	GET  /v1/terraform/configurations/NAME/versions/latest
+//	POST /v1/terraform/configurations/NAME/versions
+//	PUT  the "upload_path" returned by the POST, with the archive as body
+//	GET  /v1/terraform/configurations/NAME/versions/VERSION/logs
+//
+// This allows plan and apply to be delegated to self-hosted runners.
+type httpPushClient struct {
+	// Address is the base URL of the runner
+	Address *url.URL
+
+	// Token, if set, is sent as a bearer token with every request
+	Token string
+
+	Client *http.Client
+}
+
+// newHTTPPushClient creates a client for the runner at the given address.
+// If token is empty, the TF_REMOTE_TOKEN environment variable is used.
+func newHTTPPushClient(address, token string) (*httpPushClient, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("address must be an http or https URL: %s", address)
+	}
+
+	if token == "" {
+		token = os.Getenv("TF_REMOTE_TOKEN")
+	}
+
+	return &httpPushClient{
+		Address: u,
+		Token:   token,
+		Client:  cleanhttp.DefaultClient(),
+	}, nil
+}
+
+// httpPushVersion is the body of the latest version of a configuration
+type httpPushVersion struct {
+	Version   int               `json:"version"`
+	Variables map[string]string `json:"variables"`
+}
+
+// httpPushUpload is the body of a newly created version
+type httpPushUpload struct {
+	Version    int    `json:"version"`
+	UploadPath string `json:"upload_path"`
+}
+
+func (c *httpPushClient) url(name string, parts ...string) string {
+	// The name is escaped, so a name with a slash or other special
+	// characters can't change the path of the request.
+	prefix := strings.TrimSuffix(c.Address.Path, "/") +
+		"/v1/terraform/configurations/"
+	suffix := "/versions"
+	if len(parts) > 0 {
+		suffix += "/" + strings.Join(parts, "/")
+	}
+
+	u := *c.Address
+	u.Path = prefix + name + suffix
+	u.RawPath = prefix + url.PathEscape(name) + suffix
+	return u.String()
+}
+
+// do sends a request to the runner. The token is only sent if auth is
+// true, since it must not be sent to other hosts.
+func (c *httpPushClient) do(method, u string, body io.Reader, size int64, auth bool) (*http.Response, error) {
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 {
+		req.ContentLength = size
+	}
+	if auth && c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	return c.Client.Do(req)
+}
+
+// sameOrigin returns true if u has the scheme and host of the runner
+// address, so it's safe to send the token there.
+func (c *httpPushClient) sameOrigin(u *url.URL) bool {
+	return strings.EqualFold(u.Scheme, c.Address.Scheme) &&
+		strings.EqualFold(u.Host, c.Address.Host)
+}
+
+func (c *httpPushClient) Get(name string) (map[string]string, error) {
+	resp, err := c.do("GET", c.url(name, "latest"), nil, -1, true)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		// Nothing was pushed yet
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	var version httpPushVersion
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return nil, fmt.Errorf("error decoding response: %s", err)
+	}
+
+	return version.Variables, nil
+}
+
+func (c *httpPushClient) Upsert(opts *pushUpsertOptions) (int, error) {
+	var buf bytes.Buffer
+	err := json.NewEncoder(&buf).Encode(map[string]interface{}{
+		"variables": opts.Variables,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.do("POST", c.url(opts.Name), &buf, int64(buf.Len()), true)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return 0, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	var upload httpPushUpload
+	if err := json.NewDecoder(resp.Body).Decode(&upload); err != nil {
+		return 0, fmt.Errorf("error decoding response: %s", err)
+	}
+	if upload.UploadPath == "" {
+		return 0, fmt.Errorf("runner returned no upload path")
+	}
+
+	// The upload path may be relative to the runner address, or point to
+	// another host such as a presigned storage URL. The token is only
+	// sent to the runner itself: other hosts must not see it, and
+	// presigned URLs are rejected with an extra Authorization header.
+	rel, err := url.Parse(upload.UploadPath)
+	if err != nil {
+		return 0, fmt.Errorf("invalid upload path: %s", err)
+	}
+	uploadURL := c.Address.ResolveReference(rel)
+
+	uploadResp, err := c.do(
+		"PUT", uploadURL.String(), opts.Archive, opts.Archive.Size,
+		c.sameOrigin(uploadURL))
+	if err != nil {
+		return 0, fmt.Errorf("error uploading archive: %s", err)
+	}
+	defer uploadResp.Body.Close()
+
+	if uploadResp.StatusCode/100 != 2 {
+		return 0, fmt.Errorf("error uploading archive: %s", uploadResp.Status)
+	}
+
+	return upload.Version, nil
+}
+
+func (c *httpPushClient) Logs(name string, version int) (io.ReadCloser, error) {
+	resp, err := c.do("GET", c.url(name, fmt.Sprintf("%d", version), "logs"), nil, -1, true)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, nil
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+}
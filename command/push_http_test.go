@@ -0,0 +1,66 @@
+package command
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestHTTPPushClient_url(t *testing.T) {
+	c, err := newHTTPPushClient("https://runner.example.com/api/", "")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	cases := []struct {
+		Name     string
+		Parts    []string
+		Expected string
+	}{
+		{
+			"foo",
+			nil,
+			"https://runner.example.com/api/v1/terraform/configurations/foo/versions",
+		},
+		{
+			"foo",
+			[]string{"3", "logs"},
+			"https://runner.example.com/api/v1/terraform/configurations/foo/versions/3/logs",
+		},
+		{
+			"org/foo bar?",
+			[]string{"latest"},
+			"https://runner.example.com/api/v1/terraform/configurations/org%2Ffoo%20bar%3F/versions/latest",
+		},
+	}
+
+	for _, tc := range cases {
+		if actual := c.url(tc.Name, tc.Parts...); actual != tc.Expected {
+			t.Fatalf("%s: bad: %s", tc.Name, actual)
+		}
+	}
+}
+
+func TestHTTPPushClient_sameOrigin(t *testing.T) {
+	c, err := newHTTPPushClient("https://runner.example.com/api", "")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	cases := map[string]bool{
+		"https://runner.example.com/upload/1":         true,
+		"https://RUNNER.example.com/upload/1":         true,
+		"http://runner.example.com/upload/1":          false,
+		"https://runner.example.com:8443/upload/1":    false,
+		"https://bucket.s3.amazonaws.com/foo?sig=abc": false,
+	}
+
+	for raw, expected := range cases {
+		u, err := url.Parse(raw)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if actual := c.sameOrigin(u); actual != expected {
+			t.Fatalf("%s: expected %t", raw, expected)
+		}
+	}
+}
@@ -4,10 +4,16 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"encoding/json"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/terraform"
@@ -446,6 +452,174 @@ func TestPush_name(t *testing.T) {
 	}
 }
 
+func TestPush_remoteAddress(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	// Create remote state file, this should be pulled
+	conf, srv := testRemoteState(t, testState(), 200)
+	defer srv.Close()
+
+	// Persist local remote state
+	s := terraform.NewState()
+	s.Serial = 5
+	s.Remote = conf
+	testStateFileRemote(t, s)
+
+	// Path where the archive will be uploaded to
+	archivePath := testTempFile(t)
+	defer os.Remove(archivePath)
+
+	// The handlers run on other goroutines, where t.Fatalf can't be used,
+	// so errors are checked after the push.
+	errs := make(chan error, 10)
+
+	var variables map[string]string
+	var token, uploadToken string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/terraform/configurations/foo/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"version":2,"variables":{"foo":"bar"}}`)
+	})
+	mux.HandleFunc("/v1/terraform/configurations/foo/versions", func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("Authorization")
+
+		var body struct{ Variables map[string]string }
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			errs <- err
+			return
+		}
+		variables = body.Variables
+
+		fmt.Fprint(w, `{"version":3,"upload_path":"/upload/3"}`)
+	})
+	mux.HandleFunc("/upload/3", func(w http.ResponseWriter, r *http.Request) {
+		uploadToken = r.Header.Get("Authorization")
+
+		f, err := os.Create(archivePath)
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer f.Close()
+
+		if _, err := io.Copy(f, r.Body); err != nil {
+			errs <- err
+		}
+	})
+	mux.HandleFunc("/v1/terraform/configurations/foo/versions/3/logs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "Plan: 1 to add, 0 to change, 0 to destroy.\nApply complete!\n")
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	ui := new(cli.MockUi)
+	c := &PushCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-remote-address", ts.URL,
+		"-token", "abc123",
+		"-vcs=false",
+		testFixturePath("push"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	close(errs)
+	for err := range errs {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := testArchiveStr(t, archivePath)
+	expected := []string{
+		".terraform/",
+		".terraform/terraform.tfstate",
+		"main.tf",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	if token != "Bearer abc123" {
+		t.Fatalf("bad: %q", token)
+	}
+	if uploadToken != "Bearer abc123" {
+		t.Fatalf("bad: %q", uploadToken)
+	}
+	if !reflect.DeepEqual(variables, map[string]string{"foo": "bar"}) {
+		t.Fatalf("bad: %#v", variables)
+	}
+
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, "(v3)") || !strings.Contains(output, "Apply complete!") {
+		t.Fatalf("bad: %s", output)
+	}
+}
+
+func TestPush_remoteAddressPresignedUpload(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	conf, srv := testRemoteState(t, testState(), 200)
+	defer srv.Close()
+
+	s := terraform.NewState()
+	s.Serial = 5
+	s.Remote = conf
+	testStateFileRemote(t, s)
+
+	// The archive is uploaded to another host, which must not get the
+	// token.
+	uploaded := false
+	var uploadToken string
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploaded = true
+		uploadToken = r.Header.Get("Authorization")
+		io.Copy(ioutil.Discard, r.Body)
+	}))
+	defer storage.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/terraform/configurations/foo/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/v1/terraform/configurations/foo/versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"version":1,"upload_path":%q}`, storage.URL+"/bucket/foo?signature=x")
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	ui := new(cli.MockUi)
+	c := &PushCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-remote-address", ts.URL,
+		"-token", "abc123",
+		"-vcs=false",
+		"-stream-logs=false",
+		testFixturePath("push"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	if !uploaded {
+		t.Fatal("archive should be uploaded")
+	}
+	if uploadToken != "" {
+		t.Fatalf("bad: %q", uploadToken)
+	}
+}
+
 func TestPush_noState(t *testing.T) {
 	tmp, cwd := testCwd(t)
 	defer testFixCwd(t, tmp, cwd)
@@ -563,3 +737,28 @@ func testArchiveStr(t *testing.T, path string) []string {
 	sort.Strings(result)
 	return result
 }
+
+// testPushLogsClient returns fixed logs
+type testPushLogsClient struct {
+	Output string
+}
+
+func (c *testPushLogsClient) Logs(name string, version int) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader(c.Output)), nil
+}
+
+func TestPush_streamLogsLongLine(t *testing.T) {
+	long := strings.Repeat("x", 100*1024)
+
+	ui := new(cli.MockUi)
+	c := &PushCommand{Meta: Meta{Ui: ui}}
+	lc := &testPushLogsClient{Output: "first\n" + long + "\nlast"}
+	if err := c.streamLogs(lc, "foo", 1); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := "\nfirst\n" + long + "\nlast\n"
+	if actual := ui.OutputWriter.String(); actual != expected {
+		t.Fatalf("bad: %d bytes", len(actual))
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
 )
 
 // RefreshCommand is a cli.Command implementation that refreshes the state
@@ -77,12 +79,16 @@ func (c *RefreshCommand) Run(args []string) int {
 		}
 	}
 
+	defer c.Meta.closeLogs()
+
 	// Build the context based on the arguments given
 	ctx, _, err := c.Context(contextOpts{
 		Path:        configPath,
 		StatePath:   c.Meta.statePath,
 		Parallelism: c.Meta.parallelism,
+		LockReason:  "refresh",
 	})
+	defer c.Meta.unlockState()
 	if err != nil {
 		c.Ui.Error(err.Error())
 		return 1
@@ -109,7 +115,7 @@ func (c *RefreshCommand) Run(args []string) int {
 		return 1
 	}
 
-	if outputs := outputsAsString(newState, ctx.Module().Config().Outputs, true); outputs != "" {
+	if outputs := outputsAsString(newState, terraform.RootModulePath, ctx.Module().Config().Outputs, true); outputs != "" {
 		c.Ui.Output(c.Colorize().Color(outputs))
 	}
 
@@ -134,9 +140,13 @@ Options:
                       ".backup" extension. Set to "-" to disable backup.
 
   -input=true         Ask for input for variables if not directly set.
+                      If false, missing required variables are an error.
 
   -no-color           If specified, output won't contain any color.
 
+  -parallelism=n      Limit the number of resources refreshed concurrently.
+                      Defaults to 10.
+
   -state=path         Path to read and save state (unless state-out
                       is specified). Defaults to "terraform.tfstate".
 
@@ -151,8 +161,8 @@ Options:
                       flag can be set multiple times.
 
   -var-file=foo       Set variables in the Terraform configuration from
-                      a file. If "terraform.tfvars" is present, it will be
-                      automatically loaded if this flag is not specified.
+                      a file. If "terraform.tfvars" or any ".auto.tfvars"
+                      files are present, they will be automatically loaded.
 
 `
 	return strings.TrimSpace(helpText)
@@ -78,6 +78,84 @@ func TestRefresh_badState(t *testing.T) {
 	}
 }
 
+func TestRefresh_lock(t *testing.T) {
+	state := testState()
+	statePath := testStateFile(t, state)
+
+	ls := &testLockingState{}
+	ls.WriteState(state)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &RefreshCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+			state:       ls,
+		},
+	}
+
+	var reason string
+	p.RefreshFn = func(
+		*terraform.InstanceInfo,
+		*terraform.InstanceState) (*terraform.InstanceState, error) {
+		reason = ls.reason
+		return &terraform.InstanceState{ID: "yes"}, nil
+	}
+
+	args := []string{
+		"-state", statePath,
+		testFixturePath("refresh"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	if reason != "refresh" {
+		t.Fatalf("state should be locked during refresh, got %q", reason)
+	}
+	if ls.reason != "" {
+		t.Fatalf("state should be unlocked, got %q", ls.reason)
+	}
+}
+
+func TestRefresh_locked(t *testing.T) {
+	state := testState()
+	statePath := testStateFile(t, state)
+
+	ls := &testLockingState{reason: "apply"}
+	ls.WriteState(state)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &RefreshCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+			state:       ls,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		testFixturePath("refresh"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if p.RefreshCalled {
+		t.Fatal("refresh shouldn't be called")
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "locked for apply") {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+
+	// The lock of the other process is left alone
+	if ls.reason != "apply" {
+		t.Fatalf("bad: %q", ls.reason)
+	}
+}
+
 func TestRefresh_cwd(t *testing.T) {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -120,7 +120,8 @@ Options:
   -module-depth=n     Specifies the depth of modules to show in the output.
                       By default this is -1, which will expand all.
 
-  -no-color           If specified, output won't contain any color.
+  -no-color           If specified, output won't contain any color, and
+                      non-ASCII characters in attribute values are escaped.
 
 `
 	return strings.TrimSpace(helpText)
@@ -33,6 +33,12 @@ type StateOpts struct {
 	// it is assumed to be the path where the state is stored locally
 	// plus the DefaultBackupExtension.
 	BackupPath string
+
+	// ReadOnly, if true, makes sure the state is never written. The remote
+	// state cache isn't updated, a newer cache isn't pushed to the remote
+	// state, no backup is made, and writing the resulting state is an
+	// error.
+	ReadOnly bool
 }
 
 // StateResult is the result of calling State and holds various different
@@ -90,9 +96,13 @@ func State(opts *StateOpts) (*StateResult, error) {
 		} else {
 			if _, err := os.Stat(opts.RemotePath); err == nil {
 				// We have a remote state, initialize that.
-				remote, err = remoteStateFromPath(
-					opts.RemotePath,
-					opts.RemoteRefresh)
+				if opts.ReadOnly {
+					remote, err = remoteStateReadOnly(opts.RemotePath)
+				} else {
+					remote, err = remoteStateFromPath(
+						opts.RemotePath,
+						opts.RemoteRefresh)
+				}
 				if err != nil {
 					return nil, err
 				}
@@ -148,6 +158,12 @@ func State(opts *StateOpts) (*StateResult, error) {
 		}
 	}
 
+	// A read-only state is never written, so it isn't backed up either
+	if result.State != nil && opts.ReadOnly {
+		result.State = &state.ReadOnlyState{Real: result.State}
+		return result, nil
+	}
+
 	// If we have a result, make sure to back it up
 	if result.State != nil {
 		backupPath := result.StatePath + DefaultBackupExtension
@@ -203,22 +219,11 @@ func StateFromPlan(
 func remoteState(
 	local *terraform.State,
 	localPath string, refresh bool) (*state.CacheState, error) {
-	// If there is no remote settings, it is an error
-	if local.Remote == nil {
-		return nil, fmt.Errorf("Remote state cache has no remote info")
-	}
-
-	// Initialize the remote client based on the local state
-	client, err := remote.NewClient(strings.ToLower(local.Remote.Type), local.Remote.Config)
+	durable, err := remoteDurableState(local)
 	if err != nil {
-		return nil, errwrap.Wrapf(fmt.Sprintf(
-			"Error initializing remote driver '%s': {{err}}",
-			local.Remote.Type), err)
+		return nil, err
 	}
 
-	// Create the remote client
-	durable := &remote.State{Client: client}
-
 	// Create the cached client
 	cache := &state.CacheState{
 		Cache:   &state.LocalState{Path: localPath},
@@ -264,6 +269,58 @@ func remoteState(
 	return cache, nil
 }
 
+// remoteDurableState returns the remote state configured in the given
+// remote state cache.
+func remoteDurableState(local *terraform.State) (*remote.State, error) {
+	// If there is no remote settings, it is an error
+	if local.Remote == nil {
+		return nil, fmt.Errorf("Remote state cache has no remote info")
+	}
+
+	// Initialize the remote client based on the local state
+	client, err := remote.NewClient(strings.ToLower(local.Remote.Type), local.Remote.Config)
+	if err != nil {
+		return nil, errwrap.Wrapf(fmt.Sprintf(
+			"Error initializing remote driver '%s': {{err}}",
+			local.Remote.Type), err)
+	}
+
+	return &remote.State{Client: client}, nil
+}
+
+// remoteStateReadOnly returns the remote state configured in the remote
+// state cache at the path, refreshed without writing anything. The cache
+// is only updated in memory, and a cache that is newer than the remote
+// state isn't pushed.
+func remoteStateReadOnly(path string) (*state.CacheState, error) {
+	local := &state.LocalState{Path: path}
+	if err := local.RefreshState(); err != nil {
+		return nil, err
+	}
+
+	durable, err := remoteDurableState(local.State())
+	if err != nil {
+		return nil, err
+	}
+
+	cache := &state.InmemState{}
+	cache.WriteState(local.State())
+	result := &state.CacheState{
+		Cache:   cache,
+		Durable: durable,
+	}
+	if err := result.RefreshState(); err != nil {
+		return nil, errwrap.Wrapf(
+			"Error reloading remote state: {{err}}", err)
+	}
+	if result.RefreshResult() == state.CacheRefreshConflict {
+		return nil, fmt.Errorf(
+			"Error reloading remote state: %s", result.RefreshResult())
+	}
+
+	return result, nil
+}
+
 func remoteStateFromPath(path string, refresh bool) (*state.CacheState, error) {
 	// First create the local state for the path
 	local := &state.LocalState{Path: path}
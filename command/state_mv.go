@@ -19,6 +19,8 @@ func (c *StateMvCommand) Run(args []string) int {
 
 	// We create two metas to track the two states
 	var meta1, meta2 Meta
+	meta1.Ui = c.Meta.Ui
+	meta2.Ui = c.Meta.Ui
 	cmdFlags := c.Meta.flagSet("state show")
 	cmdFlags.StringVar(&meta1.stateOutPath, "backup", "", "backup")
 	cmdFlags.StringVar(&meta1.statePath, "state", DefaultStateFilename, "path")
@@ -44,6 +46,11 @@ func (c *StateMvCommand) Run(args []string) int {
 		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
 		return cli.RunResultHelp
 	}
+	if err := meta1.lockState("state mv", true); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	defer meta1.unlockState()
 
 	stateFromReal := stateFrom.State()
 	if stateFromReal == nil {
@@ -60,6 +67,11 @@ func (c *StateMvCommand) Run(args []string) int {
 			c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
 			return cli.RunResultHelp
 		}
+		if err := meta2.lockState("state mv", true); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		defer meta2.unlockState()
 
 		stateToReal = stateTo.State()
 		if stateToReal == nil {
@@ -0,0 +1,184 @@
+package command
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+// stateProviderRegexp matches a provider given as NAME or NAME.ALIAS
+var stateProviderRegexp = regexp.MustCompile(`^[0-9A-Za-z-]+(\.[0-9A-Za-z_-]+)?$`)
+
+// StateReplaceProviderCommand is a Command implementation that changes
+// the provider resources in the state are associated with.
+type StateReplaceProviderCommand struct {
+	Meta
+	StateMeta
+}
+
+func (c *StateReplaceProviderCommand) Run(args []string) int {
+	args = c.Meta.process(args, true)
+
+	cmdFlags := c.Meta.flagSet("state replace-provider")
+	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	if err := cmdFlags.Parse(args); err != nil {
+		return cli.RunResultHelp
+	}
+	args = cmdFlags.Args()
+	if len(args) < 2 {
+		c.Ui.Error("At least two arguments expected.\n")
+		return cli.RunResultHelp
+	}
+	from, to := args[0], args[1]
+
+	for _, p := range []string{from, to} {
+		if !stateProviderRegexp.MatchString(p) {
+			c.Ui.Error(fmt.Sprintf(
+				"Invalid provider %q. Providers are given as NAME or NAME.ALIAS.", p))
+			return 1
+		}
+	}
+
+	state, err := c.StateMeta.State(&c.Meta)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+		return cli.RunResultHelp
+	}
+	if err := c.Meta.lockState("state replace-provider", true); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	defer c.Meta.unlockState()
+
+	stateReal := state.State()
+	if stateReal == nil {
+		c.Ui.Error(fmt.Sprintf(errStateNotFound))
+		return 1
+	}
+
+	// Only the resources matching the addresses are changed, or all of
+	// them if no addresses were given.
+	filter := &terraform.StateFilter{State: stateReal}
+	results, err := filter.Filter(args[2:]...)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateReplaceProvider, err))
+		return cli.RunResultHelp
+	}
+
+	var replaced []string
+	seen := make(map[*terraform.ResourceState]struct{})
+	for _, r := range results {
+		rs, ok := r.Value.(*terraform.ResourceState)
+		if !ok {
+			continue
+		}
+		if _, ok := seen[rs]; ok {
+			continue
+		}
+		seen[rs] = struct{}{}
+
+		if stateResourceProvider(rs) != from {
+			continue
+		}
+
+		// The provider isn't recorded if it's the default provider for
+		// the resource type.
+		rs.Provider = to
+		if to == stateTypeProvider(rs.Type) {
+			rs.Provider = ""
+		}
+
+		replaced = append(replaced, r.Address)
+	}
+
+	if len(replaced) == 0 {
+		c.Ui.Output(fmt.Sprintf("No resources use the provider %s.", from))
+		return 0
+	}
+
+	if err := state.WriteState(stateReal); err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateReplaceProviderPersist, err))
+		return 1
+	}
+
+	if err := state.PersistState(); err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateReplaceProviderPersist, err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf(
+		"Replaced the provider %s with %s for %d resource(s):\n\n  %s",
+		from, to, len(replaced), strings.Join(replaced, "\n  ")))
+	return 0
+}
+
+// stateResourceProvider returns the provider a resource in the state is
+// associated with. This is the provider recorded in the state, or else
+// the default provider for the resource type.
+func stateResourceProvider(rs *terraform.ResourceState) string {
+	if rs.Provider != "" {
+		return rs.Provider
+	}
+
+	return stateTypeProvider(rs.Type)
+}
+
+// stateTypeProvider returns the default provider for a resource type,
+// which is the prefix of the type up to the first underscore.
+func stateTypeProvider(t string) string {
+	idx := strings.IndexRune(t, '_')
+	if idx == -1 {
+		return ""
+	}
+
+	return t[:idx]
+}
+
+func (c *StateReplaceProviderCommand) Help() string {
+	helpText := `
+Usage: terraform state replace-provider [options] FROM TO [ADDRESS...]
+
+  Replace the provider that resources in the state are associated with.
+
+  Providers are given as NAME for the default configuration of a provider,
+  or NAME.ALIAS for a configuration with an alias. Every resource that is
+  associated with the provider FROM is associated with TO instead. If
+  addresses are given, only the resources matching them are changed.
+
+  The provider of a resource in the state is used to destroy the resource
+  once it's removed from the configuration. Use this command after moving
+  resources to a different provider configuration, for example when
+  renaming an alias or switching between a fork of a provider and the
+  original.
+
+  This command creates a timestamped backup of the state on every
+  invocation. This can't be disabled. Due to the destructive nature of
+  this command, the backup is ensured by Terraform for safety reasons.
+
+Options:
+
+  -state=PATH         Path to a Terraform state file to use to look
+                      up Terraform-managed resources. By default it will
+                      use the state "terraform.tfstate" if it exists.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateReplaceProviderCommand) Synopsis() string {
+	return "Replace the provider of resources in the state"
+}
+
+const errStateReplaceProvider = `Error replacing the provider: %[1]s
+
+Please ensure your addresses and state paths are valid. No
+state was persisted. Your existing state is untouched.`
+
+const errStateReplaceProviderPersist = `Error saving the state: %s
+
+The state wasn't saved properly. If the error happening after a partial
+write occurred, a backup file will have been created. Otherwise, the state
+is in the same state it was when the operation started.`
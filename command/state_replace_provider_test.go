@@ -0,0 +1,241 @@
+package command
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestStateReplaceProvider(t *testing.T) {
+	statePath := testStateFile(t, testStateReplaceProviderState())
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &StateReplaceProviderCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"test",
+		"test.east",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	// Test it is correct
+	testStateOutput(t, statePath, testStateReplaceProviderOutput)
+
+	if !strings.Contains(ui.OutputWriter.String(), "for 2 resource(s)") {
+		t.Fatalf("bad: %s", ui.OutputWriter.String())
+	}
+
+	// Test we have backups
+	backups := testStateBackups(t, filepath.Dir(statePath))
+	if len(backups) != 1 {
+		t.Fatalf("bad: %#v", backups)
+	}
+	testStateOutput(t, backups[0], testStateReplaceProviderOutputOriginal)
+}
+
+func TestStateReplaceProvider_default(t *testing.T) {
+	statePath := testStateFile(t, testStateReplaceProviderState())
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &StateReplaceProviderCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	// Replacing an alias with the default provider doesn't record it
+	args := []string{
+		"-state", statePath,
+		"test.west",
+		"test",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	testStateOutput(t, statePath, testStateReplaceProviderOutputDefault)
+}
+
+func TestStateReplaceProvider_address(t *testing.T) {
+	statePath := testStateFile(t, testStateReplaceProviderState())
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &StateReplaceProviderCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"test",
+		"test.east",
+		"module.child",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	testStateOutput(t, statePath, testStateReplaceProviderOutputAddress)
+}
+
+func TestStateReplaceProvider_noMatch(t *testing.T) {
+	statePath := testStateFile(t, testStateReplaceProviderState())
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &StateReplaceProviderCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"other",
+		"test",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	if !strings.Contains(ui.OutputWriter.String(), "No resources use the provider other") {
+		t.Fatalf("bad: %s", ui.OutputWriter.String())
+	}
+
+	// Nothing is written, so there are no backups
+	backups := testStateBackups(t, filepath.Dir(statePath))
+	if len(backups) != 0 {
+		t.Fatalf("bad: %#v", backups)
+	}
+}
+
+func TestStateReplaceProvider_invalid(t *testing.T) {
+	statePath := testStateFile(t, testStateReplaceProviderState())
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &StateReplaceProviderCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"test",
+		"test.east.extra",
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "Invalid provider") {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+}
+
+func testStateReplaceProviderState() *terraform.State {
+	return &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Resources: map[string]*terraform.ResourceState{
+					"test_instance.foo": &terraform.ResourceState{
+						Type: "test_instance",
+						Primary: &terraform.InstanceState{
+							ID: "foo",
+						},
+					},
+
+					"test_instance.bar": &terraform.ResourceState{
+						Type:     "test_instance",
+						Provider: "test.west",
+						Primary: &terraform.InstanceState{
+							ID: "bar",
+						},
+					},
+				},
+			},
+
+			&terraform.ModuleState{
+				Path: []string{"root", "child"},
+				Resources: map[string]*terraform.ResourceState{
+					"test_instance.baz": &terraform.ResourceState{
+						Type: "test_instance",
+						Primary: &terraform.InstanceState{
+							ID: "baz",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+const testStateReplaceProviderOutput = `
+test_instance.bar:
+  ID = bar
+  provider = test.west
+test_instance.foo:
+  ID = foo
+  provider = test.east
+
+module.child:
+  test_instance.baz:
+    ID = baz
+    provider = test.east
+`
+
+const testStateReplaceProviderOutputOriginal = `
+test_instance.bar:
+  ID = bar
+  provider = test.west
+test_instance.foo:
+  ID = foo
+
+module.child:
+  test_instance.baz:
+    ID = baz
+`
+
+const testStateReplaceProviderOutputDefault = `
+test_instance.bar:
+  ID = bar
+test_instance.foo:
+  ID = foo
+
+module.child:
+  test_instance.baz:
+    ID = baz
+`
+
+const testStateReplaceProviderOutputAddress = `
+test_instance.bar:
+  ID = bar
+  provider = test.west
+test_instance.foo:
+  ID = foo
+
+module.child:
+  test_instance.baz:
+    ID = baz
+    provider = test.east
+`
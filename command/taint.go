@@ -62,6 +62,11 @@ func (c *TaintCommand) Run(args []string) int {
 		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
 		return 1
 	}
+	if err := c.lockState("taint", true); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	defer c.unlockState()
 
 	// Get the actual state structure
 	s := state.State()
@@ -146,6 +151,9 @@ Usage: terraform taint [options] name
   its own will not modify infrastructure. This command can be undone by
   reverting the state backup file that is created.
 
+  To destroy and recreate a resource in a single step instead, use the
+  -replace flag of the "plan" and "apply" commands.
+
 Options:
 
   -allow-missing      If specified, the command will succeed (exit code 0)
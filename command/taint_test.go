@@ -2,6 +2,7 @@ package command
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/terraform"
@@ -44,6 +45,53 @@ func TestTaint(t *testing.T) {
 	testStateOutput(t, statePath, testTaintStr)
 }
 
+func TestTaint_locked(t *testing.T) {
+	state := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Resources: map[string]*terraform.ResourceState{
+					"test_instance.foo": &terraform.ResourceState{
+						Type: "test_instance",
+						Primary: &terraform.InstanceState{
+							ID: "bar",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ls := &testLockingState{reason: "apply"}
+	ls.WriteState(state)
+
+	ui := new(cli.MockUi)
+	c := &TaintCommand{
+		Meta: Meta{
+			Ui:    ui,
+			state: ls,
+		},
+	}
+
+	args := []string{
+		"test_instance.foo",
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "locked for apply") {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+
+	rs := ls.State().RootModule().Resources["test_instance.foo"]
+	if rs.Primary.Tainted {
+		t.Fatal("resource shouldn't be tainted while the state is locked")
+	}
+	if ls.reason != "apply" {
+		t.Fatalf("bad: %q", ls.reason)
+	}
+}
+
 func TestTaint_backup(t *testing.T) {
 	// Get a temp cwd
 	tmp, cwd := testCwd(t)
@@ -0,0 +1,29 @@
+variable "region" {
+    description = "The region to deploy to"
+    default = "us-east-1"
+}
+
+variable "amis" {
+    type = "map"
+}
+
+provider "test" {
+    alias = "east"
+}
+
+resource "test_instance" "foo" {
+    ami = "${lookup(var.amis, var.region)}"
+}
+
+data "test_data_source" "bar" {
+    provider = "test.east"
+}
+
+module "child" {
+    source = "./child"
+}
+
+output "id" {
+    value = "${test_instance.foo.id}"
+    sensitive = true
+}
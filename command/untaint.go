@@ -49,6 +49,11 @@ func (c *UntaintCommand) Run(args []string) int {
 		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
 		return 1
 	}
+	if err := c.lockState("untaint", true); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	defer c.unlockState()
 
 	// Get the actual state structure
 	s := state.State()
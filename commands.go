@@ -91,6 +91,12 @@ func init() {
 			}, nil
 		},
 
+		"metadata": func() (cli.Command, error) {
+			return &command.MetadataCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"output": func() (cli.Command, error) {
 			return &command.OutputCommand{
 				Meta: meta,
@@ -171,6 +177,12 @@ func init() {
 			}, nil
 		},
 
+		"state replace-provider": func() (cli.Command, error) {
+			return &command.StateReplaceProviderCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"state show": func() (cli.Command, error) {
 			return &command.StateShowCommand{
 				Meta: meta,
@@ -610,34 +610,84 @@ func BastionConnectFunc(
 	bConf *ssh.ClientConfig,
 	proto string,
 	addr string) func() (net.Conn, error) {
+	return BastionChainConnectFunc(bProto, []BastionHop{{Addr: bAddr, Config: bConf}}, proto, addr)
+}
+
+// BastionHop is a single bastion host in a chain of them.
+type BastionHop struct {
+	Addr   string
+	Config *ssh.ClientConfig
+}
+
+// BastionChainConnectFunc is a convenience method for returning a function
+// that connects to a host through a chain of bastion hosts. Every bastion
+// is connected to from the one before it.
+func BastionChainConnectFunc(
+	bProto string,
+	hops []BastionHop,
+	proto string,
+	addr string) func() (net.Conn, error) {
 	return func() (net.Conn, error) {
-		log.Printf("[DEBUG] Connecting to bastion: %s", bAddr)
-		bastion, err := ssh.Dial(bProto, bAddr, bConf)
-		if err != nil {
-			return nil, fmt.Errorf("Error connecting to bastion: %s", err)
+		var bastions []*ssh.Client
+		closeBastions := func() {
+			for i := len(bastions) - 1; i >= 0; i-- {
+				bastions[i].Close()
+			}
+		}
+
+		for i, hop := range hops {
+			log.Printf("[DEBUG] Connecting to bastion: %s", hop.Addr)
+
+			var conn net.Conn
+			var err error
+			if i == 0 {
+				conn, err = net.DialTimeout(bProto, hop.Addr, 15*time.Second)
+			} else {
+				conn, err = bastions[i-1].Dial(bProto, hop.Addr)
+			}
+			if err != nil {
+				closeBastions()
+				return nil, fmt.Errorf("Error connecting to bastion %s: %s", hop.Addr, err)
+			}
+
+			sshConn, chans, reqs, err := ssh.NewClientConn(conn, hop.Addr, hop.Config)
+			if err != nil {
+				conn.Close()
+				closeBastions()
+				return nil, fmt.Errorf("Error connecting to bastion %s: %s", hop.Addr, err)
+			}
+			bastions = append(bastions, ssh.NewClient(sshConn, chans, reqs))
 		}
 
-		log.Printf("[DEBUG] Connecting via bastion (%s) to host: %s", bAddr, addr)
-		conn, err := bastion.Dial(proto, addr)
+		log.Printf("[DEBUG] Connecting via bastion (%s) to host: %s", hops[len(hops)-1].Addr, addr)
+		conn, err := bastions[len(bastions)-1].Dial(proto, addr)
 		if err != nil {
-			bastion.Close()
+			closeBastions()
 			return nil, err
 		}
 
-		// Wrap it up so we close both things properly
+		// Wrap it up so we close all the things properly
 		return &bastionConn{
-			Conn:    conn,
-			Bastion: bastion,
+			Conn:     conn,
+			Bastions: bastions,
 		}, nil
 	}
 }
 
 type bastionConn struct {
 	net.Conn
-	Bastion *ssh.Client
+	Bastions []*ssh.Client
 }
 
 func (c *bastionConn) Close() error {
 	c.Conn.Close()
-	return c.Bastion.Close()
+
+	// Close the bastions in reverse, each connection runs over the last
+	var err error
+	for i := len(c.Bastions) - 1; i >= 0; i-- {
+		if e := c.Bastions[i].Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
 }
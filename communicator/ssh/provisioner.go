@@ -1,11 +1,18 @@
 package ssh
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
 	"encoding/pem"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"path"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/pathorcontents"
@@ -45,11 +52,17 @@ type connectionInfo struct {
 	ScriptPath string        `mapstructure:"script_path"`
 	TimeoutVal time.Duration `mapstructure:"-"`
 
+	// HostKey holds the accepted host keys, one per line in either the
+	// known_hosts or the authorized_keys format. Any key is accepted if
+	// it is empty.
+	HostKey string `mapstructure:"host_key"`
+
 	BastionUser       string `mapstructure:"bastion_user"`
 	BastionPassword   string `mapstructure:"bastion_password"`
 	BastionPrivateKey string `mapstructure:"bastion_private_key"`
 	BastionHost       string `mapstructure:"bastion_host"`
 	BastionPort       int    `mapstructure:"bastion_port"`
+	BastionHostKey    string `mapstructure:"bastion_host_key"`
 
 	// Deprecated
 	KeyFile        string `mapstructure:"key_file"`
@@ -146,31 +159,43 @@ func prepareSSHConfig(connInfo *connectionInfo) (*sshConfig, error) {
 		user:       connInfo.User,
 		privateKey: connInfo.PrivateKey,
 		password:   connInfo.Password,
+		hostKey:    connInfo.HostKey,
 		sshAgent:   sshAgent,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	var bastionConf *ssh.ClientConfig
+	host := fmt.Sprintf("%s:%d", connInfo.Host, connInfo.Port)
+	connectFunc := ConnectFunc("tcp", host)
+
 	if connInfo.BastionHost != "" {
-		bastionConf, err = buildSSHClientConfig(sshClientConfigOpts{
-			user:       connInfo.BastionUser,
-			privateKey: connInfo.BastionPrivateKey,
-			password:   connInfo.BastionPassword,
-			sshAgent:   sshAgent,
-		})
+		jumpHosts, err := parseJumpHosts(
+			connInfo.BastionHost, connInfo.BastionUser, connInfo.BastionPort)
 		if err != nil {
 			return nil, err
 		}
-	}
 
-	host := fmt.Sprintf("%s:%d", connInfo.Host, connInfo.Port)
-	connectFunc := ConnectFunc("tcp", host)
+		hops := make([]BastionHop, len(jumpHosts))
+		for i, jh := range jumpHosts {
+			bastionConf, err := buildSSHClientConfig(sshClientConfigOpts{
+				user:       jh.user,
+				privateKey: connInfo.BastionPrivateKey,
+				password:   connInfo.BastionPassword,
+				hostKey:    connInfo.BastionHostKey,
+				sshAgent:   sshAgent,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			hops[i] = BastionHop{
+				Addr:   jh.addr,
+				Config: bastionConf,
+			}
+		}
 
-	if bastionConf != nil {
-		bastionHost := fmt.Sprintf("%s:%d", connInfo.BastionHost, connInfo.BastionPort)
-		connectFunc = BastionConnectFunc("tcp", bastionHost, bastionConf, "tcp", host)
+		connectFunc = BastionChainConnectFunc("tcp", hops, "tcp", host)
 	}
 
 	config := &sshConfig{
@@ -184,6 +209,7 @@ func prepareSSHConfig(connInfo *connectionInfo) (*sshConfig, error) {
 type sshClientConfigOpts struct {
 	privateKey string
 	password   string
+	hostKey    string
 	sshAgent   *sshAgent
 	user       string
 }
@@ -193,6 +219,14 @@ func buildSSHClientConfig(opts sshClientConfigOpts) (*ssh.ClientConfig, error) {
 		User: opts.user,
 	}
 
+	if opts.hostKey != "" {
+		callback, err := hostKeyCallback(opts.hostKey)
+		if err != nil {
+			return nil, err
+		}
+		conf.HostKeyCallback = callback
+	}
+
 	if opts.privateKey != "" {
 		pubKeyAuth, err := readPrivateKey(opts.privateKey)
 		if err != nil {
@@ -214,6 +248,173 @@ func buildSSHClientConfig(opts sshClientConfigOpts) (*ssh.ClientConfig, error) {
 	return conf, nil
 }
 
+// jumpHost is a single hop of a bastion host chain
+type jumpHost struct {
+	user string
+	addr string
+}
+
+// parseJumpHosts parses a comma separated chain of bastion hosts in the
+// same format as the ProxyJump option of OpenSSH, [user@]host[:port].
+// Hosts are connected to in the given order, and those without a user or
+// port use the defaults.
+func parseJumpHosts(hosts string, defaultUser string, defaultPort int) ([]jumpHost, error) {
+	var result []jumpHost
+	for _, h := range strings.Split(hosts, ",") {
+		h = strings.TrimSpace(h)
+		if h == "" {
+			return nil, fmt.Errorf("Empty host in bastion_host %q", hosts)
+		}
+
+		jh := jumpHost{user: defaultUser}
+		if i := strings.LastIndex(h, "@"); i >= 0 {
+			jh.user = h[:i]
+			h = h[i+1:]
+		}
+
+		host, port := h, strconv.Itoa(defaultPort)
+		// A port is only split off if there is exactly one colon or the
+		// host is a bracketed IPv6 address, so bare IPv6 addresses work.
+		if strings.HasPrefix(h, "[") || strings.Count(h, ":") == 1 {
+			var err error
+			host, port, err = net.SplitHostPort(h)
+			if err != nil {
+				if !strings.HasPrefix(h, "[") {
+					return nil, fmt.Errorf("Invalid host %q in bastion_host: %s", h, err)
+				}
+				// A bracketed address without a port
+				host = strings.Trim(h, "[]")
+				port = strconv.Itoa(defaultPort)
+			}
+		}
+		if host == "" {
+			return nil, fmt.Errorf("Invalid host %q in bastion_host", h)
+		}
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return nil, fmt.Errorf("Invalid port %q in bastion_host", port)
+		}
+
+		jh.addr = net.JoinHostPort(host, port)
+		result = append(result, jh)
+	}
+
+	return result, nil
+}
+
+// knownHost is a single accepted host key. If hosts is empty the key is
+// accepted for any host.
+type knownHost struct {
+	hosts []string
+	key   ssh.PublicKey
+}
+
+// hostKeyCallback returns a callback that only accepts the given host
+// keys. Each line holds a key in the known_hosts format, which limits it
+// to the listed hosts, or in the authorized_keys format, which accepts it
+// for any host.
+func hostKeyCallback(hostKeys string) (func(string, net.Addr, ssh.PublicKey) error, error) {
+	var known []knownHost
+	for _, line := range strings.Split(hostKeys, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		marker, hosts, key, _, _, err := ssh.ParseKnownHosts([]byte(line))
+		if err == nil {
+			if marker != "" {
+				return nil, fmt.Errorf("Unsupported host key marker @%s", marker)
+			}
+			known = append(known, knownHost{hosts: hosts, key: key})
+			continue
+		}
+
+		key, _, _, _, err = ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse host key %q: %s", line, err)
+		}
+		known = append(known, knownHost{key: key})
+	}
+
+	if len(known) == 0 {
+		return nil, fmt.Errorf("No host keys found in %q", hostKeys)
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		for _, k := range known {
+			if len(k.hosts) > 0 && !matchKnownHost(k.hosts, hostname) {
+				continue
+			}
+			if bytes.Equal(k.key.Marshal(), key.Marshal()) {
+				return nil
+			}
+		}
+		return fmt.Errorf("Host key verification failed for %s: %s key %s is not accepted",
+			hostname, key.Type(), base64.StdEncoding.EncodeToString(key.Marshal()))
+	}, nil
+}
+
+// matchKnownHost returns true if the address matches one of the host
+// patterns of a known_hosts entry, including hashed ones.
+func matchKnownHost(patterns []string, addr string) bool {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		host, port = addr, strconv.Itoa(DefaultPort)
+	}
+
+	// known_hosts only adds the port for non-standard ones
+	name := host
+	if port != strconv.Itoa(DefaultPort) {
+		name = fmt.Sprintf("[%s]:%s", host, port)
+	}
+
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "|1|") {
+			if matchHashedHost(pattern, name) {
+				return true
+			}
+			continue
+		}
+
+		patternHost, patternPort := pattern, strconv.Itoa(DefaultPort)
+		if strings.HasPrefix(pattern, "[") {
+			h, p, err := net.SplitHostPort(pattern)
+			if err != nil {
+				continue
+			}
+			patternHost, patternPort = h, p
+		}
+		if patternPort != port {
+			continue
+		}
+		if ok, _ := path.Match(patternHost, host); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchHashedHost checks a name against a hashed known_hosts entry of
+// the form |1|base64(salt)|base64(HMAC-SHA1(salt, name)).
+func matchHashedHost(entry, name string) bool {
+	parts := strings.Split(entry, "|")
+	if len(parts) != 4 {
+		return false
+	}
+	salt, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	hash, err := base64.StdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha1.New, salt)
+	mac.Write([]byte(name))
+	return hmac.Equal(mac.Sum(nil), hash)
+}
+
 func readPrivateKey(pk string) (ssh.AuthMethod, error) {
 	key, _, err := pathorcontents.Read(pk)
 	if err != nil {
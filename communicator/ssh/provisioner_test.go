@@ -1,9 +1,14 @@
 package ssh
 
 import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform/terraform"
+	"golang.org/x/crypto/ssh"
 )
 
 func TestProvisioner_connInfo(t *testing.T) {
@@ -89,3 +94,135 @@ func TestProvisioner_connInfoLegacy(t *testing.T) {
 		t.Fatalf("bad: %v", conf)
 	}
 }
+
+func TestProvisioner_parseJumpHosts(t *testing.T) {
+	cases := map[string]struct {
+		Hosts    string
+		Expected []jumpHost
+		Err      bool
+	}{
+		"Single": {
+			Hosts: "127.0.1.1",
+			Expected: []jumpHost{
+				{user: "root", addr: "127.0.1.1:22"},
+			},
+		},
+
+		"Chain": {
+			Hosts: "jump@bastion1:2222, bastion2,[::1]:22,[fe80::1]",
+			Expected: []jumpHost{
+				{user: "jump", addr: "bastion1:2222"},
+				{user: "root", addr: "bastion2:22"},
+				{user: "root", addr: "[::1]:22"},
+				{user: "root", addr: "[fe80::1]:22"},
+			},
+		},
+
+		"BareIPv6": {
+			Hosts: "fe80::1",
+			Expected: []jumpHost{
+				{user: "root", addr: "[fe80::1]:22"},
+			},
+		},
+
+		"EmptyHop": {
+			Hosts: "bastion1,,bastion2",
+			Err:   true,
+		},
+
+		"BadPort": {
+			Hosts: "bastion1:ssh",
+			Err:   true,
+		},
+	}
+
+	for k, tc := range cases {
+		actual, err := parseJumpHosts(tc.Hosts, "root", 22)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%s: err: %v", k, err)
+		}
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%s: expected %#v, got %#v", k, tc.Expected, actual)
+		}
+	}
+}
+
+func TestProvisioner_hostKeyCallback(t *testing.T) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(testClientPublicKey))
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	salt := []byte("0123456789abcdef0123")
+	mac := hmac.New(sha1.New, salt)
+	mac.Write([]byte("hashed.example.com"))
+	hashed := "|1|" + base64.StdEncoding.EncodeToString(salt) +
+		"|" + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	cases := map[string]struct {
+		HostKey string
+		Addr    string
+		Err     bool
+	}{
+		"AuthorizedKey": {
+			HostKey: testClientPublicKey,
+			Addr:    "example.com:22",
+		},
+
+		"KnownHost": {
+			HostKey: "example.com " + testClientPublicKey,
+			Addr:    "example.com:22",
+		},
+
+		"KnownHostOtherHost": {
+			HostKey: "example.com " + testClientPublicKey,
+			Addr:    "other.example.com:22",
+			Err:     true,
+		},
+
+		"KnownHostPort": {
+			HostKey: "[example.com]:2222 " + testClientPublicKey,
+			Addr:    "example.com:2222",
+		},
+
+		"KnownHostWrongPort": {
+			HostKey: "example.com " + testClientPublicKey,
+			Addr:    "example.com:2222",
+			Err:     true,
+		},
+
+		"KnownHostWildcard": {
+			HostKey: "# comment\n\n*.example.com,10.0.0.1 " + testClientPublicKey,
+			Addr:    "web.example.com:22",
+		},
+
+		"KnownHostHashed": {
+			HostKey: hashed + " " + testClientPublicKey,
+			Addr:    "hashed.example.com:22",
+		},
+	}
+
+	for k, tc := range cases {
+		callback, err := hostKeyCallback(tc.HostKey)
+		if err != nil {
+			t.Fatalf("%s: err: %v", k, err)
+		}
+
+		err = callback(tc.Addr, nil, key)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%s: expected error %t, got: %v", k, tc.Err, err)
+		}
+	}
+}
+
+func TestProvisioner_hostKeyCallbackInvalid(t *testing.T) {
+	for _, hostKey := range []string{
+		"not a key",
+		"# only a comment",
+		"@revoked example.com " + testClientPublicKey,
+	} {
+		if _, err := hostKeyCallback(hostKey); err == nil {
+			t.Fatalf("expected error for %q", hostKey)
+		}
+	}
+}
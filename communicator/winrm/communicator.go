@@ -5,15 +5,16 @@ import (
 	"io"
 	"log"
 	"math/rand"
+	"net/http"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/Azure/go-ntlmssp"
 	"github.com/hashicorp/terraform/communicator/remote"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/masterzen/winrm/winrm"
-	"github.com/packer-community/winrmcp/winrmcp"
 
 	// This import is a bit strange, but it's needed so `make updatedeps` can see and download it
 	_ "github.com/dylanmei/winrmtest"
@@ -38,7 +39,10 @@ func New(s *terraform.InstanceState) (*Communicator, error) {
 		Port:     connInfo.Port,
 		HTTPS:    connInfo.HTTPS,
 		Insecure: connInfo.Insecure,
-		CACert:   connInfo.CACert,
+	}
+	if connInfo.CACert != "" {
+		cert := []byte(connInfo.CACert)
+		endpoint.CACert = &cert
 	}
 
 	comm := &Communicator{
@@ -57,6 +61,13 @@ func (c *Communicator) Connect(o terraform.UIOutput) error {
 
 	params := winrm.DefaultParameters()
 	params.Timeout = formatDuration(c.Timeout())
+	if c.connInfo.UseNTLM {
+		// The negotiator turns the basic auth credentials set on every
+		// request into an NTLM handshake.
+		params.TransportDecorator = func(t *http.Transport) http.RoundTripper {
+			return ntlmssp.Negotiator{RoundTripper: t}
+		}
+	}
 
 	client, err := winrm.NewClientWithParameters(
 		c.endpoint, c.connInfo.User, c.connInfo.Password, params)
@@ -73,6 +84,7 @@ func (c *Communicator) Connect(o terraform.UIOutput) error {
 				"  Password: %t\n"+
 				"  HTTPS: %t\n"+
 				"  Insecure: %t\n"+
+				"  NTLM: %t\n"+
 				"  CACert: %t",
 			c.connInfo.Host,
 			c.connInfo.Port,
@@ -80,7 +92,8 @@ func (c *Communicator) Connect(o terraform.UIOutput) error {
 			c.connInfo.Password != "",
 			c.connInfo.HTTPS,
 			c.connInfo.Insecure,
-			c.connInfo.CACert != nil,
+			c.connInfo.UseNTLM,
+			c.connInfo.CACert != "",
 		))
 	}
 
@@ -168,12 +181,12 @@ func runCommand(shell *winrm.Shell, cmd *winrm.Command, rc *remote.Cmd) {
 
 // Upload implementation of communicator.Communicator interface
 func (c *Communicator) Upload(path string, input io.Reader) error {
-	wcp, err := c.newCopyClient()
-	if err != nil {
+	if err := c.Connect(nil); err != nil {
 		return err
 	}
+
 	log.Printf("Uploading file to '%s'", path)
-	return wcp.Write(path, input)
+	return uploadFile(c.client, path, input)
 }
 
 // UploadScript implementation of communicator.Communicator interface
@@ -183,31 +196,10 @@ func (c *Communicator) UploadScript(path string, input io.Reader) error {
 
 // UploadDir implementation of communicator.Communicator interface
 func (c *Communicator) UploadDir(dst string, src string) error {
-	log.Printf("Uploading dir '%s' to '%s'", src, dst)
-	wcp, err := c.newCopyClient()
-	if err != nil {
+	if err := c.Connect(nil); err != nil {
 		return err
 	}
-	return wcp.Copy(src, dst)
-}
 
-func (c *Communicator) newCopyClient() (*winrmcp.Winrmcp, error) {
-	addr := fmt.Sprintf("%s:%d", c.endpoint.Host, c.endpoint.Port)
-
-	config := winrmcp.Config{
-		Auth: winrmcp.Auth{
-			User:     c.connInfo.User,
-			Password: c.connInfo.Password,
-		},
-		Https:                 c.connInfo.HTTPS,
-		Insecure:              c.connInfo.Insecure,
-		OperationTimeout:      c.Timeout(),
-		MaxOperationsPerShell: 15, // lowest common denominator
-	}
-
-	if c.connInfo.CACert != nil {
-		config.CACertBytes = *c.connInfo.CACert
-	}
-
-	return winrmcp.New(addr, &config)
+	log.Printf("Uploading dir '%s' to '%s'", src, dst)
+	return uploadDir(c.client, dst, src)
 }
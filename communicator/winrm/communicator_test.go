@@ -2,9 +2,14 @@ package winrm
 
 import (
 	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/base64"
 	"io"
+	"io/ioutil"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/dylanmei/winrmtest"
@@ -23,7 +28,7 @@ func newMockWinRMServer(t *testing.T) *winrmtest.Remote {
 		})
 
 	wrm.CommandFunc(
-		winrmtest.MatchPattern(`^echo c29tZXRoaW5n >> ".*"$`),
+		winrmtest.MatchPattern(`^echo [A-Za-z0-9+/=]+ >> ".*"$`),
 		func(out, err io.Writer) int {
 			return 0
 		})
@@ -115,6 +120,82 @@ func TestUpload(t *testing.T) {
 	}
 }
 
+func TestUpload_compressed(t *testing.T) {
+	wrm := winrmtest.NewRemote()
+	defer wrm.Close()
+
+	// Collect the uploaded chunks before the mock server handles them
+	var chunks []string
+	chunkRe := regexp.MustCompile(`^echo ([A-Za-z0-9+/=]+) >> ".*"$`)
+	wrm.CommandFunc(
+		func(candidate string) bool {
+			if m := chunkRe.FindStringSubmatch(candidate); m != nil {
+				chunks = append(chunks, m[1])
+				return true
+			}
+			return false
+		},
+		func(out, err io.Writer) int {
+			return 0
+		})
+	wrm.CommandFunc(
+		winrmtest.MatchPattern(`^powershell.exe -EncodedCommand .*$`),
+		func(out, err io.Writer) int {
+			return 0
+		})
+
+	r := &terraform.InstanceState{
+		Ephemeral: terraform.EphemeralState{
+			ConnInfo: map[string]string{
+				"type":     "winrm",
+				"user":     "user",
+				"password": "pass",
+				"host":     wrm.Host,
+				"port":     strconv.Itoa(wrm.Port),
+				"timeout":  "30s",
+			},
+		},
+	}
+
+	c, err := New(r)
+	if err != nil {
+		t.Fatalf("error creating communicator: %s", err)
+	}
+	defer c.Disconnect()
+
+	// Random data doesn't compress, so it needs several chunks
+	content := make([]byte, 3*maxCommandLength)
+	if _, err := rand.Read(content); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	err = c.Upload("C:/Temp/terraform.bin", bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("error uploading file: %s", err)
+	}
+
+	if len(chunks) < 2 {
+		t.Fatalf("expected multiple chunks, got %d", len(chunks))
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.Join(chunks, ""))
+	if err != nil {
+		t.Fatalf("error decoding chunks: %s", err)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(decoded))
+	if err != nil {
+		t.Fatalf("error decompressing chunks: %s", err)
+	}
+	uploaded, err := ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("error decompressing chunks: %s", err)
+	}
+
+	if !bytes.Equal(uploaded, content) {
+		t.Fatalf("uploaded content does not match")
+	}
+}
+
 func TestScriptPath(t *testing.T) {
 	cases := []struct {
 		Input   string
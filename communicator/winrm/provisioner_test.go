@@ -49,6 +49,37 @@ func TestProvisioner_connInfo(t *testing.T) {
 	}
 }
 
+func TestProvisioner_connInfoNTLM(t *testing.T) {
+	r := &terraform.InstanceState{
+		Ephemeral: terraform.EphemeralState{
+			ConnInfo: map[string]string{
+				"type":     "winrm",
+				"user":     "DOMAIN\\Administrator",
+				"password": "supersecret",
+				"host":     "127.0.0.1",
+				"https":    "true",
+				"use_ntlm": "true",
+				"cacert":   "certcontents",
+			},
+		},
+	}
+
+	conf, err := parseConnectionInfo(r)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	if conf.User != "DOMAIN\\Administrator" {
+		t.Fatalf("expected: %v: got: %v", "DOMAIN\\Administrator", conf)
+	}
+	if conf.UseNTLM != true {
+		t.Fatalf("expected: %v: got: %v", true, conf)
+	}
+	if conf.CACert != "certcontents" {
+		t.Fatalf("expected: %v: got: %v", "certcontents", conf)
+	}
+}
+
 func TestProvisioner_formatDuration(t *testing.T) {
 	cases := map[string]struct {
 		InstanceState *terraform.InstanceState
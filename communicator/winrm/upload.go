@@ -0,0 +1,204 @@
+package winrm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/masterzen/winrm/winrm"
+)
+
+const (
+	// maxCommandLength is kept below the 8191 character limit of cmd.exe
+	maxCommandLength = 8000
+
+	// maxOperationsPerShell is the number of commands run in a single shell
+	// while uploading, as Windows limits it to 15 by default.
+	maxOperationsPerShell = 15
+)
+
+// uploadFile copies the contents of input to the remote path dst. The
+// contents are gzip compressed and base64 encoded, appended to a temporary
+// file in chunks that fit on the command line, and then decoded into place.
+func uploadFile(client *winrm.Client, dst string, input io.Reader) error {
+	tempFile := fmt.Sprintf("terraform-upload-%d-%d.tmp", time.Now().UnixNano(), rand.Int31())
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		enc := base64.NewEncoder(base64.StdEncoding, pw)
+		gz := gzip.NewWriter(enc)
+		_, err := io.Copy(gz, input)
+		if err == nil {
+			err = gz.Close()
+		}
+		if err == nil {
+			err = enc.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	tempPath := `%TEMP%\` + tempFile
+	chunk := make([]byte, maxCommandLength-len(appendCommand("", tempPath)))
+	for done := false; !done; {
+		var err error
+		done, err = uploadChunks(client, tempPath, pr, chunk)
+		if err != nil {
+			return fmt.Errorf("Error uploading file to %s: %s", tempPath, err)
+		}
+	}
+
+	shell, err := client.CreateShell()
+	if err != nil {
+		return err
+	}
+	defer shell.Close()
+
+	if err := runUploadCommand(shell, winrm.Powershell(restoreScript(tempFile, winPath(dst)))); err != nil {
+		return fmt.Errorf("Error restoring file from %s to %s: %s", tempPath, dst, err)
+	}
+
+	return nil
+}
+
+// uploadChunks appends chunks read from r to the remote file, using a
+// single shell for up to maxOperationsPerShell chunks. It returns true
+// once all of r is uploaded.
+func uploadChunks(client *winrm.Client, filePath string, r io.Reader, chunk []byte) (bool, error) {
+	shell, err := client.CreateShell()
+	if err != nil {
+		return false, fmt.Errorf("Couldn't create shell: %s", err)
+	}
+	defer shell.Close()
+
+	for i := 0; i < maxOperationsPerShell; i++ {
+		n, err := io.ReadFull(r, chunk)
+		if n > 0 {
+			if err := runUploadCommand(shell, appendCommand(string(chunk[:n]), filePath)); err != nil {
+				return false, err
+			}
+		}
+
+		switch err {
+		case nil:
+		case io.EOF, io.ErrUnexpectedEOF:
+			return true, nil
+		default:
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+// uploadDir uploads the files in the local directory src to the remote
+// directory dst, keeping their paths relative to src.
+func uploadDir(client *winrm.Client, dst, src string) error {
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Skip directories, they are created along with the files, and
+		// the special hidden file of OS X
+		if info.IsDir() || info.Name() == ".DS_Store" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return fmt.Errorf("Couldn't read file %s: %s", p, err)
+		}
+		defer f.Close()
+
+		return uploadFile(client, path.Join(filepath.ToSlash(dst), filepath.ToSlash(rel)), f)
+	})
+}
+
+func appendCommand(content, filePath string) string {
+	return fmt.Sprintf(`echo %s >> "%s"`, content, filePath)
+}
+
+// restoreScript returns the PowerShell script that decodes the uploaded
+// temporary file into dst and removes it. The decompression is written
+// out so it also works with the .NET versions of PowerShell 2.0.
+func restoreScript(tempFile, dst string) string {
+	return fmt.Sprintf(`$ErrorActionPreference = "Stop"
+$tmp_file_path = [System.IO.Path]::Combine($env:TEMP, %s)
+$dest_file_path = [System.IO.Path]::GetFullPath(%s)
+$dest_dir = [System.IO.Path]::GetDirectoryName($dest_file_path)
+New-Item -ItemType directory -Force -Path $dest_dir | Out-Null
+$base64_string = [string]::join("", (Get-Content $tmp_file_path))
+$bytes = [System.Convert]::FromBase64String($base64_string)
+$in = New-Object System.IO.MemoryStream(,$bytes)
+$gzip = New-Object System.IO.Compression.GZipStream($in, [System.IO.Compression.CompressionMode]::Decompress)
+$out = [System.IO.File]::Create($dest_file_path)
+try {
+  $buffer = New-Object byte[] 65536
+  while (($n = $gzip.Read($buffer, 0, $buffer.Length)) -gt 0) {
+    $out.Write($buffer, 0, $n)
+  }
+} finally {
+  $out.Close()
+  $gzip.Close()
+}
+Remove-Item $tmp_file_path
+`, psQuote(tempFile), psQuote(dst))
+}
+
+// runUploadCommand runs a command in the shell, returning its error
+// output if it fails
+func runUploadCommand(shell *winrm.Shell, command string) error {
+	cmd, err := shell.Execute(command)
+	if err != nil {
+		return err
+	}
+	defer cmd.Close()
+
+	var stderr bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		io.Copy(ioutil.Discard, cmd.Stdout)
+	}()
+	go func() {
+		defer wg.Done()
+		io.Copy(&stderr, cmd.Stderr)
+	}()
+
+	cmd.Wait()
+	wg.Wait()
+
+	if cmd.ExitCode() != 0 {
+		log.Printf("[DEBUG] Upload command failed: %s", stderr.String())
+		return fmt.Errorf("upload operation returned code=%d: %s", cmd.ExitCode(), stderr.String())
+	}
+	return nil
+}
+
+// winPath converts the slashes of a remote path to backslashes
+func winPath(p string) string {
+	return strings.Replace(p, "/", `\`, -1)
+}
+
+// psQuote quotes s as a PowerShell string literal
+func psQuote(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
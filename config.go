@@ -240,6 +240,11 @@ func (c *Config) discoverSingle(glob string, m *map[string]string) error {
 // ProviderFactories returns the mapping of prefixes to
 // ResourceProviderFactory that can be used to instantiate a
 // binary-based plugin.
+//
+// Each plugin process is started the first time its factory is called and
+// is then shared by every provider the factory returns, so the graph walks
+// of a command and all the aliases of a provider reuse the same process.
+// The processes are killed by plugin.CleanupClients when Terraform exits.
 func (c *Config) ProviderFactories() map[string]terraform.ResourceProviderFactory {
 	result := make(map[string]terraform.ResourceProviderFactory)
 	for k, v := range c.Providers {
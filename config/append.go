@@ -30,6 +30,13 @@ func Append(c1, c2 *Config) (*Config, error) {
 		}
 	}
 
+	// Merge Terraform configuration. This is a dumb one overrides the
+	// other sort of merge.
+	c.Terraform = c1.Terraform
+	if c2.Terraform != nil {
+		c.Terraform = c2.Terraform
+	}
+
 	c.Atlas = c1.Atlas
 	if c2.Atlas != nil {
 		c.Atlas = c2.Atlas
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hil"
 	"github.com/hashicorp/hil/ast"
 	"github.com/mitchellh/mapstructure"
@@ -27,6 +28,7 @@ type Config struct {
 	// any meaningful directory.
 	Dir string
 
+	Terraform       *Terraform
 	Atlas           *AtlasConfig
 	Modules         []*Module
 	ProviderConfigs []*ProviderConfig
@@ -39,6 +41,14 @@ type Config struct {
 	unknownKeys []string
 }
 
+// Terraform is the Terraform meta-configuration that can be present
+// in configuration files for configuring Terraform itself.
+type Terraform struct {
+	// RequiredVersion is a version constraint, such as ">= 0.7.0", that
+	// the running Terraform must satisfy to use this configuration.
+	RequiredVersion string `hcl:"required_version"`
+}
+
 // AtlasConfig is the configuration for building in HashiCorp's Atlas.
 type AtlasConfig struct {
 	Name    string
@@ -53,6 +63,7 @@ type AtlasConfig struct {
 type Module struct {
 	Name      string
 	Source    string
+	Version   string
 	RawConfig *RawConfig
 }
 
@@ -110,6 +121,10 @@ type ResourceLifecycle struct {
 	CreateBeforeDestroy bool     `mapstructure:"create_before_destroy"`
 	PreventDestroy      bool     `mapstructure:"prevent_destroy"`
 	IgnoreChanges       []string `mapstructure:"ignore_changes"`
+
+	// DestroyBefore lists resources that must not be destroyed until this
+	// one has been, for when the ordering isn't implied by a dependency.
+	DestroyBefore []string `mapstructure:"destroy_before"`
 }
 
 // Copy returns a copy of this ResourceLifecycle
@@ -118,8 +133,10 @@ func (r *ResourceLifecycle) Copy() *ResourceLifecycle {
 		CreateBeforeDestroy: r.CreateBeforeDestroy,
 		PreventDestroy:      r.PreventDestroy,
 		IgnoreChanges:       make([]string, len(r.IgnoreChanges)),
+		DestroyBefore:       make([]string, len(r.DestroyBefore)),
 	}
 	copy(n.IgnoreChanges, r.IgnoreChanges)
+	copy(n.DestroyBefore, r.DestroyBefore)
 	return n
 }
 
@@ -145,6 +162,18 @@ type Variable struct {
 	DeclaredType string `mapstructure:"type"`
 	Default      interface{}
 	Description  string
+	Validations  []*VariableValidation
+}
+
+// VariableValidation is a rule that the value of a variable must follow.
+//
+// The condition is an interpolation that may only reference the variable
+// itself. The value is invalid if the condition evaluates to "false" or
+// if evaluating it fails, for example because a function rejects the
+// value. In that case ErrorMessage is shown to the user.
+type VariableValidation struct {
+	Condition    string
+	ErrorMessage string
 }
 
 // Output is an output defined within the configuration. An output is
@@ -153,6 +182,7 @@ type Variable struct {
 // application, but will still be available in state.
 type Output struct {
 	Name      string
+	DependsOn []string
 	Sensitive bool
 	RawConfig *RawConfig
 }
@@ -202,9 +232,21 @@ func (r *Module) Id() string {
 }
 
 // Count returns the count of this resource.
+//
+// If the count depends on a value that isn't known yet, such as an
+// attribute of a data source that can only be read during apply, an
+// error is returned. Validation replaces such a count with a placeholder,
+// since data sources aren't read yet at that point.
 func (r *Resource) Count() (int, error) {
 	v, err := strconv.ParseInt(r.RawCount.Value().(string), 0, 0)
 	if err != nil {
+		if len(r.RawCount.UnknownKeys()) > 0 {
+			return 0, fmt.Errorf(
+				"%s: value of 'count' must be known, but it depends on a "+
+					"value that is computed during apply: %s",
+				r.Id(), r.RawCount.Raw[r.RawCount.Key])
+		}
+
 		return 0, err
 	}
 
@@ -236,6 +278,13 @@ func (c *Config) Validate() error {
 			"Unknown root level key: %s", k))
 	}
 
+	if c.Terraform != nil && c.Terraform.RequiredVersion != "" {
+		if _, err := version.NewConstraint(c.Terraform.RequiredVersion); err != nil {
+			errs = append(errs, fmt.Errorf(
+				"terraform.required_version: invalid version constraint: %s", err))
+		}
+	}
+
 	vars := c.InterpolatedVariables()
 	varMap := make(map[string]*Variable)
 	for _, v := range c.Variables {
@@ -263,7 +312,56 @@ func (c *Config) Validate() error {
 					errs = append(errs, fmt.Errorf(
 						"Variable '%s': cannot contain interpolations",
 						v.Name))
+					continue
+				}
+			}
+		}
+
+		validationValid := true
+		for _, validation := range v.Validations {
+			if validation.ErrorMessage == "" {
+				validationValid = false
+				errs = append(errs, fmt.Errorf(
+					"Variable '%s': validation requires an error_message",
+					v.Name))
+			}
+
+			rc, err := NewRawConfig(map[string]interface{}{
+				"condition": validation.Condition,
+			})
+			if err != nil {
+				validationValid = false
+				errs = append(errs, fmt.Errorf(
+					"Variable '%s': validation condition: %s", v.Name, err))
+				continue
+			}
+
+			selfRef := false
+			for _, iv := range rc.Variables {
+				if uv, ok := iv.(*UserVariable); ok && uv.Name == v.Name {
+					selfRef = true
+					continue
 				}
+
+				validationValid = false
+				errs = append(errs, fmt.Errorf(
+					"Variable '%s': validation condition can only reference "+
+						"the variable itself, not %s",
+					v.Name, iv.FullKey()))
+			}
+			if !selfRef {
+				validationValid = false
+				errs = append(errs, fmt.Errorf(
+					"Variable '%s': validation condition must reference var.%s",
+					v.Name, v.Name))
+			}
+		}
+
+		// The default must be valid too, so that the rules hold whether
+		// or not the variable is set.
+		if validationValid && v.Default != nil {
+			if err := v.ValidateValue(v.Default); err != nil {
+				errs = append(errs, err)
 			}
 		}
 	}
@@ -364,6 +462,15 @@ func (c *Config) Validate() error {
 				m.Id()))
 		}
 
+		// Check that the version is a valid constraint
+		if m.Version != "" {
+			if _, err := version.NewConstraint(m.Version); err != nil {
+				errs = append(errs, fmt.Errorf(
+					"%s: invalid version constraint: %s",
+					m.Id(), err))
+			}
+		}
+
 		// Check that the configuration can all be strings, lists or maps
 		raw := make(map[string]interface{})
 		for k, v := range m.RawConfig.Raw {
@@ -464,6 +571,12 @@ func (c *Config) Validate() error {
 					n,
 					v.FullKey()))
 			case *ResourceVariable:
+				// Data sources are read before the plan is made, so
+				// their values are usually known in time.
+				if v.(*ResourceVariable).Mode == DataResourceMode {
+					continue
+				}
+
 				errs = append(errs, fmt.Errorf(
 					"%s: resource count can't reference resource variable: %s",
 					n,
@@ -517,6 +630,22 @@ func (c *Config) Validate() error {
 			}
 		}
 
+		// Verify destroy_before points to resources that all exist
+		for _, d := range r.Lifecycle.DestroyBefore {
+			if d == n {
+				errs = append(errs, fmt.Errorf(
+					"%s: destroy_before cannot reference the resource itself",
+					n))
+				continue
+			}
+
+			if _, ok := resources[d]; !ok {
+				errs = append(errs, fmt.Errorf(
+					"%s: destroy_before references non-existent resource '%s'",
+					n, d))
+			}
+		}
+
 		// Verify provider points to a provider that is configured
 		if r.Provider != "" {
 			if _, ok := providerSet[r.Provider]; !ok {
@@ -620,6 +749,25 @@ func (c *Config) Validate() error {
 					"%s: count variables are only valid within resources", o.Name))
 			}
 		}
+
+		// Verify depends on points to resources that all exist
+		for _, d := range o.DependsOn {
+			rc, err := NewRawConfig(map[string]interface{}{
+				"value": d,
+			})
+			if err == nil && len(rc.Variables) > 0 {
+				errs = append(errs, fmt.Errorf(
+					"%s: depends on value cannot contain interpolations: %s",
+					o.Name, d))
+				continue
+			}
+
+			if _, ok := resources[d]; !ok {
+				errs = append(errs, fmt.Errorf(
+					"%s: output depends on non-existent resource '%s'",
+					o.Name, d))
+			}
+		}
 	}
 
 	// Check that all variables are in the proper context
@@ -769,6 +917,9 @@ func (m *Module) mergerMerge(other merger) merger {
 	if m2.Source != "" {
 		result.Source = m2.Source
 	}
+	if m2.Version != "" {
+		result.Version = m2.Version
+	}
 
 	return &result
 }
@@ -784,6 +935,10 @@ func (o *Output) mergerMerge(m merger) merger {
 	result.Name = o2.Name
 	result.RawConfig = result.RawConfig.merge(o2.RawConfig)
 
+	if len(o2.DependsOn) > 0 {
+		result.DependsOn = o2.DependsOn
+	}
+
 	return &result
 }
 
@@ -851,6 +1006,9 @@ func (v *Variable) Merge(v2 *Variable) *Variable {
 	if v2.Description != "" {
 		result.Description = v2.Description
 	}
+	if len(v2.Validations) > 0 {
+		result.Validations = v2.Validations
+	}
 
 	return &result
 }
@@ -897,6 +1055,41 @@ func (v *Variable) ValidateTypeAndDefault() error {
 	return nil
 }
 
+// ValidateValue checks the value of the variable against its validation
+// rules and returns an error with the message of the first rule that the
+// value violates. Unknown values aren't checked.
+func (v *Variable) ValidateValue(value interface{}) error {
+	if len(v.Validations) == 0 || value == UnknownVariableValue {
+		return nil
+	}
+
+	variable, err := hil.InterfaceToVariable(value)
+	if err != nil {
+		return fmt.Errorf("Variable '%s': %s", v.Name, err)
+	}
+	vs := map[string]ast.Variable{"var." + v.Name: variable}
+
+	for _, validation := range v.Validations {
+		rc, err := NewRawConfig(map[string]interface{}{
+			"condition": validation.Condition,
+		})
+		if err != nil {
+			return fmt.Errorf("Variable '%s': %s", v.Name, err)
+		}
+
+		// An error while evaluating the condition means the value was
+		// rejected, such as by cidrhost for an invalid CIDR block.
+		if err := rc.Interpolate(vs); err == nil && rc.Config()["condition"] != "false" {
+			continue
+		}
+
+		return fmt.Errorf(
+			"Invalid value for variable '%s': %s", v.Name, validation.ErrorMessage)
+	}
+
+	return nil
+}
+
 func (v *Variable) mergerName() string {
 	return v.Name
 }
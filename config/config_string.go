@@ -76,6 +76,9 @@ func modulesStr(ms []*Module) string {
 		sort.Strings(ks)
 
 		result += fmt.Sprintf("  source = %s\n", m.Source)
+		if m.Version != "" {
+			result += fmt.Sprintf("  version = %s\n", m.Version)
+		}
 
 		for _, k := range ks {
 			result += fmt.Sprintf("  %s\n", k)
@@ -100,6 +103,13 @@ func outputsStr(os []*Output) string {
 
 		result += fmt.Sprintf("%s\n", n)
 
+		if len(o.DependsOn) > 0 {
+			result += fmt.Sprintf("  dependsOn\n")
+			for _, d := range o.DependsOn {
+				result += fmt.Sprintf("    %s\n", d)
+			}
+		}
+
 		if len(o.RawConfig.Variables) > 0 {
 			result += fmt.Sprintf("  vars\n")
 			for _, rawV := range o.RawConfig.Variables {
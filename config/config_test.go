@@ -99,6 +99,20 @@ func TestConfigValidate_badDependsOn(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_badDestroyBefore(t *testing.T) {
+	c := testConfig(t, "validate-bad-destroy-before")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_tfVersionInvalid(t *testing.T) {
+	c := testConfig(t, "validate-tf-version-invalid")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_countInt(t *testing.T) {
 	c := testConfig(t, "validate-count-int")
 	if err := c.Validate(); err != nil {
@@ -150,6 +164,13 @@ func TestConfigValidate_countResourceVar(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_countResourceVarData(t *testing.T) {
+	c := testConfig(t, "validate-count-resource-var-data")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
 func TestConfigValidate_countUserVar(t *testing.T) {
 	c := testConfig(t, "validate-count-user-var")
 	if err := c.Validate(); err != nil {
@@ -206,6 +227,20 @@ func TestConfigValidate_moduleSourceVar(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_moduleVersionBad(t *testing.T) {
+	c := testConfig(t, "validate-module-version-bad")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+
+	if v := c.Modules[0].Version; v != "not a version" {
+		t.Fatalf("bad: %q", v)
+	}
+	if _, ok := c.Modules[0].RawConfig.Raw["version"]; ok {
+		t.Fatal("version should not be a module variable")
+	}
+}
+
 func TestConfigValidate_moduleVarInt(t *testing.T) {
 	c := testConfig(t, "validate-module-var-int")
 	if err := c.Validate(); err != nil {
@@ -241,6 +276,20 @@ func TestConfigValidate_nil(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_outputDependsOn(t *testing.T) {
+	c := testConfig(t, "validate-output-depends-on")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestConfigValidate_outputDependsOnBad(t *testing.T) {
+	c := testConfig(t, "validate-output-depends-on-bad")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_outputBadField(t *testing.T) {
 	c := testConfig(t, "validate-output-bad-field")
 	if err := c.Validate(); err == nil {
@@ -367,6 +416,38 @@ func TestConfigValidate_unknownVarCount(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_varValidation(t *testing.T) {
+	c := testConfig(t, "validate-var-validation")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestConfigValidate_varValidationBadDefault(t *testing.T) {
+	c := testConfig(t, "validate-var-validation-bad-default")
+	err := c.Validate()
+	if err == nil {
+		t.Fatal("should not be valid")
+	}
+	if !strings.Contains(err.Error(), "must be a CIDR block") {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
+func TestConfigValidate_varValidationNoMessage(t *testing.T) {
+	c := testConfig(t, "validate-var-validation-no-message")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_varValidationOtherVar(t *testing.T) {
+	c := testConfig(t, "validate-var-validation-other-var")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_varDefault(t *testing.T) {
 	c := testConfig(t, "validate-var-default")
 	if err := c.Validate(); err != nil {
@@ -430,6 +511,44 @@ func TestConfigValidate_varModuleInvalid(t *testing.T) {
 	}
 }
 
+func TestVariableValidateValue(t *testing.T) {
+	v := &Variable{
+		Name: "name",
+		Validations: []*VariableValidation{
+			&VariableValidation{
+				Condition:    `${replace(var.name, "/^.*[^a-z0-9-].*$/", "false")}`,
+				ErrorMessage: "Only lowercase letters, digits and dashes are allowed.",
+			},
+			&VariableValidation{
+				Condition:    `${index(split(",", "foo,bar-1"), var.name)}`,
+				ErrorMessage: "Must be foo or bar-1.",
+			},
+		},
+	}
+
+	cases := map[interface{}]string{
+		"foo":                "",
+		"bar-1":              "",
+		UnknownVariableValue: "",
+		"Foo":                "Only lowercase letters",
+		"baz":                "Must be foo or bar-1",
+	}
+
+	for value, expected := range cases {
+		err := v.ValidateValue(value)
+		if expected == "" {
+			if err != nil {
+				t.Fatalf("%v: err: %s", value, err)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("%v: expected error with %q, got %v", value, expected, err)
+		}
+	}
+}
+
 func TestNameRegexp(t *testing.T) {
 	cases := []struct {
 		Input string
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"bytes"
+	"compress/gzip"
 	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
@@ -9,18 +11,23 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
+	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/apparentlymart/go-cidr/cidr"
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/hil"
 	"github.com/hashicorp/hil/ast"
 	"github.com/mitchellh/go-homedir"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // stringSliceToVariableValue converts a string slice into the value
@@ -51,36 +58,57 @@ func listVariableValueToStringSlice(values []ast.Variable) ([]string, error) {
 // Funcs is the mapping of built-in functions for configuration.
 func Funcs() map[string]ast.Function {
 	return map[string]ast.Function{
-		"base64decode": interpolationFuncBase64Decode(),
-		"base64encode": interpolationFuncBase64Encode(),
-		"base64sha256": interpolationFuncBase64Sha256(),
-		"cidrhost":     interpolationFuncCidrHost(),
-		"cidrnetmask":  interpolationFuncCidrNetmask(),
-		"cidrsubnet":   interpolationFuncCidrSubnet(),
-		"coalesce":     interpolationFuncCoalesce(),
-		"compact":      interpolationFuncCompact(),
-		"concat":       interpolationFuncConcat(),
-		"element":      interpolationFuncElement(),
-		"file":         interpolationFuncFile(),
-		"format":       interpolationFuncFormat(),
-		"formatlist":   interpolationFuncFormatList(),
-		"index":        interpolationFuncIndex(),
-		"join":         interpolationFuncJoin(),
-		"jsonencode":   interpolationFuncJSONEncode(),
-		"length":       interpolationFuncLength(),
-		"lower":        interpolationFuncLower(),
-		"md5":          interpolationFuncMd5(),
-		"uuid":         interpolationFuncUUID(),
-		"replace":      interpolationFuncReplace(),
-		"sha1":         interpolationFuncSha1(),
-		"sha256":       interpolationFuncSha256(),
-		"signum":       interpolationFuncSignum(),
-		"split":        interpolationFuncSplit(),
-		"trimspace":    interpolationFuncTrimSpace(),
-		"upper":        interpolationFuncUpper(),
+		"base64decode":   interpolationFuncBase64Decode(),
+		"base64encode":   interpolationFuncBase64Encode(),
+		"base64gzip":     interpolationFuncBase64Gzip(),
+		"base64sha256":   interpolationFuncBase64Sha256(),
+		"bcrypt":         interpolationFuncBcrypt(),
+		"cidrhost":       interpolationFuncCidrHost(),
+		"cidrnetmask":    interpolationFuncCidrNetmask(),
+		"cidrsubnet":     interpolationFuncCidrSubnet(),
+		"coalesce":       interpolationFuncCoalesce(),
+		"compact":        interpolationFuncCompact(),
+		"concat":         interpolationFuncConcat(),
+		"element":        interpolationFuncElement(),
+		"file":           interpolationFuncFile(nil),
+		"format":         interpolationFuncFormat(),
+		"formatdate":     interpolationFuncFormatDate(),
+		"formatlist":     interpolationFuncFormatList(),
+		"index":          interpolationFuncIndex(),
+		"join":           interpolationFuncJoin(),
+		"jsondecode":     interpolationFuncJSONDecode(),
+		"jsondecodelist": interpolationFuncJSONDecodeList(),
+		"jsonencode":     interpolationFuncJSONEncode(),
+		"length":         interpolationFuncLength(),
+		"lower":          interpolationFuncLower(),
+		"md5":            interpolationFuncMd5(),
+		"uuid":           interpolationFuncUUID(),
+		"replace":        interpolationFuncReplace(),
+		"sha1":           interpolationFuncSha1(),
+		"sha256":         interpolationFuncSha256(),
+		"signum":         interpolationFuncSignum(),
+		"split":          interpolationFuncSplit(),
+		"timeadd":        interpolationFuncTimeAdd(),
+		"timestamp":      interpolationFuncTimestamp(),
+		"trimspace":      interpolationFuncTrimSpace(),
+		"upper":          interpolationFuncUpper(),
 	}
 }
 
+// FuncNames returns the sorted names of all functions that can be called
+// from an interpolation. This includes functions such as "lookup" that
+// depend on the variables in scope and so aren't part of Funcs.
+func FuncNames() []string {
+	funcMap := langEvalConfig(nil).GlobalScope.FuncMap
+	names := make([]string, 0, len(funcMap))
+	for k, _ := range funcMap {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
 // interpolationFuncCompact strips a list of multi-variable values
 // (e.g. as returned by "split") of any empty strings.
 func interpolationFuncCompact() ast.Function {
@@ -254,7 +282,12 @@ func interpolationFuncConcat() ast.Function {
 
 // interpolationFuncFile implements the "file" function that allows
 // loading contents from a file.
-func interpolationFuncFile() ast.Function {
+//
+// Relative paths are resolved against the working directory. If the file
+// doesn't exist there and "path.module" is in scope, they're resolved
+// against the directory of the module instead, so modules can load files
+// bundled with them.
+func interpolationFuncFile(vs map[string]ast.Variable) ast.Function {
 	return ast.Function{
 		ArgTypes:   []ast.Type{ast.TypeString},
 		ReturnType: ast.TypeString,
@@ -264,6 +297,14 @@ func interpolationFuncFile() ast.Function {
 				return "", err
 			}
 			data, err := ioutil.ReadFile(path)
+			if os.IsNotExist(err) && !filepath.IsAbs(path) {
+				if v, ok := vs["path.module"]; ok && v.Type == ast.TypeString {
+					if moduleData, moduleErr := ioutil.ReadFile(
+						filepath.Join(v.Value.(string), path)); moduleErr == nil {
+						data, err = moduleData, nil
+					}
+				}
+			}
 			if err != nil {
 				return "", err
 			}
@@ -463,6 +504,113 @@ func interpolationFuncJSONEncode() ast.Function {
 	}
 }
 
+// interpolationFuncJSONDecode implements the "jsondecode" function that
+// parses a JSON object into a map of strings.
+//
+// Interpolations can't hold nested values yet, so values that are objects
+// or arrays are kept as their JSON encoding. Objects can be decoded again
+// with another call to jsondecode, and arrays with jsondecodelist.
+func interpolationFuncJSONDecode() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeString},
+		ReturnType: ast.TypeMap,
+		Callback: func(args []interface{}) (interface{}, error) {
+			var decoded map[string]json.RawMessage
+			if err := jsonDecodeOne(args[0].(string), &decoded); err != nil {
+				return nil, err
+			}
+			if decoded == nil {
+				return nil, fmt.Errorf(
+					"JSON value must be an object, use jsondecodelist for arrays")
+			}
+
+			result := make(map[string]ast.Variable, len(decoded))
+			for k, raw := range decoded {
+				s, err := jsonValueToString(raw)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %s", k, err)
+				}
+
+				result[k] = ast.Variable{Type: ast.TypeString, Value: s}
+			}
+
+			return result, nil
+		},
+	}
+}
+
+// interpolationFuncJSONDecodeList implements the "jsondecodelist" function
+// that parses a JSON array into a list of strings. It's separate from
+// jsondecode since the type a function returns can't depend on its
+// arguments. The elements are converted the same way as the values of
+// jsondecode.
+func interpolationFuncJSONDecodeList() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeString},
+		ReturnType: ast.TypeList,
+		Callback: func(args []interface{}) (interface{}, error) {
+			var decoded []json.RawMessage
+			if err := jsonDecodeOne(args[0].(string), &decoded); err != nil {
+				return nil, err
+			}
+			if decoded == nil {
+				return nil, fmt.Errorf(
+					"JSON value must be an array, use jsondecode for objects")
+			}
+
+			result := make([]string, len(decoded))
+			for i, raw := range decoded {
+				s, err := jsonValueToString(raw)
+				if err != nil {
+					return nil, fmt.Errorf("element %d: %s", i, err)
+				}
+
+				result[i] = s
+			}
+
+			return stringSliceToVariableValue(result), nil
+		},
+	}
+}
+
+// jsonDecodeOne decodes a single JSON value from s into v, and fails if
+// anything but whitespace follows it.
+func jsonDecodeOne(s string, v interface{}) error {
+	dec := json.NewDecoder(strings.NewReader(s))
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("failed to decode JSON: %s", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data after JSON value")
+	}
+
+	return nil
+}
+
+// jsonValueToString converts a decoded JSON value to the string it's
+// represented by in interpolations.
+func jsonValueToString(raw json.RawMessage) (string, error) {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", err
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case nil:
+		return "", fmt.Errorf("null JSON values are not supported")
+	default:
+		// Numbers and booleans are kept as they're written, and so are
+		// nested objects and arrays after their whitespace is removed.
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, raw); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+}
+
 // interpolationFuncReplace implements the "replace" function that does
 // string replacement.
 func interpolationFuncReplace() ast.Function {
@@ -698,6 +846,56 @@ func interpolationFuncBase64Decode() ast.Function {
 	}
 }
 
+// interpolationFuncBase64Gzip implements the "base64gzip" function that
+// compresses a string with gzip and Base64 encodes the result.
+func interpolationFuncBase64Gzip() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeString},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			s := args[0].(string)
+
+			var b bytes.Buffer
+			gz := gzip.NewWriter(&b)
+			if _, err := gz.Write([]byte(s)); err != nil {
+				return "", fmt.Errorf("failed to compress data: %s", err)
+			}
+			if err := gz.Close(); err != nil {
+				return "", fmt.Errorf("failed to compress data: %s", err)
+			}
+
+			return base64.StdEncoding.EncodeToString(b.Bytes()), nil
+		},
+	}
+}
+
+// interpolationFuncBcrypt implements the "bcrypt" function that hashes a
+// string with bcrypt. The cost defaults to 10 if it isn't given.
+func interpolationFuncBcrypt() ast.Function {
+	return ast.Function{
+		ArgTypes:     []ast.Type{ast.TypeString},
+		Variadic:     true,
+		VariadicType: ast.TypeInt,
+		ReturnType:   ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			cost := bcrypt.DefaultCost
+			if len(args) > 2 {
+				return "", fmt.Errorf("bcrypt() takes at most 2 arguments, got %d", len(args))
+			}
+			if len(args) == 2 {
+				cost = args[1].(int)
+			}
+
+			hash, err := bcrypt.GenerateFromPassword([]byte(args[0].(string)), cost)
+			if err != nil {
+				return "", fmt.Errorf("failed to hash with bcrypt: %s", err)
+			}
+
+			return string(hash), nil
+		},
+	}
+}
+
 // interpolationFuncLower implements the "lower" function that does
 // string lower casing.
 func interpolationFuncLower() ast.Function {
@@ -802,3 +1000,153 @@ func interpolationFuncUUID() ast.Function {
 		},
 	}
 }
+
+// interpolationFuncTimestamp returns the current time as an RFC 3339
+// timestamp in UTC. Values calling it are unknown while planning, see
+// RawConfig.InterpolateUnknownTime.
+func interpolationFuncTimestamp() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			return time.Now().UTC().Format(time.RFC3339), nil
+		},
+	}
+}
+
+// interpolationFuncTimeAdd adds a duration like "1h30m" or "-24h" to an
+// RFC 3339 timestamp.
+func interpolationFuncTimeAdd() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeString, ast.TypeString},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			ts, err := time.Parse(time.RFC3339, args[0].(string))
+			if err != nil {
+				return "", fmt.Errorf("timeadd: invalid timestamp: %s", err)
+			}
+
+			d, err := time.ParseDuration(args[1].(string))
+			if err != nil {
+				return "", fmt.Errorf("timeadd: invalid duration: %s", err)
+			}
+
+			return ts.Add(d).Format(time.RFC3339), nil
+		},
+	}
+}
+
+// interpolationFuncFormatDate formats an RFC 3339 timestamp according to
+// a format spec like "YYYY-MM-DD". See formatDate for the spec.
+func interpolationFuncFormatDate() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeString, ast.TypeString},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			ts, err := time.Parse(time.RFC3339, args[1].(string))
+			if err != nil {
+				return "", fmt.Errorf("formatdate: invalid timestamp: %s", err)
+			}
+
+			return formatDate(args[0].(string), ts)
+		},
+	}
+}
+
+// formatDateSequences maps the sequences of a formatdate spec to the
+// layout elements of the time package.
+var formatDateSequences = map[string]string{
+	"YYYY":  "2006",
+	"YY":    "06",
+	"MMMM":  "January",
+	"MMM":   "Jan",
+	"MM":    "01",
+	"M":     "1",
+	"DD":    "02",
+	"D":     "2",
+	"EEEE":  "Monday",
+	"EEE":   "Mon",
+	"hh":    "15",
+	"HH":    "03",
+	"H":     "3",
+	"AA":    "PM",
+	"mm":    "04",
+	"m":     "4",
+	"ss":    "05",
+	"s":     "5",
+	"ZZZZZ": "-07:00",
+	"ZZZZ":  "-0700",
+	"ZZZ":   "MST",
+	"Z":     "Z07:00",
+}
+
+// formatDate formats t according to spec. A spec consists of sequences
+// of the same letter, such as "YYYY" for the year, that are replaced with
+// the part of the time they stand for. Text in single quotes is copied
+// literally, with two single quotes standing for one, and all other
+// characters besides letters are copied as is.
+func formatDate(spec string, t time.Time) (string, error) {
+	var buf bytes.Buffer
+	for i := 0; i < len(spec); {
+		c := spec[i]
+		switch {
+		case c == '\'':
+			// An escaped quote, or a quoted literal that may contain
+			// escaped quotes itself
+			if i+1 < len(spec) && spec[i+1] == '\'' {
+				buf.WriteByte('\'')
+				i += 2
+				continue
+			}
+
+			j := i + 1
+			for {
+				if j >= len(spec) {
+					return "", fmt.Errorf(
+						"formatdate: unterminated quote in %q", spec)
+				}
+				if spec[j] == '\'' {
+					if j+1 < len(spec) && spec[j+1] == '\'' {
+						buf.WriteByte('\'')
+						j += 2
+						continue
+					}
+					break
+				}
+
+				buf.WriteByte(spec[j])
+				j++
+			}
+			i = j + 1
+
+		case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			j := i + 1
+			for j < len(spec) && spec[j] == c {
+				j++
+			}
+
+			seq := spec[i:j]
+			switch seq {
+			case "h":
+				// The time package has no unpadded 24-hour layout
+				buf.WriteString(strconv.Itoa(t.Hour()))
+			case "aa":
+				buf.WriteString(strings.ToLower(t.Format("PM")))
+			default:
+				layout, ok := formatDateSequences[seq]
+				if !ok {
+					return "", fmt.Errorf(
+						"formatdate: unsupported sequence %q in %q", seq, spec)
+				}
+				buf.WriteString(t.Format(layout))
+			}
+			i = j
+
+		default:
+			buf.WriteByte(c)
+			i++
+		}
+	}
+
+	return buf.String(), nil
+}
@@ -1,16 +1,37 @@
 package config
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/hil"
 	"github.com/hashicorp/hil/ast"
+	"golang.org/x/crypto/bcrypt"
 )
 
+func TestFuncNames(t *testing.T) {
+	names := FuncNames()
+	if !sort.StringsAreSorted(names) {
+		t.Fatalf("not sorted: %#v", names)
+	}
+
+	for _, n := range []string{"concat", "keys", "lookup", "values"} {
+		i := sort.SearchStrings(names, n)
+		if i == len(names) || names[i] != n {
+			t.Fatalf("missing %q: %#v", n, names)
+		}
+	}
+}
+
 func TestInterpolateFuncCompact(t *testing.T) {
 	testFunction(t, testFunctionConfig{
 		Cases: []testFunctionCase{
@@ -261,6 +282,53 @@ func TestInterpolateFuncFile(t *testing.T) {
 	})
 }
 
+func TestInterpolateFuncFile_moduleDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "bundled.txt"), []byte("foo"), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			// Relative paths fall back to the module directory
+			{
+				`${file("bundled.txt")}`,
+				"foo",
+				false,
+			},
+
+			{
+				`${file("missing.txt")}`,
+				nil,
+				true,
+			},
+		},
+		Vars: map[string]ast.Variable{
+			"path.module": ast.Variable{
+				Value: dir,
+				Type:  ast.TypeString,
+			},
+		},
+	})
+
+	// Without a module directory in scope, only the working directory
+	// is used.
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${file("bundled.txt")}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
 func TestInterpolateFuncFormat(t *testing.T) {
 	testFunction(t, testFunctionConfig{
 		Cases: []testFunctionCase{
@@ -408,6 +476,160 @@ func TestInterpolateFuncJoin(t *testing.T) {
 	})
 }
 
+func TestInterpolateFuncJSONDecode(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Vars: map[string]ast.Variable{
+			"policy": ast.Variable{
+				Type: ast.TypeString,
+				Value: `{
+					"Version": "2012-10-17",
+					"Statement": [{"Action": ["s3:GetObject"], "Effect": "Allow"}]
+				}`,
+			},
+		},
+		Cases: []testFunctionCase{
+			{
+				`${jsondecode("{\"foo\": \"bar\", \"count\": 1.50, \"enabled\": true}")}`,
+				map[string]interface{}{
+					"foo":     "bar",
+					"count":   "1.50",
+					"enabled": "true",
+				},
+				false,
+			},
+
+			// Nested values are kept as JSON
+			{
+				`${jsondecode(policy)}`,
+				map[string]interface{}{
+					"Version":   "2012-10-17",
+					"Statement": `[{"Action":["s3:GetObject"],"Effect":"Allow"}]`,
+				},
+				false,
+			},
+
+			{
+				`${lookup(jsondecode(lookup(jsondecode("{\"foo\": {\"bar\": \"baz\"}}"), "foo")), "bar")}`,
+				"baz",
+				false,
+			},
+
+			{
+				`${lookup(jsondecode("{\"foo\": \"bar\"}"), "foo")}`,
+				"bar",
+				false,
+			},
+
+			{
+				`${join(",", keys(jsondecode("{\"foo\": \"1\", \"bar\": \"2\"}")))}`,
+				"bar,foo",
+				false,
+			},
+
+			// Not an object
+			{
+				`${jsondecode("[\"foo\"]")}`,
+				nil,
+				true,
+			},
+
+			{
+				`${jsondecode("null")}`,
+				nil,
+				true,
+			},
+
+			// Invalid JSON
+			{
+				`${jsondecode("{")}`,
+				nil,
+				true,
+			},
+
+			{
+				`${jsondecode("{} {}")}`,
+				nil,
+				true,
+			},
+
+			{
+				`${jsondecode("{\"foo\": null}")}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncJSONDecodeList(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${jsondecodelist("[\"foo\", 1.50, true]")}`,
+				[]interface{}{"foo", "1.50", "true"},
+				false,
+			},
+
+			{
+				`${jsondecodelist("[]")}`,
+				[]interface{}{},
+				false,
+			},
+
+			// Nested values are kept as JSON
+			{
+				`${jsondecodelist("[{\"foo\": \"bar\"}, [1, 2]]")}`,
+				[]interface{}{`{"foo":"bar"}`, `[1,2]`},
+				false,
+			},
+
+			{
+				`${lookup(jsondecode(element(jsondecodelist("[{\"foo\": \"bar\"}]"), 0)), "foo")}`,
+				"bar",
+				false,
+			},
+
+			{
+				`${join(",", jsondecodelist(lookup(jsondecode("{\"ids\": [\"a\", \"b\"]}"), "ids")))}`,
+				"a,b",
+				false,
+			},
+
+			// Not an array
+			{
+				`${jsondecodelist("{\"foo\": \"bar\"}")}`,
+				nil,
+				true,
+			},
+
+			{
+				`${jsondecodelist("null")}`,
+				nil,
+				true,
+			},
+
+			{
+				`${jsondecodelist("[\"foo\", null]")}`,
+				nil,
+				true,
+			},
+
+			// Invalid JSON
+			{
+				`${jsondecodelist("[")}`,
+				nil,
+				true,
+			},
+
+			{
+				`${jsondecodelist("[] []")}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
 func TestInterpolateFuncJSONEncode(t *testing.T) {
 	testFunction(t, testFunctionConfig{
 		Vars: map[string]ast.Variable{
@@ -1030,6 +1252,80 @@ func TestInterpolateFuncBase64Sha256(t *testing.T) {
 	})
 }
 
+func TestInterpolateFuncBase64Gzip(t *testing.T) {
+	ast, err := hil.Parse(`${base64gzip("test")}`)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	result, err := hil.Eval(ast, langEvalConfig(nil))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(result.Value.(string))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	data, err := ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if string(data) != "test" {
+		t.Fatalf("bad: %q", data)
+	}
+}
+
+func TestInterpolateFuncBcrypt(t *testing.T) {
+	cases := map[string]int{
+		`${bcrypt("test")}`:    bcrypt.DefaultCost,
+		`${bcrypt("test", 5)}`: 5,
+	}
+
+	for input, expected := range cases {
+		ast, err := hil.Parse(input)
+		if err != nil {
+			t.Fatalf("%s: err: %s", input, err)
+		}
+
+		result, err := hil.Eval(ast, langEvalConfig(nil))
+		if err != nil {
+			t.Fatalf("%s: err: %s", input, err)
+		}
+
+		hash := []byte(result.Value.(string))
+		if err := bcrypt.CompareHashAndPassword(hash, []byte("test")); err != nil {
+			t.Fatalf("%s: err: %s", input, err)
+		}
+		if cost, err := bcrypt.Cost(hash); err != nil || cost != expected {
+			t.Fatalf("%s: bad cost %d: %v", input, cost, err)
+		}
+	}
+
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			// Cost out of range
+			{
+				`${bcrypt("test", 100)}`,
+				nil,
+				true,
+			},
+
+			// Too many args
+			{
+				`${bcrypt("test", 5, 6)}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
 func TestInterpolateFuncMd5(t *testing.T) {
 	testFunction(t, testFunctionConfig{
 		Cases: []testFunctionCase{
@@ -1074,6 +1370,113 @@ func TestInterpolateFuncUUID(t *testing.T) {
 	}
 }
 
+func TestInterpolateFuncTimestamp(t *testing.T) {
+	ast, err := hil.Parse("${timestamp()}")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	result, err := hil.Eval(ast, langEvalConfig(nil))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	ts, err := time.Parse(time.RFC3339, result.Value.(string))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d := time.Since(ts); d < -time.Second || d > time.Minute {
+		t.Fatalf("bad: %s", result.Value)
+	}
+	if ts.Location() != time.UTC {
+		t.Fatalf("not UTC: %s", result.Value)
+	}
+}
+
+func TestInterpolateFuncTimeAdd(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${timeadd("2017-01-02T15:04:05Z", "1h30m")}`,
+				"2017-01-02T16:34:05Z",
+				false,
+			},
+			{
+				`${timeadd("2017-01-02T15:04:05Z", "-24h")}`,
+				"2017-01-01T15:04:05Z",
+				false,
+			},
+			{
+				`${timeadd("2017-01-02T15:04:05+01:00", "10m")}`,
+				"2017-01-02T15:14:05+01:00",
+				false,
+			},
+			{
+				`${timeadd("2017-01-02", "1h")}`,
+				nil,
+				true,
+			},
+			{
+				`${timeadd("2017-01-02T15:04:05Z", "1d")}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncFormatDate(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${formatdate("YYYY-MM-DD", "2017-01-02T15:04:05Z")}`,
+				"2017-01-02",
+				false,
+			},
+			{
+				`${formatdate("EEE, DD MMM YYYY hh:mm:ss ZZZ", "2017-01-02T15:04:05Z")}`,
+				"Mon, 02 Jan 2017 15:04:05 UTC",
+				false,
+			},
+			{
+				`${formatdate("EEEE MMMM D, H:mmaa", "2017-01-02T15:04:05Z")}`,
+				"Monday January 2, 3:04pm",
+				false,
+			},
+			{
+				`${formatdate("h'h'm YY/M ZZZZZ", "2017-01-02T05:04:05-08:00")}`,
+				"5h4 17/1 -08:00",
+				false,
+			},
+			{
+				`${formatdate("HH AA 'o''clock' ''", "2017-01-02T05:04:05Z")}`,
+				"05 AM o'clock '",
+				false,
+			},
+			{
+				`${formatdate("YYYYMMDDhhmmssZ", "2017-01-02T15:04:05Z")}`,
+				"20170102150405Z",
+				false,
+			},
+			{
+				`${formatdate("YYY", "2017-01-02T15:04:05Z")}`,
+				nil,
+				true,
+			},
+			{
+				`${formatdate("'unterminated", "2017-01-02T15:04:05Z")}`,
+				nil,
+				true,
+			},
+			{
+				`${formatdate("YYYY", "yesterday")}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
 type testFunctionConfig struct {
 	Cases []testFunctionCase
 	Vars  map[string]ast.Variable
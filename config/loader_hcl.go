@@ -19,13 +19,14 @@ type hclConfigurable struct {
 
 func (t *hclConfigurable) Config() (*Config, error) {
 	validKeys := map[string]struct{}{
-		"atlas":    struct{}{},
-		"data":     struct{}{},
-		"module":   struct{}{},
-		"output":   struct{}{},
-		"provider": struct{}{},
-		"resource": struct{}{},
-		"variable": struct{}{},
+		"atlas":     struct{}{},
+		"data":      struct{}{},
+		"module":    struct{}{},
+		"output":    struct{}{},
+		"provider":  struct{}{},
+		"resource":  struct{}{},
+		"terraform": struct{}{},
+		"variable":  struct{}{},
 	}
 
 	type hclVariable struct {
@@ -55,6 +56,13 @@ func (t *hclConfigurable) Config() (*Config, error) {
 	// duplicates aren't overriden
 	config := new(Config)
 	if len(rawConfig.Variable) > 0 {
+		// Validation blocks can be repeated, which the decoder above
+		// doesn't handle, so they're read separately.
+		validations, err := loadVariableValidationsHcl(list.Filter("variable"))
+		if err != nil {
+			return nil, err
+		}
+
 		config.Variables = make([]*Variable, 0, len(rawConfig.Variable))
 		for k, v := range rawConfig.Variable {
 			// Defaults turn into a slice of map[string]interface{} and
@@ -76,6 +84,7 @@ func (t *hclConfigurable) Config() (*Config, error) {
 				DeclaredType: v.DeclaredType,
 				Default:      v.Default,
 				Description:  v.Description,
+				Validations:  validations[k],
 			}
 
 			if err := newVar.ValidateTypeAndDefault(); err != nil {
@@ -86,6 +95,15 @@ func (t *hclConfigurable) Config() (*Config, error) {
 		}
 	}
 
+	// Get the Terraform configuration
+	if tf := list.Filter("terraform"); len(tf.Items) > 0 {
+		var err error
+		config.Terraform, err = loadTerraformHcl(tf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Get Atlas configuration
 	if atlas := list.Filter("atlas"); len(atlas.Items) > 0 {
 		var err error
@@ -230,6 +248,32 @@ func loadFileHcl(root string) (configurable, []string, error) {
 	return result, nil, nil
 }
 
+// Given a handle to a HCL object, this transforms it into the Terraform
+// configuration.
+func loadTerraformHcl(list *ast.ObjectList) (*Terraform, error) {
+	if len(list.Items) > 1 {
+		return nil, fmt.Errorf("only one 'terraform' block allowed")
+	}
+
+	// Get our one item
+	item := list.Items[0]
+
+	// Check for invalid keys
+	valid := []string{"required_version"}
+	if err := checkHCLKeys(item.Val, valid); err != nil {
+		return nil, multierror.Prefix(err, "terraform:")
+	}
+
+	var config Terraform
+	if err := hcl.DecodeObject(&config, item.Val); err != nil {
+		return nil, fmt.Errorf(
+			"Error reading terraform config: %s",
+			err)
+	}
+
+	return &config, nil
+}
+
 // Given a handle to a HCL object, this transforms it into the Atlas
 // configuration.
 func loadAtlasHcl(list *ast.ObjectList) (*AtlasConfig, error) {
@@ -287,6 +331,7 @@ func loadModulesHcl(list *ast.ObjectList) ([]*Module, error) {
 
 		// Remove the fields we handle specially
 		delete(config, "source")
+		delete(config, "version")
 
 		rawConfig, err := NewRawConfig(config)
 		if err != nil {
@@ -308,9 +353,21 @@ func loadModulesHcl(list *ast.ObjectList) ([]*Module, error) {
 			}
 		}
 
+		var version string
+		if o := listVal.Filter("version"); len(o.Items) > 0 {
+			err = hcl.DecodeObject(&version, o.Items[0].Val)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Error parsing version for %s: %s",
+					k,
+					err)
+			}
+		}
+
 		result = append(result, &Module{
 			Name:      k,
 			Source:    source,
+			Version:   version,
 			RawConfig: rawConfig,
 		})
 	}
@@ -331,11 +388,21 @@ func loadOutputsHcl(list *ast.ObjectList) ([]*Output, error) {
 	for _, item := range list.Items {
 		n := item.Keys[0].Token.Value().(string)
 
+		var listVal *ast.ObjectList
+		if ot, ok := item.Val.(*ast.ObjectType); ok {
+			listVal = ot.List
+		} else {
+			return nil, fmt.Errorf("output '%s': should be an object", n)
+		}
+
 		var config map[string]interface{}
 		if err := hcl.DecodeObject(&config, item.Val); err != nil {
 			return nil, err
 		}
 
+		// Delete special keys
+		delete(config, "depends_on")
+
 		rawConfig, err := NewRawConfig(config)
 		if err != nil {
 			return nil, fmt.Errorf(
@@ -344,8 +411,21 @@ func loadOutputsHcl(list *ast.ObjectList) ([]*Output, error) {
 				err)
 		}
 
+		// If we have depends fields, then add those in
+		var dependsOn []string
+		if o := listVal.Filter("depends_on"); len(o.Items) > 0 {
+			err := hcl.DecodeObject(&dependsOn, o.Items[0].Val)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Error reading depends_on for output %s: %s",
+					n,
+					err)
+			}
+		}
+
 		result = append(result, &Output{
 			Name:      n,
+			DependsOn: dependsOn,
 			RawConfig: rawConfig,
 		})
 	}
@@ -675,7 +755,10 @@ func loadManagedResourcesHcl(list *ast.ObjectList) ([]*Resource, error) {
 		var lifecycle ResourceLifecycle
 		if o := listVal.Filter("lifecycle"); len(o.Items) > 0 {
 			// Check for invalid keys
-			valid := []string{"create_before_destroy", "ignore_changes", "prevent_destroy"}
+			valid := []string{
+				"create_before_destroy", "destroy_before",
+				"ignore_changes", "prevent_destroy",
+			}
 			if err := checkHCLKeys(o.Items[0].Val, valid); err != nil {
 				return nil, multierror.Prefix(err, fmt.Sprintf(
 					"%s[%s]:", t, k))
@@ -715,6 +798,46 @@ func loadManagedResourcesHcl(list *ast.ObjectList) ([]*Resource, error) {
 	return result, nil
 }
 
+// Given a handle to a HCL object, this returns the validation blocks of
+// every variable, keyed by the name of the variable.
+func loadVariableValidationsHcl(list *ast.ObjectList) (map[string][]*VariableValidation, error) {
+	type hclVariableValidation struct {
+		Condition    string
+		ErrorMessage string `hcl:"error_message"`
+	}
+
+	result := make(map[string][]*VariableValidation)
+	for _, item := range list.Children().Items {
+		n := item.Keys[0].Token.Value().(string)
+
+		ot, ok := item.Val.(*ast.ObjectType)
+		if !ok {
+			continue
+		}
+
+		for _, v := range ot.List.Filter("validation").Items {
+			valid := []string{"condition", "error_message"}
+			if err := checkHCLKeys(v.Val, valid); err != nil {
+				return nil, multierror.Prefix(err, fmt.Sprintf(
+					"variable %s validation:", n))
+			}
+
+			var validation hclVariableValidation
+			if err := hcl.DecodeObject(&validation, v.Val); err != nil {
+				return nil, fmt.Errorf(
+					"Error reading validation for variable %s: %s", n, err)
+			}
+
+			result[n] = append(result[n], &VariableValidation{
+				Condition:    validation.Condition,
+				ErrorMessage: validation.ErrorMessage,
+			})
+		}
+	}
+
+	return result, nil
+}
+
 func loadProvisionersHcl(list *ast.ObjectList, connInfo map[string]interface{}) ([]*Provisioner, error) {
 	list = list.Children()
 	if len(list.Items) == 0 {
@@ -191,6 +191,47 @@ func TestLoadFileBasic(t *testing.T) {
 	}
 }
 
+func TestLoadFile_outputDependsOn(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "output-depends-on.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if c == nil {
+		t.Fatal("config should not be nil")
+	}
+
+	actual := outputsStr(c.Outputs)
+	if actual != strings.TrimSpace(outputDependsOnStr) {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
+func TestLoadFile_variableValidation(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "variable-validation.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(c.Variables) != 1 {
+		t.Fatalf("bad: %#v", c.Variables)
+	}
+
+	expected := []*VariableValidation{
+		&VariableValidation{
+			Condition:    "${cidrhost(var.cidr, 0)}",
+			ErrorMessage: "The cidr value must be a CIDR block.",
+		},
+		&VariableValidation{
+			Condition:    `${replace(var.cidr, "/^.*[^0-9./].*$/", "false")}`,
+			ErrorMessage: "The cidr value must only contain digits, dots and a slash.",
+		},
+	}
+	if actual := c.Variables[0].Validations; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
 func TestLoadFileBasic_empty(t *testing.T) {
 	c, err := LoadFile(filepath.Join(fixtureDir, "empty.tf"))
 	if err != nil {
@@ -618,6 +659,50 @@ func TestLoadFile_ignoreChanges(t *testing.T) {
 	}
 }
 
+func TestLoadFile_terraformBlock(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "terraform-block.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := &Terraform{RequiredVersion: ">= 0.7.0"}
+	if !reflect.DeepEqual(c.Terraform, expected) {
+		t.Fatalf("bad: %#v", c.Terraform)
+	}
+}
+
+func TestLoadFile_terraformBlockBadKey(t *testing.T) {
+	_, err := LoadFile(filepath.Join(fixtureDir, "terraform-block-bad-key.tf"))
+	if err == nil {
+		t.Fatal("should have error")
+	}
+}
+
+func TestLoadFile_destroyBefore(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "destroy-before.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if c == nil {
+		t.Fatal("config should not be nil")
+	}
+
+	r := c.Resources[1]
+	if r.Name != "eni" || r.Type != "aws_network_interface" {
+		t.Fatalf("Bad: %#v", r)
+	}
+
+	expected := []string{"aws_security_group.sg"}
+	if !reflect.DeepEqual(r.Lifecycle.DestroyBefore, expected) {
+		t.Fatalf("Bad: %#v", r.Lifecycle.DestroyBefore)
+	}
+
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
 func TestLoad_preventDestroyString(t *testing.T) {
 	c, err := LoadFile(filepath.Join(fixtureDir, "prevent-destroy-string.tf"))
 	if err != nil {
@@ -1017,3 +1102,9 @@ aws_instance.baz (x1)
 aws_instance.web (x1)
   ami
 `
+
+const outputDependsOnStr = `
+value
+  dependsOn
+    aws_instance.web
+`
@@ -25,6 +25,13 @@ func Merge(c1, c2 *Config) (*Config, error) {
 		}
 	}
 
+	// Merge Terraform configuration. This is a dumb one overrides the
+	// other sort of merge.
+	c.Terraform = c1.Terraform
+	if c2.Terraform != nil {
+		c.Terraform = c2.Terraform
+	}
+
 	// Merge Atlas configuration. This is a dumb one overrides the other
 	// sort of merge.
 	c.Atlas = c1.Atlas
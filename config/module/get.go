@@ -56,6 +56,20 @@ func GetCopy(dst, src string) error {
 	return copyDir(dst, tmpDir)
 }
 
+// needsGet returns whether getStorage will download the module with the
+// given key, so the source has to be known.
+func needsGet(s getter.Storage, key string, mode GetMode) bool {
+	switch mode {
+	case GetModeNone:
+		return false
+	case GetModeGet:
+		_, ok, err := s.Dir(key)
+		return err != nil || !ok
+	default:
+		return true
+	}
+}
+
 func getStorage(s getter.Storage, key string, src string, mode GetMode) (string, bool, error) {
 	// Get the module with the level specified if we were told to.
 	if mode > GetModeNone {
@@ -0,0 +1,211 @@
+package module
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-version"
+)
+
+// defaultRegistryHost is the registry that module sources without a
+// hostname are resolved against.
+const defaultRegistryHost = "registry.terraform.io"
+
+// registrySourceRegexp matches module sources of the form
+// "[HOST/]NAMESPACE/NAME/PROVIDER". A host must contain a dot or a port
+// so it can't be confused with a namespace.
+var registrySourceRegexp = regexp.MustCompile(
+	`^(?:((?:[0-9A-Za-z-]+\.)+[0-9A-Za-z-]+(?::\d+)?|[0-9A-Za-z-]+:\d+)/)?` +
+		`([0-9A-Za-z_-]+)/([0-9A-Za-z_-]+)/([0-9A-Za-z]+)$`)
+
+// registrySource is a module source that is resolved against a module
+// registry.
+type registrySource struct {
+	Host      string
+	Namespace string
+	Name      string
+	Provider  string
+}
+
+// parseRegistrySource parses src as a registry module source. Relative
+// paths that exist within pwd are never treated as registry sources, so
+// a local "foo/bar/baz" directory keeps working.
+func parseRegistrySource(src, pwd string) (*registrySource, bool) {
+	m := registrySourceRegexp.FindStringSubmatch(src)
+	if m == nil {
+		return nil, false
+	}
+
+	if pwd != "" {
+		if _, err := os.Stat(filepath.Join(pwd, src)); err == nil {
+			return nil, false
+		}
+	}
+
+	host := m[1]
+	if host == "" {
+		host = defaultRegistryHost
+	}
+
+	return &registrySource{
+		Host:      host,
+		Namespace: m[2],
+		Name:      m[3],
+		Provider:  m[4],
+	}, true
+}
+
+func (s *registrySource) String() string {
+	return fmt.Sprintf("%s/%s/%s/%s", s.Host, s.Namespace, s.Name, s.Provider)
+}
+
+// registryClient talks to the module registry API to find the versions
+// of a module and where to download them from.
+type registryClient struct {
+	Client *http.Client
+
+	// Scheme is the URL scheme used to talk to registries. This is
+	// always "https" except in tests.
+	Scheme string
+}
+
+// registry is the client used to resolve registry sources while loading
+// a tree.
+var registry = &registryClient{
+	Client: cleanhttp.DefaultClient(),
+	Scheme: "https",
+}
+
+// registryVersions is the response of the versions endpoint.
+type registryVersions struct {
+	Modules []struct {
+		Versions []struct {
+			Version string `json:"version"`
+		} `json:"versions"`
+	} `json:"modules"`
+}
+
+func (c *registryClient) url(s *registrySource, parts ...string) string {
+	return fmt.Sprintf("%s://%s/v1/modules/%s/%s/%s/%s",
+		c.Scheme, s.Host, s.Namespace, s.Name, s.Provider,
+		strings.Join(parts, "/"))
+}
+
+// Version returns the newest version of the module matching the version
+// constraint. Pre-release versions are only selected if the constraint
+// is given.
+func (c *registryClient) Version(s *registrySource, constraint string) (*version.Version, error) {
+	var cs version.Constraints
+	if constraint != "" {
+		var err error
+		cs, err = version.NewConstraint(constraint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint: %s", err)
+		}
+	}
+
+	resp, err := c.Client.Get(c.url(s, "versions"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing versions of %s: %s", s, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("module %s not found in the registry", s)
+	default:
+		return nil, fmt.Errorf(
+			"error listing versions of %s: %s", s, resp.Status)
+	}
+
+	var body registryVersions
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf(
+			"error decoding versions of %s: %s", s, err)
+	}
+
+	var result *version.Version
+	for _, m := range body.Modules {
+		for _, raw := range m.Versions {
+			v, err := version.NewVersion(raw.Version)
+			if err != nil {
+				// Skip versions we don't understand rather than fail
+				// the whole module.
+				continue
+			}
+
+			if cs == nil && v.Prerelease() != "" {
+				continue
+			}
+			if cs != nil && !cs.Check(v) {
+				continue
+			}
+
+			if result == nil || v.GreaterThan(result) {
+				result = v
+			}
+		}
+	}
+
+	if result == nil {
+		if constraint == "" {
+			return nil, fmt.Errorf("module %s has no versions", s)
+		}
+
+		return nil, fmt.Errorf(
+			"no version of %s matches the constraint %q", s, constraint)
+	}
+
+	return result, nil
+}
+
+// Location returns the source to download the given version of the
+// module from, as given by the X-Terraform-Get header of the registry.
+func (c *registryClient) Location(s *registrySource, v *version.Version) (string, error) {
+	u := c.url(s, v.String(), "download")
+	resp, err := c.Client.Get(u)
+	if err != nil {
+		return "", fmt.Errorf("error getting location of %s %s: %s", s, v, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+	default:
+		return "", fmt.Errorf(
+			"error getting location of %s %s: %s", s, v, resp.Status)
+	}
+
+	location := resp.Header.Get("X-Terraform-Get")
+	if location == "" {
+		return "", fmt.Errorf(
+			"registry returned no location for %s %s", s, v)
+	}
+
+	// The location may be relative to the download URL
+	if strings.HasPrefix(location, "/") ||
+		strings.HasPrefix(location, "./") ||
+		strings.HasPrefix(location, "../") {
+		base, err := url.Parse(u)
+		if err != nil {
+			return "", err
+		}
+		rel, err := url.Parse(location)
+		if err != nil {
+			return "", fmt.Errorf(
+				"invalid location for %s %s: %s", s, v, err)
+		}
+
+		location = base.ResolveReference(rel).String()
+	}
+
+	return location, nil
+}
@@ -0,0 +1,199 @@
+package module
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+)
+
+// testRegistry starts a registry serving hashicorp/foo/aws in versions
+// 1.0.0, 1.2.0, 2.0.0 and 2.1.0-beta. Every version downloads from
+// location.
+func testRegistry(t *testing.T, location string) (*httptest.Server, func()) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/modules/hashicorp/foo/aws/versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"modules":[{"versions":[`+
+			`{"version":"1.0.0"},{"version":"1.2.0"},`+
+			`{"version":"2.0.0"},{"version":"2.1.0-beta"}]}]}`)
+	})
+	mux.HandleFunc("/v1/modules/hashicorp/foo/aws/", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/download") {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("X-Terraform-Get", location)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ts := httptest.NewServer(mux)
+
+	old := registry
+	registry = &registryClient{Client: http.DefaultClient, Scheme: "http"}
+	return ts, func() {
+		registry = old
+		ts.Close()
+	}
+}
+
+func TestParseRegistrySource(t *testing.T) {
+	cases := []struct {
+		Input  string
+		Output *registrySource
+	}{
+		{
+			"hashicorp/consul/aws",
+			&registrySource{defaultRegistryHost, "hashicorp", "consul", "aws"},
+		},
+		{
+			"example.com/hashicorp/consul/aws",
+			&registrySource{"example.com", "hashicorp", "consul", "aws"},
+		},
+		{
+			"localhost:8080/hashicorp/consul/aws",
+			&registrySource{"localhost:8080", "hashicorp", "consul", "aws"},
+		},
+		{"./hashicorp/consul/aws", nil},
+		{"github.com/hashicorp/consul", nil},
+		{"git::https://example.com/consul.git", nil},
+		{"hashicorp/consul", nil},
+		{"foo/bar/baz/qux", nil},
+
+		// Exists as a directory in the fixtures
+		{"child/foo/bar", nil},
+	}
+
+	for _, tc := range cases {
+		actual, ok := parseRegistrySource(tc.Input, fixtureDir)
+		if ok != (tc.Output != nil) {
+			t.Fatalf("%s: bad: %#v", tc.Input, actual)
+		}
+		if !reflect.DeepEqual(actual, tc.Output) {
+			t.Fatalf("%s: bad: %#v", tc.Input, actual)
+		}
+	}
+}
+
+func TestRegistryClientVersion(t *testing.T) {
+	ts, closeFn := testRegistry(t, "")
+	defer closeFn()
+
+	src, _ := parseRegistrySource(
+		strings.TrimPrefix(ts.URL, "http://")+"/hashicorp/foo/aws", "")
+
+	cases := []struct {
+		Constraint string
+		Version    string
+		Err        bool
+	}{
+		{"", "2.0.0", false},
+		{"~> 1.0", "1.2.0", false},
+		{"< 1.2.0", "1.0.0", false},
+		{">= 2.1.0-beta", "2.1.0-beta", false},
+		{">= 3.0.0", "", true},
+	}
+
+	for _, tc := range cases {
+		v, err := registry.Version(src, tc.Constraint)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%q: err: %s", tc.Constraint, err)
+		}
+		if err != nil {
+			continue
+		}
+
+		if v.String() != tc.Version {
+			t.Fatalf("%q: bad: %s", tc.Constraint, v)
+		}
+	}
+}
+
+func TestRegistryClientLocation(t *testing.T) {
+	ts, closeFn := testRegistry(t, "./archive.tar.gz")
+	defer closeFn()
+
+	src, _ := parseRegistrySource(
+		strings.TrimPrefix(ts.URL, "http://")+"/hashicorp/foo/aws", "")
+	v, err := registry.Version(src, "")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual, err := registry.Location(src, v)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := ts.URL + "/v1/modules/hashicorp/foo/aws/2.0.0/archive.tar.gz"
+	if actual != expected {
+		t.Fatalf("bad: %s", actual)
+	}
+}
+
+func TestTreeLoad_registry(t *testing.T) {
+	location, err := filepath.Abs(filepath.Join(fixtureDir, "basic", "foo"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	ts, closeFn := testRegistry(t, "file://"+filepath.ToSlash(location))
+	defer closeFn()
+
+	storage := testStorage(t)
+	tree := NewTree("", &config.Config{
+		Dir: tempDir(t),
+		Modules: []*config.Module{
+			&config.Module{
+				Name:    "foo",
+				Source:  strings.TrimPrefix(ts.URL, "http://") + "/hashicorp/foo/aws",
+				Version: "~> 1.0",
+			},
+		},
+	})
+
+	if err := tree.Load(storage, GetModeGet); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := strings.TrimSpace(tree.String())
+	expected := strings.TrimSpace(treeLoadRegistryStr)
+	if actual != expected {
+		t.Fatalf("bad: \n\n%s", actual)
+	}
+
+	// Loading again doesn't need the registry
+	ts.Close()
+	if err := tree.Load(storage, GetModeNone); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestTreeLoad_versionNotRegistry(t *testing.T) {
+	storage := testStorage(t)
+	tree := NewTree("", &config.Config{
+		Dir: filepath.Join(fixtureDir, "basic"),
+		Modules: []*config.Module{
+			&config.Module{
+				Name:    "foo",
+				Source:  "./foo",
+				Version: "1.0.0",
+			},
+		},
+	})
+
+	err := tree.Load(storage, GetModeGet)
+	if err == nil || !strings.Contains(err.Error(), "registry") {
+		t.Fatalf("bad: %v", err)
+	}
+}
+
+const treeLoadRegistryStr = `
+root
+  foo (path: foo)
+`
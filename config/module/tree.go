@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"log"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -103,8 +104,9 @@ func (t *Tree) Modules() []*Module {
 	result := make([]*Module, len(t.config.Modules))
 	for i, m := range t.config.Modules {
 		result[i] = &Module{
-			Name:   m.Name,
-			Source: m.Source,
+			Name:    m.Name,
+			Source:  m.Source,
+			Version: m.Version,
 		}
 	}
 
@@ -157,6 +159,31 @@ func (t *Tree) Load(s getter.Storage, mode GetMode) error {
 		// Split out the subdir if we have one
 		source, subDir := getter.SourceDirSubdir(m.Source)
 
+		// Get the directory where this module is so we can load it
+		key := strings.Join(path, ".")
+		key = "root." + key
+
+		// Registry sources are resolved to the location of the selected
+		// version. That is only needed if we're going to download it.
+		rs, isRegistry := parseRegistrySource(source, t.config.Dir)
+		if m.Version != "" && !isRegistry {
+			return fmt.Errorf(
+				"module %s: version can only be set for registry sources", m.Name)
+		}
+		if isRegistry && needsGet(s, key, mode) {
+			v, err := registry.Version(rs, m.Version)
+			if err != nil {
+				return fmt.Errorf("module %s: %s", m.Name, err)
+			}
+
+			source, err = registry.Location(rs, v)
+			if err != nil {
+				return fmt.Errorf("module %s: %s", m.Name, err)
+			}
+
+			log.Printf("[DEBUG] module %s: using %s %s from %s", m.Name, rs, v, source)
+		}
+
 		source, err := getter.Detect(source, t.config.Dir, getter.Detectors)
 		if err != nil {
 			return fmt.Errorf("module %s: %s", m.Name, err)
@@ -168,9 +195,6 @@ func (t *Tree) Load(s getter.Storage, mode GetMode) error {
 			subDir = filepath.Join(subDir2, subDir)
 		}
 
-		// Get the directory where this module is so we can load it
-		key := strings.Join(path, ".")
-		key = "root." + key
 		dir, ok, err := getStorage(s, key, source, mode)
 		if err != nil {
 			return err
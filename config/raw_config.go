@@ -104,6 +104,18 @@ func (r *RawConfig) Config() map[string]interface{} {
 //
 // If a variable key is missing, this will panic.
 func (r *RawConfig) Interpolate(vs map[string]ast.Variable) error {
+	return r.interpolateVars(vs, false)
+}
+
+// InterpolateUnknownTime is like Interpolate, except that every value
+// calling the timestamp function is unknown, like values depending on a
+// computed variable. This is used while the time the configuration is
+// applied at isn't known yet, such as while planning.
+func (r *RawConfig) InterpolateUnknownTime(vs map[string]ast.Variable) error {
+	return r.interpolateVars(vs, true)
+}
+
+func (r *RawConfig) interpolateVars(vs map[string]ast.Variable, unknownTime bool) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
@@ -129,6 +141,9 @@ func (r *RawConfig) Interpolate(vs map[string]ast.Variable) error {
 				return UnknownVariableValue, nil
 			}
 		}
+		if unknownTime && callsFunc(root, "timestamp") {
+			return UnknownVariableValue, nil
+		}
 
 		// None of the variables we need are computed, meaning we should
 		// be able to properly evaluate.
@@ -141,6 +156,21 @@ func (r *RawConfig) Interpolate(vs map[string]ast.Variable) error {
 	})
 }
 
+// callsFunc returns true if the function with the given name is called
+// anywhere within root.
+func callsFunc(root ast.Node, name string) bool {
+	found := false
+	root.Accept(func(n ast.Node) ast.Node {
+		if c, ok := n.(*ast.Call); ok && c.Func == name {
+			found = true
+		}
+
+		return n
+	})
+
+	return found
+}
+
 // Merge merges another RawConfig into this one (overriding any conflicting
 // values in this config) and returns a new config. The original config
 // is not modified.
@@ -308,6 +338,7 @@ func langEvalConfig(vs map[string]ast.Variable) *hil.EvalConfig {
 	for k, v := range Funcs() {
 		funcMap[k] = v
 	}
+	funcMap["file"] = interpolationFuncFile(vs)
 	funcMap["lookup"] = interpolationFuncLookup(vs)
 	funcMap["keys"] = interpolationFuncKeys(vs)
 	funcMap["values"] = interpolationFuncValues(vs)
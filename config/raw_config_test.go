@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/gob"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/hil/ast"
@@ -270,6 +271,44 @@ func TestRawConfig_unknown(t *testing.T) {
 	}
 }
 
+func TestRawConfig_unknownTime(t *testing.T) {
+	raw := map[string]interface{}{
+		"foo": "expires-${timeadd(timestamp(), \"1h\")}",
+		"bar": "baz",
+	}
+
+	rc, err := NewRawConfig(raw)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := rc.InterpolateUnknownTime(nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := rc.Config()
+	expected := map[string]interface{}{"bar": "baz"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	expectedKeys := []string{"foo"}
+	if !reflect.DeepEqual(rc.UnknownKeys(), expectedKeys) {
+		t.Fatalf("bad: %#v", rc.UnknownKeys())
+	}
+
+	// Interpolating normally gives the time
+	if err := rc.Interpolate(nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v, ok := rc.Config()["foo"].(string); !ok || !strings.HasPrefix(v, "expires-") {
+		t.Fatalf("bad: %#v", rc.Config())
+	}
+	if len(rc.UnknownKeys()) != 0 {
+		t.Fatalf("bad: %#v", rc.UnknownKeys())
+	}
+}
+
 func TestRawConfig_unknownPartial(t *testing.T) {
 	raw := map[string]interface{}{
 		"foo": "${var.bar}/32",
@@ -0,0 +1,7 @@
+resource "aws_security_group" "sg" {}
+
+resource "aws_network_interface" "eni" {
+    lifecycle {
+        destroy_before = ["aws_security_group.sg"]
+    }
+}
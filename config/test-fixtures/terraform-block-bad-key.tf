@@ -0,0 +1,3 @@
+terraform {
+    required_versoin = ">= 0.7.0"
+}
@@ -0,0 +1,5 @@
+resource "aws_instance" "web" {
+    lifecycle {
+        destroy_before = ["aws_instance.db"]
+    }
+}
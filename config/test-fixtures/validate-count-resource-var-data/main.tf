@@ -0,0 +1,5 @@
+data "aws_ami" "foo" {}
+
+resource "aws_instance" "web" {
+    count = "${data.aws_ami.foo.bar}"
+}
@@ -0,0 +1,4 @@
+module "foo" {
+    source = "hashicorp/foo/aws"
+    version = "not a version"
+}
@@ -0,0 +1,6 @@
+resource "aws_instance" "web" {}
+
+output "value" {
+    value = "foo"
+    depends_on = ["aws_instance.web"]
+}
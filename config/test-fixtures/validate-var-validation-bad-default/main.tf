@@ -0,0 +1,8 @@
+variable "cidr" {
+    default = "10.0.0.0"
+
+    validation {
+        condition = "${cidrhost(var.cidr, 0)}"
+        error_message = "The cidr value must be a CIDR block."
+    }
+}
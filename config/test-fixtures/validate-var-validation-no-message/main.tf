@@ -0,0 +1,5 @@
+variable "cidr" {
+    validation {
+        condition = "${cidrhost(var.cidr, 0)}"
+    }
+}
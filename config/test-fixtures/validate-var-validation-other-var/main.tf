@@ -0,0 +1,8 @@
+variable "other" {}
+
+variable "cidr" {
+    validation {
+        condition = "${cidrhost(var.cidr, var.other)}"
+        error_message = "The cidr value must be a CIDR block."
+    }
+}
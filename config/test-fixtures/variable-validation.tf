@@ -0,0 +1,11 @@
+variable "cidr" {
+    validation {
+        condition = "${cidrhost(var.cidr, 0)}"
+        error_message = "The cidr value must be a CIDR block."
+    }
+
+    validation {
+        condition = "${replace(var.cidr, "/^.*[^0-9./].*$/", "false")}"
+        error_message = "The cidr value must only contain digits, dots and a slash."
+    }
+}
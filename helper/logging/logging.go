@@ -13,8 +13,10 @@ import (
 // These are the environmental variables that determine if we log, and if
 // we log whether or not the log should go to a file.
 const (
-	EnvLog     = "TF_LOG"      // Set to True
-	EnvLogFile = "TF_LOG_PATH" // Set to a file
+	EnvLog       = "TF_LOG"        // Set to True
+	EnvLogFile   = "TF_LOG_PATH"   // Set to a file
+	EnvLogTiming = "TF_LOG_TIMING" // Set to a file for the timing report
+	EnvAuditLog  = "TF_AUDIT_LOG"  // Set to a file for the audit log
 )
 
 var validLevels = []logutils.LogLevel{"TRACE", "DEBUG", "INFO", "WARN", "ERROR"}
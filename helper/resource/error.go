@@ -3,23 +3,14 @@ package resource
 import (
 	"fmt"
 	"strings"
-)
-
-type NotFoundError struct {
-	LastError    error
-	LastRequest  interface{}
-	LastResponse interface{}
-	Message      string
-	Retries      int
-}
 
-func (e *NotFoundError) Error() string {
-	if e.Message != "" {
-		return e.Message
-	}
+	"github.com/hashicorp/terraform/helper/schema"
+)
 
-	return "couldn't find resource"
-}
+// NotFoundError is returned when a resource can't be found, for example
+// by WaitForState. Read functions of helper/schema resources can return it
+// to have the resource removed from the state.
+type NotFoundError = schema.NotFoundError
 
 // UnexpectedStateError is returned when Refresh returns a state that's neither in Target nor Pending
 type UnexpectedStateError struct {
@@ -0,0 +1,24 @@
+package schema
+
+// NotFoundError is returned when a resource can't be found. Read functions
+// can return it to have the resource removed from the state instead of
+// failing the refresh.
+//
+// It is defined here rather than in helper/resource so that providers don't
+// pull the acceptance testing framework into their binaries; helper/resource
+// re-exports it as resource.NotFoundError.
+type NotFoundError struct {
+	LastError    error
+	LastRequest  interface{}
+	LastResponse interface{}
+	Message      string
+	Retries      int
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	return "couldn't find resource"
+}
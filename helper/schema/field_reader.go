@@ -3,6 +3,8 @@ package schema
 import (
 	"fmt"
 	"strconv"
+
+	"github.com/hashicorp/terraform/config"
 )
 
 // FieldReaders are responsible for decoding fields out of data into
@@ -96,7 +98,11 @@ func addrToSchema(addr []string, schemaMap map[string]*Schema) []*Schema {
 			}
 		case TypeMap:
 			if len(addr) > 0 {
-				current = &Schema{Type: TypeString}
+				if elem, ok := current.Elem.(*Schema); ok {
+					current = elem
+				} else {
+					current = &Schema{Type: TypeString}
+				}
 			}
 		case typeObject:
 			// If we're already in the object, then we want to handle Sets
@@ -214,6 +220,35 @@ func readObjectField(
 	}, nil
 }
 
+// mapValuesToPrimitive converts the string values of a map read for a
+// TypeMap schema to the type of the schema's Elem, if it has one. Values
+// that already have a type, such as those read from the configuration,
+// are left alone.
+func mapValuesToPrimitive(m map[string]interface{}, schema *Schema) error {
+	elem, ok := schema.Elem.(*Schema)
+	if !ok {
+		return nil
+	}
+
+	for k, v := range m {
+		// Values of interpolated maps that aren't known yet are left as
+		// they are until they're known during apply.
+		s, ok := v.(string)
+		if !ok || s == config.UnknownVariableValue {
+			continue
+		}
+
+		pv, err := stringToPrimitive(s, false, elem)
+		if err != nil {
+			return err
+		}
+
+		m[k] = pv
+	}
+
+	return nil
+}
+
 func stringToPrimitive(
 	value string, computed bool, schema *Schema) (interface{}, error) {
 	var returnVal interface{}
@@ -85,7 +85,7 @@ func (r *ConfigFieldReader) readField(
 	case TypeList:
 		return readListField(&nestedConfigFieldReader{r}, address, schema)
 	case TypeMap:
-		return r.readMap(k)
+		return r.readMap(k, schema)
 	case TypeSet:
 		return r.readSet(address, schema)
 	case typeObject:
@@ -97,7 +97,7 @@ func (r *ConfigFieldReader) readField(
 	}
 }
 
-func (r *ConfigFieldReader) readMap(k string) (FieldReadResult, error) {
+func (r *ConfigFieldReader) readMap(k string, schema *Schema) (FieldReadResult, error) {
 	// We want both the raw value and the interpolated. We use the interpolated
 	// to store actual values and we use the raw one to check for
 	// computed keys. Actual values are obtained in the switch, depending on
@@ -172,6 +172,10 @@ func (r *ConfigFieldReader) readMap(k string) (FieldReadResult, error) {
 
 	var value interface{}
 	if !computed {
+		if err := mapValuesToPrimitive(result, schema); err != nil {
+			return FieldReadResult{}, err
+		}
+
 		value = result
 	}
 
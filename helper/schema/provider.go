@@ -3,6 +3,7 @@ package schema
 import (
 	"errors"
 	"fmt"
+	"log"
 	"sort"
 
 	"github.com/hashicorp/terraform/terraform"
@@ -198,7 +199,13 @@ func (p *Provider) Refresh(
 		return nil, fmt.Errorf("unknown resource type: %s", info.Type)
 	}
 
-	return r.Refresh(s, p.meta)
+	state, err := r.Refresh(s, p.meta)
+	if err == nil && state == nil && s != nil && s.ID != "" {
+		log.Printf("[WARN] %s (ID: %s): not found, removing from state",
+			info.HumanId(), s.ID)
+	}
+
+	return state, err
 }
 
 // Resources implementation of terraform.ResourceProvider interface.
@@ -72,11 +72,16 @@ type Resource struct {
 	// returned. If a resource was partially updated, be careful to enable
 	// partial state mode for ResourceData and use it accordingly.
 	//
+	// Read can signal that the resource no longer exists by returning a
+	// *NotFoundError (or by setting the ID to ""). The resource
+	// is then removed from the state instead of failing the refresh.
+	//
 	// Exists is a function that is called to check if a resource still
 	// exists. If this returns false, then this will affect the diff
-	// accordingly. If this function isn't set, it will not be called. It
-	// is highly recommended to set it. The *ResourceData passed to Exists
-	// should _not_ be modified.
+	// accordingly. If this function isn't set, it will not be called. The
+	// *ResourceData passed to Exists should _not_ be modified. Exists is
+	// deprecated: new resources should return a *NotFoundError
+	// from Read instead, which saves an extra API call per refresh.
 	Create CreateFunc
 	Read   ReadFunc
 	Update UpdateFunc
@@ -257,6 +262,10 @@ func (r *Resource) Refresh(
 	}
 
 	err = r.Read(data, meta)
+	if _, ok := err.(*NotFoundError); ok {
+		return nil, nil
+	}
+
 	state := data.State()
 	if state != nil && state.ID == "" {
 		state = nil
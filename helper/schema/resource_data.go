@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 	"sync"
@@ -229,6 +230,14 @@ func (d *ResourceData) SetType(t string) {
 	d.newState.Ephemeral.Type = t
 }
 
+// Warn adds a warning that is shown to the user once the current
+// operation completes. Warnings are only shown for creates and updates.
+func (d *ResourceData) Warn(format string, a ...interface{}) {
+	d.once.Do(d.init)
+	d.newState.Ephemeral.Warnings = append(
+		d.newState.Ephemeral.Warnings, fmt.Sprintf(format, a...))
+}
+
 // State returns the new InstanceState after the diff and any Set
 // calls.
 func (d *ResourceData) State() *terraform.InstanceState {
@@ -732,6 +732,63 @@ func TestResourceDataGet(t *testing.T) {
 
 			Value: []interface{}{80},
 		},
+
+		// #24 Maps with typed elements
+		{
+			Schema: map[string]*Schema{
+				"ports": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem:     &Schema{Type: TypeInt},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"ports.#":    "2",
+					"ports.http": "80",
+					"ports.ssh":  "22",
+				},
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"ports.ssh": &terraform.ResourceAttrDiff{
+						Old: "22",
+						New: "2222",
+					},
+				},
+			},
+
+			Key: "ports",
+
+			Value: map[string]interface{}{
+				"http": 80,
+				"ssh":  2222,
+			},
+		},
+
+		// #25 Element of a map with typed elements
+		{
+			Schema: map[string]*Schema{
+				"enabled": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem:     &Schema{Type: TypeBool},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"enabled.#":   "1",
+					"enabled.foo": "true",
+				},
+			},
+
+			Key: "enabled.foo",
+
+			Value: true,
+		},
 	}
 
 	for i, tc := range cases {
@@ -2896,6 +2953,25 @@ func TestResourceDataSetConnInfo(t *testing.T) {
 	}
 }
 
+func TestResourceDataWarn(t *testing.T) {
+	d := &ResourceData{
+		state: &terraform.InstanceState{
+			ID: "foo",
+			Ephemeral: terraform.EphemeralState{
+				Warnings: []string{"old"},
+			},
+		},
+	}
+	d.Warn("%d warnings", 1)
+
+	expected := []string{"1 warnings"}
+
+	actual := d.State()
+	if !reflect.DeepEqual(actual.Ephemeral.Warnings, expected) {
+		t.Fatalf("bad: %#v", actual.Ephemeral.Warnings)
+	}
+}
+
 func TestResourceDataSetId(t *testing.T) {
 	d := &ResourceData{}
 	d.SetId("foo")
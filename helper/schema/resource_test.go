@@ -598,6 +598,39 @@ func TestResourceRefresh_delete(t *testing.T) {
 	}
 }
 
+func TestResourceRefresh_notFound(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+	}
+
+	r.Read = func(d *ResourceData, m interface{}) error {
+		return &NotFoundError{
+			LastError: fmt.Errorf("404"),
+		}
+	}
+
+	s := &terraform.InstanceState{
+		ID: "bar",
+		Attributes: map[string]string{
+			"foo": "12",
+		},
+	}
+
+	actual, err := r.Refresh(s, 42)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if actual != nil {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
 func TestResourceRefresh_existsError(t *testing.T) {
 	r := &Resource{
 		Schema: map[string]*Schema{
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/mapstructure"
 	"log"
@@ -102,24 +103,39 @@ type Schema struct {
 	// the element type is just a simple value. If it is *Resource, the
 	// element type is a complex structure, potentially with its own lifecycle.
 	//
+	// Elem can also be set to a *Schema with a primitive Type for a TypeMap,
+	// in which case every value of the map is validated and read as that
+	// type. The ValidateFunc of the Elem is called for every value, with
+	// the key of the value. Without an Elem, map values are strings.
+	//
 	// MaxItems defines a maximum amount of items that can exist within a
 	// TypeSet or TypeList. Specific use cases would be if a TypeSet is being
 	// used to wrap a complex structure, however more than one instance would
 	// cause instability.
+	//
+	// MinItems defines a minimum amount of items that must exist within a
+	// TypeSet or TypeList when it is set. Combined with Required, this can
+	// be used to require a nested block, since an empty list otherwise
+	// satisfies Required.
 	Elem     interface{}
 	MaxItems int
+	MinItems int
 
 	// The following fields are only valid for a TypeSet type.
 	//
 	// Set defines a function to determine the unique ID of an item so that
-	// a proper set can be built.
+	// a proper set can be built. If it isn't set, HashSchema or HashResource
+	// is used, depending on Elem. HashResource includes every Optional
+	// attribute of the element, so adding one later changes the hash of all
+	// existing members; use HashResourceOmitZero to avoid that.
 	Set SchemaSetFunc
 
-	// ComputedWhen is a set of queries on the configuration. Whenever any
-	// of these things is changed, it will require a recompute (this requires
-	// that Computed is set to true).
-	//
-	// NOTE: This currently does not work.
+	// ComputedWhen is a set of keys of sibling attributes. Whenever any of
+	// these changes in the diff, this value is marked as computed so it is
+	// read again after the apply (this requires that Computed is set to
+	// true). Nested keys such as "rule.0.port" can be used too. This only
+	// has an effect on top-level attributes of a resource, and not if the
+	// value itself is set in the configuration or already changes.
 	ComputedWhen []string
 
 	// ConflictsWith is a set of schema keys that conflict with this schema
@@ -408,8 +424,31 @@ func (m schemaMap) Diff(
 	}
 
 	// Go through and detect all of the ComputedWhens now that we've
-	// finished the diff.
-	// TODO
+	// finished the diff. A ComputedWhen key can itself be computed by
+	// another ComputedWhen, so this repeats until nothing else changes,
+	// which makes the result independent of the order of the keys.
+	for changed := true; changed; {
+		changed = false
+		for k, schema := range m {
+			if len(schema.ComputedWhen) == 0 {
+				continue
+			}
+
+			// Values that are diffed already or set in the configuration
+			// aren't recomputed.
+			if diffHasKey(result, k) {
+				continue
+			}
+			if _, ok := c.Raw[k]; ok {
+				continue
+			}
+
+			if diffChanged(result, schema.ComputedWhen) {
+				m.diffComputedWhen(k, schema, result, s)
+				changed = true
+			}
+		}
+	}
 
 	if result.Empty() {
 		// If we don't have any diff elements, just return nil
@@ -419,6 +458,65 @@ func (m schemaMap) Diff(
 	return result, nil
 }
 
+// diffComputedWhen marks the value of k as computed in the diff, since
+// one of its ComputedWhen keys changed. Lists, maps and sets are marked
+// computed as a whole.
+func (m schemaMap) diffComputedWhen(
+	k string,
+	schema *Schema,
+	diff *terraform.InstanceDiff,
+	s *terraform.InstanceState) {
+	switch schema.Type {
+	case TypeList, TypeMap, TypeSet:
+		k = k + ".#"
+	}
+
+	var old string
+	if s != nil {
+		old = s.Attributes[k]
+	}
+
+	diff.Attributes[k] = &terraform.ResourceAttrDiff{
+		Old:         old,
+		NewComputed: true,
+		RequiresNew: schema.ForceNew,
+		Sensitive:   schema.Sensitive,
+	}
+}
+
+// diffHasKey returns whether the diff has an attribute for k, or for
+// anything nested within it.
+func diffHasKey(diff *terraform.InstanceDiff, k string) bool {
+	for dk := range diff.Attributes {
+		if dk == k || strings.HasPrefix(dk, k+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diffChanged returns whether the diff changes any of the given keys, or
+// anything nested within them.
+func diffChanged(diff *terraform.InstanceDiff, keys []string) bool {
+	for dk, attr := range diff.Attributes {
+		if attr == nil {
+			continue
+		}
+		if attr.Old == attr.New && !attr.NewComputed && !attr.NewRemoved {
+			continue
+		}
+
+		for _, k := range keys {
+			if dk == k || strings.HasPrefix(dk, k+".") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // Input implements the terraform.ResourceProvider method by asking
 // for input for required configuration keys that don't have a value.
 func (m schemaMap) Input(
@@ -586,6 +684,26 @@ func (m schemaMap) InternalValidate(topSchemaMap schemaMap) error {
 			if v.MaxItems > 0 {
 				return fmt.Errorf("%s: MaxItems is only supported on lists or sets", k)
 			}
+
+			if v.MinItems > 0 {
+				return fmt.Errorf("%s: MinItems is only supported on lists or sets", k)
+			}
+		}
+
+		// Other values of Elem have always been ignored for maps, so only
+		// typed elements are checked.
+		if t, ok := v.Elem.(*Schema); ok && v.Type == TypeMap {
+			switch t.Type {
+			case TypeBool, TypeInt, TypeFloat, TypeString:
+			default:
+				return fmt.Errorf(
+					"%s: Elem must be a primitive type for maps", k)
+			}
+
+			if t.Computed || t.Optional || t.Required {
+				return fmt.Errorf(
+					"%s: Elem must have only Type and ValidateFunc set", k)
+			}
 		}
 
 		if v.ValidateFunc != nil {
@@ -749,12 +867,13 @@ func (m schemaMap) diffMap(
 	prefix := k + "."
 
 	// First get all the values from the state
-	var stateMap, configMap map[string]string
 	o, n, _, nComputed := d.diffChange(k)
-	if err := mapstructure.WeakDecode(o, &stateMap); err != nil {
+	stateMap, err := mapToStrings(o, schema)
+	if err != nil {
 		return fmt.Errorf("%s: %s", k, err)
 	}
-	if err := mapstructure.WeakDecode(n, &configMap); err != nil {
+	configMap, err := mapToStrings(n, schema)
+	if err != nil {
 		return fmt.Errorf("%s: %s", k, err)
 	}
 
@@ -834,6 +953,36 @@ func (m schemaMap) diffMap(
 	return nil
 }
 
+// mapToStrings decodes a map read from ResourceData into strings. For
+// maps with a typed Elem, the values are formatted the way the field
+// writers store them, so they compare equal to what is in the state.
+func mapToStrings(raw interface{}, schema *Schema) (map[string]string, error) {
+	m, ok := raw.(map[string]interface{})
+	if _, typed := schema.Elem.(*Schema); !ok || !typed {
+		var result map[string]string
+		err := mapstructure.WeakDecode(raw, &result)
+		return result, err
+	}
+
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		switch tv := v.(type) {
+		case bool:
+			result[k] = strconv.FormatBool(tv)
+		case float64:
+			result[k] = strconv.FormatFloat(tv, 'G', -1, 64)
+		default:
+			var s string
+			if err := mapstructure.WeakDecode(v, &s); err != nil {
+				return nil, err
+			}
+			result[k] = s
+		}
+	}
+
+	return result, nil
+}
+
 func (m schemaMap) diffSet(
 	k string,
 	schema *Schema,
@@ -1100,6 +1249,11 @@ func (m schemaMap) validateList(
 			"%s: attribute supports %d item maximum, config has %d declared", k, schema.MaxItems, rawV.Len())}
 	}
 
+	if schema.MinItems > 0 && rawV.Len() < schema.MinItems {
+		return nil, []error{fmt.Errorf(
+			"%s: attribute supports %d item as a minimum, config has %d declared", k, schema.MinItems, rawV.Len())}
+	}
+
 	// Now build the []interface{}
 	raws := make([]interface{}, rawV.Len())
 	for i, _ := range raws {
@@ -1153,16 +1307,12 @@ func (m schemaMap) validateMap(
 		// Otherwise it's likely raw is an interpolation.
 		return nil, nil
 	case reflect.Map:
+		return m.validateInterpolatedMap(k, rawV, schema)
 	case reflect.Slice:
 	default:
 		return nil, []error{fmt.Errorf("%s: should be a map", k)}
 	}
 
-	// If it is not a slice, it is valid
-	if rawV.Kind() != reflect.Slice {
-		return nil, nil
-	}
-
 	// It is a slice, verify that all the elements are maps
 	raws := make([]interface{}, rawV.Len())
 	for i, _ := range raws {
@@ -1177,6 +1327,31 @@ func (m schemaMap) validateMap(
 		}
 	}
 
+	if elem, ok := schema.Elem.(*Schema); ok {
+		var ws []string
+		var es []error
+		for i, raw := range raws {
+			for subK, v := range raw.(map[string]interface{}) {
+				// Values that aren't known yet are validated during apply
+				if c.IsComputed(fmt.Sprintf("%s.%d.%s", k, i, subK)) {
+					continue
+				}
+
+				ws2, es2 := m.validatePrimitive(
+					fmt.Sprintf("%s.%s", k, subK), v, elem, c)
+				ws = append(ws, ws2...)
+				es = append(es, es2...)
+			}
+		}
+
+		if len(es) > 0 {
+			return ws, es
+		}
+		if schema.ValidateFunc == nil {
+			return ws, nil
+		}
+	}
+
 	if schema.ValidateFunc != nil {
 		validatableMap := make(map[string]interface{})
 		for _, raw := range raws {
@@ -1191,6 +1366,36 @@ func (m schemaMap) validateMap(
 	return nil, nil
 }
 
+// validateInterpolatedMap validates the typed elements of a map that was
+// read from an interpolated variable. The values of such a map can't be
+// looked up in the configuration, so values that aren't known yet are
+// recognized by their placeholder instead.
+func (m schemaMap) validateInterpolatedMap(
+	k string,
+	rawV reflect.Value,
+	schema *Schema) ([]string, []error) {
+	elem, ok := schema.Elem.(*Schema)
+	if !ok {
+		return nil, nil
+	}
+
+	var ws []string
+	var es []error
+	for _, subK := range rawV.MapKeys() {
+		v := rawV.MapIndex(subK).Interface()
+		if v == config.UnknownVariableValue {
+			continue
+		}
+
+		ws2, es2 := m.validatePrimitive(
+			fmt.Sprintf("%s.%s", k, subK.Interface()), v, elem, nil)
+		ws = append(ws, ws2...)
+		es = append(es, es2...)
+	}
+
+	return ws, es
+}
+
 func (m schemaMap) validateObject(
 	k string,
 	schema map[string]*Schema,
@@ -1253,7 +1458,9 @@ func (m schemaMap) validatePrimitive(
 	default: // ok
 	}
 
-	if c.IsComputed(k) {
+	// c is nil for values that can't be computed, see
+	// validateInterpolatedMap.
+	if c != nil && c.IsComputed(k) {
 		// If the key is being computed, then it is not an error as
 		// long as it's not a slice or map.
 		return nil, nil
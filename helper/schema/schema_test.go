@@ -2400,6 +2400,231 @@ func TestSchemaMap_Diff(t *testing.T) {
 
 			Err: false,
 		},
+
+		"ComputedWhen marks the value computed when a key changes": {
+			Schema: map[string]*Schema{
+				"port": &Schema{
+					Type:     TypeInt,
+					Optional: true,
+				},
+				"url": &Schema{
+					Type:         TypeString,
+					Computed:     true,
+					ComputedWhen: []string{"port"},
+				},
+				"ports": &Schema{
+					Type:         TypeList,
+					Computed:     true,
+					ComputedWhen: []string{"port"},
+					Elem:         &Schema{Type: TypeInt},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "foo",
+				Attributes: map[string]string{
+					"port":    "80",
+					"url":     "http://example.com:80",
+					"ports.#": "1",
+					"ports.0": "80",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"port": 8080,
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"port": &terraform.ResourceAttrDiff{
+						Old: "80",
+						New: "8080",
+					},
+					"url": &terraform.ResourceAttrDiff{
+						Old:         "http://example.com:80",
+						NewComputed: true,
+					},
+					"ports.#": &terraform.ResourceAttrDiff{
+						Old:         "1",
+						NewComputed: true,
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"ComputedWhen chained through other ComputedWhens": {
+			Schema: map[string]*Schema{
+				"port": &Schema{
+					Type:     TypeInt,
+					Optional: true,
+				},
+				"address": &Schema{
+					Type:         TypeString,
+					Computed:     true,
+					ComputedWhen: []string{"port"},
+				},
+				"url": &Schema{
+					Type:         TypeString,
+					Computed:     true,
+					ComputedWhen: []string{"address"},
+				},
+				"urls": &Schema{
+					Type:         TypeList,
+					Computed:     true,
+					ComputedWhen: []string{"url"},
+					Elem:         &Schema{Type: TypeString},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "foo",
+				Attributes: map[string]string{
+					"port":    "80",
+					"address": "example.com:80",
+					"url":     "http://example.com:80",
+					"urls.#":  "1",
+					"urls.0":  "http://example.com:80",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"port": 8080,
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"port": &terraform.ResourceAttrDiff{
+						Old: "80",
+						New: "8080",
+					},
+					"address": &terraform.ResourceAttrDiff{
+						Old:         "example.com:80",
+						NewComputed: true,
+					},
+					"url": &terraform.ResourceAttrDiff{
+						Old:         "http://example.com:80",
+						NewComputed: true,
+					},
+					"urls.#": &terraform.ResourceAttrDiff{
+						Old:         "1",
+						NewComputed: true,
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"ComputedWhen without a change": {
+			Schema: map[string]*Schema{
+				"port": &Schema{
+					Type:     TypeInt,
+					Optional: true,
+				},
+				"url": &Schema{
+					Type:         TypeString,
+					Computed:     true,
+					ComputedWhen: []string{"port"},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "foo",
+				Attributes: map[string]string{
+					"port": "80",
+					"url":  "http://example.com:80",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"port": 80,
+			},
+
+			Diff: nil,
+
+			Err: false,
+		},
+
+		"Maps with typed elements compare typed values": {
+			Schema: map[string]*Schema{
+				"enabled": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem:     &Schema{Type: TypeBool},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"enabled.#": "2",
+					"enabled.a": "true",
+					"enabled.b": "false",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"enabled": []map[string]interface{}{
+					map[string]interface{}{
+						"a": true,
+						"b": "1",
+					},
+				},
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"enabled.b": &terraform.ResourceAttrDiff{
+						Old: "false",
+						New: "true",
+					},
+				},
+			},
+
+			Err: false,
+		},
+		"Maps with typed elements from a variable with unknown values": {
+			Schema: map[string]*Schema{
+				"ports": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem:     &Schema{Type: TypeInt},
+				},
+			},
+
+			State: nil,
+
+			Config: map[string]interface{}{
+				"ports": "${var.ports}",
+			},
+
+			ConfigVariables: map[string]ast.Variable{
+				"var.ports": interfaceToVariableSwallowError(map[string]interface{}{
+					"http":  "80",
+					"https": config.UnknownVariableValue,
+				}),
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"ports.#": &terraform.ResourceAttrDiff{
+						Old: "0",
+						New: "2",
+					},
+					"ports.http": &terraform.ResourceAttrDiff{
+						Old: "",
+						New: "80",
+					},
+					"ports.https": &terraform.ResourceAttrDiff{
+						Old: "",
+						New: config.UnknownVariableValue,
+					},
+				},
+			},
+
+			Err: false,
+		},
 	}
 
 	for tn, tc := range cases {
@@ -2905,6 +3130,74 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 			},
 			true,
 		},
+
+		"MinItems on non-list": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeString,
+					Optional: true,
+					MinItems: 1,
+				},
+			},
+			true,
+		},
+
+		"Map with primitive Elem": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem:     &Schema{Type: TypeInt},
+				},
+			},
+			false,
+		},
+
+		"Map with legacy non-Schema Elem": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"foo": &Schema{
+								Type:     TypeInt,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		"Map with list Elem": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem: &Schema{
+						Type: TypeList,
+						Elem: &Schema{Type: TypeString},
+					},
+				},
+			},
+			true,
+		},
+
+		"Map with optional Elem": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem: &Schema{
+						Type:     TypeInt,
+						Optional: true,
+					},
+				},
+			},
+			true,
+		},
 	}
 
 	for tn, tc := range cases {
@@ -2921,12 +3214,13 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 
 func TestSchemaMap_Validate(t *testing.T) {
 	cases := map[string]struct {
-		Schema   map[string]*Schema
-		Config   map[string]interface{}
-		Vars     map[string]string
-		Err      bool
-		Errors   []error
-		Warnings []string
+		Schema          map[string]*Schema
+		Config          map[string]interface{}
+		Vars            map[string]string
+		ConfigVariables map[string]ast.Variable
+		Err             bool
+		Errors          []error
+		Warnings        []string
 	}{
 		"Good": {
 			Schema: map[string]*Schema{
@@ -3308,6 +3602,165 @@ func TestSchemaMap_Validate(t *testing.T) {
 			Err: true,
 		},
 
+		"Good map: typed elements": {
+			Schema: map[string]*Schema{
+				"ports": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem:     &Schema{Type: TypeInt},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"ports": []map[string]interface{}{
+					map[string]interface{}{
+						"http":  80,
+						"https": "443",
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"Bad map: typed element of the wrong type": {
+			Schema: map[string]*Schema{
+				"ports": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem:     &Schema{Type: TypeInt},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"ports": []map[string]interface{}{
+					map[string]interface{}{
+						"http": "eighty",
+					},
+				},
+			},
+
+			Err: true,
+		},
+
+		"Good map: typed element is computed": {
+			Schema: map[string]*Schema{
+				"ports": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem:     &Schema{Type: TypeInt},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"ports": []map[string]interface{}{
+					map[string]interface{}{
+						"http": "${var.foo}",
+					},
+				},
+			},
+
+			Vars: map[string]string{
+				"var.foo": config.UnknownVariableValue,
+			},
+
+			Err: false,
+		},
+
+		"Bad map: typed element of a map variable": {
+			Schema: map[string]*Schema{
+				"ports": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem:     &Schema{Type: TypeInt},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"ports": "${var.ports}",
+			},
+
+			ConfigVariables: map[string]ast.Variable{
+				"var.ports": interfaceToVariableSwallowError(map[string]interface{}{
+					"http": "abc",
+				}),
+			},
+
+			Err: true,
+		},
+
+		"Good map: typed element of a map variable is computed": {
+			Schema: map[string]*Schema{
+				"ports": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem:     &Schema{Type: TypeInt},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"ports": "${var.ports}",
+			},
+
+			ConfigVariables: map[string]ast.Variable{
+				"var.ports": interfaceToVariableSwallowError(map[string]interface{}{
+					"http":  "80",
+					"https": config.UnknownVariableValue,
+				}),
+			},
+
+			Err: false,
+		},
+
+		"Bad map: typed element of a map without a list": {
+			Schema: map[string]*Schema{
+				"ports": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem:     &Schema{Type: TypeInt},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"ports": map[string]interface{}{
+					"http": "abc",
+				},
+			},
+
+			Err: true,
+		},
+
+		"Bad map: element ValidateFunc": {
+			Schema: map[string]*Schema{
+				"ports": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+					Elem: &Schema{
+						Type: TypeInt,
+						ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+							if v.(int) > 65535 {
+								es = append(es, fmt.Errorf("%s: invalid port", k))
+							}
+							return
+						},
+					},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"ports": []map[string]interface{}{
+					map[string]interface{}{
+						"http": 80000,
+					},
+				},
+			},
+
+			Err: true,
+			Errors: []error{
+				fmt.Errorf("ports.http: invalid port"),
+			},
+		},
+
 		"Good set: config has slice with single interpolated value": {
 			Schema: map[string]*Schema{
 				"security_groups": &Schema{
@@ -3673,6 +4126,11 @@ func TestSchemaMap_Validate(t *testing.T) {
 				t.Fatalf("err: %s", err)
 			}
 		}
+		if tc.ConfigVariables != nil {
+			if err := c.Interpolate(tc.ConfigVariables); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+		}
 
 		ws, es := schemaMap(tc.Schema).Validate(terraform.NewResourceConfig(c))
 		if len(es) > 0 != tc.Err {
@@ -3789,3 +4247,120 @@ func TestSchemaSet_ValidateMaxItems(t *testing.T) {
 		}
 	}
 }
+
+func TestSchemaSet_ValidateMinItems(t *testing.T) {
+	cases := map[string]struct {
+		Schema          map[string]*Schema
+		State           *terraform.InstanceState
+		Config          map[string]interface{}
+		ConfigVariables map[string]string
+		Diff            *terraform.InstanceDiff
+		Err             bool
+		Errors          []error
+	}{
+		"#0": {
+			Schema: map[string]*Schema{
+				"aliases": &Schema{
+					Type:     TypeSet,
+					Optional: true,
+					MinItems: 2,
+					Elem:     &Schema{Type: TypeString},
+				},
+			},
+			State: nil,
+			Config: map[string]interface{}{
+				"aliases": []interface{}{"foo", "bar"},
+			},
+			Diff:   nil,
+			Err:    false,
+			Errors: nil,
+		},
+		"#1": {
+			Schema: map[string]*Schema{
+				"aliases": &Schema{
+					Type:     TypeSet,
+					Optional: true,
+					Elem:     &Schema{Type: TypeString},
+				},
+			},
+			State: nil,
+			Config: map[string]interface{}{
+				"aliases": []interface{}{"foo", "bar"},
+			},
+			Diff:   nil,
+			Err:    false,
+			Errors: nil,
+		},
+		"#2": {
+			Schema: map[string]*Schema{
+				"aliases": &Schema{
+					Type:     TypeSet,
+					Optional: true,
+					MinItems: 2,
+					Elem:     &Schema{Type: TypeString},
+				},
+			},
+			State: nil,
+			Config: map[string]interface{}{
+				"aliases": []interface{}{"foo"},
+			},
+			Diff: nil,
+			Err:  true,
+			Errors: []error{
+				fmt.Errorf("aliases: attribute supports 2 item as a minimum, config has 1 declared"),
+			},
+		},
+		"#3": {
+			Schema: map[string]*Schema{
+				"audio": &Schema{
+					Type:     TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"codec": &Schema{
+								Type:     TypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+			State: nil,
+			Config: map[string]interface{}{
+				"audio": []interface{}{},
+			},
+			Diff: nil,
+			Err:  true,
+			Errors: []error{
+				fmt.Errorf("audio: attribute supports 1 item as a minimum, config has 0 declared"),
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		c, err := config.NewRawConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("%q: err: %s", tn, err)
+		}
+		_, es := schemaMap(tc.Schema).Validate(terraform.NewResourceConfig(c))
+
+		if len(es) > 0 != tc.Err {
+			if len(es) == 0 {
+				t.Errorf("%q: no errors", tn)
+			}
+
+			for _, e := range es {
+				t.Errorf("%q: err: %s", tn, e)
+			}
+
+			t.FailNow()
+		}
+
+		if tc.Errors != nil {
+			if !reflect.DeepEqual(es, tc.Errors) {
+				t.Fatalf("%q: expected: %q\ngot: %q", tn, tc.Errors, es)
+			}
+		}
+	}
+}
@@ -7,6 +7,10 @@ import (
 )
 
 func SerializeValueForHash(buf *bytes.Buffer, val interface{}, schema *Schema) {
+	serializeValueForHash(buf, val, schema, false)
+}
+
+func serializeValueForHash(buf *bytes.Buffer, val interface{}, schema *Schema, omitZero bool) {
 	if val == nil {
 		buf.WriteRune(';')
 		return
@@ -29,7 +33,7 @@ func SerializeValueForHash(buf *bytes.Buffer, val interface{}, schema *Schema) {
 		buf.WriteRune('(')
 		l := val.([]interface{})
 		for _, innerVal := range l {
-			serializeCollectionMemberForHash(buf, innerVal, schema.Elem)
+			serializeCollectionMemberForHash(buf, innerVal, schema.Elem, omitZero)
 		}
 		buf.WriteRune(')')
 	case TypeMap:
@@ -44,14 +48,14 @@ func SerializeValueForHash(buf *bytes.Buffer, val interface{}, schema *Schema) {
 			innerVal := m[k]
 			buf.WriteString(k)
 			buf.WriteRune(':')
-			serializeCollectionMemberForHash(buf, innerVal, schema.Elem)
+			serializeCollectionMemberForHash(buf, innerVal, schema.Elem, omitZero)
 		}
 		buf.WriteRune(']')
 	case TypeSet:
 		buf.WriteRune('{')
 		s := val.(*Set)
 		for _, innerVal := range s.List() {
-			serializeCollectionMemberForHash(buf, innerVal, schema.Elem)
+			serializeCollectionMemberForHash(buf, innerVal, schema.Elem, omitZero)
 		}
 		buf.WriteRune('}')
 	default:
@@ -68,6 +72,18 @@ func SerializeValueForHash(buf *bytes.Buffer, val interface{}, schema *Schema) {
 // to hash complex substructures when used in sets, and so the serialization
 // is not reversible.
 func SerializeResourceForHash(buf *bytes.Buffer, val interface{}, resource *Resource) {
+	serializeResourceForHash(buf, val, resource, false)
+}
+
+// SerializeResourceForHashOmitZero is like SerializeResourceForHash, but
+// attributes that are unset or have the zero value of their type are left
+// out, including in nested resources. Adding an attribute to the resource
+// therefore doesn't change the serialization of existing values.
+func SerializeResourceForHashOmitZero(buf *bytes.Buffer, val interface{}, resource *Resource) {
+	serializeResourceForHash(buf, val, resource, true)
+}
+
+func serializeResourceForHash(buf *bytes.Buffer, val interface{}, resource *Resource, omitZero bool) {
 	sm := resource.Schema
 	m := val.(map[string]interface{})
 	var keys []string
@@ -84,20 +100,41 @@ func SerializeResourceForHash(buf *bytes.Buffer, val interface{}, resource *Reso
 			continue
 		}
 
+		innerVal := m[k]
+		if omitZero && isZeroForHash(innerVal, innerSchema) {
+			continue
+		}
+
 		buf.WriteString(k)
 		buf.WriteRune(':')
-		innerVal := m[k]
-		SerializeValueForHash(buf, innerVal, innerSchema)
+		serializeValueForHash(buf, innerVal, innerSchema, omitZero)
+	}
+}
+
+// isZeroForHash returns whether val is unset or the zero value of the
+// type of schema.
+func isZeroForHash(val interface{}, schema *Schema) bool {
+	switch v := val.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	case *Set:
+		return v.Len() == 0
+	default:
+		return val == schema.Type.Zero()
 	}
 }
 
-func serializeCollectionMemberForHash(buf *bytes.Buffer, val interface{}, elem interface{}) {
+func serializeCollectionMemberForHash(buf *bytes.Buffer, val interface{}, elem interface{}, omitZero bool) {
 	switch tElem := elem.(type) {
 	case *Schema:
-		SerializeValueForHash(buf, val, tElem)
+		serializeValueForHash(buf, val, tElem, omitZero)
 	case *Resource:
 		buf.WriteRune('<')
-		SerializeResourceForHash(buf, val, tElem)
+		serializeResourceForHash(buf, val, tElem, omitZero)
 		buf.WriteString(">;")
 	default:
 		panic("invalid element type")
@@ -212,3 +212,58 @@ func TestSerializeForHash(t *testing.T) {
 		}
 	}
 }
+
+func TestSerializeResourceForHashOmitZero(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"name": &Schema{
+				Type:     TypeString,
+				Required: true,
+			},
+			"size": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+			"tags": &Schema{
+				Type:     TypeMap,
+				Optional: true,
+			},
+			"rule": &Schema{
+				Type:     TypeList,
+				Optional: true,
+				Elem: &Resource{
+					Schema: map[string]*Schema{
+						"port": &Schema{
+							Type:     TypeInt,
+							Optional: true,
+						},
+						"cidr": &Schema{
+							Type:     TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	v := map[string]interface{}{
+		"name": "db",
+		"size": 0,
+		"tags": map[string]interface{}{},
+		"rule": []interface{}{
+			map[string]interface{}{
+				"port": 80,
+				"cidr": "",
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	SerializeResourceForHashOmitZero(&buf, v, r)
+
+	expected := "name:db;rule:(<port:80;>;);"
+	if got := buf.String(); got != expected {
+		t.Fatalf("got %#v, but want %#v", got, expected)
+	}
+}
@@ -28,6 +28,22 @@ func HashResource(resource *Resource) SchemaSetFunc {
 	}
 }
 
+// HashResourceOmitZero hashes complex structures that are described using
+// a *Resource, like HashResource, but ignores attributes that are unset or
+// have their zero value. Adding an Optional attribute to the resource later
+// then doesn't change the hash of existing set members, as long as the new
+// attribute isn't set.
+//
+// Switching an existing set from HashResource to this changes the hashes
+// stored in the state, so it's best used for new sets.
+func HashResourceOmitZero(resource *Resource) SchemaSetFunc {
+	return func(v interface{}) int {
+		var buf bytes.Buffer
+		SerializeResourceForHashOmitZero(&buf, v, resource)
+		return hashcode.String(buf.String())
+	}
+}
+
 // HashSchema hashes values that are described using a *Schema. This is the
 // default set implementation used when a set's element type is a single
 // schema.
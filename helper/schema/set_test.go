@@ -32,6 +32,56 @@ func TestSetAdd_negative(t *testing.T) {
 	}
 }
 
+func TestHashResourceOmitZero(t *testing.T) {
+	permission := map[string]*Schema{
+		"user": &Schema{
+			Type:     TypeString,
+			Required: true,
+		},
+		"level": &Schema{
+			Type:     TypeInt,
+			Optional: true,
+		},
+	}
+
+	v := map[string]interface{}{
+		"user":  "alice",
+		"level": 0,
+	}
+	before := HashResourceOmitZero(&Resource{Schema: permission})(v)
+
+	// Adding an attribute that isn't set keeps the hash
+	permission["groups"] = &Schema{
+		Type:     TypeList,
+		Optional: true,
+		Elem:     &Schema{Type: TypeString},
+	}
+	v["groups"] = []interface{}{}
+
+	if after := HashResourceOmitZero(&Resource{Schema: permission})(v); after != before {
+		t.Fatalf("hash changed: %d != %d", before, after)
+	}
+
+	// Setting it changes the hash
+	v["groups"] = []interface{}{"admin"}
+	if after := HashResourceOmitZero(&Resource{Schema: permission})(v); after == before {
+		t.Fatal("hash should change")
+	}
+
+	// HashResource includes unset attributes
+	delete(permission, "groups")
+	delete(v, "groups")
+	before = HashResource(&Resource{Schema: permission})(v)
+	permission["groups"] = &Schema{
+		Type:     TypeList,
+		Optional: true,
+		Elem:     &Schema{Type: TypeString},
+	}
+	if after := HashResource(&Resource{Schema: permission})(v); after == before {
+		t.Fatal("HashResource hash should change")
+	}
+}
+
 func TestSetContains(t *testing.T) {
 	s := &Set{F: testSetInt}
 	s.Add(5)
@@ -61,3 +61,25 @@ func (s *BackupState) backup() error {
 	s.done = true
 	return nil
 }
+
+// Lock locks the real state if it supports locking.
+//
+// Locker impl.
+func (s *BackupState) Lock(reason string) error {
+	if l, ok := s.Real.(Locker); ok {
+		return l.Lock(reason)
+	}
+
+	return nil
+}
+
+// Unlock unlocks the real state if it supports locking.
+//
+// Locker impl.
+func (s *BackupState) Unlock() error {
+	if l, ok := s.Real.(Locker); ok {
+		return l.Unlock()
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -27,3 +28,47 @@ func TestBackupState(t *testing.T) {
 		t.Fatalf("bad: %d", fi.Size())
 	}
 }
+
+func TestBackupState_lock(t *testing.T) {
+	real := new(lockingState)
+	s := &BackupState{Real: real}
+
+	if err := s.Lock("apply"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if real.reason != "apply" {
+		t.Fatalf("bad: %#v", real)
+	}
+
+	if err := s.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if real.reason != "" {
+		t.Fatalf("bad: %#v", real)
+	}
+}
+
+func TestBackupState_impl(t *testing.T) {
+	var _ Locker = new(BackupState)
+}
+
+// lockingState is an InmemState that can be locked
+type lockingState struct {
+	InmemState
+
+	reason string
+}
+
+func (s *lockingState) Lock(reason string) error {
+	if s.reason != "" {
+		return fmt.Errorf("already locked for %s", s.reason)
+	}
+
+	s.reason = reason
+	return nil
+}
+
+func (s *lockingState) Unlock() error {
+	s.reason = ""
+	return nil
+}
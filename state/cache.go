@@ -232,3 +232,26 @@ func (sc CacheRefreshResult) SuccessfulPull() bool {
 		return false
 	}
 }
+
+// Lock locks the durable state if it supports locking. The cache is local,
+// so it doesn't need a lock.
+//
+// Locker impl.
+func (s *CacheState) Lock(reason string) error {
+	if l, ok := s.Durable.(Locker); ok {
+		return l.Lock(reason)
+	}
+
+	return nil
+}
+
+// Unlock unlocks the durable state if it supports locking.
+//
+// Locker impl.
+func (s *CacheState) Unlock() error {
+	if l, ok := s.Durable.(Locker); ok {
+		return l.Unlock()
+	}
+
+	return nil
+}
@@ -50,9 +50,40 @@ func TestCacheState_persistDurable(t *testing.T) {
 	}
 }
 
+func TestCacheState_lock(t *testing.T) {
+	cache := testLocalState(t)
+	defer os.Remove(cache.Path)
+
+	durable := new(lockingState)
+	cs := &CacheState{
+		Cache:   cache,
+		Durable: durable,
+	}
+
+	if err := cs.Lock("apply"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if durable.reason != "apply" {
+		t.Fatalf("bad: %#v", durable)
+	}
+
+	// A second lock fails while the durable state is locked
+	if err := cs.Lock("refresh"); err == nil {
+		t.Fatal("should error")
+	}
+
+	if err := cs.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if durable.reason != "" {
+		t.Fatalf("bad: %#v", durable)
+	}
+}
+
 func TestCacheState_impl(t *testing.T) {
 	var _ StateReader = new(CacheState)
 	var _ StateWriter = new(CacheState)
 	var _ StatePersister = new(CacheState)
 	var _ StateRefresher = new(CacheState)
+	var _ Locker = new(CacheState)
 }
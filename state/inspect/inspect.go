@@ -0,0 +1,395 @@
+// Package inspect provides a read-only view of Terraform state for tools
+// that need to query it, such as which resources of a type exist, the
+// outputs of a module or how resources depend on each other.
+//
+// The types here are plain values copied out of the state, so tools using
+// this package aren't affected by changes to the internal structure of
+// terraform.State between releases.
+package inspect
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/copystructure"
+)
+
+// State is a read-only view of a Terraform state. The resources and
+// outputs it returns are copies, so changing them doesn't change the view.
+type State struct {
+	// Version, TFVersion and Serial are the values of the state file's
+	// header.
+	Version   int
+	TFVersion string
+	Serial    int64
+
+	resources []*Resource
+	outputs   []*Output
+}
+
+// Resource is a single resource instance in the state.
+type Resource struct {
+	// Address is the address of the resource, including its module, in
+	// the same format as "terraform state list", e.g.
+	// "module.network.aws_subnet.private.1".
+	Address string
+
+	// Module is the path to the module containing the resource. It is
+	// empty for resources in the root module.
+	Module []string
+
+	// Mode is either "managed" or "data".
+	Mode string
+
+	Type string
+	Name string
+
+	// Index is the index of the resource when count is used, or -1.
+	Index int
+
+	// Provider is the provider the resource is associated with, if it was
+	// set explicitly, e.g. "aws.west".
+	Provider string
+
+	ID         string
+	Attributes map[string]string
+	Tainted    bool
+
+	// Dependencies are the resources this resource depends on, as
+	// addresses including the module path.
+	Dependencies []string
+}
+
+// Output is an output of a module in the state.
+type Output struct {
+	// Module is the path to the module containing the output. It is empty
+	// for outputs of the root module.
+	Module []string
+
+	Name      string
+	Sensitive bool
+
+	// Type is one of "string", "list" or "map".
+	Type  string
+	Value interface{}
+}
+
+// Edge is a dependency of one resource on another.
+type Edge struct {
+	From string
+	To   string
+}
+
+// ReadFile reads the state file at path.
+func ReadFile(path string) (*State, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Read(f)
+}
+
+// Read reads a state in any format that Terraform can read.
+func Read(r io.Reader) (*State, error) {
+	s, err := terraform.ReadState(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(s), nil
+}
+
+// New returns a view of a state that has already been read. Later
+// changes to s are not reflected in the view.
+func New(s *terraform.State) *State {
+	result := &State{
+		resources: make([]*Resource, 0),
+		outputs:   make([]*Output, 0),
+	}
+	if s == nil {
+		return result
+	}
+
+	result.Version = s.Version
+	result.TFVersion = s.TFVersion
+	result.Serial = s.Serial
+
+	for _, ms := range s.Modules {
+		path := modulePath(ms.Path)
+		prefix := modulePrefix(path)
+
+		for k, rs := range ms.Resources {
+			key, err := terraform.ParseResourceStateKey(k)
+			if err != nil {
+				// Not a resource we know how to address, so it can't be
+				// queried either.
+				continue
+			}
+
+			r := &Resource{
+				Address:      prefix + key.String(),
+				Module:       path,
+				Mode:         modeString(key.Mode),
+				Type:         key.Type,
+				Name:         key.Name,
+				Index:        key.Index,
+				Provider:     rs.Provider,
+				Attributes:   make(map[string]string),
+				Dependencies: make([]string, 0, len(rs.Dependencies)),
+			}
+			if rs.Primary != nil {
+				r.ID = rs.Primary.ID
+				r.Tainted = rs.Primary.Tainted
+				for ak, av := range rs.Primary.Attributes {
+					r.Attributes[ak] = av
+				}
+			}
+			for _, dep := range rs.Dependencies {
+				r.Dependencies = append(r.Dependencies, prefix+dep)
+			}
+			sort.Strings(r.Dependencies)
+
+			result.resources = append(result.resources, r)
+		}
+
+		for name, o := range ms.Outputs {
+			result.outputs = append(result.outputs, &Output{
+				Module:    path,
+				Name:      name,
+				Sensitive: o.Sensitive,
+				Type:      o.Type,
+				Value:     copyValue(o.Value),
+			})
+		}
+	}
+
+	sort.Sort(resourcesByAddress(result.resources))
+	sort.Sort(outputsByName(result.outputs))
+
+	return result
+}
+
+// Resources returns all the resources in the state, sorted by address.
+func (s *State) Resources() []*Resource {
+	result := make([]*Resource, len(s.resources))
+	for i, r := range s.resources {
+		result[i] = r.copy()
+	}
+	return result
+}
+
+// Resource returns the resource with the given address, or nil if there
+// is no such resource in the state.
+func (s *State) Resource(addr string) *Resource {
+	for _, r := range s.resources {
+		if r.Address == addr {
+			return r.copy()
+		}
+	}
+
+	return nil
+}
+
+// ResourcesByType returns the resources of the given type in all modules.
+func (s *State) ResourcesByType(t string) []*Resource {
+	result := make([]*Resource, 0)
+	for _, r := range s.resources {
+		if r.Type == t {
+			result = append(result, r.copy())
+		}
+	}
+
+	return result
+}
+
+// ModuleResources returns the resources in the module at path. An empty
+// path is the root module. Resources in child modules aren't included.
+func (s *State) ModuleResources(path ...string) []*Resource {
+	result := make([]*Resource, 0)
+	for _, r := range s.resources {
+		if pathEqual(r.Module, path) {
+			result = append(result, r.copy())
+		}
+	}
+
+	return result
+}
+
+// Outputs returns the outputs of the module at path, sorted by name. An
+// empty path is the root module.
+func (s *State) Outputs(path ...string) []*Output {
+	result := make([]*Output, 0)
+	for _, o := range s.outputs {
+		if pathEqual(o.Module, path) {
+			result = append(result, o.copy())
+		}
+	}
+
+	return result
+}
+
+// Dependencies returns the dependencies between all the resources in the
+// state, sorted by From and then To.
+//
+// The state only records dependencies on resources by name, so a
+// dependency on a resource with a count has an edge to each of its
+// instances in the state.
+func (s *State) Dependencies() []Edge {
+	// Map the name of each resource without its index to its instances
+	instances := make(map[string][]string)
+	for _, r := range s.resources {
+		k := resourceBase(r)
+		instances[k] = append(instances[k], r.Address)
+	}
+
+	result := make([]Edge, 0)
+	for _, r := range s.resources {
+		for _, dep := range r.Dependencies {
+			// Dependencies can reference all instances with ".*"
+			dep = strings.TrimSuffix(dep, ".*")
+
+			targets, ok := instances[dep]
+			if !ok {
+				// Could be a reference to a single instance
+				if s.Resource(dep) != nil {
+					targets = []string{dep}
+				}
+			}
+
+			for _, to := range targets {
+				result = append(result, Edge{From: r.Address, To: to})
+			}
+		}
+	}
+
+	sort.Sort(edgesByAddress(result))
+	return result
+}
+
+// copy returns a deep copy of the resource
+func (r *Resource) copy() *Resource {
+	result := *r
+	result.Module = append([]string{}, r.Module...)
+	result.Dependencies = append([]string{}, r.Dependencies...)
+	result.Attributes = make(map[string]string, len(r.Attributes))
+	for k, v := range r.Attributes {
+		result.Attributes[k] = v
+	}
+
+	return &result
+}
+
+// copy returns a deep copy of the output
+func (o *Output) copy() *Output {
+	result := *o
+	result.Module = append([]string{}, o.Module...)
+	result.Value = copyValue(o.Value)
+	return &result
+}
+
+// copyValue returns a deep copy of the value of an output
+func copyValue(v interface{}) interface{} {
+	result, err := copystructure.Copy(v)
+	if err != nil {
+		// Outputs only hold strings, lists and maps, which can always
+		// be copied
+		panic(err)
+	}
+
+	return result
+}
+
+func (e Edge) String() string {
+	return fmt.Sprintf("%s -> %s", e.From, e.To)
+}
+
+// resourceBase returns the address of a resource without its index.
+func resourceBase(r *Resource) string {
+	prefix := modulePrefix(r.Module)
+	if r.Mode == "data" {
+		prefix += "data."
+	}
+
+	return fmt.Sprintf("%s%s.%s", prefix, r.Type, r.Name)
+}
+
+// modulePath returns a module path in the state without the leading root.
+func modulePath(path []string) []string {
+	result := make([]string, 0, len(path))
+	if len(path) > 0 {
+		result = append(result, path[1:]...)
+	}
+
+	return result
+}
+
+func modulePrefix(path []string) string {
+	var buf bytes.Buffer
+	for _, p := range path {
+		buf.WriteString("module.")
+		buf.WriteString(p)
+		buf.WriteString(".")
+	}
+
+	return buf.String()
+}
+
+func modeString(m config.ResourceMode) string {
+	if m == config.DataResourceMode {
+		return "data"
+	}
+
+	return "managed"
+}
+
+func pathEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+type resourcesByAddress []*Resource
+
+func (s resourcesByAddress) Len() int           { return len(s) }
+func (s resourcesByAddress) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s resourcesByAddress) Less(i, j int) bool { return s[i].Address < s[j].Address }
+
+type outputsByName []*Output
+
+func (s outputsByName) Len() int      { return len(s) }
+func (s outputsByName) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s outputsByName) Less(i, j int) bool {
+	a, b := strings.Join(s[i].Module, "."), strings.Join(s[j].Module, ".")
+	if a != b {
+		return a < b
+	}
+
+	return s[i].Name < s[j].Name
+}
+
+type edgesByAddress []Edge
+
+func (s edgesByAddress) Len() int      { return len(s) }
+func (s edgesByAddress) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s edgesByAddress) Less(i, j int) bool {
+	if s[i].From != s[j].From {
+		return s[i].From < s[j].From
+	}
+
+	return s[i].To < s[j].To
+}
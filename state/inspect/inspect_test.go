@@ -0,0 +1,269 @@
+package inspect
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func testState() *terraform.State {
+	return &terraform.State{
+		Version: terraform.StateVersion,
+		Serial:  3,
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: terraform.RootModulePath,
+				Resources: map[string]*terraform.ResourceState{
+					"aws_vpc.main": &terraform.ResourceState{
+						Type: "aws_vpc",
+						Primary: &terraform.InstanceState{
+							ID: "vpc-1",
+							Attributes: map[string]string{
+								"id":         "vpc-1",
+								"cidr_block": "10.0.0.0/16",
+							},
+						},
+					},
+					"aws_instance.web.0": &terraform.ResourceState{
+						Type:         "aws_instance",
+						Dependencies: []string{"aws_subnet.private"},
+						Primary: &terraform.InstanceState{
+							ID:      "i-0",
+							Tainted: true,
+						},
+					},
+					"aws_instance.web.1": &terraform.ResourceState{
+						Type:         "aws_instance",
+						Dependencies: []string{"aws_subnet.private"},
+						Primary: &terraform.InstanceState{
+							ID: "i-1",
+						},
+					},
+					"aws_subnet.private": &terraform.ResourceState{
+						Type:         "aws_subnet",
+						Provider:     "aws.west",
+						Dependencies: []string{"aws_vpc.main"},
+						Primary: &terraform.InstanceState{
+							ID: "subnet-1",
+						},
+					},
+					"data.aws_ami.ubuntu": &terraform.ResourceState{
+						Type: "aws_ami",
+						Primary: &terraform.InstanceState{
+							ID: "ami-1",
+						},
+					},
+				},
+				Outputs: map[string]*terraform.OutputState{
+					"vpc_id": &terraform.OutputState{
+						Type:  "string",
+						Value: "vpc-1",
+					},
+				},
+			},
+			&terraform.ModuleState{
+				Path: []string{"root", "child"},
+				Resources: map[string]*terraform.ResourceState{
+					"aws_instance.web": &terraform.ResourceState{
+						Type:         "aws_instance",
+						Dependencies: []string{"aws_instance.db.*"},
+						Primary: &terraform.InstanceState{
+							ID: "i-2",
+						},
+					},
+					"aws_instance.db.0": &terraform.ResourceState{
+						Type: "aws_instance",
+						Primary: &terraform.InstanceState{
+							ID: "i-3",
+						},
+					},
+				},
+				Outputs: map[string]*terraform.OutputState{
+					"password": &terraform.OutputState{
+						Type:      "string",
+						Sensitive: true,
+						Value:     "hunter2",
+					},
+				},
+			},
+		},
+	}
+}
+
+func addresses(rs []*Resource) []string {
+	result := make([]string, len(rs))
+	for i, r := range rs {
+		result[i] = r.Address
+	}
+	return result
+}
+
+func TestNew_resources(t *testing.T) {
+	s := New(testState())
+
+	actual := addresses(s.Resources())
+	expected := []string{
+		"aws_instance.web.0",
+		"aws_instance.web.1",
+		"aws_subnet.private",
+		"aws_vpc.main",
+		"data.aws_ami.ubuntu",
+		"module.child.aws_instance.db.0",
+		"module.child.aws_instance.web",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	r := s.Resource("aws_instance.web.0")
+	if r == nil {
+		t.Fatal("resource not found")
+	}
+	if r.Index != 0 || !r.Tainted || r.Mode != "managed" || len(r.Module) != 0 {
+		t.Fatalf("bad: %#v", r)
+	}
+
+	r = s.Resource("data.aws_ami.ubuntu")
+	if r == nil || r.Mode != "data" || r.Index != -1 || r.ID != "ami-1" {
+		t.Fatalf("bad: %#v", r)
+	}
+
+	r = s.Resource("aws_subnet.private")
+	if r == nil || r.Provider != "aws.west" {
+		t.Fatalf("bad: %#v", r)
+	}
+
+	if r := s.Resource("aws_vpc.nope"); r != nil {
+		t.Fatalf("bad: %#v", r)
+	}
+}
+
+func TestNew_copy(t *testing.T) {
+	state := testState()
+	s := New(state)
+
+	state.Modules[0].Resources["aws_vpc.main"].Primary.Attributes["cidr_block"] = "changed"
+
+	r := s.Resource("aws_vpc.main")
+	if v := r.Attributes["cidr_block"]; v != "10.0.0.0/16" {
+		t.Fatalf("bad: %s", v)
+	}
+}
+
+func TestState_copies(t *testing.T) {
+	s := New(testState())
+
+	// Changing what is returned doesn't change the view
+	r := s.Resource("aws_vpc.main")
+	r.Attributes["cidr_block"] = "changed"
+	r.Dependencies = append(r.Dependencies, "aws_vpc.other")
+	s.Resources()[0].Attributes["id"] = "changed"
+	s.ResourcesByType("aws_vpc")[0].ID = "changed"
+	s.ModuleResources()[0].Attributes["cidr_block"] = "changed"
+
+	r = s.Resource("aws_vpc.main")
+	if v := r.Attributes["cidr_block"]; v != "10.0.0.0/16" {
+		t.Fatalf("bad: %s", v)
+	}
+	if r.ID == "changed" || r.Attributes["id"] == "changed" {
+		t.Fatalf("bad: %#v", r)
+	}
+	if len(r.Dependencies) != 0 {
+		t.Fatalf("bad: %#v", r.Dependencies)
+	}
+
+	o := s.Outputs()[0]
+	o.Value = "changed"
+	o.Module = append(o.Module, "changed")
+	if o := s.Outputs()[0]; o.Value != "vpc-1" || len(o.Module) != 0 {
+		t.Fatalf("bad: %#v", o)
+	}
+}
+
+func TestNew_nil(t *testing.T) {
+	s := New(nil)
+	if len(s.Resources()) != 0 || len(s.Outputs()) != 0 {
+		t.Fatalf("bad: %#v", s)
+	}
+}
+
+func TestStateResourcesByType(t *testing.T) {
+	s := New(testState())
+
+	actual := addresses(s.ResourcesByType("aws_instance"))
+	expected := []string{
+		"aws_instance.web.0",
+		"aws_instance.web.1",
+		"module.child.aws_instance.db.0",
+		"module.child.aws_instance.web",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestStateModuleResources(t *testing.T) {
+	s := New(testState())
+
+	actual := addresses(s.ModuleResources("child"))
+	expected := []string{
+		"module.child.aws_instance.db.0",
+		"module.child.aws_instance.web",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	if n := len(s.ModuleResources()); n != 5 {
+		t.Fatalf("bad: %d", n)
+	}
+}
+
+func TestStateOutputs(t *testing.T) {
+	s := New(testState())
+
+	root := s.Outputs()
+	if len(root) != 1 || root[0].Name != "vpc_id" || root[0].Value != "vpc-1" {
+		t.Fatalf("bad: %#v", root)
+	}
+
+	child := s.Outputs("child")
+	if len(child) != 1 || child[0].Name != "password" || !child[0].Sensitive {
+		t.Fatalf("bad: %#v", child)
+	}
+}
+
+func TestStateDependencies(t *testing.T) {
+	s := New(testState())
+
+	actual := s.Dependencies()
+	expected := []Edge{
+		{"aws_instance.web.0", "aws_subnet.private"},
+		{"aws_instance.web.1", "aws_subnet.private"},
+		{"aws_subnet.private", "aws_vpc.main"},
+		{"module.child.aws_instance.web", "module.child.aws_instance.db.0"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestRead(t *testing.T) {
+	var buf bytes.Buffer
+	if err := terraform.WriteState(testState(), &buf); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	s, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if s.Serial != 3 {
+		t.Fatalf("bad: %#v", s)
+	}
+	if n := len(s.Resources()); n != 7 {
+		t.Fatalf("bad: %d", n)
+	}
+}
@@ -1,6 +1,10 @@
 package state
 
 import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
 	"os"
 	"path/filepath"
 
@@ -57,16 +61,15 @@ func (s *LocalState) WriteState(state *terraform.State) error {
 		return err
 	}
 
-	f, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
 	s.state.IncrementSerialMaybe(s.readState)
 	s.readState = s.state
 
-	if err := terraform.WriteState(s.state, f); err != nil {
+	var buf bytes.Buffer
+	if err := terraform.WriteState(s.state, &buf); err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(path, buf.Bytes()); err != nil {
 		return err
 	}
 
@@ -113,3 +116,106 @@ func (s *LocalState) RefreshState() error {
 	s.readState = state
 	return nil
 }
+
+// writeFileAtomic replaces the file at path with data, so that the file
+// holds either its old or its new contents if Terraform is interrupted or
+// the disk is full. The data is written to a temporary file in the same
+// directory, synced to disk and read back to verify it before the
+// temporary file is renamed over path. If path is a symlink, the file it
+// points to is replaced.
+func writeFileAtomic(path string, data []byte) error {
+	// The mode of an existing file is kept. Otherwise the file is created
+	// with the same mode as os.Create would.
+	mode := os.FileMode(0666)
+	exists := false
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+
+		fi, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		mode = fi.Mode().Perm()
+		exists = true
+	}
+
+	f, err := createTempFile(filepath.Dir(path), filepath.Base(path), mode)
+	if err != nil {
+		return err
+	}
+	tmpPath := f.Name()
+
+	// The umask could have removed permissions from the existing mode
+	if exists {
+		if err := f.Chmod(mode); err != nil {
+			f.Close()
+			os.Remove(tmpPath)
+			return err
+		}
+	}
+
+	// Don't leave the temporary file behind if anything goes wrong
+	renamed := false
+	defer func() {
+		if !renamed {
+			os.Remove(tmpPath)
+		}
+	}()
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to write state to %s: %s", tmpPath, err)
+	}
+
+	written, err := ioutil.ReadFile(tmpPath)
+	if err != nil {
+		return fmt.Errorf("Failed to verify state in %s: %s", tmpPath, err)
+	}
+	if !bytes.Equal(written, data) {
+		return fmt.Errorf(
+			"Failed to verify state in %s: the file doesn't hold the written state",
+			tmpPath)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	renamed = true
+
+	// Sync the directory so that the rename itself is persisted. Not all
+	// platforms support this, so errors are ignored.
+	if dir, err := os.Open(filepath.Dir(path)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+
+	return nil
+}
+
+// createTempFile creates a new hidden file in dir with a name based on
+// name. Unlike ioutil.TempFile, the file is created with the given mode
+// subject to the umask.
+func createTempFile(dir, name string, mode os.FileMode) (*os.File, error) {
+	var err error
+	for i := 0; i < 10000; i++ {
+		tmpPath := filepath.Join(dir, fmt.Sprintf(
+			".%s.%d.%d.tmp", name, os.Getpid(), rand.Int31()))
+
+		var f *os.File
+		f, err = os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_EXCL, mode)
+		if err == nil {
+			return f, nil
+		}
+		if !os.IsExist(err) {
+			break
+		}
+	}
+
+	return nil, err
+}
@@ -3,6 +3,7 @@ package state
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform/terraform"
@@ -66,3 +67,83 @@ func testLocalState(t *testing.T) *LocalState {
 
 	return ls
 }
+
+func TestLocalState_writeAtomic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "terraform.tfstate")
+	if err := ioutil.WriteFile(path, []byte("old"), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	ls := &LocalState{Path: path}
+	if err := ls.WriteState(TestStateInitial()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Only the state file is left in the directory
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "terraform.tfstate" {
+		t.Fatalf("bad: %#v", entries)
+	}
+
+	// The mode of the existing file is kept
+	if mode := entries[0].Mode().Perm(); mode != 0600 {
+		t.Fatalf("bad mode: %s", mode)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer f.Close()
+	if _, err := terraform.ReadState(f); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestLocalState_writeSymlink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	target := filepath.Join(dir, "target.tfstate")
+	if err := ioutil.WriteFile(target, []byte("old"), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	link := filepath.Join(dir, "terraform.tfstate")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks not supported: %s", err)
+	}
+
+	ls := &LocalState{Path: link}
+	if err := ls.WriteState(TestStateInitial()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The symlink is kept and the state is written to its target
+	fi, err := os.Lstat(link)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if fi.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("symlink was replaced: %s", fi.Mode())
+	}
+
+	data, err := ioutil.ReadFile(target)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if string(data) == "old" {
+		t.Fatal("state wasn't written to the symlink target")
+	}
+}
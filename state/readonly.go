@@ -0,0 +1,33 @@
+package state
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// ErrReadOnly is returned when writing a ReadOnlyState.
+var ErrReadOnly = errors.New("the state is read-only")
+
+// ReadOnlyState wraps a State so that it can only be read. Writing or
+// persisting the state returns ErrReadOnly and leaves the real state
+// untouched.
+type ReadOnlyState struct {
+	Real State
+}
+
+func (s *ReadOnlyState) State() *terraform.State {
+	return s.Real.State()
+}
+
+func (s *ReadOnlyState) RefreshState() error {
+	return s.Real.RefreshState()
+}
+
+func (s *ReadOnlyState) WriteState(*terraform.State) error {
+	return ErrReadOnly
+}
+
+func (s *ReadOnlyState) PersistState() error {
+	return ErrReadOnly
+}
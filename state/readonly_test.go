@@ -0,0 +1,38 @@
+package state
+
+import (
+	"testing"
+)
+
+func TestReadOnlyState(t *testing.T) {
+	real := &InmemState{state: TestStateInitial()}
+	s := &ReadOnlyState{Real: real}
+
+	if err := s.RefreshState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !s.State().Equal(TestStateInitial()) {
+		t.Fatalf("bad: %s", s.State())
+	}
+
+	changed := TestStateInitial()
+	changed.Serial++
+	if err := s.WriteState(changed); err != ErrReadOnly {
+		t.Fatalf("bad: %v", err)
+	}
+	if err := s.PersistState(); err != ErrReadOnly {
+		t.Fatalf("bad: %v", err)
+	}
+
+	// The real state isn't changed
+	if real.State().Serial != TestStateInitial().Serial {
+		t.Fatalf("bad: %s", real.State())
+	}
+}
+
+func TestReadOnlyState_impl(t *testing.T) {
+	var _ StateReader = new(ReadOnlyState)
+	var _ StateWriter = new(ReadOnlyState)
+	var _ StatePersister = new(ReadOnlyState)
+	var _ StateRefresher = new(ReadOnlyState)
+}
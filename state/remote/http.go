@@ -5,25 +5,79 @@ import (
 	"crypto/md5"
 	"crypto/tls"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
 )
 
+// httpHeaderPrefix is the prefix of the configuration keys that set
+// additional headers for every request, such as "header.X-Api-Key".
+const httpHeaderPrefix = "header."
+
 func httpFactory(conf map[string]string) (Client, error) {
 	address, ok := conf["address"]
 	if !ok {
 		return nil, fmt.Errorf("missing 'address' configuration")
 	}
 
-	url, err := url.Parse(address)
+	u, err := httpParseURL("address", address)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTTP URL: %s", err)
+		return nil, err
+	}
+
+	var lockURL, unlockURL *url.URL
+	if raw, ok := conf["lock_address"]; ok && raw != "" {
+		if lockURL, err = httpParseURL("lock_address", raw); err != nil {
+			return nil, err
+		}
+
+		// The lock is released at the same address by default
+		unlockURL = lockURL
+	}
+	if raw, ok := conf["unlock_address"]; ok && raw != "" {
+		if unlockURL, err = httpParseURL("unlock_address", raw); err != nil {
+			return nil, err
+		}
+	}
+
+	lockMethod := conf["lock_method"]
+	if lockMethod == "" {
+		lockMethod = "LOCK"
+	}
+	unlockMethod := conf["unlock_method"]
+	if unlockMethod == "" {
+		unlockMethod = "UNLOCK"
 	}
-	if url.Scheme != "http" && url.Scheme != "https" {
-		return nil, fmt.Errorf("address must be HTTP or HTTPS")
+
+	headers := make(map[string]string)
+	for k, v := range conf {
+		if strings.HasPrefix(k, httpHeaderPrefix) {
+			headers[strings.TrimPrefix(k, httpHeaderPrefix)] = v
+		}
+	}
+
+	retryMax := 2
+	if raw, ok := conf["retry_max"]; ok {
+		if retryMax, err = strconv.Atoi(raw); err != nil || retryMax < 0 {
+			return nil, fmt.Errorf("retry_max must be a non-negative integer")
+		}
+	}
+	retryWaitMin, err := httpParseSeconds(conf, "retry_wait_min", 1)
+	if err != nil {
+		return nil, err
+	}
+	retryWaitMax, err := httpParseSeconds(conf, "retry_wait_max", 30)
+	if err != nil {
+		return nil, err
 	}
 
 	client := &http.Client{}
@@ -45,19 +99,132 @@ func httpFactory(conf map[string]string) (Client, error) {
 	}
 
 	return &HTTPClient{
-		URL:    url,
+		URL:    u,
 		Client: client,
+
+		LockURL:      lockURL,
+		LockMethod:   lockMethod,
+		UnlockURL:    unlockURL,
+		UnlockMethod: unlockMethod,
+
+		Username: conf["username"],
+		Password: conf["password"],
+		Headers:  headers,
+
+		RetryMax:     retryMax,
+		RetryWaitMin: retryWaitMin,
+		RetryWaitMax: retryWaitMax,
 	}, nil
 }
 
+// httpParseURL parses the URL of the given configuration key
+func httpParseURL(key, raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTTP URL for %s: %s", key, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%s must be HTTP or HTTPS", key)
+	}
+
+	return u, nil
+}
+
+// httpParseSeconds parses the number of seconds of the given configuration
+// key, which is def if the key isn't set.
+func httpParseSeconds(conf map[string]string, key string, def int) (time.Duration, error) {
+	n := def
+	if raw, ok := conf[key]; ok {
+		var err error
+		if n, err = strconv.Atoi(raw); err != nil || n < 0 {
+			return 0, fmt.Errorf("%s must be a non-negative number of seconds", key)
+		}
+	}
+
+	return time.Duration(n) * time.Second, nil
+}
+
 // HTTPClient is a remote client that stores data in Consul or HTTP REST.
+//
+// If LockURL is set, the state is locked by sending a request with the
+// LockMethod to it, and unlocked by sending a request with the UnlockMethod
+// to the UnlockURL. The server responds with 409 Conflict or 423 Locked if
+// the state is already locked.
 type HTTPClient struct {
 	URL    *url.URL
 	Client *http.Client
+
+	LockURL      *url.URL
+	LockMethod   string
+	UnlockURL    *url.URL
+	UnlockMethod string
+
+	// Username and Password are used for basic authentication if set, and
+	// Headers are added to every request.
+	Username string
+	Password string
+	Headers  map[string]string
+
+	// Requests that fail with a connection error or a 5xx response are
+	// retried up to RetryMax times.
+	RetryMax     int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// lockInfo is the body of the lock request while the state is locked
+	lockInfo []byte
+}
+
+// httpLockInfo is sent to the lock and unlock addresses, so that the server
+// can tell who holds the lock.
+type httpLockInfo struct {
+	Reason  string `json:"reason"`
+	Who     string `json:"who"`
+	Created string `json:"created"`
+}
+
+// httpRequest makes a request with the configured authentication, headers
+// and retries. What describes the request in errors.
+func (c *HTTPClient) httpRequest(method string, u *url.URL, data []byte, what string) (*http.Response, error) {
+	var body io.ReadSeeker
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+
+	req, err := retryablehttp.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to make HTTP request: %s", err)
+	}
+
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	if data != nil {
+		hash := md5.Sum(data)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(hash[:]))
+		req.ContentLength = int64(len(data))
+	}
+
+	client := retryablehttp.NewClient()
+	client.HTTPClient = c.Client
+	client.RetryMax = c.RetryMax
+	client.RetryWaitMin = c.RetryWaitMin
+	client.RetryWaitMax = c.RetryWaitMax
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to %s: %v", what, err)
+	}
+
+	return resp, nil
 }
 
 func (c *HTTPClient) Get() (*Payload, error) {
-	resp, err := c.Client.Get(c.URL.String())
+	resp, err := c.httpRequest("GET", c.URL, nil, "get state")
 	if err != nil {
 		return nil, err
 	}
@@ -119,10 +286,6 @@ func (c *HTTPClient) Put(data []byte) error {
 	// Copy the target URL
 	base := *c.URL
 
-	// Generate the MD5
-	hash := md5.Sum(data)
-	b64 := base64.StdEncoding.EncodeToString(hash[:])
-
 	/*
 		// Set the force query parameter if needed
 		if force {
@@ -132,20 +295,27 @@ func (c *HTTPClient) Put(data []byte) error {
 		}
 	*/
 
-	req, err := http.NewRequest("POST", base.String(), bytes.NewReader(data))
+	// Make the request. The Content-MD5 header is set for the data.
+	resp, err := c.httpRequest("POST", &base, data, "upload state")
 	if err != nil {
-		return fmt.Errorf("Failed to make HTTP request: %s", err)
+		return err
 	}
+	defer resp.Body.Close()
 
-	// Prepare the request
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Content-MD5", b64)
-	req.ContentLength = int64(len(data))
+	// Handle the error codes
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	default:
+		return fmt.Errorf("HTTP error: %d", resp.StatusCode)
+	}
+}
 
+func (c *HTTPClient) Delete() error {
 	// Make the request
-	resp, err := c.Client.Do(req)
+	resp, err := c.httpRequest("DELETE", c.URL, nil, "delete state")
 	if err != nil {
-		return fmt.Errorf("Failed to upload state: %v", err)
+		return err
 	}
 	defer resp.Body.Close()
 
@@ -158,22 +328,60 @@ func (c *HTTPClient) Put(data []byte) error {
 	}
 }
 
-func (c *HTTPClient) Delete() error {
-	req, err := http.NewRequest("DELETE", c.URL.String(), nil)
+func (c *HTTPClient) Lock(reason string) error {
+	if c.LockURL == nil {
+		return nil
+	}
+
+	who := os.Getenv("USER")
+	if host, err := os.Hostname(); err == nil {
+		who += "@" + host
+	}
+	info, err := json.Marshal(&httpLockInfo{
+		Reason:  reason,
+		Who:     who,
+		Created: time.Now().UTC().Format(time.RFC3339),
+	})
 	if err != nil {
-		return fmt.Errorf("Failed to make HTTP request: %s", err)
+		return err
 	}
 
-	// Make the request
-	resp, err := c.Client.Do(req)
+	resp, err := c.httpRequest(c.LockMethod, c.LockURL, info, "lock state")
 	if err != nil {
-		return fmt.Errorf("Failed to delete state: %s", err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		c.lockInfo = info
+		return nil
+	case http.StatusConflict, http.StatusLocked:
+		// The server may describe who holds the lock in the body
+		body, _ := ioutil.ReadAll(resp.Body)
+		if len(body) > 0 {
+			return fmt.Errorf("state is locked by another process: %s", body)
+		}
+		return fmt.Errorf("state is locked by another process")
+	default:
+		return fmt.Errorf("HTTP error: %d", resp.StatusCode)
+	}
+}
+
+func (c *HTTPClient) Unlock() error {
+	if c.UnlockURL == nil {
+		return nil
+	}
+
+	resp, err := c.httpRequest(c.UnlockMethod, c.UnlockURL, c.lockInfo, "unlock state")
+	if err != nil {
+		return err
 	}
 	defer resp.Body.Close()
 
-	// Handle the error codes
 	switch resp.StatusCode {
 	case http.StatusOK:
+		c.lockInfo = nil
 		return nil
 	default:
 		return fmt.Errorf("HTTP error: %d", resp.StatusCode)
@@ -2,18 +2,84 @@ package remote
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-cleanhttp"
 )
 
 func TestHTTPClient_impl(t *testing.T) {
 	var _ Client = new(HTTPClient)
+	var _ ClientLocker = new(HTTPClient)
+}
+
+func TestHTTPFactory(t *testing.T) {
+	conf := map[string]string{
+		"address":                "http://127.0.0.1:8888/state",
+		"lock_address":           "http://127.0.0.1:8888/lock",
+		"username":               "user",
+		"password":               "pass",
+		"header.X-Api-Key":       "secret",
+		"retry_max":              "5",
+		"retry_wait_min":         "2",
+		"skip_cert_verification": "false",
+	}
+	c, err := httpFactory(conf)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	client := c.(*HTTPClient)
+
+	if client.LockURL.String() != "http://127.0.0.1:8888/lock" {
+		t.Fatalf("bad: %s", client.LockURL)
+	}
+	if client.UnlockURL != client.LockURL {
+		t.Fatalf("unlock address should default to the lock address: %s", client.UnlockURL)
+	}
+	if client.LockMethod != "LOCK" || client.UnlockMethod != "UNLOCK" {
+		t.Fatalf("bad: %s %s", client.LockMethod, client.UnlockMethod)
+	}
+	if client.Username != "user" || client.Password != "pass" {
+		t.Fatalf("bad: %s %s", client.Username, client.Password)
+	}
+	if !reflect.DeepEqual(client.Headers, map[string]string{"X-Api-Key": "secret"}) {
+		t.Fatalf("bad: %#v", client.Headers)
+	}
+	if client.RetryMax != 5 || client.RetryWaitMin != 2*time.Second || client.RetryWaitMax != 30*time.Second {
+		t.Fatalf("bad: %d %s %s", client.RetryMax, client.RetryWaitMin, client.RetryWaitMax)
+	}
+
+	// Without a lock address the state isn't locked
+	c, err = httpFactory(map[string]string{"address": "http://127.0.0.1:8888/state"})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if c.(*HTTPClient).LockURL != nil {
+		t.Fatal("lock address should not be set")
+	}
+	if err := c.(ClientLocker).Lock("test"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Invalid values are errors
+	invalid := []map[string]string{
+		{"address": "http://127.0.0.1:8888/state", "lock_address": "ftp://foo"},
+		{"address": "http://127.0.0.1:8888/state", "retry_max": "many"},
+		{"address": "http://127.0.0.1:8888/state", "retry_wait_max": "-1"},
+	}
+	for _, conf := range invalid {
+		if _, err := httpFactory(conf); err == nil {
+			t.Fatalf("should error: %#v", conf)
+		}
+	}
 }
 
 func TestHTTPClient(t *testing.T) {
@@ -30,11 +96,144 @@ func TestHTTPClient(t *testing.T) {
 	testClient(t, client)
 }
 
+func TestHTTPClient_lock(t *testing.T) {
+	handler := new(testHTTPHandler)
+	ts := httptest.NewServer(http.HandlerFunc(handler.Handle))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	lockURL, err := url.Parse(ts.URL + "/lock")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	newClient := func() *HTTPClient {
+		return &HTTPClient{
+			URL:          u,
+			Client:       cleanhttp.DefaultClient(),
+			LockURL:      lockURL,
+			LockMethod:   "PUT",
+			UnlockURL:    lockURL,
+			UnlockMethod: "DELETE",
+		}
+	}
+	a, b := newClient(), newClient()
+
+	if err := a.Lock("apply"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	var info httpLockInfo
+	if err := json.Unmarshal(handler.Lock, &info); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if info.Reason != "apply" {
+		t.Fatalf("bad: %#v", info)
+	}
+
+	// The holder of the lock is described in the error
+	err = b.Lock("refresh")
+	if err == nil {
+		t.Fatal("should error while the state is locked")
+	}
+	if !strings.Contains(err.Error(), `"reason":"apply"`) {
+		t.Fatalf("bad: %s", err)
+	}
+
+	if err := a.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := b.Lock("refresh"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := b.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestHTTPClient_auth(t *testing.T) {
+	var user, pass, key string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ = r.BasicAuth()
+		key = r.Header.Get("X-Api-Key")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	client := &HTTPClient{
+		URL:      u,
+		Client:   cleanhttp.DefaultClient(),
+		Username: "user",
+		Password: "pass",
+		Headers:  map[string]string{"X-Api-Key": "secret"},
+	}
+	if _, err := client.Get(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if user != "user" || pass != "pass" || key != "secret" {
+		t.Fatalf("bad: %q %q %q", user, pass, key)
+	}
+}
+
+func TestHTTPClient_retry(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("data"))
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	client := &HTTPClient{
+		URL:          u,
+		Client:       cleanhttp.DefaultClient(),
+		RetryMax:     1,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	}
+	p, err := client.Get()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if string(p.Data) != "data" || requests != 2 {
+		t.Fatalf("bad: %q after %d requests", p.Data, requests)
+	}
+
+	// Without retries the error is returned
+	requests = 0
+	client.RetryMax = 0
+	if _, err := client.Get(); err == nil {
+		t.Fatal("should error")
+	}
+}
+
 type testHTTPHandler struct {
 	Data []byte
+	Lock []byte
 }
 
 func (h *testHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/lock" {
+		h.handleLock(w, r)
+		return
+	}
+
 	switch r.Method {
 	case "GET":
 		w.Write(h.Data)
@@ -53,3 +252,29 @@ func (h *testHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(fmt.Sprintf("Unknown method: %s", r.Method)))
 	}
 }
+
+func (h *testHTTPHandler) handleLock(w http.ResponseWriter, r *http.Request) {
+	buf := new(bytes.Buffer)
+	if _, err := io.Copy(buf, r.Body); err != nil {
+		w.WriteHeader(500)
+		return
+	}
+
+	switch r.Method {
+	case "PUT":
+		if h.Lock != nil {
+			w.WriteHeader(http.StatusLocked)
+			w.Write(h.Lock)
+			return
+		}
+		h.Lock = buf.Bytes()
+	case "DELETE":
+		if !bytes.Equal(buf.Bytes(), h.Lock) {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		h.Lock = nil
+	default:
+		w.WriteHeader(500)
+	}
+}
@@ -0,0 +1,205 @@
+package remote
+
+import (
+	"crypto/md5"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"regexp"
+
+	"github.com/lib/pq"
+)
+
+// pgDefaultSchema is the schema the states are stored in if no schema_name
+// is configured.
+const pgDefaultSchema = "terraform_remote_state"
+
+// pgSchemaRegexp matches the schema names that can be used without
+// quoting them.
+var pgSchemaRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+func pgFactory(conf map[string]string) (Client, error) {
+	connStr, ok := conf["conn_str"]
+	if !ok || connStr == "" {
+		return nil, fmt.Errorf("missing 'conn_str' configuration")
+	}
+
+	schema := conf["schema_name"]
+	if schema == "" {
+		schema = pgDefaultSchema
+	}
+	if !pgSchemaRegexp.MatchString(schema) {
+		return nil, fmt.Errorf(
+			"invalid 'schema_name' %q: must only contain lowercase letters, "+
+				"digits and underscores", schema)
+	}
+
+	name := conf["name"]
+	if name == "" {
+		name = "default"
+	}
+
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// The schema and the table are only created by the first write, so
+	// reading the state, e.g. for terraform_remote_state or a plan with
+	// -state-read-only, works with a role that can only SELECT.
+	return &PgClient{
+		DB:     db,
+		Schema: schema,
+		Name:   name,
+	}, nil
+}
+
+// PgClient is a remote client that stores data in a PostgreSQL database.
+// Every state is a row of the states table in the configured schema, and
+// is locked with a transaction level advisory lock.
+type PgClient struct {
+	DB     *sql.DB
+	Schema string
+	Name   string
+
+	// lockTx is the transaction that holds the lock. database/sql can
+	// run queries on any connection of its pool, so the lock is tied to
+	// a transaction, which keeps its connection until it ends. If the
+	// connection is lost, the lock is released and Unlock fails.
+	lockTx *sql.Tx
+
+	// tableCreated is set once createTable succeeded.
+	tableCreated bool
+}
+
+func (c *PgClient) Get() (*Payload, error) {
+	var data []byte
+	err := c.DB.QueryRow(fmt.Sprintf(
+		`SELECT data FROM %s.states WHERE name = $1`, c.Schema),
+		c.Name).Scan(&data)
+	if err == sql.ErrNoRows || pgIsUndefinedTable(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	md5 := md5.Sum(data)
+	return &Payload{
+		Data: data,
+		MD5:  md5[:],
+	}, nil
+}
+
+func (c *PgClient) Put(data []byte) error {
+	if !c.tableCreated {
+		if err := c.createTable(); err != nil {
+			return err
+		}
+		c.tableCreated = true
+	}
+
+	_, err := c.DB.Exec(fmt.Sprintf(
+		`INSERT INTO %s.states (name, data) VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET data = EXCLUDED.data`, c.Schema),
+		c.Name, data)
+	return err
+}
+
+func (c *PgClient) Delete() error {
+	_, err := c.DB.Exec(fmt.Sprintf(
+		`DELETE FROM %s.states WHERE name = $1`, c.Schema), c.Name)
+	if pgIsUndefinedTable(err) {
+		return nil
+	}
+	return err
+}
+
+func (c *PgClient) Lock(reason string) error {
+	if c.lockTx != nil {
+		return fmt.Errorf("state %s.%s is already locked", c.Schema, c.Name)
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	var locked bool
+	err = tx.QueryRow(
+		`SELECT pg_try_advisory_xact_lock($1)`, c.lockID()).Scan(&locked)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if !locked {
+		tx.Rollback()
+		return fmt.Errorf(
+			"state %s.%s is locked by another process", c.Schema, c.Name)
+	}
+
+	c.lockTx = tx
+	log.Printf("[DEBUG] Locked state %s.%s for %s", c.Schema, c.Name, reason)
+	return nil
+}
+
+func (c *PgClient) Unlock() error {
+	if c.lockTx == nil {
+		return fmt.Errorf("state %s.%s wasn't locked", c.Schema, c.Name)
+	}
+
+	// Ending the transaction releases the lock
+	tx := c.lockTx
+	c.lockTx = nil
+	if err := tx.Rollback(); err != nil {
+		return fmt.Errorf(
+			"error releasing the lock of state %s.%s, it may have been "+
+				"lost before: %s", c.Schema, c.Name, err)
+	}
+
+	return nil
+}
+
+// lockID returns the key of the advisory lock of the state, which is
+// derived from the schema and the name of the state.
+func (c *PgClient) lockID() int64 {
+	h := fnv.New64a()
+	h.Write([]byte(c.Schema + "." + c.Name))
+	return int64(h.Sum64())
+}
+
+// createTable creates the schema and the table for the states if they
+// don't exist yet.
+func (c *PgClient) createTable() error {
+	stmts := []string{
+		fmt.Sprintf(`CREATE SCHEMA IF NOT EXISTS %s`, c.Schema),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s.states (
+			name text PRIMARY KEY,
+			data bytea NOT NULL
+		)`, c.Schema),
+	}
+	for _, stmt := range stmts {
+		if _, err := c.DB.Exec(stmt); err != nil {
+			return fmt.Errorf("Error creating the states table: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// pgIsUndefinedTable returns true if err is PostgreSQL's error for a missing
+// schema or table, which means that no state has been written yet.
+func pgIsUndefinedTable(err error) bool {
+	pgErr, ok := err.(*pq.Error)
+	if !ok {
+		return false
+	}
+
+	switch pgErr.Code {
+	case "3F000", "42P01": // invalid_schema_name, undefined_table
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,174 @@
+package remote
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPgClient_impl(t *testing.T) {
+	var _ Client = new(PgClient)
+	var _ ClientLocker = new(PgClient)
+}
+
+func TestPgClient_unlockNotLocked(t *testing.T) {
+	c := &PgClient{Schema: "terraform_remote_state", Name: "default"}
+	if err := c.Unlock(); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestPgFactory_config(t *testing.T) {
+	if _, err := pgFactory(map[string]string{}); err == nil {
+		t.Fatal("should error without conn_str")
+	}
+
+	_, err := pgFactory(map[string]string{
+		"conn_str":    "postgres://localhost/terraform",
+		"schema_name": "Bad-Schema",
+	})
+	if err == nil {
+		t.Fatal("should error with an invalid schema_name")
+	}
+}
+
+func TestPgClient(t *testing.T) {
+	connStr := os.Getenv("TF_PG_TEST_CONN_STR")
+	if connStr == "" {
+		t.Skip("skipping, TF_PG_TEST_CONN_STR isn't set")
+	}
+
+	conf := map[string]string{
+		"conn_str":    connStr,
+		"schema_name": "terraform_test",
+		"name":        fmt.Sprintf("tf-unit-%d", time.Now().UnixNano()),
+	}
+	client, err := pgFactory(conf)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+
+	testClient(t, client)
+
+	// A second client can't lock the state while the first one holds
+	// the lock.
+	other, err := pgFactory(conf)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+
+	c := client.(ClientLocker)
+	if err := c.Lock("test"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := other.(ClientLocker).Lock("test"); err == nil {
+		t.Fatal("should error while the state is locked")
+	}
+
+	// The state can still be written while it's locked
+	if err := c.Put([]byte("locked")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := c.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := other.(ClientLocker).Lock("test"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := other.(ClientLocker).Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestPgClient_readOnly(t *testing.T) {
+	connStr := os.Getenv("TF_PG_TEST_CONN_STR")
+	if connStr == "" {
+		t.Skip("skipping, TF_PG_TEST_CONN_STR isn't set")
+	}
+
+	suffix := time.Now().UnixNano()
+	conf := map[string]string{
+		"conn_str":    connStr,
+		"schema_name": "terraform_test",
+		"name":        fmt.Sprintf("tf-unit-%d", suffix),
+	}
+	client, err := pgFactory(conf)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	if err := client.Put([]byte("foo")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer client.Delete()
+
+	// Create a role that can only read the states and connect as it.
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer db.Close()
+
+	role := fmt.Sprintf("tf_unit_readonly_%d", suffix)
+	stmts := []string{
+		fmt.Sprintf(`CREATE ROLE %s NOLOGIN`, role),
+		fmt.Sprintf(`GRANT %s TO CURRENT_USER`, role),
+		fmt.Sprintf(`GRANT USAGE ON SCHEMA terraform_test TO %s`, role),
+		fmt.Sprintf(`GRANT SELECT ON terraform_test.states TO %s`, role),
+	}
+	for _, stmt := range stmts {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+	defer func() {
+		db.Exec(fmt.Sprintf(`DROP OWNED BY %s`, role))
+		db.Exec(fmt.Sprintf(`DROP ROLE %s`, role))
+	}()
+
+	// lib/pq sends unknown parameters to the server as run-time
+	// parameters, so this sets the role of every connection.
+	roConnStr := connStr + " role=" + role
+	if strings.HasPrefix(connStr, "postgres://") || strings.HasPrefix(connStr, "postgresql://") {
+		sep := "?"
+		if strings.Contains(connStr, "?") {
+			sep = "&"
+		}
+		roConnStr = connStr + sep + "role=" + role
+	}
+
+	roConf := map[string]string{
+		"conn_str":    roConnStr,
+		"schema_name": conf["schema_name"],
+		"name":        conf["name"],
+	}
+	ro, err := pgFactory(roConf)
+	if err != nil {
+		t.Fatalf("read-only client should be created: %s", err)
+	}
+
+	p, err := ro.Get()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if p == nil || !bytes.Equal(p.Data, []byte("foo")) {
+		t.Fatalf("bad: %#v", p)
+	}
+
+	if err := ro.Put([]byte("bar")); err == nil {
+		t.Fatal("should error writing with a read-only role")
+	}
+
+	// A schema that doesn't exist yet is just an empty state.
+	roConf["schema_name"] = fmt.Sprintf("terraform_test_missing_%d", suffix)
+	missing, err := pgFactory(roConf)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if p, err := missing.Get(); err != nil || p != nil {
+		t.Fatalf("bad: %#v, %v", p, err)
+	}
+}
@@ -13,6 +13,16 @@ type Client interface {
 	Delete() error
 }
 
+// ClientLocker is implemented by clients that can lock the remote state,
+// so that only one Terraform operation at a time changes it. Clients that
+// don't implement it aren't locked.
+type ClientLocker interface {
+	Client
+
+	Lock(reason string) error
+	Unlock() error
+}
+
 // Payload is the return value from the remote state storage.
 type Payload struct {
 	MD5  []byte
@@ -41,6 +51,7 @@ var BuiltinClients = map[string]Factory{
 	"etcd":        etcdFactory,
 	"gcs":         gcsFactory,
 	"http":        httpFactory,
+	"pg":          pgFactory,
 	"s3":          s3Factory,
 	"swift":       swiftFactory,
 	"artifactory": artifactoryFactory,
@@ -58,3 +58,25 @@ func (s *State) PersistState() error {
 
 	return s.Client.Put(buf.Bytes())
 }
+
+// Lock locks the remote state if the client supports locking.
+//
+// Locker impl.
+func (s *State) Lock(reason string) error {
+	if l, ok := s.Client.(ClientLocker); ok {
+		return l.Lock(reason)
+	}
+
+	return nil
+}
+
+// Unlock unlocks the remote state if the client supports locking.
+//
+// Locker impl.
+func (s *State) Unlock() error {
+	if l, ok := s.Client.(ClientLocker); ok {
+		return l.Unlock()
+	}
+
+	return nil
+}
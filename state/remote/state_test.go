@@ -24,4 +24,52 @@ func TestState_impl(t *testing.T) {
 	var _ state.StateWriter = new(State)
 	var _ state.StatePersister = new(State)
 	var _ state.StateRefresher = new(State)
+	var _ state.Locker = new(State)
+}
+
+func TestState_lock(t *testing.T) {
+	client := new(lockingClient)
+	s := &State{Client: client}
+
+	if err := s.Lock("apply"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.reason != "apply" {
+		t.Fatalf("bad: %#v", client)
+	}
+
+	if err := s.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.reason != "" {
+		t.Fatalf("bad: %#v", client)
+	}
+}
+
+func TestState_lockUnsupported(t *testing.T) {
+	// Clients that can't lock are never locked
+	s := &State{Client: new(InmemClient)}
+	if err := s.Lock("apply"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := s.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+// lockingClient is an InmemClient that can be locked
+type lockingClient struct {
+	InmemClient
+
+	reason string
+}
+
+func (c *lockingClient) Lock(reason string) error {
+	c.reason = reason
+	return nil
+}
+
+func (c *lockingClient) Unlock() error {
+	c.reason = ""
+	return nil
 }
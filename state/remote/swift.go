@@ -5,7 +5,9 @@ import (
 	"crypto/md5"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rackspace/gophercloud"
 	"github.com/rackspace/gophercloud/openstack"
@@ -15,10 +17,29 @@ import (
 
 const TFSTATE_NAME = "tfstate.tf"
 
+// swiftSegmentsPrefix is the prefix of the names of the segments of a
+// state that is stored as a large object.
+const swiftSegmentsPrefix = TFSTATE_NAME + "/segments/"
+
 // SwiftClient implements the Client interface for an Openstack Swift server.
 type SwiftClient struct {
 	client *gophercloud.ServiceClient
 	path   string
+
+	// expireAfter is the number of seconds after which Swift deletes the
+	// state if it isn't written again. Zero means it never expires.
+	expireAfter int
+
+	// segmentSize is the size in bytes of the segments a state is split
+	// into if it's larger than that. Zero means states aren't segmented.
+	segmentSize int
+}
+
+// swiftSegment is an entry of the manifest of a static large object
+type swiftSegment struct {
+	Path      string `json:"path"`
+	ETag      string `json:"etag"`
+	SizeBytes int    `json:"size_bytes"`
 }
 
 func swiftFactory(conf map[string]string) (Client, error) {
@@ -49,6 +70,26 @@ func (c *SwiftClient) validateConfig(conf map[string]string) (err error) {
 		return fmt.Errorf("missing 'path' configuration")
 	}
 
+	if raw, ok := conf["expire_after"]; ok && raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid 'expire_after' configuration: %s", err)
+		}
+		if d < time.Second {
+			return fmt.Errorf("'expire_after' must be at least one second")
+		}
+		c.expireAfter = int(d / time.Second)
+	}
+
+	if raw, ok := conf["segment_size"]; ok && raw != "" {
+		size, err := strconv.Atoi(raw)
+		if err != nil || size <= 0 {
+			return fmt.Errorf(
+				"invalid 'segment_size' configuration: must be a positive number of bytes")
+		}
+		c.segmentSize = size
+	}
+
 	provider, err := openstack.AuthenticatedClient(gophercloud.AuthOptions{
 		IdentityEndpoint: os.Getenv("OS_AUTH_URL"),
 		Username:         os.Getenv("OS_USERNAME"),
@@ -93,17 +134,114 @@ func (c *SwiftClient) Put(data []byte) error {
 		return err
 	}
 
-	reader := bytes.NewReader(data)
-	result := objects.Create(c.client, c.path, TFSTATE_NAME, reader, nil)
+	if c.segmentSize == 0 {
+		return c.putObject(TFSTATE_NAME, data)
+	}
 
-	return result.Err
+	// Large states are uploaded in segments under a new prefix, so that
+	// the segments of the current state aren't touched until the new
+	// manifest replaces it.
+	prefix := ""
+	if len(data) > c.segmentSize {
+		prefix = fmt.Sprintf("%s%d/", swiftSegmentsPrefix, time.Now().UnixNano())
+		if err := c.putSegments(prefix, data); err != nil {
+			return err
+		}
+	} else if err := c.putObject(TFSTATE_NAME, data); err != nil {
+		return err
+	}
+
+	return c.deleteSegments(prefix)
 }
 
 func (c *SwiftClient) Delete() error {
 	result := objects.Delete(c.client, c.path, TFSTATE_NAME, nil)
+	if result.Err != nil {
+		return result.Err
+	}
+
+	return c.deleteSegments("")
+}
+
+func (c *SwiftClient) putObject(name string, data []byte) error {
+	opts := objects.CreateOpts{DeleteAfter: c.expireAfter}
+	result := objects.Create(c.client, c.path, name, bytes.NewReader(data), opts)
 	return result.Err
 }
 
+// putSegments uploads the state as a static large object, with segments of
+// segmentSize bytes stored under the given prefix.
+func (c *SwiftClient) putSegments(prefix string, data []byte) error {
+	var manifest []swiftSegment
+	for i := 0; len(data) > 0; i++ {
+		n := c.segmentSize
+		if n > len(data) {
+			n = len(data)
+		}
+
+		name := fmt.Sprintf("%s%08d", prefix, i)
+		if err := c.putObject(name, data[:n]); err != nil {
+			return fmt.Errorf("Error uploading segment %s: %s", name, err)
+		}
+
+		manifest = append(manifest, swiftSegment{
+			Path:      "/" + c.path + "/" + name,
+			ETag:      fmt.Sprintf("%x", md5.Sum(data[:n])),
+			SizeBytes: n,
+		})
+		data = data[n:]
+	}
+
+	// The ETag of a manifest is computed from the ETags of the segments,
+	// which objects.Create doesn't support, so it's written directly.
+	headers := map[string]string{}
+	if c.expireAfter > 0 {
+		headers["X-Delete-After"] = strconv.Itoa(c.expireAfter)
+	}
+	url := c.client.ServiceURL(c.path, TFSTATE_NAME) + "?multipart-manifest=put"
+	_, err := c.client.Request("PUT", url, gophercloud.RequestOpts{
+		JSONBody:    manifest,
+		MoreHeaders: headers,
+		OkCodes:     []int{201},
+	})
+	if err != nil {
+		return fmt.Errorf("Error uploading the manifest: %s", err)
+	}
+
+	return nil
+}
+
+// deleteSegments deletes the segments of states written before, except
+// for the segments under the given prefix.
+func (c *SwiftClient) deleteSegments(keep string) error {
+	pages, err := objects.List(c.client, c.path, objects.ListOpts{
+		Prefix: swiftSegmentsPrefix,
+	}).AllPages()
+	if err != nil {
+		if strings.Contains(err.Error(), "but got 404 instead") {
+			return nil
+		}
+		return err
+	}
+	names, err := objects.ExtractNames(pages)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if keep != "" && strings.HasPrefix(name, keep) {
+			continue
+		}
+
+		result := objects.Delete(c.client, c.path, name, nil)
+		if result.Err != nil && !strings.Contains(result.Err.Error(), "but got 404 instead") {
+			return fmt.Errorf("Error deleting segment %s: %s", name, result.Err)
+		}
+	}
+
+	return nil
+}
+
 func (c *SwiftClient) ensureContainerExists() error {
 	result := containers.Create(c.client, c.path, nil)
 	if result.Err != nil {
@@ -1,9 +1,20 @@
 package remote
 
 import (
+	"bytes"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+
+	"github.com/rackspace/gophercloud"
 )
 
 func TestSwiftClient_impl(t *testing.T) {
@@ -29,3 +40,221 @@ func TestSwiftClient(t *testing.T) {
 
 	testClient(t, client)
 }
+
+func TestSwiftClient_segments(t *testing.T) {
+	server := newTestSwiftServer()
+	defer server.Close()
+
+	client := server.client("swift_test")
+	client.segmentSize = 100
+	testClient(t, client)
+
+	// A large state is split into segments
+	large := bytes.Repeat([]byte("x"), 250)
+	if err := client.Put(large); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	p, err := client.Get()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !bytes.Equal(p.Data, large) {
+		t.Fatalf("bad: %s", p.Data)
+	}
+	if n := len(server.names(swiftSegmentsPrefix)); n != 3 {
+		t.Fatalf("expected 3 segments, got %d", n)
+	}
+
+	// Writing it again replaces the segments
+	if err := client.Put(large[:150]); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if n := len(server.names(swiftSegmentsPrefix)); n != 2 {
+		t.Fatalf("expected 2 segments, got %d", n)
+	}
+
+	// A small state is stored as a single object
+	if err := client.Put([]byte("small")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	p, err = client.Get()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if string(p.Data) != "small" {
+		t.Fatalf("bad: %s", p.Data)
+	}
+	if names := server.names(""); len(names) != 1 || names[0] != TFSTATE_NAME {
+		t.Fatalf("bad: %#v", names)
+	}
+
+	// Deleting the state deletes the segments
+	if err := client.Put(large); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := client.Delete(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if names := server.names(""); len(names) != 0 {
+		t.Fatalf("bad: %#v", names)
+	}
+}
+
+func TestSwiftClient_expireAfter(t *testing.T) {
+	server := newTestSwiftServer()
+	defer server.Close()
+
+	client := server.client("swift_test")
+	client.expireAfter = 3600
+	client.segmentSize = 100
+
+	if err := client.Put([]byte("small")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v := server.deleteAfter[TFSTATE_NAME]; v != "3600" {
+		t.Fatalf("bad: %q", v)
+	}
+
+	// The segments expire together with the manifest
+	if err := client.Put(bytes.Repeat([]byte("x"), 150)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for name, v := range server.deleteAfter {
+		if v != "3600" {
+			t.Fatalf("%s: bad: %q", name, v)
+		}
+	}
+}
+
+// testSwiftServer is a fake Swift server with a single container that
+// supports just enough of the API for the SwiftClient.
+type testSwiftServer struct {
+	*httptest.Server
+
+	l           sync.Mutex
+	objects     map[string][]byte
+	manifests   map[string][]swiftSegment
+	deleteAfter map[string]string
+}
+
+func newTestSwiftServer() *testSwiftServer {
+	s := &testSwiftServer{
+		objects:     make(map[string][]byte),
+		manifests:   make(map[string][]swiftSegment),
+		deleteAfter: make(map[string]string),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *testSwiftServer) client(container string) *SwiftClient {
+	return &SwiftClient{
+		client: &gophercloud.ServiceClient{
+			ProviderClient: &gophercloud.ProviderClient{},
+			Endpoint:       s.URL + "/",
+		},
+		path: container,
+	}
+}
+
+// names returns the sorted names of the objects with the given prefix
+func (s *testSwiftServer) names(prefix string) []string {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	var result []string
+	for name := range s.objects {
+		if strings.HasPrefix(name, prefix) {
+			result = append(result, name)
+		}
+	}
+	for name := range s.manifests {
+		if strings.HasPrefix(name, prefix) {
+			result = append(result, name)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+func (s *testSwiftServer) handle(w http.ResponseWriter, r *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	if len(parts) == 1 {
+		s.handleContainer(w, r)
+		return
+	}
+	name := parts[1]
+
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	switch r.Method {
+	case "PUT":
+		body, _ := ioutil.ReadAll(r.Body)
+		delete(s.objects, name)
+		delete(s.manifests, name)
+		s.deleteAfter[name] = r.Header.Get("X-Delete-After")
+
+		if r.URL.Query().Get("multipart-manifest") == "put" {
+			var manifest []swiftSegment
+			if err := json.Unmarshal(body, &manifest); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			s.manifests[name] = manifest
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+
+		s.objects[name] = body
+		w.Header().Set("ETag", fmt.Sprintf("%x", md5.Sum(body)))
+		w.WriteHeader(http.StatusCreated)
+	case "GET":
+		if manifest, ok := s.manifests[name]; ok {
+			for _, seg := range manifest {
+				segName := strings.SplitN(strings.TrimPrefix(seg.Path, "/"), "/", 2)[1]
+				w.Write(s.objects[segName])
+			}
+			return
+		}
+
+		data, ok := s.objects[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case "DELETE":
+		_, ok := s.objects[name]
+		_, okManifest := s.manifests[name]
+		if !ok && !okManifest {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		delete(s.objects, name)
+		delete(s.manifests, name)
+		delete(s.deleteAfter, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *testSwiftServer) handleContainer(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "PUT":
+		w.WriteHeader(http.StatusCreated)
+	case "GET":
+		marker := r.URL.Query().Get("marker")
+		w.Header().Set("Content-Type", "text/plain")
+		for _, name := range s.names(r.URL.Query().Get("prefix")) {
+			if name > marker {
+				fmt.Fprintln(w, name)
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
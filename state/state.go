@@ -40,3 +40,12 @@ type StateRefresher interface {
 type StatePersister interface {
 	PersistState() error
 }
+
+// Locker is implemented by states that can be locked, so that only one
+// Terraform operation at a time changes them. Lock returns an error if the
+// state is already locked. The reason is a short description of the
+// operation holding the lock, such as "apply".
+type Locker interface {
+	Lock(reason string) error
+	Unlock() error
+}
@@ -45,6 +45,7 @@ type ContextOpts struct {
 	Providers          map[string]ResourceProviderFactory
 	Provisioners       map[string]ResourceProvisionerFactory
 	Targets            []string
+	Replace            []string
 	Variables          map[string]string
 
 	UIInput UIInput
@@ -63,6 +64,7 @@ type Context struct {
 	module       *module.Tree
 	providers    map[string]ResourceProviderFactory
 	provisioners map[string]ResourceProvisionerFactory
+	replace      []string
 	sh           *stopHook
 	state        *State
 	stateLock    sync.RWMutex
@@ -146,6 +148,7 @@ func NewContext(opts *ContextOpts) (*Context, error) {
 		module:       opts.Module,
 		providers:    opts.Providers,
 		provisioners: opts.Provisioners,
+		replace:      opts.Replace,
 		state:        state,
 		targets:      opts.Targets,
 		uiInput:      opts.UIInput,
@@ -250,8 +253,9 @@ func (c *Context) Input(mode InputMode) error {
 				var err error
 				value, err = c.uiInput.Input(&InputOpts{
 					Id:          fmt.Sprintf("var.%s", n),
-					Query:       fmt.Sprintf("var.%s", n),
+					Query:       fmt.Sprintf("var.%s (%s)", n, v.Type().Printable()),
 					Description: v.Description,
+					Default:     c.variables[n],
 				})
 				if err != nil {
 					return fmt.Errorf(
@@ -361,6 +365,14 @@ func (c *Context) Plan() (*Plan, error) {
 			c.state = old
 		}()
 
+		// Resources that should be replaced are tainted in the temporary
+		// state, so the providers plan to destroy and recreate them. The
+		// planned diffs keep DestroyTainted, so the saved state doesn't
+		// need to be tainted for the plan to be applied.
+		if err := c.taintReplaced(); err != nil {
+			return nil, err
+		}
+
 		operation = walkPlan
 	}
 
@@ -391,6 +403,105 @@ func (c *Context) Plan() (*Plan, error) {
 	return p, nil
 }
 
+// PlanRefreshOnly generates an execution plan that makes no changes to
+// the infrastructure. Applying it only saves the state of this context, so
+// calling it after Refresh gives a plan that accepts whatever changed in
+// the real resources without applying the configuration.
+func (c *Context) PlanRefreshOnly() *Plan {
+	v := c.acquireRun()
+	defer c.releaseRun(v)
+
+	c.diffLock.Lock()
+	c.diff = new(Diff)
+	c.diff.init()
+	c.diffLock.Unlock()
+
+	return &Plan{
+		Diff:    c.diff,
+		Module:  c.module,
+		Vars:    c.variables,
+		State:   c.state,
+		Targets: c.targets,
+	}
+}
+
+// ResumePlan returns a plan to continue the last Apply of this context
+// after it failed part way. Apply removes the diff of every instance it
+// applies, so the plan targets the instances left in the diff along with
+// the failed ones, and its state is the state after the apply. Planning it
+// again only plans the changes that weren't made, without refreshing or
+// planning the rest of the configuration.
+func (c *Context) ResumePlan(failed []*InstanceInfo) *Plan {
+	seen := make(map[string]bool)
+	var targets []string
+	addTarget := func(path []string, name string) {
+		key, err := ParseResourceStateKey(name)
+		if err != nil {
+			return
+		}
+
+		addr := &ResourceAddress{
+			Path:  path[1:],
+			Mode:  key.Mode,
+			Type:  key.Type,
+			Name:  key.Name,
+			Index: key.Index,
+		}
+		if v := addr.String(); !seen[v] {
+			seen[v] = true
+			targets = append(targets, v)
+		}
+	}
+
+	if c.diff != nil {
+		for _, m := range c.diff.Modules {
+			for name, d := range m.Resources {
+				if !d.Empty() {
+					addTarget(m.Path, name)
+				}
+			}
+		}
+	}
+	for _, info := range failed {
+		path := info.ModulePath
+		if len(path) == 0 {
+			path = rootModulePath
+		}
+		addTarget(path, info.Id)
+	}
+	sort.Strings(targets)
+
+	return &Plan{
+		Diff:    c.diff,
+		Module:  c.module,
+		Vars:    c.variables,
+		State:   c.state,
+		Targets: targets,
+	}
+}
+
+// taintReplaced marks the resources matching the Replace addresses as
+// tainted in the state of this context.
+func (c *Context) taintReplaced() error {
+	if len(c.replace) == 0 {
+		return nil
+	}
+
+	filter := &StateFilter{State: c.state}
+	results, err := filter.Filter(c.replace...)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		if rs, ok := r.Value.(*ResourceState); ok {
+			rs.Taint()
+		}
+	}
+
+	return nil
+}
+
 // Refresh goes through all the resources in the state and refreshes them
 // to their latest state. This will update the state that this context
 // works with, along with returning it.
@@ -489,6 +600,15 @@ func (c *Context) Validate() ([]string, []error) {
 	return walker.ValidationWarnings, rerrs.Errors
 }
 
+// Diff returns the diff that Apply will apply, as set by Plan or the
+// plan this context was created from. The diff must not be modified.
+func (c *Context) Diff() *Diff {
+	c.diffLock.RLock()
+	defer c.diffLock.RUnlock()
+
+	return c.diff
+}
+
 // Module returns the module tree associated with this context.
 func (c *Context) Module() *module.Tree {
 	return c.module
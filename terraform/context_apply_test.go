@@ -553,6 +553,39 @@ func TestContext2Apply_minimal(t *testing.T) {
 	}
 }
 
+func TestContext2Apply_timestamp(t *testing.T) {
+	m := testModule(t, "plan-timestamp")
+	p := testProvider("aws")
+	p.ApplyFn = testApplyFn
+	p.DiffFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	start := time.Now()
+	state, err := ctx.Apply()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The time is only known once the plan is applied
+	raw := state.RootModule().Resources["aws_instance.foo"].Primary.Attributes["foo"]
+	expires, err := time.Parse(time.RFC3339, strings.TrimPrefix(raw, "expires-"))
+	if err != nil {
+		t.Fatalf("bad: %q: %s", raw, err)
+	}
+	if d := expires.Sub(start); d < 23*time.Hour || d > 25*time.Hour {
+		t.Fatalf("bad: %q", raw)
+	}
+}
+
 func TestContext2Apply_badDiff(t *testing.T) {
 	m := testModule(t, "apply-good")
 	p := testProvider("aws")
@@ -1616,6 +1649,81 @@ func TestContext2Apply_providerComputedVar(t *testing.T) {
 	}
 }
 
+func TestContext2Apply_destroyProviderComputedVar(t *testing.T) {
+	m := testModule(t, "apply-provider-computed")
+	h := new(HookRecordApplyOrder)
+	p := testProvider("aws")
+	p.ApplyFn = testApplyFn
+	p.DiffFn = testDiffFn
+
+	pTest := testProvider("test")
+	pTest.ApplyFn = testApplyFn
+	pTest.DiffFn = testDiffFn
+
+	providers := map[string]ResourceProviderFactory{
+		"aws":  testProviderFuncFixed(p),
+		"test": testProviderFuncFixed(pTest),
+	}
+
+	// First plan and apply a create operation
+	ctx := testContext2(t, &ContextOpts{
+		Module:    m,
+		Providers: providers,
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	state, err := ctx.Apply()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Destroying must still configure the provider with the value of the
+	// resource it refers to, so that resource has to go last.
+	p.ConfigureFn = func(c *ResourceConfig) error {
+		if c.IsComputed("value") {
+			return fmt.Errorf("value is computed")
+		}
+
+		v, ok := c.Get("value")
+		if !ok {
+			return fmt.Errorf("value is not found")
+		}
+		if v != "yes" {
+			return fmt.Errorf("value is not 'yes': %v", v)
+		}
+
+		return nil
+	}
+
+	h.Active = true
+	ctx = testContext2(t, &ContextOpts{
+		Destroy:   true,
+		State:     state,
+		Module:    m,
+		Hooks:     []Hook{h},
+		Providers: providers,
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	state, err = ctx.Apply()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	checkStateString(t, state, "<no state>")
+
+	expected := []string{"aws_instance.bar", "test_instance.foo"}
+	if !reflect.DeepEqual(h.IDs, expected) {
+		t.Fatalf("expected: %#v\n\ngot:%#v", expected, h.IDs)
+	}
+}
+
 func TestContext2Apply_Provisioner_compute(t *testing.T) {
 	m := testModule(t, "apply-provisioner-compute")
 	p := testProvider("aws")
@@ -3087,6 +3195,110 @@ func TestContext2Apply_error(t *testing.T) {
 	}
 }
 
+func TestContext2Apply_resumePlan(t *testing.T) {
+	m := testModule(t, "apply-error")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	p.ApplyFn = func(info *InstanceInfo, s *InstanceState, d *InstanceDiff) (*InstanceState, error) {
+		if info.Id == "aws_instance.bar" {
+			return nil, fmt.Errorf("error")
+		}
+		return testApplyFn(info, s, d)
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := ctx.Apply(); err == nil {
+		t.Fatal("should have error")
+	}
+
+	plan := ctx.ResumePlan([]*InstanceInfo{
+		&InstanceInfo{Id: "aws_instance.bar", ModulePath: rootModulePath},
+	})
+	if !reflect.DeepEqual(plan.Targets, []string{"aws_instance.bar"}) {
+		t.Fatalf("bad: %#v", plan.Targets)
+	}
+	if plan.State.RootModule().Resources["aws_instance.foo"] == nil {
+		t.Fatalf("bad: %s", plan.State)
+	}
+
+	var buf bytes.Buffer
+	if err := WritePlan(plan, &buf); err != nil {
+		t.Fatalf("plan write err: %s", err)
+	}
+	planFromFile, err := ReadPlan(&buf)
+	if err != nil {
+		t.Fatalf("plan read err: %s", err)
+	}
+
+	var applied []string
+	p.ApplyFn = func(info *InstanceInfo, s *InstanceState, d *InstanceDiff) (*InstanceState, error) {
+		applied = append(applied, info.Id)
+		return testApplyFn(info, s, d)
+	}
+	ctx, err = planFromFile.Context(&ContextOpts{
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	state, err := ctx.Apply()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !reflect.DeepEqual(applied, []string{"aws_instance.bar"}) {
+		t.Fatalf("bad: %#v", applied)
+	}
+	if len(state.RootModule().Resources) != 2 {
+		t.Fatalf("bad: %s", state)
+	}
+}
+
+func TestContext2Apply_resumePlanNotStarted(t *testing.T) {
+	m := testModule(t, "apply-error")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	p.ApplyFn = func(*InstanceInfo, *InstanceState, *InstanceDiff) (*InstanceState, error) {
+		return nil, fmt.Errorf("error")
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := ctx.Apply(); err == nil {
+		t.Fatal("should have error")
+	}
+
+	// aws_instance.bar depends on the failed aws_instance.foo, so it's
+	// never applied and must be targeted from the remaining diff.
+	plan := ctx.ResumePlan([]*InstanceInfo{
+		&InstanceInfo{Id: "aws_instance.foo", ModulePath: rootModulePath},
+	})
+	expected := []string{"aws_instance.bar", "aws_instance.foo"}
+	if !reflect.DeepEqual(plan.Targets, expected) {
+		t.Fatalf("bad: %#v", plan.Targets)
+	}
+}
+
 func TestContext2Apply_errorPartial(t *testing.T) {
 	errored := false
 
@@ -423,6 +423,38 @@ func TestContext2Input_varOnly(t *testing.T) {
 	}
 }
 
+func TestContext2Input_varPrompt(t *testing.T) {
+	input := new(MockUIInput)
+	m := testModule(t, "input-var-prompt")
+	p := testProvider("aws")
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		Variables: map[string]string{
+			"foo": "us-west-2",
+		},
+		UIInput: input,
+	})
+
+	input.InputReturnString = "us-east-1"
+
+	if err := ctx.Input(InputModeVar); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := &InputOpts{
+		Id:          "var.foo",
+		Query:       "var.foo (string)",
+		Description: "The region to deploy to",
+		Default:     "us-west-2",
+	}
+	if !reflect.DeepEqual(input.InputOpts, expected) {
+		t.Fatalf("bad: %#v", input.InputOpts)
+	}
+}
+
 func TestContext2Input_varOnlyUnset(t *testing.T) {
 	input := new(MockUIInput)
 	m := testModule(t, "input-vars-unset")
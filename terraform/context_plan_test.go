@@ -104,6 +104,117 @@ func TestContext2Plan_emptyDiff(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_refreshOnly(t *testing.T) {
+	m := testModule(t, "refresh-basic")
+	p := testProvider("aws")
+	p.ApplyFn = testApplyFn
+	p.DiffFn = testDiffFn
+	p.RefreshFn = nil
+	p.RefreshReturn = &InstanceState{
+		ID: "foo",
+		Attributes: map[string]string{
+			"id":  "foo",
+			"foo": "changed",
+		},
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		State: &State{
+			Modules: []*ModuleState{
+				&ModuleState{
+					Path: rootModulePath,
+					Resources: map[string]*ResourceState{
+						"aws_instance.web": resourceState("aws_instance", "foo"),
+					},
+				},
+			},
+		},
+	})
+
+	if _, err := ctx.Refresh(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	plan := ctx.PlanRefreshOnly()
+	if !plan.Diff.Empty() {
+		t.Fatalf("bad: %s", plan.Diff)
+	}
+
+	var buf bytes.Buffer
+	if err := WritePlan(plan, &buf); err != nil {
+		t.Fatalf("plan write err: %s", err)
+	}
+	planFromFile, err := ReadPlan(&buf)
+	if err != nil {
+		t.Fatalf("plan read err: %s", err)
+	}
+
+	ctx, err = planFromFile.Context(&ContextOpts{
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	state, err := ctx.Apply()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if p.ApplyCalled {
+		t.Fatal("apply should not be called")
+	}
+
+	actual := state.RootModule().Resources["aws_instance.web"].Primary.Attributes["foo"]
+	if actual != "changed" {
+		t.Fatalf("bad: %s", state)
+	}
+}
+
+func TestContext2Plan_replace(t *testing.T) {
+	m := testModule(t, "refresh-basic")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	s := &State{
+		Modules: []*ModuleState{
+			&ModuleState{
+				Path: rootModulePath,
+				Resources: map[string]*ResourceState{
+					"aws_instance.web": resourceState("aws_instance", "foo"),
+				},
+			},
+		},
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		State:   s,
+		Replace: []string{"aws_instance.web"},
+	})
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := plan.Diff.RootModule().Resources["aws_instance.web"]
+	if d == nil || !d.DestroyTainted || d.ChangeType() != DiffDestroyCreate {
+		t.Fatalf("bad: %s", plan.Diff)
+	}
+
+	// The saved state must not be tainted
+	if plan.State.RootModule().Resources["aws_instance.web"].Primary.Tainted {
+		t.Fatalf("bad: %s", plan.State)
+	}
+}
+
 func TestContext2Plan_escapedVar(t *testing.T) {
 	m := testModule(t, "plan-escaped-var")
 	p := testProvider("aws")
@@ -257,6 +368,29 @@ func TestContext2Plan_moduleInput(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_moduleFile(t *testing.T) {
+	m := testModule(t, "plan-module-file")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := strings.TrimSpace(plan.String())
+	expected := strings.TrimSpace(testTerraformPlanModuleFileStr)
+	if actual != expected {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
 func TestContext2Plan_moduleInputComputed(t *testing.T) {
 	m := testModule(t, "plan-module-input-computed")
 	p := testProvider("aws")
@@ -280,6 +414,26 @@ func TestContext2Plan_moduleInputComputed(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_moduleVarValidation(t *testing.T) {
+	m := testModule(t, "plan-module-var-validation")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	_, err := ctx.Plan()
+	if err == nil {
+		t.Fatal("should error")
+	}
+	if !strings.Contains(err.Error(), "module child: Invalid value for variable 'cidr'") {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
 func TestContext2Plan_moduleInputFromVar(t *testing.T) {
 	m := testModule(t, "plan-module-input-var")
 	p := testProvider("aws")
@@ -864,6 +1018,29 @@ func TestContext2Plan_computed(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_timestamp(t *testing.T) {
+	m := testModule(t, "plan-timestamp")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := strings.TrimSpace(plan.String())
+	expected := strings.TrimSpace(testTerraformPlanTimestampStr)
+	if actual != expected {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
 func TestContext2Plan_computedDataResource(t *testing.T) {
 	m := testModule(t, "plan-computed-data-resource")
 	p := testProvider("aws")
@@ -1065,6 +1242,80 @@ func TestContext2Plan_countComputed(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_countComputedModule(t *testing.T) {
+	m := testModule(t, "plan-count-computed-module")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	_, err := ctx.Plan()
+	if err == nil {
+		t.Fatal("should error")
+	}
+
+	expected := "aws_instance.bar: value of 'count' must be known"
+	if !strings.Contains(err.Error(), expected) {
+		t.Fatalf("expected %q in error:\n%s", expected, err)
+	}
+}
+
+func TestContext2Plan_countData(t *testing.T) {
+	m := testModule(t, "plan-count-data")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	p.ReadDataDiffFn = nil
+	p.ReadDataDiffReturn = &InstanceDiff{
+		Attributes: map[string]*ResourceAttrDiff{
+			"num": &ResourceAttrDiff{
+				NewComputed: true,
+				Type:        DiffAttrOutput,
+			},
+		},
+	}
+	p.ReadDataApplyFn = nil
+	p.ReadDataApplyReturn = &InstanceState{
+		ID: "foo",
+		Attributes: map[string]string{
+			"id":  "foo",
+			"num": "3",
+		},
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	// This is the order of a plan with refresh on a fresh project, so the
+	// data source hasn't been read before the count is validated.
+	if w, e := ctx.Validate(); len(w) > 0 || len(e) > 0 {
+		t.Fatalf("bad: %#v %s", w, e)
+	}
+	if _, err := ctx.Refresh(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resources := plan.Diff.RootModule().Resources
+	for i := 0; i < 3; i++ {
+		if _, ok := resources[fmt.Sprintf("aws_instance.foo.%d", i)]; !ok {
+			t.Fatalf("missing aws_instance.foo.%d:\n%s", i, plan)
+		}
+	}
+	if len(resources) != 3 {
+		t.Fatalf("bad:\n%s", plan)
+	}
+}
+
 func TestContext2Plan_countIndex(t *testing.T) {
 	m := testModule(t, "plan-count-index")
 	p := testProvider("aws")
@@ -297,8 +297,24 @@ func (ctx *BuiltinEvalContext) Interpolate(
 			return nil, err
 		}
 
-		// Do the interpolation
-		if err := cfg.Interpolate(vs); err != nil {
+		// The file function falls back to resolving relative paths
+		// against the module directory, so it's always in scope.
+		if _, ok := vs["path.module"]; !ok && ctx.Interpolater.Module != nil {
+			err := ctx.Interpolater.valuePathVar(scope, "path.module",
+				&config.PathVariable{Type: config.PathValueModule}, vs)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Do the interpolation. The time is unknown until the changes
+		// are applied, so values using it are computed before that.
+		interpolate := cfg.Interpolate
+		switch ctx.Interpolater.Operation {
+		case walkInput, walkValidate, walkPlan, walkPlanDestroy:
+			interpolate = cfg.InterpolateUnknownTime
+		}
+		if err := interpolate(vs); err != nil {
 			return nil, err
 		}
 	}
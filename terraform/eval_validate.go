@@ -37,10 +37,12 @@ func (n *EvalValidateCount) Eval(ctx EvalContext) (interface{}, error) {
 	count, err = n.Resource.Count()
 	if err != nil {
 		// If we can't get the count during validation, then
-		// just replace it with the number 1.
+		// just replace it with the number 1. This is the case for
+		// counts that depend on data sources, which are read later.
 		c := n.Resource.RawCount.Config()
 		c[n.Resource.RawCount.Key] = "1"
 		count = 1
+		err = nil
 	}
 
 	if count < 0 {
@@ -102,6 +102,41 @@ func (n *EvalTypeCheckVariable) Eval(ctx EvalContext) (interface{}, error) {
 	return nil, nil
 }
 
+// EvalValidateVariable is an EvalNode implementation that checks the
+// variable values which are assigned as inputs to a module against the
+// validation rules of the variables. Values that aren't known yet are
+// checked in a later walk.
+type EvalValidateVariable struct {
+	Variables  map[string]interface{}
+	ModulePath []string
+	ModuleTree *module.Tree
+}
+
+func (n *EvalValidateVariable) Eval(ctx EvalContext) (interface{}, error) {
+	currentTree := n.ModuleTree
+	for _, pathComponent := range n.ModulePath[1:] {
+		currentTree = currentTree.Children()[pathComponent]
+	}
+
+	for _, variable := range currentTree.Config().Variables {
+		value, ok := n.Variables[variable.Name]
+		if !ok {
+			continue
+		}
+
+		if err := variable.ValidateValue(value); err != nil {
+			if len(n.ModulePath) > 1 {
+				err = fmt.Errorf("module %s: %s",
+					strings.Join(n.ModulePath[1:], "."), err)
+			}
+
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
 // EvalSetVariables is an EvalNode implementation that sets the variables
 // explicitly for interpolation later.
 type EvalSetVariables struct {
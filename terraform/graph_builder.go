@@ -152,6 +152,7 @@ func (b *BuiltinGraphBuilder) Steps(path []string) []GraphTransformer {
 
 			// Create the destruction nodes
 			&DestroyTransformer{FullDestroy: b.Destroy},
+			&DestroyOrderTransformer{FullDestroy: b.Destroy},
 			b.conditional(&conditionalOpts{
 				If:   func() bool { return !b.Destroy },
 				Then: &CreateBeforeDestroyTransformer{},
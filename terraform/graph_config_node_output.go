@@ -31,7 +31,8 @@ func (n *GraphNodeConfigOutput) DependableName() []string {
 
 func (n *GraphNodeConfigOutput) DependentOn() []string {
 	vars := n.Output.RawConfig.Variables
-	result := make([]string, 0, len(vars))
+	result := make([]string, len(n.Output.DependsOn), len(n.Output.DependsOn)+len(vars))
+	copy(result, n.Output.DependsOn)
 	for _, v := range vars {
 		if vn := varNameForVar(v); vn != "" {
 			result = append(result, vn)
@@ -389,6 +389,15 @@ func (n *graphNodeResourceDestroyFlat) CreateNode() dag.Vertex {
 	return n.FlatCreateNode
 }
 
+func (n *graphNodeResourceDestroyFlat) DestroyBefore() []string {
+	// Copy so that prefixing doesn't modify the configuration
+	result := make([]string, len(n.graphNodeResourceDestroy.DestroyBefore()))
+	copy(result, n.graphNodeResourceDestroy.DestroyBefore())
+
+	prefix := modulePrefixStr(n.PathValue)
+	return modulePrefixList(result, prefix)
+}
+
 func (n *graphNodeResourceDestroyFlat) ProvidedBy() []string {
 	prefix := modulePrefixStr(n.PathValue)
 	return modulePrefixList(
@@ -413,6 +422,10 @@ func (n *graphNodeResourceDestroy) CreateNode() dag.Vertex {
 	return n.Original
 }
 
+func (n *graphNodeResourceDestroy) DestroyBefore() []string {
+	return n.Original.Resource.Lifecycle.DestroyBefore
+}
+
 func (n *graphNodeResourceDestroy) DestroyInclude(d *ModuleDiff, s *ModuleState) bool {
 	if n.Destroy {
 		return n.destroyInclude(d, s)
@@ -16,6 +16,29 @@ func TestGraphNodeConfigOutput_impl(t *testing.T) {
 	var _ GraphNodeProxy = new(GraphNodeConfigOutput)
 }
 
+func TestGraphNodeConfigOutput_DependentOn(t *testing.T) {
+	rc, err := config.NewRawConfig(map[string]interface{}{
+		"value": "${aws_instance.foo.id}",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	n := &GraphNodeConfigOutput{
+		Output: &config.Output{
+			Name:      "foo",
+			DependsOn: []string{"null_resource.bar"},
+			RawConfig: rc,
+		},
+	}
+
+	actual := n.DependentOn()
+	expected := []string{"null_resource.bar", "aws_instance.foo"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
 func TestGraphNodeConfigProvider_impl(t *testing.T) {
 	var _ dag.Vertex = new(GraphNodeConfigProvider)
 	var _ dag.NamedVertex = new(GraphNodeConfigProvider)
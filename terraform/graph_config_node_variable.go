@@ -182,6 +182,12 @@ func (n *GraphNodeConfigVariable) EvalTree() EvalNode {
 				ModuleTree: n.ModuleTree,
 			},
 
+			&EvalValidateVariable{
+				Variables:  variables,
+				ModulePath: n.ModulePath,
+				ModuleTree: n.ModuleTree,
+			},
+
 			&EvalSetVariables{
 				Module:    &n.Module,
 				Variables: variables,
@@ -2,6 +2,8 @@ package terraform
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform/config"
@@ -87,10 +89,21 @@ func smcUserVariables(c *config.Config, vs map[string]string) []error {
 	for k, _ := range vs {
 		delete(required, k)
 	}
+	// The missing variables are reported in a single error so that they
+	// can all be set at once when input is disabled.
 	if len(required) > 0 {
+		names := make([]string, 0, len(required))
 		for k, _ := range required {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+
+		if len(names) == 1 {
+			errs = append(errs, fmt.Errorf(
+				"Required variable not set: %s", names[0]))
+		} else {
 			errs = append(errs, fmt.Errorf(
-				"Required variable not set: %s", k))
+				"Required variables not set: %s", strings.Join(names, ", ")))
 		}
 	}
 
@@ -108,6 +121,18 @@ func smcUserVariables(c *config.Config, vs map[string]string) []error {
 		}
 	}
 
+	// Check that the values follow the validation rules of the variables
+	for k, value := range vs {
+		v, ok := cvs[k]
+		if !ok {
+			continue
+		}
+
+		if err := v.ValidateValue(value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	// TODO(mitchellh): variables that are unknown
 
 	return errs
@@ -1,6 +1,7 @@
 package terraform
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -38,3 +39,34 @@ func TestSMCUserVariables(t *testing.T) {
 	}
 
 }
+
+func TestSMCUserVariables_requiredMultiple(t *testing.T) {
+	c := testConfig(t, "smc-uservars-required")
+
+	errs := smcUserVariables(c, nil)
+	if len(errs) != 1 {
+		t.Fatalf("bad: %#v", errs)
+	}
+
+	expected := "Required variables not set: a, b, c"
+	if errs[0].Error() != expected {
+		t.Fatalf("bad: %s", errs[0])
+	}
+}
+
+func TestSMCUserVariables_validation(t *testing.T) {
+	c := testConfig(t, "smc-uservars-validation")
+
+	errs := smcUserVariables(c, map[string]string{"cidr": "10.0.0.0/16"})
+	if len(errs) != 0 {
+		t.Fatalf("err: %#v", errs)
+	}
+
+	errs = smcUserVariables(c, map[string]string{"cidr": "10.0.0.0"})
+	if len(errs) != 1 {
+		t.Fatalf("bad: %#v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "must be a CIDR block") {
+		t.Fatalf("bad: %s", errs[0])
+	}
+}
@@ -1353,6 +1353,12 @@ type EphemeralState struct {
 	// doesn't state that you need to set this, then don't worry about
 	// setting it.
 	Type string `json:"-"`
+
+	// Warnings are messages a provider wants to show to the user about
+	// the operation that returned this state, such as a resource that
+	// was created but may not work as expected. They are only kept for
+	// that operation and are not copied.
+	Warnings []string `json:"-"`
 }
 
 func (e *EphemeralState) init() {
@@ -698,6 +698,19 @@ STATE:
 <no state>
 `
 
+const testTerraformPlanTimestampStr = `
+DIFF:
+
+CREATE: aws_instance.foo
+  foo:  "" => "<computed>"
+  num:  "" => "2"
+  type: "" => "aws_instance"
+
+STATE:
+
+<no state>
+`
+
 const testTerraformPlanComputedIdStr = `
 DIFF:
 
@@ -1081,6 +1094,22 @@ STATE:
 <no state>
 `
 
+const testTerraformPlanModuleFileStr = `
+DIFF:
+
+module.child:
+  CREATE: aws_instance.bar
+    foo:  "" => "hello"
+    type: "" => "aws_instance"
+  CREATE: aws_instance.foo
+    foo:  "" => "hello"
+    type: "" => "aws_instance"
+
+STATE:
+
+<no state>
+`
+
 const testTerraformPlanModuleInputComputedStr = `
 DIFF:
 
@@ -0,0 +1,7 @@
+variable "foo" {
+    description = "The region to deploy to"
+}
+
+resource "aws_instance" "foo" {
+    foo = "${var.foo}"
+}
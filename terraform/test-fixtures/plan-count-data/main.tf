@@ -0,0 +1,5 @@
+data "aws_data_resource" "foo" {}
+
+resource "aws_instance" "foo" {
+    count = "${data.aws_data_resource.foo.num}"
+}
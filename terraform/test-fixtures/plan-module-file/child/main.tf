@@ -0,0 +1,7 @@
+resource "aws_instance" "foo" {
+    foo = "${file("hello.txt")}"
+}
+
+resource "aws_instance" "bar" {
+    foo = "${file("${path.module}/hello.txt")}"
+}
@@ -0,0 +1,10 @@
+variable "cidr" {
+    validation {
+        condition = "${cidrhost(var.cidr, 0)}"
+        error_message = "The cidr value must be a CIDR block."
+    }
+}
+
+resource "aws_instance" "foo" {
+    foo = "${var.cidr}"
+}
@@ -0,0 +1,4 @@
+module "child" {
+    source = "./child"
+    cidr = "10.0.0.0"
+}
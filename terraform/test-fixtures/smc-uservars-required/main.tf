@@ -0,0 +1,7 @@
+variable "c" {}
+variable "a" {}
+variable "b" {}
+
+variable "optional" {
+    default = "foo"
+}
@@ -0,0 +1,6 @@
+variable "cidr" {
+    validation {
+        condition = "${cidrhost(var.cidr, 0)}"
+        error_message = "The cidr value must be a CIDR block."
+    }
+}
@@ -0,0 +1,7 @@
+resource "aws_instance" "foo" {}
+
+resource "aws_instance" "bar" {
+    lifecycle {
+        destroy_before = ["aws_instance.foo"]
+    }
+}
@@ -0,0 +1,9 @@
+resource "aws_instance" "foo" {
+    lifecycle {
+        destroy_before = ["aws_instance.bar"]
+    }
+}
+
+resource "aws_instance" "bar" {
+    foo = "${aws_instance.foo.id}"
+}
@@ -1,6 +1,8 @@
 package terraform
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/dag"
 )
 
@@ -44,6 +46,14 @@ type GraphNodeDestroyEdgeInclude interface {
 	DestroyEdgeInclude(dag.Vertex) bool
 }
 
+// GraphNodeDestroyBefore can be implemented by destroy nodes that must be
+// destroyed before other nodes even though nothing depends on them.
+type GraphNodeDestroyBefore interface {
+	// DestroyBefore returns the names of the create nodes whose destroy
+	// nodes must wait for this node.
+	DestroyBefore() []string
+}
+
 // DestroyTransformer is a GraphTransformer that creates the destruction
 // nodes for things that _might_ be destroyed.
 type DestroyTransformer struct {
@@ -101,6 +111,7 @@ func (t *DestroyTransformer) Transform(g *Graph) error {
 	// Go through the nodes we added and determine if they depend
 	// on any nodes with a destroy node. If so, depend on that instead.
 	for n, _ := range nodeToCn {
+		countDeps := destroyCountDependencies(g, n)
 		for _, downRaw := range g.DownEdges(n).List() {
 			target := downRaw.(dag.Vertex)
 			cn2, ok := target.(GraphNodeDestroyable)
@@ -108,6 +119,16 @@ func (t *DestroyTransformer) Transform(g *Graph) error {
 				continue
 			}
 
+			// The count of the destroy node is interpolated as well, so
+			// it keeps depending on the data sources the count refers
+			// to. Data sources are only read, so there's no destroy
+			// order to keep for them.
+			if dn, ok := target.(GraphNodeDependable); ok {
+				if _, ok := countDeps[dn.DependableName()[0]]; ok {
+					continue
+				}
+			}
+
 			newTarget := nodeToDn[cn2]
 			if newTarget == nil {
 				continue
@@ -132,6 +153,177 @@ func (t *DestroyTransformer) Transform(g *Graph) error {
 	return nil
 }
 
+// destroyCountDependencies returns the set of DependableNames the count of
+// the destroy node n refers to.
+func destroyCountDependencies(g *Graph, n dag.Vertex) map[string]struct{} {
+	cn, ok := n.(GraphNodeCountDependent)
+	if !ok {
+		return nil
+	}
+
+	prefix := ""
+	if pn, ok := n.(GraphNodeSubPath); ok {
+		prefix = modulePrefixStr(pn.Path())
+	}
+
+	result := make(map[string]struct{})
+	for _, d := range modulePrefixList(cn.CountDependentOn(), prefix) {
+		result[d] = struct{}{}
+	}
+
+	return result
+}
+
+// DestroyOrderTransformer is a GraphTransformer that adds the destroy
+// ordering requested by GraphNodeDestroyBefore nodes. This must run after
+// the DestroyTransformer so that the destroy nodes exist.
+//
+// For a full destroy it also orders the destroys around providers that
+// are configured from resources: the resources a provider configuration
+// refers to are destroyed after all the resources of that provider, so
+// the provider can still be configured from their state.
+type DestroyOrderTransformer struct {
+	FullDestroy bool
+}
+
+func (t *DestroyOrderTransformer) Transform(g *Graph) error {
+	// Index the destroy nodes by the name of their create node, since
+	// that is how the configuration refers to them.
+	destroyers := make(map[string]dag.Vertex)
+	for _, v := range g.Vertices() {
+		dn, ok := v.(GraphNodeDestroy)
+		if !ok {
+			continue
+		}
+
+		if cn := dn.CreateNode(); cn != nil {
+			destroyers[dag.VertexName(cn)] = v
+		}
+	}
+
+	var before, connect, remove []dag.Edge
+	for _, v := range g.Vertices() {
+		dn, ok := v.(GraphNodeDestroyBefore)
+		if !ok {
+			continue
+		}
+
+		for _, name := range dn.DestroyBefore() {
+			// The target may not have a destroy node, for example if it
+			// has already been removed from the graph. There is nothing
+			// to order against in that case.
+			target, ok := destroyers[name]
+			if !ok || target == v {
+				continue
+			}
+
+			before = append(before, dag.BasicEdge(target, v))
+		}
+	}
+
+	if t.FullDestroy {
+		for _, v := range g.Vertices() {
+			if _, ok := v.(GraphNodeProvider); !ok {
+				continue
+			}
+
+			for _, cn := range providerResourceDeps(g, v, destroyers) {
+				target := destroyers[dag.VertexName(cn)]
+
+				// Nothing is created in a full destroy, so the provider
+				// doesn't have to wait for the resource to be destroyed
+				// and recreated. Configuring it from the state is enough.
+				remove = append(remove, dag.BasicEdge(cn, target))
+
+				// Destroy the resource after everything that uses the
+				// provider.
+				for _, raw := range g.UpEdges(v).List() {
+					if _, ok := raw.(GraphNodeDestroy); ok && raw != target {
+						connect = append(connect, dag.BasicEdge(target, raw.(dag.Vertex)))
+					}
+				}
+			}
+		}
+	}
+
+	for _, e := range remove {
+		g.RemoveEdge(e)
+	}
+
+	// A destroy_before that contradicts the dependencies would only show
+	// up later as a cycle, so check each one against the graph as it is
+	// built up and report the resources involved instead.
+	for _, e := range before {
+		deps, err := g.Ancestors(e.Target())
+		if err != nil {
+			return err
+		}
+		if deps.Include(e.Source()) {
+			name := destroyCreateName(e.Target())
+			dep := destroyCreateName(e.Source())
+			return fmt.Errorf(
+				"%s: destroy_before lists %s, but %s has to be destroyed "+
+					"first because it depends on %s, directly or indirectly. "+
+					"Remove %s from destroy_before or remove the dependency.",
+				name, dep, dep, name, dep)
+		}
+
+		g.Connect(e)
+	}
+	for _, e := range connect {
+		g.Connect(e)
+	}
+
+	return nil
+}
+
+// destroyCreateName returns the name of the resource a destroy node
+// destroys, as the configuration refers to it.
+func destroyCreateName(v dag.Vertex) string {
+	if dn, ok := v.(GraphNodeDestroy); ok {
+		if cn := dn.CreateNode(); cn != nil {
+			return dag.VertexName(cn)
+		}
+	}
+
+	return dag.VertexName(v)
+}
+
+// providerResourceDeps returns the create nodes of the resources that the
+// configuration of the provider p refers to, directly or through other
+// nodes such as module variables and outputs.
+func providerResourceDeps(
+	g *Graph, p dag.Vertex, destroyers map[string]dag.Vertex) []dag.Vertex {
+	var result []dag.Vertex
+	seen := make(map[dag.Vertex]struct{})
+
+	var visit func(dag.Vertex)
+	visit = func(v dag.Vertex) {
+		for _, raw := range g.DownEdges(v).List() {
+			dep := raw.(dag.Vertex)
+			if _, ok := seen[dep]; ok {
+				continue
+			}
+			seen[dep] = struct{}{}
+
+			// Resources are the end of the search, since whatever they
+			// depend on is already destroyed after them.
+			if _, ok := destroyers[dag.VertexName(dep)]; ok {
+				result = append(result, dep)
+				continue
+			}
+			if _, ok := dep.(GraphNodeDestroy); ok {
+				continue
+			}
+
+			visit(dep)
+		}
+	}
+	visit(p)
+
+	return result
+}
+
 // CreateBeforeDestroyTransformer is a GraphTransformer that modifies
 // the destroys of some nodes so that the creation happens before the
 // destroy.
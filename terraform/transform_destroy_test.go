@@ -30,6 +30,68 @@ func TestDestroyTransformer(t *testing.T) {
 	}
 }
 
+func TestDestroyOrderTransformer(t *testing.T) {
+	mod := testModule(t, "transform-destroy-order-basic")
+
+	g := Graph{Path: RootModulePath}
+	{
+		tf := &ConfigTransformer{Module: mod}
+		if err := tf.Transform(&g); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	{
+		tf := &DestroyTransformer{}
+		if err := tf.Transform(&g); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	{
+		tf := &DestroyOrderTransformer{}
+		if err := tf.Transform(&g); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	actual := strings.TrimSpace(g.String())
+	expected := strings.TrimSpace(testTransformDestroyOrderBasicStr)
+	if actual != expected {
+		t.Fatalf("bad:\n\n%s", actual)
+	}
+}
+
+func TestDestroyOrderTransformer_conflict(t *testing.T) {
+	mod := testModule(t, "transform-destroy-order-conflict")
+
+	g := Graph{Path: RootModulePath}
+	{
+		tf := &ConfigTransformer{Module: mod}
+		if err := tf.Transform(&g); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	{
+		tf := &DestroyTransformer{}
+		if err := tf.Transform(&g); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	tf := &DestroyOrderTransformer{}
+	err := tf.Transform(&g)
+	if err == nil {
+		t.Fatal("should error")
+	}
+	for _, s := range []string{"aws_instance.foo", "aws_instance.bar", "destroy_before"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Fatalf("error should mention %q: %s", s, err)
+		}
+	}
+}
+
 func TestCreateBeforeDestroyTransformer(t *testing.T) {
 	mod := testModule(t, "transform-create-before-destroy-basic")
 
@@ -409,6 +471,16 @@ aws_instance.foo (destroy)
   aws_instance.bar (destroy)
 `
 
+const testTransformDestroyOrderBasicStr = `
+aws_instance.bar
+  aws_instance.bar (destroy)
+aws_instance.bar (destroy)
+aws_instance.foo
+  aws_instance.foo (destroy)
+aws_instance.foo (destroy)
+  aws_instance.bar (destroy)
+`
+
 const testTransformPruneDestroyBasicStr = `
 aws_instance.bar
   aws_instance.foo
@@ -0,0 +1,85 @@
+package terraform
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/config/module"
+)
+
+// CheckRequiredVersion verifies that this version of Terraform satisfies
+// the required_version constraint of the given module and all of its
+// children. This should be called before anything else is done with the
+// configuration, so that an old Terraform doesn't touch state that was
+// written by, or for, a newer one.
+func CheckRequiredVersion(m *module.Tree) error {
+	// Check any children first
+	names := make([]string, 0, len(m.Children()))
+	for name, _ := range m.Children() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := CheckRequiredVersion(m.Children()[name]); err != nil {
+			return err
+		}
+	}
+
+	var tf *config.Terraform
+	if c := m.Config(); c != nil {
+		tf = c.Terraform
+	}
+	if tf == nil || tf.RequiredVersion == "" {
+		return nil
+	}
+
+	cs, err := version.NewConstraint(tf.RequiredVersion)
+	if err != nil {
+		return fmt.Errorf(
+			"%s: terraform.required_version %q is not a valid constraint: %s",
+			moduleDisplayName(m), tf.RequiredVersion, err)
+	}
+
+	if !cs.Check(SemVersion) {
+		return &VersionRequiredError{
+			Module:          moduleDisplayName(m),
+			RequiredVersion: tf.RequiredVersion,
+		}
+	}
+
+	return nil
+}
+
+// VersionRequiredError is returned by CheckRequiredVersion when the running
+// Terraform doesn't satisfy a configuration's required_version.
+type VersionRequiredError struct {
+	// Module is "root" or the path of the module, such as "module.foo".
+	Module          string
+	RequiredVersion string
+}
+
+func (e *VersionRequiredError) Error() string {
+	return fmt.Sprintf(
+		"The currently running version of Terraform doesn't meet the\n"+
+			"version requirements explicitly specified by the configuration.\n"+
+			"Please use the required version or update the configuration.\n"+
+			"Note that version requirements are usually set for a reason, so\n"+
+			"we recommend verifying with whoever set the version requirements\n"+
+			"prior to making any manual changes.\n\n"+
+			"  Module: %s\n"+
+			"  Required version: %s\n"+
+			"  Current version: %s",
+		e.Module, e.RequiredVersion, SemVersion)
+}
+
+func moduleDisplayName(m *module.Tree) string {
+	path := m.Path()
+	if len(path) == 0 {
+		return "root"
+	}
+
+	return "module." + strings.Join(path, ".module.")
+}
@@ -0,0 +1,34 @@
+package terraform
+
+import (
+	"testing"
+)
+
+func TestCheckRequiredVersion(t *testing.T) {
+	cases := []struct {
+		Fixture string
+		Module  string
+	}{
+		{"version-required-good", ""},
+		{"version-required-bad", "root"},
+		{"version-required-child", "module.child"},
+	}
+
+	for _, tc := range cases {
+		err := CheckRequiredVersion(testModule(t, tc.Fixture))
+		if tc.Module == "" {
+			if err != nil {
+				t.Fatalf("%s: err: %s", tc.Fixture, err)
+			}
+			continue
+		}
+
+		verr, ok := err.(*VersionRequiredError)
+		if !ok {
+			t.Fatalf("%s: expected version error, got: %#v", tc.Fixture, err)
+		}
+		if verr.Module != tc.Module {
+			t.Fatalf("%s: bad module: %s", tc.Fixture, verr.Module)
+		}
+	}
+}